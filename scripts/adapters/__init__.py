@@ -0,0 +1,19 @@
+"""
+Per-show ingestion adapters.
+
+Importing this package registers the built-in adapters. To add a show,
+create a module here with an Adapter subclass decorated with @register
+and import it below.
+"""
+
+from adapters.base import (  # noqa: F401
+    Adapter,
+    EpisodeInfo,
+    RSSAdapter,
+    Segment,
+    Utterance,
+    get_adapter,
+    register,
+    registered,
+)
+from adapters import mirrored_men, monsters_among_us  # noqa: F401,E402
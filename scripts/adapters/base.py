@@ -0,0 +1,156 @@
+"""
+Adapter interface and registry for per-show ingestion.
+
+An adapter knows three things about one source:
+  fetch   - which episodes exist (usually from an RSS feed)
+  parse   - how to read that show's transcripts into utterances
+  segment - how to split an episode's utterances into candidate stories
+
+The pipeline core (scripts/ingest.py) only talks to this interface, so a
+new show with an unusual feed or transcript format is one new module.
+"""
+
+from __future__ import annotations
+
+import re
+from dataclasses import dataclass, field
+from datetime import date
+from pathlib import Path
+
+from adapters import parsers
+
+
+@dataclass
+class EpisodeInfo:
+    show: str
+    title: str
+    air_date: date | None
+    audio_url: str
+    link: str = ""
+    season: str = ""
+    number: str = ""
+
+
+@dataclass
+class Utterance:
+    speaker: str
+    start: float  # seconds
+    end: float  # seconds
+    text: str
+
+
+@dataclass
+class Segment:
+    """A candidate story: utterances start_line..end_line (1-indexed, inclusive)."""
+
+    start_line: int
+    end_line: int
+    utterances: list[Utterance] = field(default_factory=list)
+    title: str = ""
+
+    @property
+    def start(self) -> float:
+        return self.utterances[0].start if self.utterances else 0.0
+
+    @property
+    def end(self) -> float:
+        return self.utterances[-1].end if self.utterances else 0.0
+
+    @property
+    def content(self) -> str:
+        return "\n\n".join(f"[Speaker {u.speaker}] {u.text}" for u in self.utterances)
+
+    @property
+    def word_count(self) -> int:
+        return sum(len(u.text.split()) for u in self.utterances)
+
+
+class Adapter:
+    """Base adapter. Subclasses set name/show and implement fetch()."""
+
+    name = ""
+    show = ""
+    transcript_format = "assemblyai"  # "assemblyai" or "whisper"
+
+    def fetch(self) -> list[EpisodeInfo]:
+        raise NotImplementedError(f"{self.name}: fetch not implemented")
+
+    def parse(self, transcript_path: Path) -> list[Utterance]:
+        """Read a transcript written by this show's transcriber."""
+        if self.transcript_format == "whisper":
+            rows = parsers.parse_whisper_json(transcript_path)
+        else:
+            rows = parsers.parse_assemblyai_json(transcript_path)
+        return [Utterance(**row) for row in rows]
+
+    def segment(self, utterances: list[Utterance], episode: EpisodeInfo) -> list[Segment]:
+        """Default: the whole episode is one story."""
+        if not utterances:
+            return []
+        return [Segment(1, len(utterances), list(utterances), episode.title)]
+
+    def base_name(self, episode: EpisodeInfo) -> str:
+        """File stem shared by the episode's audio and transcript."""
+        day = episode.air_date.isoformat() if episode.air_date else "unknown"
+        return f"{slugify(self.name)}_{day}_{slugify(episode.title)[:40]}"
+
+
+def slugify(text: str) -> str:
+    text = re.sub(r"[^\w\s-]", "", text.lower().strip())
+    return re.sub(r"[\s_-]+", "-", text).strip("-")
+
+
+_REGISTRY: dict[str, type[Adapter]] = {}
+
+
+def register(cls: type[Adapter]) -> type[Adapter]:
+    """Class decorator adding an adapter to the registry under cls.name."""
+    if not cls.name:
+        raise ValueError(f"{cls.__name__} has no name")
+    if cls.name in _REGISTRY:
+        raise ValueError(f"Adapter already registered: {cls.name}")
+    _REGISTRY[cls.name] = cls
+    return cls
+
+
+def get_adapter(name: str) -> Adapter:
+    try:
+        return _REGISTRY[name]()
+    except KeyError:
+        raise KeyError(f"Unknown adapter '{name}' (available: {', '.join(registered())})") from None
+
+
+def registered() -> list[str]:
+    return sorted(_REGISTRY)
+
+
+class RSSAdapter(Adapter):
+    """Adapter for a show published as a podcast RSS feed."""
+
+    rss_url = ""
+
+    def include(self, title: str) -> bool:
+        """Override to keep only some items from a shared feed."""
+        return True
+
+    def fetch(self) -> list[EpisodeInfo]:
+        episodes = []
+        for item in parsers.fetch_rss_items(self.rss_url):
+            title = item.findtext("title", default="Untitled")
+            enc = item.find("enclosure")
+            audio_url = enc.get("url") if enc is not None else ""
+            if not audio_url or not self.include(title):
+                continue
+            episodes.append(
+                EpisodeInfo(
+                    show=self.show,
+                    title=title,
+                    air_date=parsers.parse_pubdate(item.findtext("pubDate", default="")),
+                    audio_url=audio_url,
+                    link=item.findtext("link", default=""),
+                    season=item.findtext("itunes:season", default="", namespaces=parsers.ITUNES_NS),
+                    number=item.findtext("itunes:episode", default="", namespaces=parsers.ITUNES_NS),
+                )
+            )
+        episodes.sort(key=lambda e: e.air_date or date.min)
+        return episodes
@@ -0,0 +1,21 @@
+"""Mirrored Men: single-story episodes from the Monsters Among Us feed, transcribed with Whisper."""
+
+from __future__ import annotations
+
+from adapters.base import EpisodeInfo, RSSAdapter, register
+
+
+@register
+class MirroredMen(RSSAdapter):
+    name = "mirrored-men"
+    show = "Monsters Among Us"
+    rss_url = "https://audioboom.com/channels/5147816.rss"
+    transcript_format = "whisper"
+
+    def include(self, title: str) -> bool:
+        return "mirrored" in title.lower()
+
+    def base_name(self, episode: EpisodeInfo) -> str:
+        # Matches files from mirrored_men_pipeline.py
+        day = episode.air_date.strftime("%d-%b-%Y") if episode.air_date else "unknown"
+        return f"mau_s{episode.season or 'unknown'}e{episode.number or 'unknown'}_{day}"
@@ -0,0 +1,60 @@
+"""Monsters Among Us: call-in show, several caller stories per episode."""
+
+from __future__ import annotations
+
+from collections import Counter
+
+from adapters.base import EpisodeInfo, RSSAdapter, Segment, Utterance, register
+
+# Host turns shorter than this stay inside the caller's story
+MAX_INTERJECTION_WORDS = 40
+# Caller runs shorter than this are chatter, not stories
+MIN_STORY_WORDS = 300
+
+
+@register
+class MonstersAmongUs(RSSAdapter):
+    name = "monsters-among-us"
+    show = "Monsters Among Us"
+    rss_url = "https://audioboom.com/channels/5147816.rss"
+    transcript_format = "assemblyai"
+
+    def base_name(self, episode: EpisodeInfo) -> str:
+        # Matches files from download_rss.py
+        day = episode.air_date.strftime("%d-%b-%Y") if episode.air_date else "unknown"
+        return f"mau_s{episode.season or 'unknown'}e{episode.number or 'unknown'}_{day}"
+
+    def segment(self, utterances: list[Utterance], episode: EpisodeInfo) -> list[Segment]:
+        """
+        Split on the host: the speaker with the most turns. Each caller's run
+        of utterances, absorbing brief host interjections, is one candidate.
+        """
+        if not utterances:
+            return []
+        host = Counter(u.speaker for u in utterances).most_common(1)[0][0]
+
+        segments: list[Segment] = []
+        start = None
+        for i, u in enumerate(utterances):
+            is_break = u.speaker == host and len(u.text.split()) >= MAX_INTERJECTION_WORDS
+            if start is None:
+                if u.speaker != host:
+                    start = i
+                continue
+            if is_break:
+                segments.append(self._make(utterances, start, i, host))
+                start = None
+        if start is not None:
+            segments.append(self._make(utterances, start, len(utterances), host))
+
+        kept = [s for s in segments if s.word_count >= MIN_STORY_WORDS]
+        for n, s in enumerate(kept, 1):
+            s.title = f"{episode.title} - story {n}"
+        return kept
+
+    @staticmethod
+    def _make(utterances: list[Utterance], start: int, stop: int, host: str) -> Segment:
+        # Drop trailing host turns so the story ends on the caller
+        while stop > start + 1 and utterances[stop - 1].speaker == host:
+            stop -= 1
+        return Segment(start + 1, stop, utterances[start:stop])
@@ -0,0 +1,54 @@
+"""Transcript and feed parsing shared by adapters."""
+
+from __future__ import annotations
+
+import json
+import xml.etree.ElementTree as ET
+from datetime import date, datetime
+from pathlib import Path
+
+ITUNES_NS = {"itunes": "http://www.itunes.com/dtds/podcast-1.0.dtd"}
+
+
+def parse_assemblyai_json(path: Path) -> list[dict]:
+    """Utterances from a transcribe.py JSON file (timestamps in ms)."""
+    data = json.loads(path.read_text())
+    return [
+        {
+            "speaker": str(u.get("speaker") or "?"),
+            "start": (u.get("start") or 0) / 1000.0,
+            "end": (u.get("end") or 0) / 1000.0,
+            "text": (u.get("text") or "").strip(),
+        }
+        for u in data.get("utterances", [])
+    ]
+
+
+def parse_whisper_json(path: Path) -> list[dict]:
+    """Segments from a Whisper JSON file (timestamps in seconds, no speakers)."""
+    data = json.loads(path.read_text())
+    return [
+        {
+            "speaker": "?",
+            "start": float(s.get("start") or 0),
+            "end": float(s.get("end") or 0),
+            "text": (s.get("text") or "").strip(),
+        }
+        for s in data.get("segments", [])
+    ]
+
+
+def parse_pubdate(pub: str) -> date | None:
+    """Parse an RSS pubDate like 'Thu, 15 Jan 2026 08:00:00 +0000'."""
+    try:
+        return datetime.strptime((pub or "").strip(), "%a, %d %b %Y %H:%M:%S %z").date()
+    except ValueError:
+        return None
+
+
+def fetch_rss_items(url: str) -> list[ET.Element]:
+    import requests
+
+    r = requests.get(url, timeout=30)
+    r.raise_for_status()
+    return ET.fromstring(r.content).findall(".//item")
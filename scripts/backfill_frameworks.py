@@ -14,6 +14,7 @@ from datetime import datetime
 
 import psycopg2
 
+import llm
 from framework_analysis import analyze_story_frameworks, FRAMEWORK_SCHEMA_VERSION
 from load_segments import ensure_framework_columns
 
@@ -124,14 +125,14 @@ def main() -> int:
         "--framework-model",
         type=str,
         default=None,
-        help="Anthropic model override (defaults to ANTHROPIC_MODEL env)",
+        help="Model override (defaults to LLM_MODEL_FRAMEWORKS, then LLM_MODEL, then the provider's default)",
     )
 
     args = parser.parse_args()
 
-    api_key = os.environ.get("ANTHROPIC_API_KEY")
-    if not args.dry_run and not api_key:
-        print("ANTHROPIC_API_KEY environment variable required", file=sys.stderr)
+    missing = llm.missing_credentials()
+    if not args.dry_run and missing:
+        print(missing, file=sys.stderr)
         return 1
 
     database_url = get_database_url()
@@ -160,11 +161,7 @@ def main() -> int:
             if args.dry_run:
                 print(f"  WOULD_UPDATE: {title} ({story_id})")
             else:
-                result = analyze_story_frameworks(
-                    content,
-                    api_key=api_key or "",
-                    model=args.framework_model,
-                )
+                result = analyze_story_frameworks(content, model=args.framework_model)
                 update_story_frameworks(conn, story_id, result.to_json(), result.model)
                 print(f"  UPDATED: {title}")
             processed += 1
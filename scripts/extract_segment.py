@@ -93,6 +93,11 @@ def get_timestamps(utterances: list[dict]) -> tuple[float, float]:
     return start_ms / 1000.0, end_ms / 1000.0
 
 
+def get_utterance_times(utterances: list[dict]) -> list[tuple[float, float]]:
+    """Get each utterance's start/end timestamps (in seconds), in order."""
+    return [(u.get("start", 0) / 1000.0, u.get("end", 0) / 1000.0) for u in utterances]
+
+
 def format_timestamp(seconds: float) -> str:
     """Format seconds as HH:MM:SS or MM:SS."""
     total_seconds = int(seconds)
@@ -133,8 +138,16 @@ def write_segment(
     location: str | None = None,
     is_first_person: bool = True,
     source_lines: str | None = None,
+    episode_url: str | None = None,
+    audio_url: str | None = None,
+    utterance_times: list[tuple[float, float]] | None = None,
 ) -> None:
-    """Write the segment markdown file with frontmatter."""
+    """
+    Write the segment markdown file with frontmatter.
+
+    utterance_times are the start/end of each paragraph of content, in
+    order; load_segments.py keeps them for the TUI to show and jump by.
+    """
 
     # Build frontmatter
     fm_lines = [
@@ -155,6 +168,13 @@ def write_segment(
         fm_lines.append(f"location: \"{location}\"")
     if source_lines:
         fm_lines.append(f"source_lines: \"{source_lines}\"")
+    if episode_url:
+        fm_lines.append(f"episode_url: \"{episode_url}\"")
+    if audio_url:
+        fm_lines.append(f"audio_url: \"{audio_url}\"")
+    if utterance_times:
+        times = ", ".join(f"[{start:.1f}, {end:.1f}]" for start, end in utterance_times)
+        fm_lines.append(f"utterance_times: [{times}]")
 
     fm_lines.append(f"first_person: {str(is_first_person).lower()}")
     fm_lines.append("---")
@@ -299,6 +319,7 @@ Output:
         location=args.location,
         is_first_person=is_first_person,
         source_lines=args.lines,
+        utterance_times=get_utterance_times(utterances),
     )
 
     print(f"Created: {output_path}")
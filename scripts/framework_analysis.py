@@ -1,24 +1,22 @@
 #!/usr/bin/env python3
 """
-Framework analysis for paranormal stories using Anthropic.
+Framework analysis for paranormal stories using an LLM (see llm.py for the
+provider and model settings).
 
 Produces strict JSON output for multiple parapsychology frameworks.
 """
 
 from __future__ import annotations
 
-import json
-import os
 import time
 from dataclasses import dataclass
 from typing import Any
 
-import requests
+import llm
 
 
-DEFAULT_ANTHROPIC_MODEL = "claude-3-haiku-20240307"
-ANTHROPIC_API_URL = "https://api.anthropic.com/v1/messages"
-ANTHROPIC_VERSION = "2023-06-01"
+# Picks the model with LLM_MODEL_FRAMEWORKS
+LLM_FEATURE = "frameworks"
 FRAMEWORK_SCHEMA_VERSION = "2026-01-26.3"
 
 BELIEF_STANCES = {"affirmed", "denied", "not_mentioned"}
@@ -252,46 +250,12 @@ def _build_tool_schema() -> dict[str, Any]:
     }
 
 
-def _call_anthropic(prompt: str, api_key: str, model: str, max_tokens: int = 3000) -> dict[str, Any]:
-    tool_schema = _build_tool_schema()
-    response = requests.post(
-        ANTHROPIC_API_URL,
-        headers={
-            "x-api-key": api_key,
-            "anthropic-version": ANTHROPIC_VERSION,
-            "content-type": "application/json",
-        },
-        json={
-            "model": model,
-            "max_tokens": max_tokens,
-            "temperature": 0,
-            "messages": [{"role": "user", "content": prompt}],
-            "tools": [
-                {
-                    "name": "framework_analysis",
-                    "description": "Return the framework analysis for the story.",
-                    "input_schema": tool_schema,
-                }
-            ],
-            "tool_choice": {"type": "tool", "name": "framework_analysis"},
-        },
-        timeout=60,
-    )
-    response.raise_for_status()
-    payload = response.json()
-    for block in payload.get("content", []):
-        if block.get("type") == "tool_use" and block.get("name") == "framework_analysis":
-            return block.get("input", {})
-    raise ValueError("No tool_use response found from model")
-
-
 def analyze_story_frameworks(
     story_text: str,
-    api_key: str,
     model: str | None = None,
     max_retries: int = 2,
 ) -> FrameworkResult:
-    model_name = model or os.environ.get("ANTHROPIC_MODEL", DEFAULT_ANTHROPIC_MODEL)
+    model_name = llm.model_for(LLM_FEATURE, model)
     base_prompt = build_framework_prompt(story_text)
 
     last_error: Exception | None = None
@@ -308,14 +272,20 @@ def analyze_story_frameworks(
                     + "\nIMPORTANT: Your previous tool call was incomplete or invalid. "
                       "You must call the tool with ALL required fields, using defaults when unknown."
                 )
-            parsed = _call_anthropic(prompt, api_key, model_name)
+            parsed = llm.call_tool(
+                prompt,
+                name="framework_analysis",
+                description="Return the framework analysis for the story.",
+                schema=_build_tool_schema(),
+                model=model_name,
+            )
             if not isinstance(parsed, dict):
                 raise ValueError(f"Tool input not an object: {parsed!r}")
             frameworks = parsed.get("frameworks")
             if frameworks is None:
                 raise ValueError(f"Tool input missing 'frameworks': {parsed!r}")
             _validate_frameworks(frameworks)
-            return FrameworkResult(frameworks=frameworks, model=model_name)
+            return FrameworkResult(frameworks=frameworks, model=llm.model_label(model_name))
         except Exception as exc:
             last_error = exc
 
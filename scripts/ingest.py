@@ -0,0 +1,258 @@
+#!/usr/bin/env python3
+"""
+Run the ingestion pipeline for one show through its adapter.
+
+Steps (each opt-in):
+  --download    fetch the feed and download missing audio
+  --transcribe  transcribe audio that has no transcript yet
+  --segment     split transcripts into candidate story .md files
+
+Everything show-specific (feed, file naming, transcript format, how an
+episode splits into stories) lives in scripts/adapters/. Segment files are
+written like extract_segment.py output, ready for review and load_segments.py.
+
+Audio, transcript and segment hashes are recorded per adapter, so re-running
+skips unchanged episodes, re-transcribes replaced audio, re-segments only
+modified transcripts, and never overwrites a segment file edited by hand.
+
+Usage:
+  python scripts/ingest.py --list-adapters
+  python scripts/ingest.py --adapter monsters-among-us --download --transcribe --limit 5
+  python scripts/ingest.py --adapter mirrored-men --segment --dry-run
+
+Environment:
+  ASSEMBLYAI_API_KEY - Required to transcribe AssemblyAI-format shows
+  WHISPER_MODEL      - Whisper model for Whisper-format shows (default: base)
+"""
+
+from __future__ import annotations
+
+import argparse
+import hashlib
+import json
+import os
+import sys
+import time
+from pathlib import Path
+
+from adapters import get_adapter, registered
+from adapters.base import slugify
+
+SCRIPT_DIR = Path(__file__).resolve().parent
+REPO_ROOT = SCRIPT_DIR.parent
+
+DEFAULT_EPISODES_DIR = REPO_ROOT / "episodes"
+DEFAULT_TRANSCRIPTS_DIR = REPO_ROOT / "transcripts"
+DEFAULT_SEGMENTS_DIR = REPO_ROOT / "segments"
+
+# Per-adapter record of source hashes, kept in the adapter's transcript directory
+STATE_FILE = ".ingest-state.json"
+
+
+def transcribe_episode(adapter, audio_path: Path, out_dir: Path, base: str, whisper_model: str) -> None:
+    out_dir.mkdir(parents=True, exist_ok=True)
+    if adapter.transcript_format == "whisper":
+        from mirrored_men_pipeline import run_whisper
+
+        run_whisper(audio_path, out_dir, model=whisper_model)
+        return
+
+    from transcribe import format_transcript_txt, get_api_key, transcribe
+
+    data = transcribe(str(audio_path), get_api_key())
+    (out_dir / f"{base}.json").write_text(json.dumps(data, indent=2, ensure_ascii=False))
+    (out_dir / f"{base}.txt").write_text(format_transcript_txt(data))
+
+
+def file_sha256(path: Path) -> str:
+    h = hashlib.sha256()
+    with path.open("rb") as f:
+        for chunk in iter(lambda: f.read(1024 * 1024), b""):
+            h.update(chunk)
+    return h.hexdigest()
+
+
+def load_state(path: Path) -> dict:
+    if not path.exists():
+        return {}
+    return json.loads(path.read_text())
+
+
+def save_state(path: Path, state: dict) -> None:
+    path.parent.mkdir(parents=True, exist_ok=True)
+    tmp = path.with_suffix(".tmp")
+    tmp.write_text(json.dumps(state, indent=2, sort_keys=True))
+    tmp.replace(path)
+
+
+def segment_episode(adapter, ep, transcript_path: Path, segments_dir: Path, entry: dict, dry_run: bool) -> int:
+    """
+    Write candidate segments for one transcript, returning how many were written.
+
+    Skipped entirely when the transcript hash matches the last run. When it
+    changed, segment files are regenerated, except ones edited since they
+    were generated (their hash no longer matches), which are left alone.
+    """
+    if ep.air_date is None:
+        print("  SKIP: no air date")
+        return 0
+
+    transcript_hash = file_sha256(transcript_path)
+    generated: dict[str, str] = entry.get("segments", {})
+    if entry.get("transcript_sha256") == transcript_hash:
+        print("  Transcript unchanged, skipping")
+        return 0
+
+    from extract_segment import write_segment
+
+    def edited(path: Path) -> bool:
+        return path.exists() and generated.get(path.name) != file_sha256(path)
+
+    written = 0
+    produced: dict[str, str] = {}
+    for seg in adapter.segment(adapter.parse(transcript_path), ep):
+        out = segments_dir / f"{ep.air_date.isoformat()}_{slugify(seg.title)[:60]}.md"
+        if edited(out):
+            print(f"  Keeping edited {out.name}")
+            produced[out.name] = generated.get(out.name, "")
+            continue
+        print(f"  Segment lines {seg.start_line}-{seg.end_line} ({seg.word_count} words) -> {out.name}")
+        if dry_run:
+            continue
+        write_segment(
+            out,
+            title=seg.title,
+            show=ep.show,
+            episode_date=ep.air_date,
+            start_time=seg.start,
+            end_time=seg.end,
+            content=seg.content,
+            source_lines=f"{seg.start_line}-{seg.end_line}",
+            episode_url=ep.link or None,
+            audio_url=ep.audio_url or None,
+            utterance_times=[(u.start, u.end) for u in seg.utterances],
+        )
+        produced[out.name] = file_sha256(out)
+        written += 1
+
+    # Segments the new transcript no longer yields
+    for name in set(generated) - set(produced):
+        path = segments_dir / name
+        if edited(path):
+            print(f"  Keeping edited {name} (no longer produced)")
+            produced[name] = generated[name]
+            continue
+        print(f"  Remove stale {name}")
+        if not dry_run and path.exists():
+            path.unlink()
+
+    if not dry_run:
+        entry["transcript_sha256"] = transcript_hash
+        entry["segments"] = produced
+    return written
+
+
+def main() -> int:
+    parser = argparse.ArgumentParser(
+        description="Download, transcribe and segment a show through its ingestion adapter.",
+        formatter_class=argparse.RawDescriptionHelpFormatter,
+        epilog=f"Adapters: {', '.join(registered())}",
+    )
+    parser.add_argument("--adapter", help="Adapter name (see --list-adapters)")
+    parser.add_argument("--list-adapters", action="store_true", help="List registered adapters and exit")
+    parser.add_argument("--download", action="store_true", help="Download missing audio")
+    parser.add_argument("--transcribe", action="store_true", help="Transcribe audio without a transcript")
+    parser.add_argument("--segment", action="store_true", help="Write candidate story segments")
+    parser.add_argument("--limit", type=int, help="Only process the newest N episodes")
+    parser.add_argument("--delay", type=float, default=2.0, help="Delay between downloads (seconds)")
+    parser.add_argument(
+        "--whisper-model",
+        default=os.environ.get("WHISPER_MODEL", "base"),
+        help="Whisper model for Whisper-format shows (default: base)",
+    )
+    parser.add_argument("--episodes-dir", type=Path, default=DEFAULT_EPISODES_DIR)
+    parser.add_argument("--transcripts-dir", type=Path, default=DEFAULT_TRANSCRIPTS_DIR)
+    parser.add_argument("--segments-dir", type=Path, default=DEFAULT_SEGMENTS_DIR)
+    parser.add_argument("--dry-run", action="store_true", help="Report what would happen without writing")
+    args = parser.parse_args()
+
+    if args.list_adapters:
+        for name in registered():
+            adapter = get_adapter(name)
+            print(f"{name:20s} {adapter.show} ({adapter.transcript_format})")
+        return 0
+
+    if not args.adapter:
+        parser.error("--adapter is required (or pass --list-adapters)")
+    try:
+        adapter = get_adapter(args.adapter)
+    except KeyError as e:
+        print(e.args[0], file=sys.stderr)
+        return 1
+
+    if not (args.download or args.transcribe or args.segment):
+        print("Nothing to do (pass --download, --transcribe and/or --segment)")
+        return 0
+
+    episodes_dir = args.episodes_dir / adapter.name
+    transcripts_dir = args.transcripts_dir / adapter.name
+    segments_dir = args.segments_dir / adapter.name
+
+    print(f"Fetching episodes for {adapter.name}...")
+    try:
+        episodes = adapter.fetch()
+    except Exception as e:
+        print(f"Fetch failed: {e}", file=sys.stderr)
+        return 1
+    if args.limit:
+        episodes = episodes[-args.limit:]
+    print(f"Found {len(episodes)} episode(s)")
+
+    state_path = transcripts_dir / STATE_FILE
+    state = load_state(state_path)
+
+    errors = 0
+    written = 0
+    for i, ep in enumerate(episodes, 1):
+        base = adapter.base_name(ep)
+        audio_path = episodes_dir / f"{base}.mp3"
+        transcript_path = transcripts_dir / f"{base}.json"
+        entry = state.setdefault(base, {})
+        print(f"[{i}/{len(episodes)}] {ep.title}")
+
+        try:
+            if args.download and not audio_path.exists():
+                print(f"  Download -> {audio_path}")
+                if not args.dry_run:
+                    from mirrored_men_pipeline import download
+
+                    download(ep.audio_url, audio_path)
+                    time.sleep(args.delay)
+
+            if args.transcribe and audio_path.exists():
+                audio_hash = file_sha256(audio_path)
+                # A replaced audio file invalidates its transcript
+                stale = transcript_path.exists() and entry.get("audio_sha256") not in (None, audio_hash)
+                if not transcript_path.exists() or stale:
+                    print(f"  Transcribe ({adapter.transcript_format}) -> {transcript_path}{' (audio changed)' if stale else ''}")
+                    if not args.dry_run:
+                        transcribe_episode(adapter, audio_path, transcripts_dir, base, args.whisper_model)
+                if not args.dry_run:
+                    entry["audio_sha256"] = audio_hash
+
+            if args.segment and transcript_path.exists():
+                written += segment_episode(adapter, ep, transcript_path, segments_dir, entry, args.dry_run)
+        except Exception as e:
+            errors += 1
+            print(f"  ERROR: {e}", file=sys.stderr)
+        finally:
+            if not args.dry_run:
+                save_state(state_path, state)
+
+    if args.segment:
+        print(f"\n{'[DRY RUN] ' if args.dry_run else ''}Segments written: {written}")
+    return 1 if errors else 0
+
+
+if __name__ == "__main__":
+    raise SystemExit(main())
@@ -0,0 +1,163 @@
+#!/usr/bin/env python3
+"""
+Generative model calls for the pipeline, against Anthropic or a local Ollama.
+
+Every LLM-powered feature goes through call_tool(), which asks the model to
+fill in a JSON schema and returns the result as a dict, so a feature doesn't
+care who answers. Choose with environment variables:
+
+  LLM_PROVIDER            anthropic (default) or ollama
+  ANTHROPIC_API_KEY       required for anthropic only
+  OLLAMA_URL              Ollama server (default: http://localhost:11434)
+  LLM_MODEL_<FEATURE>     model for one feature, e.g. LLM_MODEL_FRAMEWORKS
+  LLM_MODEL               model for every feature without its own
+  ANTHROPIC_MODEL         older name for LLM_MODEL, used with anthropic only
+
+Ollama runs entirely offline; it needs a model that supports structured
+outputs (Ollama 0.5+), pulled beforehand with `ollama pull <model>`.
+"""
+
+from __future__ import annotations
+
+import json
+import os
+from typing import Any
+
+import requests
+
+
+ANTHROPIC = "anthropic"
+OLLAMA = "ollama"
+PROVIDERS = (ANTHROPIC, OLLAMA)
+
+DEFAULT_MODELS = {
+    ANTHROPIC: "claude-3-haiku-20240307",
+    OLLAMA: "llama3.1:8b",
+}
+
+ANTHROPIC_API_URL = "https://api.anthropic.com/v1/messages"
+ANTHROPIC_VERSION = "2023-06-01"
+DEFAULT_OLLAMA_URL = "http://localhost:11434"
+
+# Local models are slower, and load on first use
+ANTHROPIC_TIMEOUT = 60
+OLLAMA_TIMEOUT = 300
+
+
+def provider() -> str:
+    """The configured provider."""
+    name = os.environ.get("LLM_PROVIDER", "").strip().lower() or ANTHROPIC
+    if name not in PROVIDERS:
+        raise ValueError(f"Unknown LLM_PROVIDER {name!r} (want {' or '.join(PROVIDERS)})")
+    return name
+
+
+def model_for(feature: str, override: str | None = None) -> str:
+    """The model a feature uses: the override, its own setting, the shared one, or the provider's default."""
+    if override:
+        return override
+    name = provider()
+    configured = os.environ.get(f"LLM_MODEL_{feature.upper()}") or os.environ.get("LLM_MODEL")
+    if not configured and name == ANTHROPIC:
+        configured = os.environ.get("ANTHROPIC_MODEL")
+    return configured or DEFAULT_MODELS[name]
+
+
+def model_label(model: str) -> str:
+    """How a model is recorded alongside its output, naming local models as such."""
+    if provider() == OLLAMA:
+        return f"{OLLAMA}/{model}"
+    return model
+
+
+def missing_credentials() -> str | None:
+    """What's missing for the configured provider to be called, if anything."""
+    if provider() == ANTHROPIC and not os.environ.get("ANTHROPIC_API_KEY"):
+        return "ANTHROPIC_API_KEY environment variable required (or set LLM_PROVIDER=ollama to use a local model)"
+    return None
+
+
+def call_tool(
+    prompt: str,
+    name: str,
+    description: str,
+    schema: dict[str, Any],
+    model: str,
+    max_tokens: int = 3000,
+) -> dict[str, Any]:
+    """Ask the model to answer prompt by filling in schema, and return what it filled in."""
+    if provider() == OLLAMA:
+        return _call_ollama(prompt, description, schema, model, max_tokens)
+    return _call_anthropic(prompt, name, description, schema, model, max_tokens)
+
+
+def _call_anthropic(
+    prompt: str,
+    name: str,
+    description: str,
+    schema: dict[str, Any],
+    model: str,
+    max_tokens: int,
+) -> dict[str, Any]:
+    response = requests.post(
+        ANTHROPIC_API_URL,
+        headers={
+            "x-api-key": os.environ.get("ANTHROPIC_API_KEY", ""),
+            "anthropic-version": ANTHROPIC_VERSION,
+            "content-type": "application/json",
+        },
+        json={
+            "model": model,
+            "max_tokens": max_tokens,
+            "temperature": 0,
+            "messages": [{"role": "user", "content": prompt}],
+            "tools": [
+                {
+                    "name": name,
+                    "description": description,
+                    "input_schema": schema,
+                }
+            ],
+            "tool_choice": {"type": "tool", "name": name},
+        },
+        timeout=ANTHROPIC_TIMEOUT,
+    )
+    response.raise_for_status()
+    payload = response.json()
+    for block in payload.get("content", []):
+        if block.get("type") == "tool_use" and block.get("name") == name:
+            return block.get("input", {})
+    raise ValueError("No tool_use response found from model")
+
+
+def _call_ollama(prompt: str, description: str, schema: dict[str, Any], model: str, max_tokens: int) -> dict[str, Any]:
+    url = os.environ.get("OLLAMA_URL", "").rstrip("/") or DEFAULT_OLLAMA_URL
+    # Prompts are written for a tool; here the reply itself is the tool's input
+    system = (
+        f"You have one tool: {description} "
+        "Call it by replying with only its input, a JSON object matching its schema."
+    )
+    response = requests.post(
+        f"{url}/api/chat",
+        json={
+            "model": model,
+            "messages": [
+                {"role": "system", "content": system},
+                {"role": "user", "content": prompt},
+            ],
+            # Constrains the reply to the schema, as a forced tool call does
+            "format": schema,
+            "stream": False,
+            "options": {"temperature": 0, "num_predict": max_tokens},
+        },
+        timeout=OLLAMA_TIMEOUT,
+    )
+    if response.status_code == 404:
+        detail = response.json().get("error", "") if response.content else ""
+        raise ValueError(f"Ollama couldn't find the model ({detail}); pull it with `ollama pull {model}`")
+    response.raise_for_status()
+    content = response.json().get("message", {}).get("content", "")
+    try:
+        return json.loads(content)
+    except json.JSONDecodeError as exc:
+        raise ValueError(f"Model reply was not JSON: {content[:200]!r}") from exc
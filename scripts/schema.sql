@@ -10,7 +10,8 @@ CREATE TABLE episodes (
     podcast_name TEXT,
     episode_number TEXT,
     air_date DATE,
-    source_url TEXT,
+    source_url TEXT,               -- Episode page
+    audio_url TEXT,                -- Audio enclosure from the feed
     audio_filename TEXT,
     duration_seconds INTEGER,
     created_at TIMESTAMPTZ DEFAULT now(),
@@ -52,6 +53,7 @@ CREATE TABLE stories (
 
     -- Embedding metadata
     token_count INTEGER,
+    word_count INTEGER,     -- Kept current by the stories_word_count trigger
     embedding_method TEXT,  -- 'full' (< 4k tokens) or 'mean_pooled' (chunked)
 
     -- Story-level embedding (1024-dim for Titan)
@@ -62,6 +64,13 @@ CREATE TABLE stories (
     umap_x FLOAT,
     umap_y FLOAT,
     umap_computed_at TIMESTAMPTZ,
+    cluster_id INTEGER,  -- Discovered semantic cluster (analyze_embeddings.py)
+
+    -- Geocoded location (populated by geocode_stories.py)
+    latitude FLOAT,
+    longitude FLOAT,
+    geocoded_at TIMESTAMPTZ,
+    geo_cluster_id INTEGER,  -- Geographic hotspot (cluster_locations.py)
 
     -- Parapsychology framework analysis (LLM-generated, story-only)
     frameworks_json JSONB,
@@ -69,6 +78,17 @@ CREATE TABLE stories (
     frameworks_model TEXT,
     frameworks_computed_at TIMESTAMPTZ,
 
+    -- Named entities (extract_entities.py); rows in story_entities
+    entities_extracted_at TIMESTAMPTZ,
+
+    -- Ingestion provenance (load_segments.py); reloading an unchanged file is a no-op
+    source_path TEXT,       -- Segment file, relative to the repo root
+    content_sha256 TEXT,    -- Hash of the segment file as last loaded
+
+    -- Trash: hidden everywhere while set; purged after the retention period
+    deleted_at TIMESTAMPTZ,
+    deleted_reason TEXT,    -- 'deleted' or 'merged into <id>'
+
     -- Full-text search (includes summary for text search, not semantic)
     search_vector tsvector GENERATED ALWAYS AS (
         setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
@@ -81,6 +101,149 @@ CREATE TABLE stories (
     updated_at TIMESTAMPTZ DEFAULT now()
 );
 
+-- Identifiers other systems use for a story (segment file, show's own IDs, ...)
+-- Merging stories re-points these rather than breaking references.
+CREATE TABLE external_ids (
+    source_system TEXT NOT NULL,   -- e.g. 'segment_file', 'audioboom'
+    external_id TEXT NOT NULL,
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ DEFAULT now(),
+    PRIMARY KEY (source_system, external_id)
+);
+
+-- Stories opened in the TUI detail view (absent = unread)
+CREATE TABLE story_reads (
+    story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+    read_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Audit log of metadata edits made in the TUI (one row per changed field)
+CREATE TABLE story_edits (
+    id BIGSERIAL PRIMARY KEY,
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    field TEXT NOT NULL,           -- title, summary, type, location
+    old_value TEXT,
+    new_value TEXT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Stories starred in the TUI
+CREATE TABLE bookmarks (
+    story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- My 1-5 star ratings of stories, set in the TUI (absent = unrated)
+CREATE TABLE story_ratings (
+    story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+    rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+    rated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Stories flagged as hoaxes in the TUI, ranked down in weighted search
+CREATE TABLE hoax_flags (
+    story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+    flagged_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Places marked within a story's text in the TUI, by line of content
+CREATE TABLE story_marks (
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (story_id, line)
+);
+
+-- Sections of long stories, found where the subject changes
+-- (paranormal-tui chapters). content_md5 is the content they were found in;
+-- an edit leaves them stale until the next run.
+CREATE TABLE story_chapters (
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    position SMALLINT NOT NULL,
+    start_line INTEGER NOT NULL,
+    title TEXT NOT NULL,
+    content_md5 TEXT NOT NULL,
+    PRIMARY KEY (story_id, position)
+);
+
+-- Named Browse filter + sort combinations saved in the TUI
+CREATE TABLE filter_presets (
+    name TEXT PRIMARY KEY,
+    filters JSONB NOT NULL,
+    sort_field TEXT NOT NULL DEFAULT 'date',
+    sort_ascending BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Named hand-picked sets of stories, e.g. a region boxed in Visualize
+CREATE TABLE collections (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE collection_stories (
+    collection_id INTEGER NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (collection_id, story_id)
+);
+
+-- Content hashes recorded by `paranormal-tui integrity seal`. Deliberately
+-- not kept current by a trigger or foreign key: a story whose content no
+-- longer matches, or that has vanished, was changed outside a seal.
+CREATE TABLE content_hashes (
+    story_id UUID PRIMARY KEY,
+    sha256 TEXT NOT NULL,
+    sealed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Signed snapshots of content_hashes, one per seal
+CREATE TABLE corpus_manifests (
+    id BIGSERIAL PRIMARY KEY,
+    created_at TIMESTAMPTZ NOT NULL,
+    story_count INTEGER NOT NULL,
+    root_sha256 TEXT NOT NULL,
+    public_key TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    entries JSONB NOT NULL
+);
+
+-- Other tracker instances the web backend fans federated searches out to
+CREATE TABLE federation_peers (
+    name TEXT PRIMARY KEY,
+    base_url TEXT NOT NULL,        -- e.g. https://tracker.example.org
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Community members who may submit accounts through the web backend.
+-- Tokens are issued by `paranormal-tui submitters add`; only the hash is kept.
+CREATE TABLE submitters (
+    id SERIAL PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    token_sha256 TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ
+);
+
+-- Submitted first-hand accounts, held for review in the TUI before they
+-- become stories
+CREATE TABLE submissions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    submitter_id INTEGER NOT NULL REFERENCES submitters(id),
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,         -- VERBATIM as submitted
+    location TEXT,
+    time_period TEXT,
+    story_type TEXT,               -- Submitter's suggestion; the reviewer classifies
+    status TEXT NOT NULL DEFAULT 'pending',  -- pending, approved, rejected
+    review_note TEXT,
+    story_id UUID REFERENCES stories(id) ON DELETE SET NULL,
+    submitted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    reviewed_at TIMESTAMPTZ
+);
+
 -- Story chunks (for late chunking / precise retrieval)
 CREATE TABLE story_chunks (
     id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
@@ -135,19 +298,49 @@ CREATE TABLE story_clusters (
     PRIMARY KEY (story_id, cluster_id)
 );
 
+-- People, places, and dates named in a story (extract_entities.py)
+CREATE TABLE story_entities (
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('person', 'place', 'date')),
+    name TEXT NOT NULL,            -- As the story gives it, e.g. 'Salem', 'summer of 1987'
+    mentions INTEGER NOT NULL DEFAULT 1,
+    source TEXT,                   -- Model that extracted it, or 'import'
+    PRIMARY KEY (story_id, kind, name)
+);
+
+-- Where each utterance of a story is in its episode's audio (load_segments.py)
+CREATE TABLE story_utterances (
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    line INTEGER NOT NULL,         -- Line of stories.content its paragraph starts on, from 0
+    start_seconds FLOAT NOT NULL,
+    end_seconds FLOAT NOT NULL,
+    PRIMARY KEY (story_id, line)
+);
+
 -- Indexes
+CREATE INDEX idx_story_entities_name ON story_entities(kind, lower(name));
 CREATE INDEX idx_stories_episode ON stories(episode_id);
 CREATE INDEX idx_stories_embedding ON stories USING ivfflat (embedding vector_cosine_ops) WITH (lists = 100);
 CREATE INDEX idx_stories_search ON stories USING GIN (search_vector);
 CREATE INDEX idx_stories_umap ON stories(umap_x, umap_y);
 CREATE INDEX idx_stories_type ON stories(story_type);
+CREATE INDEX idx_stories_geo ON stories(latitude, longitude);
+CREATE INDEX idx_stories_geo_cluster ON stories(geo_cluster_id);
+CREATE INDEX idx_stories_deleted ON stories(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_stories_word_count ON stories(word_count);
+CREATE UNIQUE INDEX idx_stories_source_path ON stories(source_path) WHERE source_path IS NOT NULL;
 CREATE INDEX idx_transcripts_episode ON transcripts(episode_id);
+CREATE INDEX idx_external_ids_story ON external_ids(story_id);
+CREATE INDEX idx_story_edits_story ON story_edits(story_id, changed_at);
+CREATE INDEX idx_submissions_pending ON submissions(submitted_at) WHERE status = 'pending';
 
--- Trigger for updated_at
+-- Trigger for updated_at (skipped for no-op updates, e.g. recompressing content)
 CREATE OR REPLACE FUNCTION update_updated_at()
 RETURNS TRIGGER AS $$
 BEGIN
-    NEW.updated_at = now();
+    IF NEW IS DISTINCT FROM OLD THEN
+        NEW.updated_at = now();
+    END IF;
     RETURN NEW;
 END;
 $$ LANGUAGE plpgsql;
@@ -163,3 +356,22 @@ CREATE TRIGGER stories_updated_at
 CREATE TRIGGER clusters_updated_at
     BEFORE UPDATE ON clusters
     FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Word count for length filters and sorting; rows from before the column
+-- existed are filled by `paranormal-tui words backfill`
+CREATE OR REPLACE FUNCTION story_word_count(content TEXT)
+RETURNS INTEGER AS $$
+    SELECT count(*)::int FROM regexp_split_to_table(content, '\s+') w WHERE w <> ''
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION set_word_count()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.word_count = story_word_count(NEW.content);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER stories_word_count
+    BEFORE INSERT OR UPDATE OF content ON stories
+    FOR EACH ROW EXECUTE FUNCTION set_word_count();
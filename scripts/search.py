@@ -76,7 +76,7 @@ def text_search(conn, query: str, limit: int = 10) -> list[dict]:
                 substring(s.content, 1, 200) as snippet
             FROM stories s
             JOIN episodes e ON s.episode_id = e.id
-            WHERE s.search_vector @@ plainto_tsquery('english', %s)
+            WHERE s.deleted_at IS NULL AND s.search_vector @@ plainto_tsquery('english', %s)
             ORDER BY rank DESC
             LIMIT %s
             """,
@@ -98,7 +98,7 @@ def vector_search(conn, query_embedding: list[float], limit: int = 10) -> list[d
                 substring(s.content, 1, 200) as snippet
             FROM stories s
             JOIN episodes e ON s.episode_id = e.id
-            WHERE s.embedding IS NOT NULL
+            WHERE s.deleted_at IS NULL AND s.embedding IS NOT NULL
             ORDER BY s.embedding <=> %s::vector
             LIMIT %s
             """,
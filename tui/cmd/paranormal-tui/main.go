@@ -1,24 +1,241 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"paranormal-tui/internal/app"
+	"paranormal-tui/internal/bench"
+	"paranormal-tui/internal/cli"
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+	"paranormal-tui/internal/export"
+	"paranormal-tui/internal/graphics"
+	"paranormal-tui/internal/readlater"
+	"paranormal-tui/internal/session"
+	"paranormal-tui/internal/styles"
+	"paranormal-tui/internal/summarize"
+	"paranormal-tui/internal/views/browse"
+	"paranormal-tui/internal/views/present"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// subcommands run headless instead of starting the TUI
+var subcommands = map[string]func(args []string, out io.Writer) error{
+	"bench-search": bench.Run,
+	"list":         cli.List,
+	"cat":          cli.Cat,
+	"hotspots":     cli.Hotspots,
+	"trash":        cli.Trash,
+	"maintenance":  cli.Maintenance,
+	"content":      cli.Content,
+	"words":        cli.Words,
+	"archive":      cli.Archive,
+	"integrity":    cli.Integrity,
+	"submitters":   cli.Submitters,
+	"pack":         cli.Pack,
+	"chapters":     cli.Chapters,
+	"graph":        cli.Graph,
+	"anki":         cli.Anki,
+	"pdf":          cli.PDF,
+	"notes":        cli.Notes,
+	"ingest":       cli.Ingest,
+	"recovery":     cli.Recovery,
+	"changelog":    cli.Changelog,
+	"migrate":      cli.Migrate,
+	"replay":       replay,
+	"serve":        serveSSH,
+}
+
 func main() {
-	// Create and run the application
-	p := tea.NewProgram(
-		app.New(),
-		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
-	)
-
-	if _, err := p.Run(); err != nil {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	// Dates look the same in the TUI and subcommand output
+	if err := dates.Configure(cfg.Display.DateFormat, cfg.Display.Timezone); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	// Both embed queries with the same provider, too
+	if err := embed.Configure(cfg.Embedding.Provider, cfg.Embedding.Model, cfg.Embedding.URL); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	if err := summarize.Configure(cfg.LLM.Provider, cfg.LLM.Model, cfg.LLM.URL); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(os.Args) > 1 {
+		if run, ok := subcommands[os.Args[1]]; ok {
+			if err := run(os.Args[2:], os.Stdout); err != nil {
+				fmt.Fprintf(os.Stderr, "Error running %s: %v\n", os.Args[1], err)
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	// Flags override the config file
+	kiosk := flag.Bool("kiosk", false, "read-only mode for shared displays (no edits, no config writes, q does not quit)")
+	interval := flag.Duration("present-interval", present.DefaultInterval, "time each story is shown in presentation mode")
+	viewName := flag.String("view", cfg.Startup.View, "view to open first: search, browse, visualize, hotspots, trash, maintenance, compare, review, or changelog")
+	query := flag.String("query", cfg.Startup.Query, "search to run on startup")
+	storyTypes := flag.String("type", cfg.Startup.StoryType, "story types (comma-separated) to filter Browse by on startup")
+	story := flag.String("story", cfg.Startup.Story, "story to open on startup: its ID, or a deep link copied with Y in the story view")
+	record := flag.String("record", "", "record keys, mouse, and resizes to `file` for a bug report, with typed text masked (play back with replay)")
+	flag.Parse()
+
+	if _, err := app.ParseView(*viewName); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(2)
+	}
+	cfg.Startup.View, cfg.Startup.Query, cfg.Startup.StoryType = *viewName, *query, *storyTypes
+	if *story != "" {
+		if cfg.Startup.Story, err = app.ParseStoryLink(*story); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(2)
+		}
+	}
+
+	// A missing or unreadable state file just means the tour is shown
+	state, _ := config.LoadState()
+
+	settings := session.Settings{Config: cfg, Kiosk: *kiosk, PresentInterval: *interval, State: state}
+	opts, err := appOptions(settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	opts.State = config.NewStore(state)
+
+	// The plot's points are drawn as an image if the terminal can show one
+	protocol, err := graphics.Parse(cfg.Visualize.Graphics, os.Getenv)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	if protocol != graphics.None {
+		opts.Graphics = graphics.NewScreen(protocol, os.Stdout)
+	}
+
+	// Stories are only sent or exported from here, not from replays or SSH
+	// sessions
+	if opts.ReadLater, err = readlater.New(cfg.ReadLater); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	if _, err := export.FileName(cfg.Export.FileName, &db.Story{}); err != nil {
+		fmt.Fprintf(os.Stderr, "Error in config: %v\n", err)
+		os.Exit(1)
+	}
+	opts.Export = &cfg.Export
+
+	programOpts := []tea.ProgramOption{tea.WithAltScreen(), tea.WithMouseCellMotion()}
+	var recorder *session.Recorder
+	if *record != "" {
+		recorder, err = session.Create(context.Background(), *record, settings)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error starting recording: %v\n", err)
+			os.Exit(1)
+		}
+		programOpts = append(programOpts, tea.WithFilter(recorder.Filter(typing)))
+	}
+
+	// Create and run the application, timing frames written to the
+	// terminal so a slow one can be drawn less
+	model := app.New(opts)
+	programOpts = append(programOpts, tea.WithOutput(model.TimeOutput(os.Stdout)))
+	p := tea.NewProgram(model, programOpts...)
+	_, err = p.Run()
+	if recorder != nil {
+		if err := recorder.Close(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+	}
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error running TUI: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+// appOptions turns the settings the TUI is started with into its options,
+// all but the state store
+func appOptions(s session.Settings) (app.Options, error) {
+	cfg := s.Config
+	startupView, err := app.ParseView(cfg.Startup.View)
+	if err != nil {
+		return app.Options{}, err
+	}
+
+	rowColor, err := styles.ParseRowColor(cfg.Display.RowColor)
+	if err != nil {
+		return app.Options{}, err
+	}
+
+	fidelity, err := app.ParseFidelity(cfg.Display.Fidelity)
+	if err != nil {
+		return app.Options{}, err
+	}
+
+	var story string
+	if cfg.Startup.Story != "" {
+		if story, err = app.ParseStoryLink(cfg.Startup.Story); err != nil {
+			return app.Options{}, err
+		}
+	}
+
+	var columns []browse.Column
+	for _, c := range cfg.Browse.Columns {
+		column, err := browse.NewColumn(c.Name, c.Width)
+		if err != nil {
+			return app.Options{}, err
+		}
+		columns = append(columns, column)
+	}
+
+	return app.Options{
+		Kiosk:             s.Kiosk,
+		PresentInterval:   s.PresentInterval,
+		StartupView:       startupView,
+		StartupQuery:      cfg.Startup.Query,
+		StartupStory:      story,
+		StartupStoryTypes: splitList(cfg.Startup.StoryType),
+		BrowseColumns:     columns,
+		BrowsePreview:     cfg.Browse.Preview,
+		BrowseContinuous:  cfg.Browse.Continuous,
+		RowColor:          rowColor,
+		VisualizeBlocks:   cfg.Visualize.Blocks,
+		ProjectCommand:    cfg.Visualize.ProjectCommand,
+		Fidelity:          fidelity,
+		TrashRetention:    cfg.Trash.Retention(),
+		AudioDir:          cfg.Audio.Directory(),
+		AudioPlayer:       cfg.Audio.Player,
+	}, nil
+}
+
+// typing reports whether the TUI is taking keys into a text field
+func typing(model tea.Model) bool {
+	m, ok := model.(app.Model)
+	return ok && m.Typing()
+}
+
+// splitList splits a comma-separated flag value, dropping blanks
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"paranormal-tui/internal/app"
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// replay plays a session recorded with --record back through the TUI,
+// started with the settings it was recorded with.
+//
+//	replay [--speed N] [--force] FILE
+//
+// Run it against a database seeded with the stories the session was
+// recorded against (e.g. restored from the same dump with
+// scripts/db_restore.sh); the replay acts on the database as the session
+// did, bookmarks and edits included. The user's state file is left alone.
+// Use a terminal at least as large as the recorded one.
+func replay(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	speed := fs.Float64("speed", 1, "playback speed; 2 plays twice as fast")
+	force := fs.Bool("force", false, "replay even if the database holds different stories than the recording was made against")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("usage: replay [--speed N] [--force] FILE")
+	}
+	if *speed <= 0 {
+		return errors.New("--speed must be positive")
+	}
+
+	header, events, err := session.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	stories, corpus, err := session.Corpus(ctx, database)
+	database.Close()
+	if err != nil {
+		return err
+	}
+	if corpus != header.Corpus && !*force {
+		return fmt.Errorf("the database has %d stories but the recording was made against %d, or their content differs; "+
+			"restore the database it was recorded against, or pass --force", stories, header.Stories)
+	}
+
+	cfg := header.Settings.Config
+	if err := dates.Configure(cfg.Display.DateFormat, cfg.Display.Timezone); err != nil {
+		return err
+	}
+	opts, err := appOptions(header.Settings)
+	if err != nil {
+		return err
+	}
+	opts.State = config.NewMemoryStore(header.Settings.State)
+
+	p := tea.NewProgram(app.New(opts), tea.WithAltScreen(), tea.WithMouseCellMotion(), tea.WithFilter(session.ReplayFilter))
+	go session.Play(p, events, *speed)
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Replayed %d events recorded %s\n", len(events), dates.Time(header.Recorded))
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/serve"
+	"paranormal-tui/internal/session"
+	"paranormal-tui/internal/views/present"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// serveSSH serves the TUI over SSH until interrupted, logging connections
+// to out. The serve section of the config says who can connect and what
+// each can do; see package serve.
+//
+//	serve [--addr ADDR] [--read-only]
+func serveSSH(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "", "address to listen on, overriding serve.address")
+	readOnly := fs.Bool("read-only", false, "make every session read-only, whatever the user's scope")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if *addr != "" {
+		cfg.Serve.Address = *addr
+	}
+	opts, err := appOptions(session.Settings{Config: cfg, PresentInterval: present.DefaultInterval})
+	if err != nil {
+		return err
+	}
+	opts.ReadOnly = *readOnly
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	connect := db.New
+	if *readOnly {
+		connect = db.NewReadOnly
+	}
+	database, err := connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+	opts.Database = database
+
+	// Styles are drawn for the visitors' terminals, not the one the server
+	// runs in (if any)
+	lipgloss.SetColorProfile(termenv.ANSI256)
+	lipgloss.SetHasDarkBackground(true)
+
+	server, err := serve.New(cfg.Serve, opts, log.New(out, "", log.LstdFlags))
+	if err != nil {
+		return err
+	}
+	return server.Serve(ctx)
+}
@@ -4,14 +4,30 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
+	"paranormal-tui/internal/config"
 	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/graphics"
+	"paranormal-tui/internal/hints"
+	"paranormal-tui/internal/quota"
+	"paranormal-tui/internal/readlater"
 	"paranormal-tui/internal/styles"
 	"paranormal-tui/internal/views/browse"
+	"paranormal-tui/internal/views/changelog"
+	"paranormal-tui/internal/views/compare"
 	"paranormal-tui/internal/views/detail"
+	"paranormal-tui/internal/views/edit"
+	"paranormal-tui/internal/views/hotspots"
+	"paranormal-tui/internal/views/maintenance"
+	"paranormal-tui/internal/views/newstory"
+	"paranormal-tui/internal/views/present"
+	"paranormal-tui/internal/views/review"
 	"paranormal-tui/internal/views/search"
+	"paranormal-tui/internal/views/trash"
 	"paranormal-tui/internal/views/visualize"
 
+	"github.com/charmbracelet/bubbles/cursor"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -30,24 +46,162 @@ type Model struct {
 	browseView    browse.Model
 	visualizeView visualize.Model
 	detailView    detail.Model
+	editView      edit.Model
+	newView       newstory.Model
+	presentView   present.Model
+	hotspotsView  hotspots.Model
+	trashView     trash.Model
+	maintView     maintenance.Model
+	compareView   compare.Model
+	reviewView    review.Model
+	changelogView changelog.Model
 
 	// State
 	currentView View
 	showDetail  bool
+	showEdit    bool
+	showNew     bool
+	exporting   bool   // Waiting for the format to export the open story in
+	listed      bool   // The open story was opened from the Browse or Search list, so ctrl+n/p step through it
+	lastShow    string // Show the last story added in the TUI was filed under
+	showPresent bool
 	showHelp    bool
+	showTour    bool
+	tourStep    int
+	tourReturn  View   // View to go back to when the tour ends
+	notice      string // Alert shown in the status bar until dismissed
+	hints       *hints.Engine
+	hint        string // One-time tip shown in the status bar for a while
+	hintID      string
+	showMetrics bool
+	metrics     *metrics // Performance HUD counters, shared by every copy of the model
+	fidelity    Fidelity
+	reduced     bool // Drawing less for a slow terminal
 	width       int
 	height      int
 	keys        KeyMap
+	opts        Options
+
+	// The open story's audio, if it's playing from p
+	playback *playback
+
+	// A new summary of the open story, being written with R
+	regen *regeneration
+}
+
+// Options configures optional application behavior
+type Options struct {
+	// Kiosk runs the TUI read-only for unattended shared displays:
+	// mutating actions and config writes are disabled and q does not quit
+	Kiosk bool
+
+	// ReadOnly disables mutating actions and config writes, as kiosk mode
+	// does, for someone at the keyboard who isn't allowed to make changes
+	ReadOnly bool
+
+	// Remote runs the TUI in someone else's terminal, over SSH: links and
+	// audio, which would open on this machine, can't be followed
+	Remote bool
+
+	// Database, if set, is used instead of connecting, and left open on
+	// quit, so SSH sessions share one pool
+	Database *db.DB
+
+	// Quota, if set, rations vector searches, projections, clusterings, and
+	// regenerated summaries for whoever is at the keyboard; nil is no limit
+	Quota *quota.User
+
+	// PresentInterval is how long each story is shown in presentation mode
+	PresentInterval time.Duration
+
+	// StartupView is the view shown once the database connects
+	StartupView View
+	// StartupQuery, if set, is searched for on startup
+	StartupQuery string
+	// StartupStory, if set, is the ID of a story opened on startup, from a
+	// deep link
+	StartupStory string
+	// StartupStoryTypes, if set, pre-filter Browse
+	StartupStoryTypes []string
+
+	// BrowseColumns is the Browse list layout; empty uses the default
+	BrowseColumns []browse.Column
+
+	// BrowsePreview opens Browse with the summary preview pane shown
+	BrowsePreview bool
+	// BrowseContinuous opens Browse as one scrolling list instead of pages
+	BrowseContinuous bool
+
+	// RowColor colors Browse and Search titles by rating or read status
+	RowColor styles.RowColor
+
+	// VisualizeBlocks draws the plot with block symbols instead of braille
+	VisualizeBlocks bool
+	// Fidelity is whether to draw less for slow terminals: automatically
+	// once frames are slow, always, or never
+	Fidelity Fidelity
+
+	// Graphics, if set, draws the Visualize plot's points as an image, on
+	// a terminal that can show one
+	Graphics *graphics.Screen
+
+	// ReadLater, if set, is where s in the story view sends the story
+	ReadLater readlater.Service
+
+	// Export, if set, is where E in the story view writes the story
+	Export *config.Export
+
+	// ProjectCommand recomputes the UMAP projection (U in Visualize);
+	// empty runs scripts/project_umap.py
+	ProjectCommand []string
+
+	// TrashRetention is how long trashed stories are kept before purging
+	TrashRetention time.Duration
+
+	// AudioDir holds the downloaded episode audio, for playing a story
+	// from a timestamp in its text
+	AudioDir string
+
+	// AudioPlayer plays a story's audio with p in the story view: "mpv"
+	// or "ffplay"; empty uses whichever is installed
+	AudioPlayer string
+
+	// State is what the TUI remembers between runs: whether the tour was
+	// taken and which hints were shown
+	State *config.Store
 }
 
 // New creates a new application model
-func New() Model {
+func New(opts Options) Model {
+	keys := DefaultKeyMap()
+	if opts.Kiosk {
+		// Visitors shouldn't be able to quit; ctrl+c still exits immediately
+		keys.Quit = key.NewBinding(
+			key.WithKeys("ctrl+c"),
+			key.WithHelp("ctrl+c", "quit"),
+		)
+	}
+
+	if opts.State == nil {
+		opts.State = config.NewStore(config.State{})
+	}
+
 	return Model{
-		keys:       DefaultKeyMap(),
+		keys:       keys,
 		connecting: true,
+		opts:       opts,
+		hints:      hints.New(opts.State),
+		metrics:    &metrics{},
+		fidelity:   opts.Fidelity,
+		reduced:    opts.Fidelity == FidelityReduced,
 	}
 }
 
+// ReadOnly reports whether mutating actions are disabled
+func (m Model) ReadOnly() bool {
+	return m.opts.Kiosk || m.opts.ReadOnly
+}
+
 // Init initializes the application
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -59,14 +213,24 @@ func (m Model) Init() tea.Cmd {
 func (m Model) connectDB() tea.Cmd {
 	return func() tea.Msg {
 		ctx := context.Background()
-		database, err := db.New(ctx)
-		if err != nil {
-			return DBConnectedMsg{Err: err}
+		database := m.opts.Database
+		if database == nil {
+			// Sessions that change nothing leave the schema alone
+			connect := db.New
+			if m.ReadOnly() {
+				connect = db.NewReadOnly
+			}
+			var err error
+			if database, err = connect(ctx); err != nil {
+				return DBConnectedMsg{Err: err}
+			}
 		}
 
 		count, err := database.GetStoryCount(ctx)
 		if err != nil {
-			database.Close()
+			if m.opts.Database == nil {
+				database.Close()
+			}
 			return DBConnectedMsg{Err: err}
 		}
 
@@ -74,8 +238,22 @@ func (m Model) connectDB() tea.Cmd {
 	}
 }
 
-// Update handles messages
+// Update handles messages, then shows a hint if the result calls for one.
+// The commands returned are counted for the metrics HUD until they finish.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.showMetrics {
+		m.metrics.msgs++
+	}
+	model, cmd := m.update(msg)
+	next := model.(Model)
+	next.checkFidelity()
+	if hint := next.checkHints(); hint != nil {
+		cmd = tea.Batch(cmd, hint)
+	}
+	return next, m.metrics.track(cmd)
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -96,39 +274,177 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Initialize views with database
 		m.searchView = search.New(m.database)
+		m.searchView.SetQuota(m.opts.Quota)
 		m.browseView = browse.New(m.database)
+		m.browseView.SetColumns(m.opts.BrowseColumns)
+		m.browseView.SetReadOnly(m.ReadOnly())
+		m.browseView.SetPreview(m.opts.BrowsePreview)
+		m.browseView.SetContinuous(m.opts.BrowseContinuous)
+		m.browseView.SetRowColor(m.opts.RowColor)
+		m.searchView.SetRowColor(m.opts.RowColor)
 		m.visualizeView = visualize.New(m.database)
+		m.visualizeView.SetBlocks(m.opts.VisualizeBlocks)
+		m.visualizeView.SetProjectCommand(m.opts.ProjectCommand)
+		m.visualizeView.SetGraphics(m.opts.Graphics.Protocol() != graphics.None)
+		m.applyFidelity()
+		m.visualizeView.SetReadOnly(m.ReadOnly())
+		// An export is written on this machine, not a remote visitor's
+		m.visualizeView.SetExport(!m.ReadOnly() && !m.opts.Remote)
+		m.visualizeView.SetQuota(m.opts.Quota)
+		if v, ok := m.opts.State.Get().Viewports[m.database.Key()]; ok {
+			m.visualizeView.RestoreViewport(v)
+		}
 		m.detailView = detail.New()
+		m.detailView.RestoreLayout(m.opts.State.Get().Reading)
+		m.presentView = present.New(m.database, m.opts.PresentInterval)
+		m.hotspotsView = hotspots.New(m.database)
+		m.trashView = trash.New(m.database, m.opts.TrashRetention, m.ReadOnly())
+		m.maintView = maintenance.New(m.database, m.ReadOnly())
+		m.compareView = compare.New(m.database)
+		m.compareView.SetQuota(m.opts.Quota)
+		m.reviewView = review.New(m.database, m.ReadOnly())
+		m.changelogView = changelog.New(m.database)
+		if m.opts.Export != nil && !m.ReadOnly() && !m.opts.Remote {
+			m.changelogView.SetExportDir(m.opts.Export.Directory())
+		}
 
 		m.updateViewSizes()
 
-		// Start on browse view and load data
-		m.currentView = ViewBrowse
-		return m, m.browseView.Init()
+		return m, m.startup()
+
+	case cursor.BlinkMsg:
+		// Each blink is a redraw, which slow terminals can do without
+		if m.reduced {
+			return m, nil
+		}
+
+	case metricsTickMsg:
+		if !m.showMetrics || msg.Gen != m.metrics.gen {
+			return m, nil
+		}
+		m.metrics.sample()
+		return m, m.metricsTick()
 
 	case tea.KeyMsg:
+		// The metrics HUD toggles from anywhere, text fields included
+		if key.Matches(msg, m.keys.Metrics) {
+			return m.toggleMetrics()
+		}
+		if key.Matches(msg, m.keys.Fidelity) {
+			m.cycleFidelity()
+			return m, nil
+		}
+
 		// Global keys (when not in detail mode)
 		if m.showHelp {
-			if msg.String() == "?" || msg.String() == "esc" {
+			switch msg.String() {
+			case "?", "esc":
 				m.showHelp = false
-				return m, nil
+			case "t":
+				m.showHelp = false
+				return m, m.startTour()
 			}
 			return m, nil
 		}
 
+		if m.showTour && msg.String() != "ctrl+c" {
+			return m.handleTourKeys(msg)
+		}
+
+		if m.showEdit && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.editView, cmd = m.editView.Update(msg)
+			return m, cmd
+		}
+
+		if m.showNew && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.newView, cmd = m.newView.Update(msg)
+			return m, cmd
+		}
+
 		if m.showDetail {
+			if m.detailView.InputActive() && msg.String() != "ctrl+c" {
+				var cmd tea.Cmd
+				m.detailView, cmd = m.detailView.Update(msg)
+				return m, cmd
+			}
+			if m.exporting {
+				return m, m.handleExportKeys(msg)
+			}
+			if m.regen != nil {
+				if cmd, used := m.handleSummaryKeys(msg); used {
+					return m, cmd
+				}
+			}
+			if key.Matches(msg, m.keys.Edit) {
+				if story := m.detailView.Story(); story != nil {
+					return m, m.openEdit(*story)
+				}
+			}
 			if msg.String() == "esc" || msg.String() == "q" {
 				m.showDetail = false
+				m.stopAudio()
+				m.discardSummary()
+				return m, nil
+			}
+			if msg.String() == "E" {
+				m.startExport()
 				return m, nil
 			}
+			if key.Matches(msg, m.keys.Bookmark) {
+				return m, m.toggleBookmark()
+			}
+			if k := msg.String(); len(k) == 1 && k >= "0" && k <= "5" && !m.ReadOnly() {
+				return m, m.rateStory(int(k[0] - '0'))
+			}
+			if msg.String() == "X" && !m.ReadOnly() {
+				return m, m.toggleHoax()
+			}
+			if msg.String() == "m" && !m.ReadOnly() {
+				return m, m.toggleMark()
+			}
+			if msg.String() == "s" {
+				return m, m.sendToReadLater()
+			}
+			if msg.String() == "o" {
+				return m, m.openEpisode()
+			}
+			if msg.String() == "p" {
+				return m, m.toggleAudio()
+			}
+			if msg.String() == "R" {
+				return m, m.regenerateSummary()
+			}
+			if msg.String() == "Y" {
+				return m, m.copyStoryLink()
+			}
+			if msg.String() == "ctrl+n" || msg.String() == "ctrl+p" {
+				delta := 1
+				if msg.String() == "ctrl+p" {
+					delta = -1
+				}
+				return m, m.stepStory(delta)
+			}
 			var cmd tea.Cmd
 			m.detailView, cmd = m.detailView.Update(msg)
 			return m, cmd
 		}
 
+		if m.showPresent {
+			return m.handlePresentKeys(msg)
+		}
+
+		// Text fields get every key except ctrl+c
+		if m.capturingInput() && msg.String() != "ctrl+c" {
+			break
+		}
+
 		// Global quit
 		if key.Matches(msg, m.keys.Quit) {
-			if m.database != nil {
+			m.saveViewport()
+			m.stopAudio()
+			if m.database != nil && m.opts.Database == nil {
 				m.database.Close()
 			}
 			return m, tea.Quit
@@ -140,39 +456,178 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// View switching
-		if key.Matches(msg, m.keys.View1) {
-			m.currentView = ViewSearch
-			m.searchView.Focus()
-			return m, nil
+		// Edit the selected story; without one the key falls through
+		if key.Matches(msg, m.keys.Edit) {
+			if story := m.selectedStory(); story != nil {
+				return m, m.openEdit(*story)
+			}
+		}
+
+		if key.Matches(msg, m.keys.NewStory) {
+			return m, m.openNewStory()
 		}
-		if key.Matches(msg, m.keys.View2) {
-			if m.currentView != ViewBrowse {
-				m.currentView = ViewBrowse
-				return m, m.browseView.Reload()
+
+		// Star the selected story; without one the key falls through
+		if key.Matches(msg, m.keys.Bookmark) {
+			if cmd := m.toggleBookmark(); cmd != nil {
+				return m, cmd
 			}
-			return m, nil
 		}
-		if key.Matches(msg, m.keys.View3) {
-			if m.currentView != ViewVisualize {
-				m.currentView = ViewVisualize
-				return m, m.visualizeView.Reload()
+
+		// Presentation mode cycles through the current browse filter
+		if key.Matches(msg, m.keys.Present) {
+			m.showPresent = true
+			return m, m.presentView.Start(m.browseView.Filters(), m.browseView.Sort())
+		}
+
+		// View switching
+		for i, binding := range []key.Binding{
+			m.keys.View1, m.keys.View2, m.keys.View3, m.keys.View4,
+			m.keys.View5, m.keys.View6, m.keys.View7, m.keys.View8,
+			m.keys.View9,
+		} {
+			if key.Matches(msg, binding) {
+				return m, m.switchView(View(i))
 			}
+		}
+
+	// Async results go to the view that requested them, even if it's
+	// not the one on screen (e.g. a startup query while on Visualize)
+	case search.SearchResultsMsg:
+		var cmd tea.Cmd
+		m.searchView, cmd = m.searchView.Update(msg)
+		return m, cmd
+
+	case browse.StoriesLoadedMsg, browse.StoriesAppendedMsg, browse.LocationsLoadedMsg, browse.PlaceResolvedMsg,
+		browse.PresetsLoadedMsg, browse.PresetsChangedMsg, browse.PrefetchTickMsg, browse.PagePrefetchedMsg:
+		var cmd tea.Cmd
+		m.browseView, cmd = m.browseView.Update(msg)
+		return m, cmd
+
+	case browse.StoryTrashedMsg:
+		var cmd tea.Cmd
+		m.browseView, cmd = m.browseView.Update(msg)
+		if msg.Err != nil {
+			return m, cmd
+		}
+		m.storyCount--
+		m.notice = fmt.Sprintf("Trashed %q • 5: trash", msg.Title)
+		return m, tea.Batch(cmd, m.trashView.Reload())
+
+	case trash.TrashLoadedMsg:
+		var cmd tea.Cmd
+		m.trashView, cmd = m.trashView.Update(msg)
+		return m, cmd
+
+	case trash.StoryRestoredMsg:
+		var cmd tea.Cmd
+		m.trashView, cmd = m.trashView.Update(msg)
+		if msg.Err != nil {
+			return m, cmd
+		}
+		m.storyCount++
+		return m, tea.Batch(cmd, m.browseView.Reload())
+
+	case maintenance.HealthLoadedMsg, maintenance.ActionDoneMsg:
+		var cmd tea.Cmd
+		m.maintView, cmd = m.maintView.Update(msg)
+		return m, cmd
+
+	case changelog.ChangelogLoadedMsg, changelog.ChangelogExportedMsg:
+		var cmd tea.Cmd
+		m.changelogView, cmd = m.changelogView.Update(msg)
+		return m, cmd
+
+	case compare.ComparedMsg:
+		var cmd tea.Cmd
+		m.compareView, cmd = m.compareView.Update(msg)
+		return m, cmd
+
+	case stateSavedMsg:
+		if msg.Err != nil {
+			m.notice = fmt.Sprintf("Couldn't save state: %v", msg.Err)
+		}
+		return m, nil
+
+	case hintExpiredMsg:
+		if msg.ID == m.hintID {
+			m.hint, m.hintID = "", ""
+		}
+		return m, nil
+
+	case tea.MouseMsg:
+		// Only the visible view gets the mouse, in its own coordinates
+		if m.showHelp || m.showTour || m.showEdit || m.showNew || m.showDetail || m.showPresent || m.currentView != ViewVisualize {
 			return m, nil
 		}
+		msg.Y -= lipgloss.Height(m.renderTabBar())
+		var cmd tea.Cmd
+		m.visualizeView, cmd = m.visualizeView.Update(msg)
+		return m, cmd
+
+	case review.SubmissionsLoadedMsg:
+		var cmd tea.Cmd
+		m.reviewView, cmd = m.reviewView.Update(msg)
+		return m, cmd
+
+	case review.SubmissionReviewedMsg:
+		var cmd tea.Cmd
+		m.reviewView, cmd = m.reviewView.Update(msg)
+		if msg.Err != nil || msg.StoryID == "" {
+			return m, cmd
+		}
+		m.storyCount++
+		m.notice = fmt.Sprintf("Approved %q into the corpus", msg.Title)
+		return m, tea.Batch(cmd, m.browseView.Reload())
+
+	case visualize.UmapPointsLoadedMsg, visualize.CollectionSavedMsg, visualize.ProjectionProgressMsg, visualize.ProjectionTickMsg, visualize.ClustersSavedMsg, visualize.ImageExportedMsg:
+		var cmd tea.Cmd
+		m.visualizeView, cmd = m.visualizeView.Update(msg)
+		return m, cmd
+
+	case hotspots.FlapsDetectedMsg:
+		var cmd tea.Cmd
+		m.hotspotsView, cmd = m.hotspotsView.Update(msg)
+		if n := len(m.hotspotsView.Recent()); n > 0 && m.currentView != ViewHotspots {
+			m.notice = fmt.Sprintf("⚠ %d recent hotspot alert(s) • 4: view", n)
+		}
+		return m, cmd
+
+	case hotspots.FlapSelectedMsg:
+		// Browse the stories inside the flap's cylinder
+		from, to := msg.Flap.From, msg.Flap.To
+		center := msg.Flap.Center
+		m.browseView.SetFilters(db.BrowseFilters{
+			Near:      &center,
+			NearLabel: msg.Flap.Place,
+			RadiusKm:  msg.Flap.RadiusKm,
+			DateFrom:  &from,
+			DateTo:    &to,
+		})
+		m.currentView = ViewBrowse
+		return m, m.browseView.Reload()
 
 	// Handle story selection from any view
 	case browse.StorySelectedMsg:
-		m.showDetail = true
-		m.detailView.SetStory(&msg.Story)
-		m.detailView.SetSize(m.width-4, m.height-6)
-		return m, nil
+		cmd := m.openDetail(&msg.Story, "")
+		m.listed = true
+		return m, cmd
+
+	case browse.StoryStepMsg:
+		if !m.showDetail || !m.listed || m.currentView != ViewBrowse {
+			return m, nil
+		}
+		cmd := m.openDetail(&msg.Story, "")
+		m.listed = true
+		return m, cmd
 
 	case search.StorySelectedMsg:
-		m.showDetail = true
-		m.detailView.SetStory(&msg.Story)
-		m.detailView.SetSize(m.width-4, m.height-6)
-		return m, nil
+		cmd := m.openDetail(&msg.Story, msg.Query)
+		m.listed = true
+		return m, cmd
+
+	case compare.StorySelectedMsg:
+		return m, m.openDetail(&msg.Story, msg.Query)
 
 	case visualize.StorySelectedMsg:
 		// Load full story from DB
@@ -185,17 +640,276 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return StorySelectedMsg{Story: story}
 		}
 
+	case detail.RelatedSelectedMsg:
+		return m, func() tea.Msg {
+			story, err := m.database.GetStoryByID(context.Background(), msg.StoryID)
+			if err != nil {
+				return ErrorMsg{Err: err}
+			}
+			return StorySelectedMsg{Story: story}
+		}
+
+	case detail.LinkSelectedMsg:
+		return m, m.followLink(msg)
+
+	case detail.EntitySelectedMsg:
+		return m, m.browseEntity(msg)
+
+	case detail.LayoutChangedMsg:
+		m.opts.State.Update(func(s *config.State) { s.Reading = msg.Layout })
+		return m, m.saveState()
+
+	case linkFollowedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+		} else {
+			m.notice = msg.Notice
+		}
+		return m, nil
+
+	case audioStartedMsg:
+		return m, m.startedAudio(msg)
+
+	case audioEndedMsg:
+		if m.playback != nil && m.playback.player == msg.Player {
+			m.playback = nil
+			m.detailView.SetPlayback(nil)
+		}
+		return m, nil
+
+	case audioTickMsg:
+		if p := m.playback; p != nil && p.player == msg.Player && p.ticks == msg.Tick && !p.resumed.IsZero() {
+			m.showPlayback()
+			return m, tickAudio(p)
+		}
+		return m, nil
+
+	case storyExportedMsg:
+		if msg.Err != nil {
+			m.notice = "Export failed: " + msg.Err.Error()
+		} else {
+			m.notice = "Exported to " + msg.Path
+		}
+		return m, nil
+
+	case readLaterSentMsg:
+		if msg.Err != nil {
+			m.notice = fmt.Sprintf("Couldn't send to %s: %v", msg.Service, msg.Err)
+		} else {
+			m.notice = fmt.Sprintf("Sent %q to %s", msg.Title, msg.Service)
+		}
+		return m, nil
+
+	case episodeFoundMsg:
+		return m, m.browseEpisode(msg)
+
 	case StorySelectedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
 		if msg.Story != nil {
-			m.showDetail = true
-			m.detailView.SetStory(msg.Story)
-			m.detailView.SetSize(m.width-4, m.height-6)
+			return m, m.openDetail(msg.Story, "")
+		}
+		return m, nil
+
+	case edit.SavedMsg:
+		var cmd tea.Cmd
+		m.editView, cmd = m.editView.Update(msg)
+		if msg.Err != nil {
+			return m, cmd
+		}
+		m.showEdit = false
+		m.notice = fmt.Sprintf("Saved %d change(s) to %q", len(msg.Changes), msg.Story.Title)
+		if story := m.detailView.Story(); m.showDetail && story != nil && story.ID == msg.Story.ID {
+			updated := msg.Story
+			m.detailView.SetStory(&updated)
+		}
+		return m, tea.Batch(cmd, m.browseView.Reload())
+
+	case edit.CancelledMsg:
+		m.showEdit = false
+		return m, nil
+
+	case newstory.EditedMsg:
+		var cmd tea.Cmd
+		m.newView, cmd = m.newView.Update(msg)
+		return m, cmd
+
+	case newstory.SavedMsg:
+		var cmd tea.Cmd
+		m.newView, cmd = m.newView.Update(msg)
+		if msg.Err != nil {
+			return m, cmd
+		}
+		m.showNew = false
+		m.lastShow = msg.Show
+		m.storyCount++
+		m.notice = fmt.Sprintf("Added %q • embedding...", msg.Title)
+		return m, tea.Batch(cmd, m.browseView.Reload(), newstory.Embed(m.database, msg.ID, msg.Title, msg.Content))
+
+	case newstory.EmbeddedMsg:
+		switch {
+		case msg.Err != nil:
+			m.notice = fmt.Sprintf("Added %q but couldn't embed it: %v", msg.Title, msg.Err)
+		case msg.StoryType != "":
+			m.notice = fmt.Sprintf("Embedded %q and classified it as %s", msg.Title, strings.ReplaceAll(msg.StoryType, "_", " "))
+		default:
+			m.notice = fmt.Sprintf("Embedded %q", msg.Title)
+		}
+		return m, m.browseView.Reload()
+
+	case newstory.CancelledMsg:
+		m.showNew = false
+		return m, nil
+
+	case BookmarkToggledMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.browseView.SetBookmarked(msg.ID, msg.Bookmarked)
+		m.searchView.SetBookmarked(msg.ID, msg.Bookmarked)
+		m.compareView.SetBookmarked(msg.ID, msg.Bookmarked)
+		m.detailView.SetBookmarked(msg.ID, msg.Bookmarked)
+		m.notice = "Bookmark removed"
+		if msg.Bookmarked {
+			m.notice = "★ Bookmarked"
+		}
+		return m, nil
+
+	case RatingSetMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.browseView.SetRating(msg.ID, msg.Rating)
+		m.searchView.SetRating(msg.ID, msg.Rating)
+		m.detailView.SetRating(msg.ID, msg.Rating)
+		m.notice = "Rating cleared"
+		if msg.Rating > 0 {
+			m.notice = "Rated " + strings.Repeat("★", msg.Rating)
+		}
+		return m, nil
+
+	case HoaxToggledMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.browseView.SetHoax(msg.ID, msg.Hoax)
+		m.searchView.SetHoax(msg.ID, msg.Hoax)
+		m.detailView.SetHoax(msg.ID, msg.Hoax)
+		m.notice = "Hoax flag removed"
+		if msg.Hoax {
+			m.notice = "Flagged as a hoax"
+		}
+		return m, nil
+
+	case ChaptersLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetChapters(msg.ID, msg.Chapters)
+		return m, nil
+
+	case MarksLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetMarks(msg.ID, msg.Lines)
+		return m, nil
+
+	case RelatedLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetRelated(msg.ID, msg.Stories)
+		return m, nil
+
+	case NeighborsLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetNeighbors(msg.ID, msg.Stories)
+		return m, nil
+
+	case EntitiesLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetEntities(msg.ID, msg.Entities)
+		return m, nil
+
+	case NoteLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetNote(msg.ID, msg.Body)
+		return m, nil
+
+	case UtterancesLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetUtterances(msg.ID, msg.Utterances)
+		return m, nil
+
+	case summaryChunkMsg:
+		return m, m.updateSummary(msg)
+
+	case summarySavedMsg:
+		return m, m.savedSummary(msg)
+
+	case detail.CitationMsg:
+		return m, m.copyCitation(msg.Text)
+
+	case EpisodeLinksLoadedMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetEpisode(msg.ID, msg.Episode)
+		return m, nil
+
+	case MarkToggledMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
+		}
+		m.detailView.SetMark(msg.ID, msg.Line, msg.Marked)
+		m.notice = "Mark removed"
+		if msg.Marked {
+			m.notice = "Marked; t lists marks in the outline"
+		}
+		return m, nil
+
+	case StoryReadMsg:
+		if msg.Err != nil {
+			m.notice = msg.Err.Error()
+			return m, nil
 		}
+		m.browseView.MarkRead(msg.ID)
+		m.searchView.MarkRead(msg.ID)
+		m.compareView.MarkRead(msg.ID)
 		return m, nil
 	}
 
-	// Route to current view
+	// Presentation runs alongside the current view
 	var cmd tea.Cmd
+	if m.showPresent {
+		m.presentView, cmd = m.presentView.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+
+	// Route to current view
 	switch m.currentView {
 	case ViewSearch:
 		m.searchView, cmd = m.searchView.Update(msg)
@@ -203,12 +917,385 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.browseView, cmd = m.browseView.Update(msg)
 	case ViewVisualize:
 		m.visualizeView, cmd = m.visualizeView.Update(msg)
+	case ViewHotspots:
+		m.hotspotsView, cmd = m.hotspotsView.Update(msg)
+	case ViewTrash:
+		m.trashView, cmd = m.trashView.Update(msg)
+	case ViewMaintenance:
+		m.maintView, cmd = m.maintView.Update(msg)
+	case ViewCompare:
+		m.compareView, cmd = m.compareView.Update(msg)
+	case ViewReview:
+		m.reviewView, cmd = m.reviewView.Update(msg)
+	case ViewChangelog:
+		m.changelogView, cmd = m.changelogView.Update(msg)
 	}
 	cmds = append(cmds, cmd)
 
 	return m, tea.Batch(cmds...)
 }
 
+// startup applies the configured startup view, query, and filter
+func (m *Model) startup() tea.Cmd {
+	if len(m.opts.StartupStoryTypes) > 0 {
+		m.browseView.SetStoryTypeFilter(m.opts.StartupStoryTypes)
+	}
+
+	// Browse always loads so it is ready when switched to, and hotspot
+	// detection runs in the background so it can raise an alert
+	cmds := []tea.Cmd{m.browseView.Init(), m.hotspotsView.Init()}
+
+	if m.opts.StartupQuery != "" {
+		cmds = append(cmds, m.searchView.SetQuery(m.opts.StartupQuery))
+	}
+	if m.opts.StartupStory != "" {
+		cmds = append(cmds, LoadStoryCmd(m.database, m.opts.StartupStory))
+	}
+
+	m.currentView = m.opts.StartupView
+	switch m.currentView {
+	case ViewSearch:
+		if m.opts.StartupQuery == "" {
+			m.searchView.Focus()
+		}
+	case ViewVisualize:
+		cmds = append(cmds, m.visualizeView.Reload())
+	case ViewTrash:
+		cmds = append(cmds, m.trashView.Reload())
+	case ViewMaintenance:
+		cmds = append(cmds, m.maintView.Reload())
+	case ViewCompare:
+		m.compareView.Focus()
+	case ViewReview:
+		cmds = append(cmds, m.reviewView.Reload())
+	case ViewChangelog:
+		cmds = append(cmds, m.changelogView.Reload())
+	}
+
+	if m.opts.Kiosk {
+		// Kiosk displays cycle through stories unattended
+		m.showPresent = true
+		cmds = append(cmds, m.presentView.Start(m.browseView.Filters(), m.browseView.Sort()))
+	} else if !m.opts.State.Get().TourDone {
+		cmds = append(cmds, m.startTour())
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// switchView shows a view, loading what it displays if it wasn't already
+// on screen. Views that hold alerts clear the status bar notice.
+func (m *Model) switchView(v View) tea.Cmd {
+	changed := v != m.currentView
+	m.currentView = v
+
+	switch v {
+	case ViewSearch:
+		m.searchView.Focus()
+	case ViewBrowse:
+		if changed {
+			return m.browseView.Reload()
+		}
+	case ViewVisualize:
+		if changed {
+			return m.visualizeView.Reload()
+		}
+	case ViewHotspots:
+		m.notice = ""
+	case ViewTrash:
+		if changed {
+			m.notice = ""
+			return m.trashView.Reload()
+		}
+	case ViewMaintenance:
+		if changed {
+			m.notice = ""
+			return m.maintView.Reload()
+		}
+	case ViewCompare:
+		m.notice = ""
+		m.compareView.Focus()
+	case ViewReview:
+		if changed {
+			m.notice = ""
+			return m.reviewView.Reload()
+		}
+	case ViewChangelog:
+		if changed {
+			return m.changelogView.Reload()
+		}
+	}
+	return nil
+}
+
+// capturingInput reports whether the current view is editing text
+func (m Model) capturingInput() bool {
+	switch m.currentView {
+	case ViewSearch:
+		return m.searchView.Typing()
+	case ViewBrowse:
+		return m.browseView.InputActive()
+	case ViewCompare:
+		return m.compareView.InputActive()
+	case ViewReview:
+		return m.reviewView.InputActive()
+	case ViewVisualize:
+		return m.visualizeView.InputActive()
+	}
+	return false
+}
+
+// Typing reports whether keys are going into a text field, so a session
+// recording can mask them
+func (m Model) Typing() bool {
+	switch {
+	case m.showEdit || m.showNew:
+		return true
+	case m.showHelp || m.showTour || m.showDetail || m.showPresent:
+		return false
+	}
+	switch m.currentView {
+	case ViewSearch:
+		return m.searchView.Typing()
+	case ViewBrowse:
+		return m.browseView.Typing()
+	case ViewCompare:
+		return m.compareView.InputActive()
+	case ViewReview:
+		return m.reviewView.InputActive()
+	case ViewVisualize:
+		return m.visualizeView.Typing()
+	}
+	return false
+}
+
+func (m Model) handlePresentKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc" || msg.String() == "q":
+		m.showPresent = false
+		m.presentView.Stop()
+		return m, nil
+	case key.Matches(msg, m.keys.Quit):
+		m.saveViewport()
+		m.stopAudio()
+		return m, tea.Quit
+	case key.Matches(msg, m.keys.Enter):
+		// Open the story on screen; the presentation waits underneath
+		if story := m.presentView.CurrentStory(); story != nil {
+			m.presentView.Pause()
+			return m, m.openDetail(story, "")
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.presentView, cmd = m.presentView.Update(msg)
+	return m, cmd
+}
+
+// openDetail shows a story in the detail modal and marks it read. A query
+// maps where its terms occur.
+func (m *Model) openDetail(story *db.Story, query string) tea.Cmd {
+	m.showDetail = true
+	m.exporting = false
+	m.listed = false
+	if m.playback != nil && m.playback.storyID != story.ID {
+		m.stopAudio()
+	}
+	if m.regen != nil && m.regen.storyID != story.ID {
+		m.discardSummary()
+	}
+	m.detailView.SetQuery(query)
+	m.detailView.SetStory(story)
+	m.detailView.SetSize(m.width-4, m.height-6)
+
+	id := story.ID
+	loadChapters := func() tea.Msg {
+		chapters, err := m.database.Chapters(context.Background(), id)
+		return ChaptersLoadedMsg{ID: id, Chapters: chapters, Err: err}
+	}
+	loadMarks := func() tea.Msg {
+		lines, err := m.database.Marks(context.Background(), id)
+		return MarksLoadedMsg{ID: id, Lines: lines, Err: err}
+	}
+	loadRelated := func() tea.Msg {
+		stories, err := m.database.SimilarStories(context.Background(), id, detail.RelatedCount)
+		return RelatedLoadedMsg{ID: id, Stories: stories, Err: err}
+	}
+	loadNeighbors := func() tea.Msg {
+		stories, err := m.database.NearestStories(context.Background(), id, detail.NeighborCount)
+		return NeighborsLoadedMsg{ID: id, Stories: stories, Err: err}
+	}
+	loadEpisode := func() tea.Msg {
+		episode, err := m.database.StoryEpisodeLinks(context.Background(), id)
+		return EpisodeLinksLoadedMsg{ID: id, Episode: episode, Err: err}
+	}
+	loadEntities := func() tea.Msg {
+		entities, err := m.database.StoryEntities(context.Background(), id)
+		return EntitiesLoadedMsg{ID: id, Entities: entities, Err: err}
+	}
+	loadNote := func() tea.Msg {
+		body, err := m.database.Note(context.Background(), id)
+		return NoteLoadedMsg{ID: id, Body: body, Err: err}
+	}
+	loadUtterances := func() tea.Msg {
+		utterances, err := m.database.StoryUtterances(context.Background(), id)
+		return UtterancesLoadedMsg{ID: id, Utterances: utterances, Err: err}
+	}
+	if m.ReadOnly() || story.Read {
+		return tea.Batch(loadChapters, loadMarks, loadRelated, loadNeighbors, loadEpisode, loadEntities, loadNote, loadUtterances)
+	}
+	return tea.Batch(loadChapters, loadMarks, loadRelated, loadNeighbors, loadEpisode, loadEntities, loadNote, loadUtterances, func() tea.Msg {
+		return StoryReadMsg{ID: id, Err: m.database.MarkRead(context.Background(), id)}
+	})
+}
+
+// stepStory opens the next (delta 1) or previous (-1) story in the list the
+// open story was opened from, keeping the story view open. The list's
+// cursor follows, so closing the story leaves it on the last one read.
+func (m *Model) stepStory(delta int) tea.Cmd {
+	if !m.listed {
+		m.notice = "ctrl+n/p step through Browse or Search; this story wasn't opened from either"
+		return nil
+	}
+
+	var story *db.Story
+	var query string
+	var cmd tea.Cmd
+	switch m.currentView {
+	case ViewBrowse:
+		story, cmd = m.browseView.Step(delta)
+	case ViewSearch:
+		story, query = m.searchView.Step(delta)
+	}
+	if story == nil {
+		if cmd == nil {
+			m.notice = "No more stories in the list"
+		}
+		return cmd
+	}
+
+	s := *story
+	open := m.openDetail(&s, query)
+	m.listed = true
+	return tea.Batch(open, cmd)
+}
+
+// selectedStory is the story highlighted in Browse or Search, if any
+func (m Model) selectedStory() *db.Story {
+	switch m.currentView {
+	case ViewBrowse:
+		return m.browseView.SelectedStory()
+	case ViewSearch:
+		return m.searchView.SelectedStory()
+	case ViewCompare:
+		return m.compareView.SelectedStory()
+	}
+	return nil
+}
+
+// openEdit shows the metadata edit form for a story
+func (m *Model) openEdit(story db.Story) tea.Cmd {
+	if m.ReadOnly() {
+		return nil
+	}
+	m.showEdit = true
+	m.editView = edit.New(m.database, story)
+	m.editView.SetSize(m.width-4, m.height-6)
+	return m.editView.Init()
+}
+
+// openNewStory shows the form for adding a story, filed under the show
+// the last one was
+func (m *Model) openNewStory() tea.Cmd {
+	// $EDITOR would open on this machine, not the remote user's
+	if m.ReadOnly() || m.opts.Remote {
+		return nil
+	}
+	m.showNew = true
+	m.newView = newstory.New(m.database, m.lastShow)
+	m.newView.SetSize(m.width-4, m.height-6)
+	return m.newView.Init()
+}
+
+// toggleBookmark stars or unstars the story in the detail modal or the
+// current view's selection. It returns nil if there's no such story.
+func (m Model) toggleBookmark() tea.Cmd {
+	if m.ReadOnly() || m.database == nil {
+		return nil
+	}
+
+	var id string
+	switch {
+	case m.showDetail:
+		if story := m.detailView.Story(); story != nil {
+			id = story.ID
+		}
+	case m.currentView == ViewBrowse:
+		if story := m.browseView.SelectedStory(); story != nil {
+			id = story.ID
+		}
+	case m.currentView == ViewSearch:
+		if story := m.searchView.SelectedStory(); story != nil {
+			id = story.ID
+		}
+	case m.currentView == ViewCompare:
+		if story := m.compareView.SelectedStory(); story != nil {
+			id = story.ID
+		}
+	case m.currentView == ViewVisualize:
+		id = m.visualizeView.SelectedStoryID()
+	}
+	if id == "" {
+		return nil
+	}
+
+	return func() tea.Msg {
+		bookmarked, err := m.database.ToggleBookmark(context.Background(), id)
+		return BookmarkToggledMsg{ID: id, Bookmarked: bookmarked, Err: err}
+	}
+}
+
+// rateStory sets the rating of the story open in the detail view
+func (m Model) rateStory(rating int) tea.Cmd {
+	story := m.detailView.Story()
+	if story == nil {
+		return nil
+	}
+	id := story.ID
+	return func() tea.Msg {
+		err := m.database.SetRating(context.Background(), id, rating)
+		return RatingSetMsg{ID: id, Rating: rating, Err: err}
+	}
+}
+
+// toggleHoax flags or unflags the story in the detail view as a hoax
+func (m Model) toggleHoax() tea.Cmd {
+	story := m.detailView.Story()
+	if story == nil {
+		return nil
+	}
+	id := story.ID
+	return func() tea.Msg {
+		hoax, err := m.database.ToggleHoax(context.Background(), id)
+		return HoaxToggledMsg{ID: id, Hoax: hoax, Err: err}
+	}
+}
+
+// toggleMark marks or unmarks the line at the top of the story open in
+// the detail view
+func (m Model) toggleMark() tea.Cmd {
+	story := m.detailView.Story()
+	if story == nil {
+		return nil
+	}
+	id, line := story.ID, m.detailView.MarkLine()
+	return func() tea.Msg {
+		marked, err := m.database.ToggleMark(context.Background(), id, line)
+		return MarkToggledMsg{ID: id, Line: line, Marked: marked, Err: err}
+	}
+}
+
 func (m *Model) updateViewSizes() {
 	contentHeight := m.height - 4 // Account for tab bar and status bar
 	contentWidth := m.width - 2
@@ -216,11 +1303,29 @@ func (m *Model) updateViewSizes() {
 	m.searchView.SetSize(contentWidth, contentHeight)
 	m.browseView.SetSize(contentWidth, contentHeight)
 	m.visualizeView.SetSize(contentWidth, contentHeight)
+	m.hotspotsView.SetSize(contentWidth, contentHeight)
+	m.trashView.SetSize(contentWidth, contentHeight)
+	m.maintView.SetSize(contentWidth, contentHeight)
+	m.compareView.SetSize(contentWidth, contentHeight)
+	m.reviewView.SetSize(contentWidth, contentHeight)
+	m.changelogView.SetSize(contentWidth, contentHeight)
 	m.detailView.SetSize(m.width-4, m.height-6)
+	m.editView.SetSize(m.width-4, m.height-6)
+	m.newView.SetSize(m.width-4, m.height-6)
+	m.presentView.SetSize(m.width, m.height)
 }
 
-// View renders the application
+// View renders the application, timing it for the metrics HUD and to
+// notice a slow terminal
 func (m Model) View() string {
+	start := time.Now()
+	view := m.view()
+	m.showGraphic()
+	m.metrics.rendered(time.Since(start))
+	return view
+}
+
+func (m Model) view() string {
 	if m.connecting {
 		return m.renderConnecting()
 	}
@@ -233,10 +1338,19 @@ func (m Model) View() string {
 		return m.renderHelp()
 	}
 
+	// Presentation takes over the whole screen
+	if m.showPresent && !m.showDetail {
+		return m.presentView.View()
+	}
+
 	var content string
 
-	// Render detail modal overlay
-	if m.showDetail {
+	// Render edit form or detail modal overlay
+	if m.showEdit {
+		content = m.editView.View()
+	} else if m.showNew {
+		content = m.newView.View()
+	} else if m.showDetail {
 		content = m.detailView.View()
 	} else {
 		// Render current view
@@ -247,9 +1361,34 @@ func (m Model) View() string {
 			content = m.browseView.View()
 		case ViewVisualize:
 			content = m.visualizeView.View()
+		case ViewHotspots:
+			content = m.hotspotsView.View()
+		case ViewTrash:
+			content = m.trashView.View()
+		case ViewMaintenance:
+			content = m.maintView.View()
+		case ViewCompare:
+			content = m.compareView.View()
+		case ViewReview:
+			content = m.reviewView.View()
+		case ViewChangelog:
+			content = m.changelogView.View()
 		}
 	}
 
+	// The tour sits below the top of the view it describes
+	if m.showTour {
+		tour := m.renderTour()
+		content = clipLines(content, m.height-4-lipgloss.Height(tour))
+		return lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.renderTabBar(),
+			content,
+			tour,
+			m.renderStatusBar(),
+		)
+	}
+
 	// Compose full screen
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
@@ -291,7 +1430,7 @@ func (m Model) renderError() string {
 }
 
 func (m Model) renderTabBar() string {
-	tabs := []string{"Search", "Browse", "Visualize"}
+	tabs := []string{"Search", "Browse", "Visualize", "Hotspots", "Trash", "Maintenance", "Compare", "Review", "Changelog"}
 	var renderedTabs []string
 
 	for i, tab := range tabs {
@@ -316,18 +1455,66 @@ func (m Model) renderTabBar() string {
 
 func (m Model) renderStatusBar() string {
 	left := fmt.Sprintf(" %d stories", m.storyCount)
+	if m.opts.Kiosk {
+		left += " • kiosk"
+	} else if m.opts.ReadOnly {
+		left += " • read-only"
+	}
+	if m.database != nil && m.database.HasReplica() {
+		left += " • replica"
+	}
+	left += m.fidelityStatus()
+	if m.notice != "" {
+		left += " • " + styles.ErrorStyle.Render(m.notice)
+	} else if m.hint != "" {
+		left += " • Tip: " + m.hint
+	}
+	if m.showMetrics {
+		left = m.renderMetrics()
+	}
 
 	viewHelp := ""
 	switch m.currentView {
 	case ViewSearch:
 		viewHelp = "enter: search • ↑↓: results"
 	case ViewBrowse:
-		viewHelp = "n/p: page • f: filter • enter: view"
+		viewHelp = "n/p: page • f: filter • d: dates • enter: view"
 	case ViewVisualize:
-		viewHelp = "arrows: move • +/-: zoom • enter: view"
+		viewHelp = "arrows/click: move • wheel: zoom • drag: pan • enter: view"
+	case ViewHotspots:
+		viewHelp = "enter: browse flap • r: rescan"
+	case ViewTrash:
+		viewHelp = "u: restore • r: refresh"
+		if m.ReadOnly() {
+			viewHelp = "r: refresh"
+		}
+	case ViewMaintenance:
+		viewHelp = "a: analyze • R: reindex • r: refresh"
+		if m.ReadOnly() {
+			viewHelp = "r: refresh"
+		}
+	case ViewCompare:
+		viewHelp = "enter: compare • m: mode • ←→: side"
+	case ViewReview:
+		viewHelp = "t: type • a: approve • x: reject"
+		if m.ReadOnly() {
+			viewHelp = "r: refresh"
+		}
+	case ViewChangelog:
+		viewHelp = "w: weeks • E: export • r: refresh"
+		if m.ReadOnly() || m.opts.Remote || m.opts.Export == nil {
+			viewHelp = "w: weeks • r: refresh"
+		}
 	}
 
-	right := fmt.Sprintf("%s • 1/2/3: views • ?: help • q: quit ", viewHelp)
+	right := fmt.Sprintf("%s • 1-9: views • ?: help • q: quit ", viewHelp)
+	if m.opts.Kiosk {
+		right = fmt.Sprintf("%s • 1-9: views • ?: help ", viewHelp)
+	}
+	if m.showMetrics {
+		// The metrics take the room
+		right = "F12: hide metrics "
+	}
 
 	gap := m.width - lipgloss.Width(left) - lipgloss.Width(right)
 	if gap < 0 {
@@ -347,6 +1534,12 @@ NAVIGATION
   1           Switch to Search view
   2           Switch to Browse view
   3           Switch to Visualize view
+  4           Switch to Hotspots view
+  5           Switch to Trash view
+  6           Switch to Maintenance view
+  7           Switch to Compare view
+  8           Switch to Review view
+  9           Switch to Changelog view
   ↑/k ↓/j     Move up/down
   ←/h →/l     Move left/right (Visualize)
   Enter       Select/view story
@@ -355,26 +1548,172 @@ NAVIGATION
 BROWSE VIEW
   n / ]       Next page
   p / [       Previous page
-  f           Filter by story type
+  f           Filter by story type (space toggles several)
+  F           Filter presets: 1-9 apply, a saves current filters + sort
+  L           Filter by location (autocomplete)
+  g           Filter by distance from a place or lat,lng
+  d           Filter by air date range, fixed or relative ("last 30 days")
   s           Cycle sort field
   S           Toggle sort direction
+  u           Toggle unread-only (unread titles are bold)
+  W           Cycle minimum length: 500, 1000, 2000, 5000 words
+  B           Toggle bookmarked-only (★)
+  C           Choose, reorder and size columns
+  v           Toggle the summary preview pane
+  i           Toggle continuous scrolling (n/p move a screenful)
+  G           Group stories under episode headers
+  Space       Collapse/expand the episode (grouped)
+  x           Move story to the trash
   c           Clear filters
+  P           Present stories matching the filters
+
+PRESENTATION
+  Space       Pause/resume
+  ←/h →/l     Previous/next story
+  Enter       Open current story
+  Esc         Exit presentation
 
 SEARCH VIEW
   Tab         Toggle search mode (Text/Hybrid/Vector)
+  Ctrl+R      Weight results by my ratings (5★ up, 1★ down) and hoax flags
+              (down) for this query
   /           Focus search input
 
 VISUALIZE VIEW
   + / =       Zoom in
   - / _       Zoom out
   r           Reset view
+  c           Color points by type, cluster, air date (old → new), or region
+  m           Toggle braille dots / block symbols
+  w           Size points by story length (· short, ● medium, ⬤ long)
+  g           Points as an image / characters, in Kitty or Sixel terminals
+  s           Spread stacked stories apart when zoomed in (from 2x)
+  n           Snap: arrows jump to the nearest story in that direction
+  Tab         Jump to the next cluster's centroid (Shift+Tab: previous)
+  f           Cycle layout: plot + info panel, full-width plot, plot + legend strip
+  i           Isolate the selected story's cluster / show all
+  I           Hide or dim other clusters while isolated
+  o           Cycle cluster overlay: centroid labels, outlines, off
+  v           Box select: arrows grow the box, Enter lists the stories inside
+              (s saves them to a named collection)
+  L           Lasso: trace an outline with the arrows or by dragging,
+              Backspace to undo, Enter lists the stories inside
+  U           Recompute the UMAP projection (runs scripts/project_umap.py)
+  C           Re-cluster stories with HDBSCAN (choose min cluster size)
+  E           Export the plot as it's shown, with its legend, to PNG or SVG
+
+HOTSPOTS VIEW
+  Enter       Browse stories in the selected flap
+  r           Rescan
+
+TRASH VIEW
+  u / Enter   Restore the selected story
+  r           Refresh
+
+MAINTENANCE VIEW
+  a           ANALYZE the story tables
+  R           REINDEX the stories table (concurrently)
+  r           Refresh
+
+COMPARE VIEW
+  Enter       Run the query under both modes
+  m / Tab     Change the focused side's mode (text, vector, hybrid α)
+  ←/h →/l     Switch side (B shows rank change vs A: ↑ ↓ = new; A marks ✗ dropped)
+  /           Focus query input
+
+REVIEW VIEW
+  t / T       Cycle the type the submission is approved as
+  a           Approve: add the submission to the corpus as a story
+  x           Reject, with an optional reason
+  r           Refresh
+
+CHANGELOG VIEW
+  w           Weeks shown: 12, 26, 52, or 104
+  E           Export to Markdown or JSON, to share with the dataset
+  r           Refresh
 
 GENERAL
+  b           Bookmark/unbookmark the selected story (any view)
+  ←/→         Switch tabs: Transcript, Summary, Metadata, Related, Notes
+              (story view)
+  1-5 / 0     Rate the open story / clear its rating (story view)
+  X           Flag/unflag the open story as a hoax (story view)
+  Ctrl+N / P  Next/previous story in the Browse or Search list it was opened
+              from, in the list's order and filters (story view)
+  w           Reading width: narrow, wide, or the whole window (story view)
+  P           Blank line between paragraphs on/off (story view)
+  L           Speaker labels on/off (story view)
+  /           Find text in the story, highlighting every match (story view)
+  n / N       Jump to the next/previous search match (story view)
+  { / }       Jump to the previous/next section of a long story (story view)
+  :           Jump to a time in the episode, e.g. :12:30 (story view)
+  t           Outline: sections, speaker changes, and marks to jump to (story view)
+  m           Mark/unmark the line at the top of the story (story view)
+  f           Follow a link: URL, "episode N", or timestamp (story view)
+  o           Open the story's episode page, or its audio, in the browser (story view)
+  p           Play the story from where it starts in its episode's audio;
+              p again pauses and resumes (story view)
+  y           Copy a timestamped citation of the paragraph at the top (story view)
+  Y           Copy a deep link to the story: the command that opens the TUI at it
+              (story view)
+  r           Related tab: stories like this one; enter opens one (story view)
+  v           Neighbors panel: the 10 nearest stories by embedding, their
+              similarity, and whether they share the story's cluster (story view)
+  c           People, places, and dates the story names; enter browses
+              the other stories naming one (story view)
+  s           Send the story to read later: Wallabag, Pocket, or email (story view)
+  E           Export the story to a Markdown or JSON file (story view)
+  R           Write a new summary with the configured LLM, shown as it's
+              written; enter saves it, esc discards it (story view)
+  e           Edit title, summary, type, location (diff shown before saving)
+  Ctrl+N      New story: details, then its text pasted or written in $EDITOR
+  F12         Show/hide performance metrics in the status bar
+  F9          Display fidelity: auto (reduced when the terminal is slow),
+              full, or reduced (plain plot, no blinking cursor)
   ?           Toggle this help
   q           Quit
 
-Press ? or Esc to close this help.
+Press t for the guided tour, ? or Esc to close this help.
 `
+	if m.opts.Kiosk {
+		help = strings.Replace(help, "  q           Quit\n", "", 1)
+	}
+	if m.opts.Kiosk || m.opts.Remote {
+		help = strings.Replace(help, "  o           Open the story's episode page, or its audio, in the browser (story view)\n", "", 1)
+		help = strings.Replace(help, "  p           Play the story from where it starts in its episode's audio;\n              p again pauses and resumes (story view)\n", "", 1)
+		help = strings.Replace(help, "  y           Copy a timestamped citation of the paragraph at the top (story view)\n", "", 1)
+		help = strings.Replace(help, "  Y           Copy a deep link to the story: the command that opens the TUI at it\n              (story view)\n", "", 1)
+	}
+	if m.ReadOnly() || m.opts.Remote || m.opts.Export == nil {
+		help = strings.Replace(help, "  E           Export the story to a Markdown or JSON file (story view)\n", "", 1)
+		help = strings.Replace(help, "  E           Export to Markdown or JSON, to share with the dataset\n", "", 1)
+	}
+	if m.ReadOnly() || m.opts.Remote || m.opts.ReadLater == nil {
+		help = strings.Replace(help, "  s           Send the story to read later: Wallabag, Pocket, or email (story view)\n", "", 1)
+	}
+	if m.ReadOnly() || m.opts.Remote {
+		help = strings.Replace(help, "  Ctrl+N      New story: details, then its text pasted or written in $EDITOR\n", "", 1)
+		help = strings.Replace(help, "  E           Export the plot as it's shown, with its legend, to PNG or SVG\n", "", 1)
+	}
+	if m.ReadOnly() {
+		help = strings.Replace(help, "  x           Move story to the trash\n", "", 1)
+		help = strings.Replace(help, "  b           Bookmark/unbookmark the selected story (any view)\n", "", 1)
+		help = strings.Replace(help, "  1-5 / 0     Rate the open story / clear its rating (story view)\n", "", 1)
+		help = strings.Replace(help, "  X           Flag/unflag the open story as a hoax (story view)\n", "", 1)
+		help = strings.Replace(help, "              (s saves them to a named collection)\n", "", 1)
+		help = strings.Replace(help, "  U           Recompute the UMAP projection (runs scripts/project_umap.py)\n", "", 1)
+		help = strings.Replace(help, "  C           Re-cluster stories with HDBSCAN (choose min cluster size)\n", "", 1)
+		help = strings.Replace(help, "  m           Mark/unmark the line at the top of the story (story view)\n", "", 1)
+		help = strings.Replace(help, "  R           Write a new summary with the configured LLM, shown as it's\n              written; enter saves it, esc discards it (story view)\n", "", 1)
+		help = strings.Replace(help, "  e           Edit title, summary, type, location (diff shown before saving)\n", "", 1)
+		help = strings.Replace(help, "  u / Enter   Restore the selected story\n", "", 1)
+		help = strings.Replace(help, "  a           ANALYZE the story tables\n", "", 1)
+		help = strings.Replace(help, "  R           REINDEX the stories table (concurrently)\n", "", 1)
+		help = strings.Replace(help, "  t / T       Cycle the type the submission is approved as\n", "", 1)
+		help = strings.Replace(help, "  a           Approve: add the submission to the corpus as a story\n", "", 1)
+		help = strings.Replace(help, "  x           Reject, with an optional reason\n", "", 1)
+	}
+
 	helpBox := lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
 		BorderForeground(styles.Primary).
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"paranormal-tui/internal/launch"
+	"paranormal-tui/internal/views/detail"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// playback is the open story's audio, played from where the story starts
+// in its episode with p in the story view
+type playback struct {
+	player  *launch.Player
+	storyID string
+	start   float64       // Seconds into the episode it started from
+	end     float64       // 0 if the story's end isn't known
+	played  time.Duration // Up to the last pause
+	resumed time.Time     // When it last started or resumed; zero while paused
+	ticks   int           // Counts resumes, so only the latest run of ticks carries on
+}
+
+// at is how far into the episode the audio is
+func (p *playback) at() time.Duration {
+	d := p.played
+	if !p.resumed.IsZero() {
+		d += time.Since(p.resumed)
+	}
+	return time.Duration(p.start*float64(time.Second)) + d
+}
+
+// audioStartedMsg reports the player started for a story
+type audioStartedMsg struct {
+	StoryID string
+	Player  *launch.Player
+	Start   float64
+	End     float64
+	Err     error
+}
+
+// audioEndedMsg reports a player finished or stopped
+type audioEndedMsg struct {
+	Player *launch.Player
+}
+
+// audioTickMsg moves the clock in the story view's footer on
+type audioTickMsg struct {
+	Player *launch.Player
+	Tick   int
+}
+
+// toggleAudio plays the open story from where it starts in its episode's
+// audio, or pauses or resumes it if it's playing. Like links, the audio
+// would play on this machine, so not for kiosk visitors or over SSH.
+func (m *Model) toggleAudio() tea.Cmd {
+	story := m.detailView.Story()
+	if story == nil {
+		return nil
+	}
+	var err error
+	switch {
+	case m.opts.Kiosk:
+		err = errors.New("audio can't be played in kiosk mode")
+	case m.opts.Remote:
+		err = errors.New("audio plays on the server, so can't be played over SSH")
+	}
+	if err != nil {
+		return func() tea.Msg { return linkFollowedMsg{Err: err} }
+	}
+
+	if p := m.playback; p != nil && p.storyID == story.ID {
+		if p.resumed.IsZero() {
+			if err := p.player.Resume(); err != nil {
+				m.notice = err.Error()
+				return nil
+			}
+			p.resumed = time.Now()
+			p.ticks++
+			m.showPlayback()
+			return tickAudio(p)
+		}
+		if err := p.player.Pause(); err != nil {
+			m.notice = err.Error()
+			return nil
+		}
+		p.played += time.Since(p.resumed)
+		p.resumed = time.Time{}
+		m.showPlayback()
+		return nil
+	}
+
+	m.stopAudio()
+	id, audioDir, player := story.ID, m.opts.AudioDir, m.opts.AudioPlayer
+	return func() tea.Msg {
+		segment, err := m.database.StoryAudioSegment(context.Background(), id)
+		if err != nil {
+			return audioStartedMsg{StoryID: id, Err: err}
+		}
+		if segment == nil {
+			return audioStartedMsg{StoryID: id, Err: errors.New("this story has no audio file or start time")}
+		}
+		path := filepath.Join(audioDir, segment.Filename)
+		if _, err := os.Stat(path); err != nil {
+			return audioStartedMsg{StoryID: id, Err: fmt.Errorf("audio not found at %s", path)}
+		}
+		p, err := launch.Play(player, path, segment.Start, segment.End)
+		return audioStartedMsg{StoryID: id, Player: p, Start: segment.Start, End: segment.End, Err: err}
+	}
+}
+
+// startedAudio keeps track of a player just started, unless the story it
+// was started for has been closed since
+func (m *Model) startedAudio(msg audioStartedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.notice = msg.Err.Error()
+		return nil
+	}
+	if story := m.detailView.Story(); !m.showDetail || story == nil || story.ID != msg.StoryID {
+		msg.Player.Stop()
+		return nil
+	}
+
+	m.stopAudio()
+	p := &playback{
+		player:  msg.Player,
+		storyID: msg.StoryID,
+		start:   msg.Start,
+		end:     msg.End,
+		resumed: time.Now(),
+	}
+	m.playback = p
+	m.showPlayback()
+	return tea.Batch(tickAudio(p), func() tea.Msg {
+		p.player.Wait()
+		return audioEndedMsg{Player: p.player}
+	})
+}
+
+// stopAudio stops the story's audio, if it's playing, when the story is
+// closed or another opened
+func (m *Model) stopAudio() {
+	if m.playback == nil {
+		return
+	}
+	m.playback.player.Stop()
+	m.playback = nil
+	m.detailView.SetPlayback(nil)
+}
+
+// showPlayback passes the audio's state to the story view's footer
+func (m *Model) showPlayback() {
+	p := m.playback
+	if p == nil {
+		m.detailView.SetPlayback(nil)
+		return
+	}
+	var end time.Duration
+	if p.end > p.start {
+		end = time.Duration(p.end * float64(time.Second))
+	}
+	m.detailView.SetPlayback(&detail.Playback{
+		StoryID: p.storyID,
+		Paused:  p.resumed.IsZero(),
+		At:      p.at(),
+		End:     end,
+	})
+}
+
+// tickAudio moves the footer's clock on in a second
+func tickAudio(p *playback) tea.Cmd {
+	player, tick := p.player, p.ticks
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return audioTickMsg{Player: player, Tick: tick}
+	})
+}
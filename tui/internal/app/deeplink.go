@@ -0,0 +1,29 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// StoryURIPrefix starts a deep link to a story written as a URI, for notes
+// apps that only link by scheme; --story takes these as well as bare IDs
+const StoryURIPrefix = "paranormal-tui://story/"
+
+var storyIDPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// StoryCommand is a deep link to a story: the command that opens the TUI
+// at it
+func StoryCommand(id string) string {
+	return "paranormal-tui --story " + id
+}
+
+// ParseStoryLink reads the story ID from a deep link: the ID itself, or a
+// paranormal-tui://story/ URI
+func ParseStoryLink(link string) (string, error) {
+	id := strings.TrimSuffix(strings.TrimPrefix(strings.TrimSpace(link), StoryURIPrefix), "/")
+	if !storyIDPattern.MatchString(id) {
+		return "", fmt.Errorf("%q is not a story ID or a %s<id> link", link, StoryURIPrefix)
+	}
+	return strings.ToLower(id), nil
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/export"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// storyExportedMsg reports a story written to a file
+type storyExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// startExport asks which format to write the open story in. Files are
+// written on this machine, so kiosk visitors and remote sessions can't.
+func (m *Model) startExport() {
+	switch {
+	case m.detailView.Story() == nil:
+		return
+	case m.opts.Export == nil || m.ReadOnly() || m.opts.Remote:
+		m.notice = "Stories can't be exported from here"
+		return
+	}
+	m.exporting = true
+	m.notice = "Export as m: Markdown • j: JSON • esc: cancel"
+}
+
+// handleExportKeys takes the format to export in
+func (m *Model) handleExportKeys(msg tea.KeyMsg) tea.Cmd {
+	m.exporting = false
+	switch msg.String() {
+	case "m":
+		return m.exportStory(export.Markdown)
+	case "j":
+		return m.exportStory(export.JSON)
+	}
+	m.notice = ""
+	return nil
+}
+
+// exportStory writes the open story to the export directory
+func (m *Model) exportStory(format string) tea.Cmd {
+	story := m.detailView.Story()
+	if story == nil {
+		return nil
+	}
+	database, s, cfg := m.database, *story, *m.opts.Export
+	m.notice = "Exporting..."
+	return func() tea.Msg {
+		citations, err := database.Citations(context.Background(), []string{s.ID})
+		if err != nil {
+			return storyExportedMsg{Err: err}
+		}
+		var c *db.Citation
+		if found, ok := citations[s.ID]; ok {
+			c = &found
+		}
+		path, err := export.Write(&s, c, format, cfg.Directory(), cfg.FileName)
+		return storyExportedMsg{Path: path, Err: err}
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"paranormal-tui/internal/graphics"
+)
+
+// Fidelity chooses between the full display and a cheaper one for slow
+// terminals: a plot without per-cell color and with block symbols, and
+// text cursors that don't blink
+type Fidelity int
+
+const (
+	FidelityAuto    Fidelity = iota // Full until frames are slow, then reduced
+	FidelityFull                    // Always full
+	FidelityReduced                 // Always reduced
+)
+
+// ParseFidelity reads the display.fidelity config value
+func ParseFidelity(s string) (Fidelity, error) {
+	switch s {
+	case "", "auto":
+		return FidelityAuto, nil
+	case "full":
+		return FidelityFull, nil
+	case "reduced":
+		return FidelityReduced, nil
+	}
+	return FidelityAuto, fmt.Errorf("unknown fidelity %q (want auto, full, or reduced)", s)
+}
+
+const (
+	// slowFrame is how long rendering or writing a frame can take before
+	// it counts as slow
+	slowFrame = 50 * time.Millisecond
+	// slowStreak is how many slow frames in a row reduce fidelity, so one
+	// big redraw doesn't
+	slowStreak = 10
+	// frameBytes is the smallest write counted as a frame; cursor moves
+	// and the like are always quick
+	frameBytes = 256
+)
+
+// timedOutput is the terminal, timing each frame written to it. Over a
+// slow link such as SSH, rendering is quick and the writes block. Any image
+// is drawn over each frame, and timed with it.
+type timedOutput struct {
+	*os.File
+	mt     *metrics
+	screen *graphics.Screen
+}
+
+func (t timedOutput) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := t.File.Write(p)
+	if len(p) >= frameBytes {
+		if t.screen != nil && err == nil {
+			// The text is written either way; a failed image leaves the
+			// plot blank until the next frame
+			_ = t.screen.Draw(t.File)
+		}
+		if time.Since(start) > slowFrame {
+			t.mt.slowWrites.Add(1)
+		} else {
+			t.mt.slowWrites.Store(0)
+		}
+	}
+	return n, err
+}
+
+// TimeOutput wraps the terminal, for tea.WithOutput, so slow writes count
+// toward reducing fidelity. It is still the terminal as far as Bubble Tea
+// can tell.
+func (m Model) TimeOutput(f *os.File) io.Writer {
+	return &timedOutput{File: f, mt: m.metrics, screen: m.opts.Graphics}
+}
+
+// slow reports whether frames have been consistently slow to render or
+// write
+func (mt *metrics) slow() bool {
+	return mt.slowViews >= slowStreak || mt.slowWrites.Load() >= slowStreak
+}
+
+// applyFidelity passes the current fidelity to the views that draw less
+// when it is reduced
+func (m *Model) applyFidelity() {
+	m.visualizeView.SetReduced(m.reduced)
+}
+
+// checkFidelity reduces fidelity once frames are consistently slow, in
+// auto mode. It stays reduced until changed with F9: the reduced display
+// is quicker, so slowness can't be judged from it.
+func (m *Model) checkFidelity() {
+	if m.fidelity == FidelityAuto && !m.reduced && m.metrics.slow() {
+		m.reduced = true
+		m.applyFidelity()
+	}
+}
+
+// cycleFidelity steps auto → full → reduced → auto
+func (m *Model) cycleFidelity() {
+	m.fidelity = (m.fidelity + 1) % 3
+	m.reduced = m.fidelity == FidelityReduced
+	m.metrics.slowViews = 0
+	m.metrics.slowWrites.Store(0)
+	m.applyFidelity()
+}
+
+// fidelityStatus is the status bar's indicator of the display fidelity
+func (m Model) fidelityStatus() string {
+	switch {
+	case m.reduced && m.fidelity == FidelityAuto:
+		return " • reduced display: slow terminal (F9)"
+	case m.reduced:
+		return " • reduced display (F9)"
+	case m.fidelity == FidelityFull:
+		return " • full display (F9)"
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+package app
+
+import (
+	"paranormal-tui/internal/graphics"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// showGraphic hands the terminal's output the Visualize plot's image to
+// draw over this frame, or hides it when the plot isn't on screen
+func (m Model) showGraphic() {
+	screen := m.opts.Graphics
+	if screen == nil {
+		return
+	}
+	var frame *graphics.Frame
+	visible := !m.connecting && m.dbErr == nil && m.currentView == ViewVisualize &&
+		!m.showHelp && !m.showTour && !m.showEdit && !m.showNew && !m.showDetail && !m.showPresent
+	if visible {
+		if frame = m.visualizeView.Graphic(screen.CellSize()); frame != nil {
+			frame.Row += lipgloss.Height(m.renderTabBar())
+		}
+	}
+	screen.Show(frame)
+}
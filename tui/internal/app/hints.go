@@ -0,0 +1,98 @@
+package app
+
+import (
+	"time"
+
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/hints"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// hintDuration is how long a hint stays in the status bar
+const hintDuration = 8 * time.Second
+
+// hintExpiredMsg clears a hint once it has been shown long enough
+type hintExpiredMsg struct {
+	ID string
+}
+
+// stateSavedMsg reports a failed write of the state file
+type stateSavedMsg struct {
+	Err error
+}
+
+// saveState writes the state file in the background; kiosk and
+// read-only modes never write it
+func (m Model) saveState() tea.Cmd {
+	if m.ReadOnly() {
+		return nil
+	}
+	return func() tea.Msg {
+		return stateSavedMsg{Err: m.opts.State.Save()}
+	}
+}
+
+// saveViewport remembers where Visualize was left for this database and
+// writes the state file now, as a background write wouldn't finish before
+// the program exits. A failed write just means starting afresh next time.
+func (m Model) saveViewport() {
+	if m.ReadOnly() || m.database == nil {
+		return
+	}
+	key, v := m.database.Key(), m.visualizeView.Viewport()
+	m.opts.State.Update(func(s *config.State) {
+		if s.Viewports == nil {
+			s.Viewports = make(map[string]config.Viewport)
+		}
+		s.Viewports[key] = v
+	})
+	_ = m.opts.State.Save()
+}
+
+// situations lists the hints that apply to what is on screen now, most
+// specific first
+func (m Model) situations() []string {
+	if m.showDetail {
+		return []string{hints.DetailActions}
+	}
+	switch m.currentView {
+	case ViewVisualize:
+		if m.visualizeView.Overlapping() > 1 {
+			return []string{hints.VisualizeOverlap, hints.VisualizeClusters}
+		}
+		return []string{hints.VisualizeClusters}
+	case ViewBrowse:
+		if m.browseView.NoMatches() {
+			return []string{hints.BrowseNoMatches}
+		}
+	case ViewSearch:
+		if m.searchView.NoResults() {
+			return []string{hints.SearchNoResults}
+		}
+	case ViewCompare:
+		return []string{hints.CompareModes}
+	}
+	return nil
+}
+
+// checkHints shows the first hint for the current situation that hasn't
+// been shown before. Overlays and the tour suppress hints, and kiosk
+// visitors never see them.
+func (m *Model) checkHints() tea.Cmd {
+	if m.opts.Kiosk || m.database == nil || m.showHelp || m.showTour || m.showEdit || m.showNew || m.showPresent {
+		return nil
+	}
+	for _, id := range m.situations() {
+		if id == m.hintID {
+			return nil
+		}
+		if text := m.hints.Take(id); text != "" {
+			m.hint, m.hintID = text, id
+			return tea.Batch(m.saveState(), tea.Tick(hintDuration, func(time.Time) tea.Msg {
+				return hintExpiredMsg{ID: id}
+			}))
+		}
+	}
+	return nil
+}
@@ -22,6 +22,12 @@ type KeyMap struct {
 	View1 key.Binding
 	View2 key.Binding
 	View3 key.Binding
+	View4 key.Binding
+	View5 key.Binding
+	View6 key.Binding
+	View7 key.Binding
+	View8 key.Binding
+	View9 key.Binding
 
 	// Pagination
 	NextPage key.Binding
@@ -34,6 +40,24 @@ type KeyMap struct {
 	ZoomIn    key.Binding
 	ZoomOut   key.Binding
 	ResetView key.Binding
+
+	// Presentation
+	Present key.Binding
+
+	// Star the selected story
+	Bookmark key.Binding
+
+	// Edit the selected story's metadata
+	Edit key.Binding
+
+	// Add a story with the New Story form
+	NewStory key.Binding
+
+	// Show or hide the performance metrics HUD
+	Metrics key.Binding
+
+	// Cycle display fidelity: auto, full, reduced
+	Fidelity key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -91,6 +115,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("3"),
 			key.WithHelp("3", "visualize"),
 		),
+		View4: key.NewBinding(
+			key.WithKeys("4"),
+			key.WithHelp("4", "hotspots"),
+		),
+		View5: key.NewBinding(
+			key.WithKeys("5"),
+			key.WithHelp("5", "trash"),
+		),
+		View6: key.NewBinding(
+			key.WithKeys("6"),
+			key.WithHelp("6", "maintenance"),
+		),
+		View7: key.NewBinding(
+			key.WithKeys("7"),
+			key.WithHelp("7", "compare"),
+		),
+		View8: key.NewBinding(
+			key.WithKeys("8"),
+			key.WithHelp("8", "review"),
+		),
+		View9: key.NewBinding(
+			key.WithKeys("9"),
+			key.WithHelp("9", "changelog"),
+		),
 		NextPage: key.NewBinding(
 			key.WithKeys("n", "]"),
 			key.WithHelp("n", "next page"),
@@ -115,6 +163,30 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "reset view"),
 		),
+		Present: key.NewBinding(
+			key.WithKeys("P"),
+			key.WithHelp("P", "present"),
+		),
+		Bookmark: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "bookmark"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit"),
+		),
+		NewStory: key.NewBinding(
+			key.WithKeys("ctrl+n"),
+			key.WithHelp("ctrl+n", "new story"),
+		),
+		Metrics: key.NewBinding(
+			key.WithKeys("f12"),
+			key.WithHelp("f12", "metrics"),
+		),
+		Fidelity: key.NewBinding(
+			key.WithKeys("f9"),
+			key.WithHelp("f9", "fidelity"),
+		),
 	}
 }
 
@@ -128,7 +200,7 @@ func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
 		{k.Enter, k.Escape, k.Help},
-		{k.View1, k.View2, k.View3},
+		{k.View1, k.View2, k.View3, k.View4, k.View5, k.View6, k.View7, k.View8, k.View9},
 		{k.NextPage, k.PrevPage},
 		{k.Quit},
 	}
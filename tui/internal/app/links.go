@@ -0,0 +1,204 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/launch"
+	"paranormal-tui/internal/views/detail"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// linkFollowedMsg reports a URL opened, audio started, or a citation
+// copied from a story
+type linkFollowedMsg struct {
+	Notice string
+	Err    error
+}
+
+// episodeFoundMsg carries the episode an "episode N" link refers to; nil
+// if there is no such episode
+type episodeFoundMsg struct {
+	Number  string
+	Episode *db.EpisodeRef
+	Err     error
+}
+
+// followLink acts on a link picked in the story view: URLs open in the
+// browser, episode references browse that episode's stories, and
+// timestamps play the episode's audio from that point. Kiosk visitors and
+// remote sessions can only follow episode references: the rest would open
+// on this machine.
+func (m Model) followLink(msg detail.LinkSelectedMsg) tea.Cmd {
+	link := msg.Link
+	if m.opts.Kiosk && link.Kind != detail.LinkEpisode {
+		return func() tea.Msg {
+			return linkFollowedMsg{Err: errors.New("links can't be opened in kiosk mode")}
+		}
+	}
+	if m.opts.Remote && link.Kind != detail.LinkEpisode {
+		return func() tea.Msg {
+			return linkFollowedMsg{Err: errors.New("links and audio open on the server, so can't be followed over SSH")}
+		}
+	}
+
+	switch link.Kind {
+	case detail.LinkURL:
+		return func() tea.Msg {
+			if err := launch.URL(link.URL); err != nil {
+				return linkFollowedMsg{Err: err}
+			}
+			return linkFollowedMsg{Notice: "Opened " + link.URL}
+		}
+
+	case detail.LinkEpisode:
+		return func() tea.Msg {
+			episode, err := m.database.FindEpisode(context.Background(), msg.StoryID, link.Episode)
+			return episodeFoundMsg{Number: link.Episode, Episode: episode, Err: err}
+		}
+
+	case detail.LinkTimestamp:
+		audioDir := m.opts.AudioDir
+		return func() tea.Msg {
+			filename, err := m.database.EpisodeAudio(context.Background(), msg.StoryID)
+			if err != nil {
+				return linkFollowedMsg{Err: err}
+			}
+			if filename == "" {
+				return linkFollowedMsg{Err: errors.New("this story's episode has no audio file")}
+			}
+			path := filepath.Join(audioDir, filename)
+			if _, err := os.Stat(path); err != nil {
+				return linkFollowedMsg{Err: fmt.Errorf("audio not found at %s", path)}
+			}
+			if err := launch.Audio(path, link.Seconds); err != nil {
+				return linkFollowedMsg{Err: err}
+			}
+			at := time.Duration(link.Seconds) * time.Second
+			return linkFollowedMsg{Notice: fmt.Sprintf("Playing %s from %s", filename, at)}
+		}
+	}
+	return nil
+}
+
+// browseEpisode closes the story and browses the stories of the episode a
+// link referred to
+func (m *Model) browseEpisode(msg episodeFoundMsg) tea.Cmd {
+	switch {
+	case msg.Err != nil:
+		m.notice = msg.Err.Error()
+		return nil
+	case msg.Episode == nil:
+		m.notice = fmt.Sprintf("No episode %s in the corpus", msg.Number)
+		return nil
+	}
+
+	m.showDetail = false
+	m.stopAudio()
+	m.discardSummary()
+	m.browseView.SetFilters(db.BrowseFilters{
+		EpisodeID:    msg.Episode.ID,
+		EpisodeLabel: msg.Episode.Label(),
+	})
+	m.currentView = ViewBrowse
+	return m.browseView.Reload()
+}
+
+// openEpisode opens the page of the open story's episode in the browser,
+// or its audio if the feed gave no page. Like links, it would open on this
+// machine, so not for kiosk visitors or over SSH.
+func (m Model) openEpisode() tea.Cmd {
+	var err error
+	episode := m.detailView.Episode()
+	switch {
+	case m.opts.Kiosk:
+		err = errors.New("episodes can't be opened in kiosk mode")
+	case m.opts.Remote:
+		err = errors.New("episodes open on the server, so can't be opened over SSH")
+	case episode == nil:
+		err = errors.New("this story has no episode")
+	case episode.URL() == "":
+		err = errors.New("no page or audio URL is known for this episode")
+	}
+	if err != nil {
+		return func() tea.Msg { return linkFollowedMsg{Err: err} }
+	}
+
+	url := episode.URL()
+	return func() tea.Msg {
+		if err := launch.URL(url); err != nil {
+			return linkFollowedMsg{Err: err}
+		}
+		return linkFollowedMsg{Notice: "Opened " + url}
+	}
+}
+
+// copyCitation puts a citation from the story view on the clipboard. The
+// clipboard is this machine's, so not for kiosk visitors or over SSH.
+func (m Model) copyCitation(text string) tea.Cmd {
+	var err error
+	switch {
+	case m.opts.Kiosk:
+		err = errors.New("citations can't be copied in kiosk mode")
+	case m.opts.Remote:
+		err = errors.New("the clipboard is the server's, so citations can't be copied over SSH")
+	}
+	if err != nil {
+		return func() tea.Msg { return linkFollowedMsg{Err: err} }
+	}
+
+	return func() tea.Msg {
+		if err := launch.Copy(text); err != nil {
+			return linkFollowedMsg{Err: err}
+		}
+		return linkFollowedMsg{Notice: "Copied citation"}
+	}
+}
+
+// copyStoryLink puts a deep link to the open story on the clipboard, to
+// reference it from notes and chats. Like citations, not for kiosk
+// visitors or over SSH.
+func (m Model) copyStoryLink() tea.Cmd {
+	story := m.detailView.Story()
+	if story == nil {
+		return nil
+	}
+	var err error
+	switch {
+	case m.opts.Kiosk:
+		err = errors.New("links can't be copied in kiosk mode")
+	case m.opts.Remote:
+		err = errors.New("the clipboard is the server's, so links can't be copied over SSH")
+	}
+	if err != nil {
+		return func() tea.Msg { return linkFollowedMsg{Err: err} }
+	}
+
+	link := StoryCommand(story.ID)
+	return func() tea.Msg {
+		if err := launch.Copy(link); err != nil {
+			return linkFollowedMsg{Err: err}
+		}
+		return linkFollowedMsg{Notice: "Copied " + link}
+	}
+}
+
+// browseEntity closes the story and browses the stories that name the
+// person, place, or date chosen from its chips
+func (m *Model) browseEntity(msg detail.EntitySelectedMsg) tea.Cmd {
+	m.showDetail = false
+	m.stopAudio()
+	m.discardSummary()
+	m.browseView.SetFilters(db.BrowseFilters{
+		EntityKind: msg.Kind,
+		EntityName: msg.Name,
+	})
+	m.currentView = ViewBrowse
+	return m.browseView.Reload()
+}
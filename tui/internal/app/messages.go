@@ -1,6 +1,9 @@
 package app
 
 import (
+	"context"
+	"fmt"
+
 	"paranormal-tui/internal/db"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,8 +16,39 @@ const (
 	ViewSearch View = iota
 	ViewBrowse
 	ViewVisualize
+	ViewHotspots
+	ViewTrash
+	ViewMaintenance
+	ViewCompare
+	ViewReview
+	ViewChangelog
 )
 
+// ParseView converts a view name ("search", "browse", ...) to a View
+func ParseView(name string) (View, error) {
+	switch name {
+	case "search":
+		return ViewSearch, nil
+	case "browse", "":
+		return ViewBrowse, nil
+	case "visualize":
+		return ViewVisualize, nil
+	case "hotspots":
+		return ViewHotspots, nil
+	case "trash":
+		return ViewTrash, nil
+	case "maintenance":
+		return ViewMaintenance, nil
+	case "compare":
+		return ViewCompare, nil
+	case "review":
+		return ViewReview, nil
+	case "changelog":
+		return ViewChangelog, nil
+	}
+	return ViewBrowse, fmt.Errorf("unknown view %q (want search, browse, visualize, hotspots, trash, maintenance, compare, review, or changelog)", name)
+}
+
 // Messages for async operations
 
 // StoriesLoadedMsg is sent when stories are loaded from the database
@@ -50,6 +84,101 @@ type DBConnectedMsg struct {
 	Err        error
 }
 
+// StoryReadMsg is sent when opening a story has marked it read
+type StoryReadMsg struct {
+	ID  string
+	Err error
+}
+
+// BookmarkToggledMsg is sent when a story has been starred or unstarred
+type BookmarkToggledMsg struct {
+	ID         string
+	Bookmarked bool
+	Err        error
+}
+
+// RatingSetMsg is sent when a story has been rated (0 = rating cleared)
+type RatingSetMsg struct {
+	ID     string
+	Rating int
+	Err    error
+}
+
+// HoaxToggledMsg is sent when a story has been flagged or unflagged as a
+// hoax
+type HoaxToggledMsg struct {
+	ID   string
+	Hoax bool
+	Err  error
+}
+
+// ChaptersLoadedMsg carries the sections of the story opened in detail
+type ChaptersLoadedMsg struct {
+	ID       string
+	Chapters []db.Chapter
+	Err      error
+}
+
+// MarksLoadedMsg carries the marked lines of the story opened in detail
+type MarksLoadedMsg struct {
+	ID    string
+	Lines []int
+	Err   error
+}
+
+// RelatedLoadedMsg carries the stories related to the one opened in detail
+type RelatedLoadedMsg struct {
+	ID      string
+	Stories []db.RelatedStory
+	Err     error
+}
+
+// NeighborsLoadedMsg carries the stories nearest the one opened in detail
+// by embedding
+type NeighborsLoadedMsg struct {
+	ID      string
+	Stories []db.RelatedStory
+	Err     error
+}
+
+// EntitiesLoadedMsg carries the people, places, and dates named by the
+// story opened in detail
+type EntitiesLoadedMsg struct {
+	ID       string
+	Entities []db.Entity
+	Err      error
+}
+
+// NoteLoadedMsg carries my note on the story opened in detail
+type NoteLoadedMsg struct {
+	ID   string
+	Body string
+	Err  error
+}
+
+// UtterancesLoadedMsg carries where each paragraph of the story opened in
+// detail is in its episode's audio
+type UtterancesLoadedMsg struct {
+	ID         string
+	Utterances []db.Utterance
+	Err        error
+}
+
+// EpisodeLinksLoadedMsg carries the episode of the story opened in detail
+type EpisodeLinksLoadedMsg struct {
+	ID      string
+	Episode *db.EpisodeLinks
+	Err     error
+}
+
+// MarkToggledMsg is sent when a line of a story has been marked or unmarked
+type MarkToggledMsg struct {
+	ID     string
+	Line   int
+	Marked bool
+	Err    error
+}
+
 // ErrorMsg represents an error that occurred
 type ErrorMsg struct {
 	Err error
@@ -89,7 +218,7 @@ func SearchCmd(database *db.DB, query string, limit int) tea.Cmd {
 // LoadStoryCmd creates a command to load a single story
 func LoadStoryCmd(database *db.DB, id string) tea.Cmd {
 	return func() tea.Msg {
-		story, err := database.GetStoryByID(nil, id)
+		story, err := database.GetStoryByID(context.Background(), id)
 		return StorySelectedMsg{Story: story, Err: err}
 	}
 }
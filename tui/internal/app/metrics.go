@@ -0,0 +1,136 @@
+package app
+
+import (
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"paranormal-tui/internal/db"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// metricsInterval is how often the metrics HUD samples the runtime
+const metricsInterval = time.Second
+
+// metricsTickMsg samples the runtime for the HUD. Gen ties it to the
+// time the HUD was shown, so toggling quickly doesn't start a second
+// ticker.
+type metricsTickMsg struct {
+	Gen int
+}
+
+// metrics is shared by every copy of the model, so View can time itself
+// and commands count themselves out from the goroutines they run in
+type metrics struct {
+	gen     int
+	pending atomic.Int64 // Commands started and not yet returned
+
+	// Slow frames in a row, for reducing fidelity: rendered by View, and
+	// written to the terminal (from the renderer's goroutine)
+	slowViews  int
+	slowWrites atomic.Int64
+
+	// Counted since the last sample
+	since   time.Time
+	frames  int
+	msgs    int
+	render  time.Duration
+	slowest time.Duration
+
+	// The last sample, as shown
+	fps        float64
+	msgRate    float64
+	avgRender  time.Duration
+	maxRender  time.Duration
+	goroutines int
+	heap       uint64
+}
+
+// rendered counts a frame that took d to render
+func (mt *metrics) rendered(d time.Duration) {
+	if d > slowFrame {
+		mt.slowViews++
+	} else {
+		mt.slowViews = 0
+	}
+	mt.frames++
+	mt.render += d
+	mt.slowest = max(mt.slowest, d)
+}
+
+// sample takes the rates since the last sample and reads the runtime
+func (mt *metrics) sample() {
+	elapsed := time.Since(mt.since).Seconds()
+	if elapsed > 0 {
+		mt.fps = float64(mt.frames) / elapsed
+		mt.msgRate = float64(mt.msgs) / elapsed
+	}
+	mt.avgRender = 0
+	if mt.frames > 0 {
+		mt.avgRender = mt.render / time.Duration(mt.frames)
+	}
+	mt.maxRender = mt.slowest
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	mt.heap = mem.HeapAlloc
+	mt.goroutines = runtime.NumGoroutine()
+
+	mt.since = time.Now()
+	mt.frames, mt.msgs, mt.render, mt.slowest = 0, 0, 0, 0
+}
+
+// track counts cmd as pending until it returns. The commands of a batch
+// it returns are counted in turn.
+func (mt *metrics) track(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	mt.pending.Add(1)
+	return func() tea.Msg {
+		msg := cmd()
+		mt.pending.Add(-1)
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for i, c := range batch {
+				batch[i] = mt.track(c)
+			}
+		}
+		return msg
+	}
+}
+
+// toggleMetrics shows or hides the HUD, sampling from scratch when shown
+func (m Model) toggleMetrics() (Model, tea.Cmd) {
+	m.showMetrics = !m.showMetrics
+	if !m.showMetrics {
+		return m, nil
+	}
+	m.metrics.gen++
+	m.metrics.since = time.Now()
+	m.metrics.frames, m.metrics.msgs, m.metrics.render, m.metrics.slowest = 0, 0, 0, 0
+	return m, m.metricsTick()
+}
+
+func (m Model) metricsTick() tea.Cmd {
+	gen := m.metrics.gen
+	return tea.Tick(metricsInterval, func(time.Time) tea.Msg {
+		return metricsTickMsg{Gen: gen}
+	})
+}
+
+// renderMetrics is the HUD, shown in the status bar in place of the story
+// count and notices. Frame times are for rendering the view, not for
+// drawing it to the terminal.
+func (m Model) renderMetrics() string {
+	mt := m.metrics
+	return fmt.Sprintf(" %.0f fps • render %s (max %s) • %.0f msgs/s • %d cmds pending • %d goroutines • heap %s",
+		mt.fps, formatMillis(mt.avgRender), formatMillis(mt.maxRender), mt.msgRate,
+		mt.pending.Load(), mt.goroutines, db.FormatBytes(int64(mt.heap)))
+}
+
+// formatMillis shows a duration in milliseconds to a tenth
+func formatMillis(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,53 @@
+package app
+
+import (
+	"context"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/readlater"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// readLaterSentMsg reports a story sent to the read-later service
+type readLaterSentMsg struct {
+	Service string
+	Title   string
+	Err     error
+}
+
+// sendToReadLater sends the open story to the configured read-later
+// service. It goes from this machine with its owner's account, so kiosk
+// visitors and remote sessions can't send.
+func (m *Model) sendToReadLater() tea.Cmd {
+	service := m.opts.ReadLater
+	story := m.detailView.Story()
+	switch {
+	case story == nil:
+		return nil
+	case service == nil:
+		m.notice = "No read-later service set up (read_later in the config)"
+		return nil
+	case m.ReadOnly() || m.opts.Remote:
+		m.notice = "Stories can't be sent to read later from here"
+		return nil
+	}
+
+	m.notice = "Sending to " + service.Name() + "..."
+	database, s := m.database, *story
+	return func() tea.Msg {
+		ctx := context.Background()
+		sent := readLaterSentMsg{Service: service.Name(), Title: s.Title}
+		citations, err := database.Citations(ctx, []string{s.ID})
+		if err != nil {
+			sent.Err = err
+			return sent
+		}
+		var c *db.Citation
+		if found, ok := citations[s.ID]; ok {
+			c = &found
+		}
+		sent.Err = service.Send(ctx, readlater.FromStory(&s, c))
+		return sent
+	}
+}
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/quota"
+	"paranormal-tui/internal/summarize"
+	"paranormal-tui/internal/views/detail"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// regeneration is a new summary for the open story, written by the model
+// with R and shown as it streams in, until it's saved or discarded
+type regeneration struct {
+	storyID string
+	old     string
+	model   string
+	text    strings.Builder
+	chunks  <-chan summarize.Chunk
+	cancel  context.CancelFunc
+	done    bool
+	err     error
+}
+
+// summaryChunkMsg carries a piece of a new summary as it's written
+type summaryChunkMsg struct {
+	chunks <-chan summarize.Chunk
+	Chunk  summarize.Chunk
+}
+
+// summarySavedMsg reports a new summary saved to the database
+type summarySavedMsg struct {
+	StoryID string
+	Summary string
+	Err     error
+}
+
+// regenerateSummary has the model write a new summary of the open story,
+// streamed into the Summary tab. It's only saved once approved.
+func (m *Model) regenerateSummary() tea.Cmd {
+	story := m.detailView.Story()
+	switch {
+	case story == nil || m.regen != nil:
+		return nil
+	case m.ReadOnly():
+		m.notice = "Summaries can't be changed here"
+		return nil
+	}
+	if err := m.opts.Quota.Allow(quota.Summary); err != nil {
+		m.notice = "Can't regenerate the summary: " + err.Error()
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &regeneration{
+		storyID: story.ID,
+		old:     story.Summary.String,
+		model:   summarize.Model(),
+		chunks:  summarize.Stream(ctx, story.Title, story.Content),
+		cancel:  cancel,
+	}
+	m.regen = r
+	m.showDraft()
+	return waitSummary(r.chunks)
+}
+
+// waitSummary reads the next piece of a new summary
+func waitSummary(chunks <-chan summarize.Chunk) tea.Cmd {
+	return func() tea.Msg {
+		chunk, ok := <-chunks
+		if !ok {
+			chunk = summarize.Chunk{Done: true}
+		}
+		return summaryChunkMsg{chunks: chunks, Chunk: chunk}
+	}
+}
+
+// updateSummary adds a piece of the new summary to the Summary tab,
+// unless it's since been discarded
+func (m *Model) updateSummary(msg summaryChunkMsg) tea.Cmd {
+	r := m.regen
+	if r == nil || r.chunks != msg.chunks || r.done {
+		return nil
+	}
+	if !msg.Chunk.Done {
+		r.text.WriteString(msg.Chunk.Text)
+		m.showDraft()
+		return waitSummary(r.chunks)
+	}
+	r.done, r.err = true, msg.Chunk.Err
+	r.cancel()
+	m.showDraft()
+	return nil
+}
+
+// handleSummaryKeys takes enter to save a finished summary and esc to
+// stop or discard one. It reports whether it used the key; the rest go to
+// the story view as usual.
+func (m *Model) handleSummaryKeys(msg tea.KeyMsg) (tea.Cmd, bool) {
+	r := m.regen
+	switch msg.String() {
+	case "esc":
+		m.discardSummary()
+		return nil, true
+	case "enter":
+		text := strings.TrimSpace(r.text.String())
+		if !r.done || r.err != nil || text == "" {
+			return nil, false
+		}
+		return m.saveSummary(text), true
+	}
+	return nil, false
+}
+
+// discardSummary stops the new summary, if it's still being written, and
+// drops it
+func (m *Model) discardSummary() {
+	if m.regen == nil {
+		return
+	}
+	m.regen.cancel()
+	m.regen = nil
+	m.detailView.SetDraft(nil)
+}
+
+// saveSummary replaces the story's summary with the new one, recording
+// the change in its edit history as an edit in the TUI would be
+func (m *Model) saveSummary(text string) tea.Cmd {
+	r := m.regen
+	m.regen = nil
+	m.detailView.SetDraft(nil)
+	m.notice = "Saving summary..."
+	id, old := r.storyID, r.old
+	return func() tea.Msg {
+		err := m.database.UpdateStory(context.Background(), id, []db.FieldChange{
+			{Field: "summary", Old: old, New: text},
+		})
+		return summarySavedMsg{StoryID: id, Summary: text, Err: err}
+	}
+}
+
+// savedSummary shows a saved summary in the story view and the lists
+func (m *Model) savedSummary(msg summarySavedMsg) tea.Cmd {
+	if msg.Err != nil {
+		m.notice = msg.Err.Error()
+		return nil
+	}
+	m.notice = "Saved the new summary"
+	m.detailView.SetSummary(msg.StoryID, msg.Summary)
+	return m.browseView.Reload()
+}
+
+// showDraft passes the new summary as far as it's written to the Summary
+// tab
+func (m *Model) showDraft() {
+	r := m.regen
+	if r == nil {
+		m.detailView.SetDraft(nil)
+		return
+	}
+	m.detailView.SetDraft(&detail.Draft{
+		StoryID: r.storyID,
+		Model:   r.model,
+		Text:    r.text.String(),
+		Done:    r.done,
+		Err:     r.err,
+	})
+}
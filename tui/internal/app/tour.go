@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tourStep is one page of the onboarding tour, shown over the view it
+// describes
+type tourStep struct {
+	view  View
+	title string
+	body  string
+}
+
+var tourSteps = []tourStep{
+	{ViewBrowse, "Welcome to Paranormal Tracker",
+		"This short tour walks through the tabs along the top. Switch between\n" +
+			"them any time with 1-9. Press ? for every shortcut; the tour can be\n" +
+			"taken again from there with t."},
+	{ViewSearch, "Search",
+		"Type a query and press Enter. Tab switches between text search, hybrid,\n" +
+			"and vector (meaning-based) search; ↑↓ moves through the results and\n" +
+			"Enter opens one."},
+	{ViewBrowse, "Browse",
+		"Every story, a page at a time (n/p). Filter by type (f), dates (d),\n" +
+			"location (L) or distance (g), and save filter sets as presets (F).\n" +
+			"Enter reads a story, b bookmarks it, P presents the filtered stories."},
+	{ViewVisualize, "Visualize: moving around",
+		"Each dot is a story; similar stories sit close together. Move the\n" +
+			"cursor with the arrows or by clicking, zoom with +/- or the wheel, and\n" +
+			"drag to pan. r resets the view."},
+	{ViewVisualize, "Visualize: reading the map",
+		"c colors the dots by story type or by discovered cluster. i isolates\n" +
+			"the selected story's cluster so its shape is visible (I dims the rest\n" +
+			"instead of hiding them). Where dots overlap, [ and ] cycle through\n" +
+			"them; Enter opens the story."},
+	{ViewHotspots, "Hotspots",
+		"Flaps: places where more stories were told in a short span than\n" +
+			"chance predicts. Enter browses the stories inside one. New flaps raise\n" +
+			"an alert in the status bar."},
+	{ViewCompare, "Compare",
+		"Run one query under two search modes side by side to see how the\n" +
+			"rankings differ. m changes a side's mode, ←→ switches side."},
+	{ViewReview, "Trash, Maintenance, and Review",
+		"Trash (5) keeps deleted stories until they're restored or purged,\n" +
+			"Maintenance (6) checks database health, and Review (8) is the queue\n" +
+			"of community submissions waiting to be approved. Changelog (9) sums\n" +
+			"up each week's additions, corrections, and merges."},
+}
+
+// startTour opens the tour at its first step
+func (m *Model) startTour() tea.Cmd {
+	m.showTour = true
+	m.tourStep = 0
+	m.tourReturn = m.currentView
+	return m.switchView(tourSteps[0].view)
+}
+
+// handleTourKeys steps through the tour; finishing or dismissing it marks
+// it done so it isn't shown on the next launch
+func (m Model) handleTourKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "right", "l", "enter", " ":
+		if m.tourStep < len(tourSteps)-1 {
+			m.tourStep++
+			return m, m.switchView(tourSteps[m.tourStep].view)
+		}
+		return m, m.endTour()
+	case "left", "h", "backspace":
+		if m.tourStep > 0 {
+			m.tourStep--
+			return m, m.switchView(tourSteps[m.tourStep].view)
+		}
+	case "esc", "q":
+		return m, m.endTour()
+	}
+	return m, nil
+}
+
+// endTour closes the tour, returning to the view it started from
+func (m *Model) endTour() tea.Cmd {
+	m.showTour = false
+	cmd := m.switchView(m.tourReturn)
+	if m.opts.State.Get().TourDone {
+		return cmd
+	}
+	m.opts.State.Update(func(s *config.State) { s.TourDone = true })
+	return tea.Batch(cmd, m.saveState())
+}
+
+// renderTour draws the current step's box, to sit below the view it
+// describes
+func (m Model) renderTour() string {
+	step := tourSteps[m.tourStep]
+
+	var b strings.Builder
+	b.WriteString(styles.BoldStyle.Foreground(styles.Primary).Render(
+		fmt.Sprintf("%s (%d/%d)", step.title, m.tourStep+1, len(tourSteps))))
+	b.WriteString("\n\n")
+	b.WriteString(step.body)
+	b.WriteString("\n\n")
+
+	next := "→/enter: next"
+	if m.tourStep == len(tourSteps)-1 {
+		next = "enter: finish"
+	}
+	b.WriteString(styles.DimStyle.Render(next + " • ←: back • esc: skip tour"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(0, 2).
+		Width(m.width - 2).
+		Render(b.String())
+}
+
+// clipLines keeps the first n lines of s
+func clipLines(s string, n int) string {
+	lines := strings.Split(s, "\n")
+	if len(lines) > n {
+		lines = lines[:max(0, n)]
+	}
+	return strings.Join(lines, "\n")
+}
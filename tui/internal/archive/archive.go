@@ -0,0 +1,208 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// Format identifies the archive layout in the manifest
+const Format = "paranormal-story-archive/1"
+
+const (
+	manifestName  = "manifest.json"
+	signatureName = "manifest.sig"
+)
+
+// Manifest lists every file in an archive with its checksum. The
+// signature covers the manifest's exact bytes, so it vouches for the
+// files through their checksums.
+type Manifest struct {
+	Format    string     `json:"format"`
+	StoryID   string     `json:"story_id"`
+	Title     string     `json:"title"`
+	CreatedAt time.Time  `json:"created_at"`
+	Files     []File     `json:"files"`
+	Omitted   []Omission `json:"omitted,omitempty"`
+}
+
+// File is one archived file
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Omission records something that belongs in the archive but couldn't be
+// included, so its absence is explained rather than silent
+type Omission struct {
+	Item   string `json:"item"`
+	Reason string `json:"reason"`
+}
+
+// Signature is the contents of manifest.sig
+type Signature struct {
+	Algorithm string `json:"algorithm"`
+	PublicKey string `json:"public_key"` // hex
+	Signature string `json:"signature"`  // hex, over manifest.json
+}
+
+// Options controls what goes into an archive
+type Options struct {
+	// AudioDir is where episode audio files were downloaded. The story's
+	// clip is cut from them with ffmpeg when both are available.
+	AudioDir string
+}
+
+// Write packages a story into a zip signed with key
+func Write(w io.Writer, rec *db.ArchiveRecord, opts Options, key ed25519.PrivateKey) (*Manifest, error) {
+	manifest := &Manifest{
+		Format:    Format,
+		StoryID:   rec.Story.ID,
+		Title:     rec.Story.Title,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	zw := zip.NewWriter(w)
+
+	add := func(name string, data []byte) error {
+		f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: manifest.CreatedAt})
+		if err != nil {
+			return fmt.Errorf("failed to add %s: %w", name, err)
+		}
+		if _, err := f.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if name != manifestName && name != signatureName {
+			sum := sha256.Sum256(data)
+			manifest.Files = append(manifest.Files, File{Path: name, Size: int64(len(data)), SHA256: hex.EncodeToString(sum[:])})
+		}
+		return nil
+	}
+
+	if err := add("story.txt", []byte(rec.Story.Content)); err != nil {
+		return nil, err
+	}
+
+	metadata, err := json.MarshalIndent(map[string]json.RawMessage{
+		"story":   rec.Row,
+		"episode": rec.Episode,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode metadata: %w", err)
+	}
+	if err := add("metadata.json", metadata); err != nil {
+		return nil, err
+	}
+
+	provenance, err := json.MarshalIndent(map[string]json.RawMessage{
+		"external_ids": rec.ExternalIDs,
+		"edits":        rec.Edits,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode provenance: %w", err)
+	}
+	if err := add("provenance.json", provenance); err != nil {
+		return nil, err
+	}
+
+	name, clip, err := audioClip(rec, opts.AudioDir)
+	if err != nil {
+		manifest.Omitted = append(manifest.Omitted, Omission{Item: "audio clip", Reason: err.Error()})
+	} else if err := add(name, clip); err != nil {
+		return nil, err
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := add(manifestName, manifestJSON); err != nil {
+		return nil, err
+	}
+	sig, err := json.MarshalIndent(Signature{
+		Algorithm: "ed25519",
+		PublicKey: hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Signature: hex.EncodeToString(ed25519.Sign(key, manifestJSON)),
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signature: %w", err)
+	}
+	if err := add(signatureName, sig); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return manifest, nil
+}
+
+// audioClip cuts the story's span out of its episode's audio
+func audioClip(rec *db.ArchiveRecord, audioDir string) (string, []byte, error) {
+	var story struct {
+		Start *float64 `json:"start_time_seconds"`
+		End   *float64 `json:"end_time_seconds"`
+	}
+	var episode struct {
+		AudioFilename string `json:"audio_filename"`
+	}
+	if err := json.Unmarshal(rec.Row, &story); err != nil {
+		return "", nil, fmt.Errorf("failed to read story timestamps: %w", err)
+	}
+	if len(rec.Episode) > 0 {
+		if err := json.Unmarshal(rec.Episode, &episode); err != nil {
+			return "", nil, fmt.Errorf("failed to read episode: %w", err)
+		}
+	}
+
+	switch {
+	case episode.AudioFilename == "":
+		return "", nil, fmt.Errorf("episode has no audio file")
+	case story.Start == nil || story.End == nil || *story.End <= *story.Start:
+		return "", nil, fmt.Errorf("story has no timestamps")
+	}
+	source := filepath.Join(audioDir, episode.AudioFilename)
+	if _, err := os.Stat(source); err != nil {
+		return "", nil, fmt.Errorf("audio not found at %s", source)
+	}
+	ffmpeg, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return "", nil, fmt.Errorf("ffmpeg is not installed")
+	}
+
+	// Stream copy keeps the original encoding rather than re-encoding
+	ext := filepath.Ext(episode.AudioFilename)
+	tmp, err := os.CreateTemp("", "clip-*"+ext)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	var stderr bytes.Buffer
+	cmd := exec.Command(ffmpeg, "-v", "error", "-y",
+		"-ss", strconv.FormatFloat(*story.Start, 'f', 3, 64),
+		"-to", strconv.FormatFloat(*story.End, 'f', 3, 64),
+		"-i", source, "-c", "copy", tmp.Name())
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", nil, fmt.Errorf("ffmpeg failed: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	clip, err := os.ReadFile(tmp.Name())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read clip: %w", err)
+	}
+	return "audio/clip" + ext, clip, nil
+}
@@ -0,0 +1,54 @@
+package archive
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// GenerateKey writes a new signing key to path, refusing to replace one.
+// The file holds the hex-encoded ed25519 seed on one line.
+func GenerateKey(path string) (ed25519.PublicKey, error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if errors.Is(err, os.ErrExist) {
+		return nil, fmt.Errorf("%s already exists", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to create key file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintln(f, hex.EncodeToString(priv.Seed())); err != nil {
+		return nil, fmt.Errorf("failed to write key file: %w", err)
+	}
+	return pub, nil
+}
+
+// LoadKey reads a signing key written by GenerateKey
+func LoadKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+	seed, err := hex.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("%s is not an archive signing key", path)
+	}
+	return ed25519.NewKeyFromSeed(seed), nil
+}
+
+// ParsePublicKey decodes a hex public key, as printed by keygen
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key %q", s)
+	}
+	return ed25519.PublicKey(key), nil
+}
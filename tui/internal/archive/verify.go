@@ -0,0 +1,102 @@
+package archive
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Verify checks an archive's signature and every file's checksum. With a
+// trusted key, the archive must also have been signed by it; without
+// one, only its own embedded key is checked.
+func Verify(r io.ReaderAt, size int64, trusted ed25519.PublicKey) (*Manifest, ed25519.PublicKey, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		if _, dup := files[f.Name]; dup {
+			return nil, nil, fmt.Errorf("archive contains %s twice", f.Name)
+		}
+		files[f.Name] = f
+	}
+
+	manifestJSON, err := readFile(files, manifestName)
+	if err != nil {
+		return nil, nil, err
+	}
+	sigJSON, err := readFile(files, signatureName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sig Signature
+	if err := json.Unmarshal(sigJSON, &sig); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse signature: %w", err)
+	}
+	if sig.Algorithm != "ed25519" {
+		return nil, nil, fmt.Errorf("unsupported signature algorithm %q", sig.Algorithm)
+	}
+	pub, err := ParsePublicKey(sig.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	if trusted != nil && !pub.Equal(trusted) {
+		return nil, pub, fmt.Errorf("signed by %s, not the trusted key", sig.PublicKey)
+	}
+	signature, err := hex.DecodeString(sig.Signature)
+	if err != nil || !ed25519.Verify(pub, manifestJSON, signature) {
+		return nil, pub, fmt.Errorf("manifest signature is invalid")
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(manifestJSON, &manifest); err != nil {
+		return nil, pub, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if manifest.Format != Format {
+		return nil, pub, fmt.Errorf("unsupported archive format %q", manifest.Format)
+	}
+
+	listed := map[string]bool{manifestName: true, signatureName: true}
+	for _, want := range manifest.Files {
+		data, err := readFile(files, want.Path)
+		if err != nil {
+			return nil, pub, err
+		}
+		sum := sha256.Sum256(data)
+		if int64(len(data)) != want.Size || hex.EncodeToString(sum[:]) != want.SHA256 {
+			return nil, pub, fmt.Errorf("%s does not match its checksum", want.Path)
+		}
+		listed[want.Path] = true
+	}
+	for name := range files {
+		if !listed[name] {
+			return nil, pub, fmt.Errorf("%s is not in the manifest", name)
+		}
+	}
+	return &manifest, pub, nil
+}
+
+func readFile(files map[string]*zip.File, name string) ([]byte, error) {
+	f, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("archive is missing %s", name)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer rc.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, rc); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
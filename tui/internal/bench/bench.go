@@ -0,0 +1,331 @@
+package bench
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+)
+
+// defaultQueries is the built-in suite used when no query file is given
+var defaultQueries = []string{
+	"shadow figure in the bedroom",
+	"ufo triangle lights",
+	"ghost in the hallway",
+	"skinwalker",
+	"sleep paralysis old hag",
+	"bigfoot in the woods",
+	"poltergeist throwing objects",
+	"premonition dream came true",
+	"near death experience tunnel of light",
+	"doppelganger seen by family",
+	"missing time on the highway",
+	"haunted house footsteps",
+}
+
+// Mode names accepted by --modes
+const (
+	modeText   = "text"
+	modeVector = "vector"
+	modeHybrid = "hybrid"
+)
+
+// options holds parsed command-line flags
+type options struct {
+	queryFile string
+	modes     []string
+	runs      int
+	limit     int
+	alpha     float64
+}
+
+// modeResult accumulates latencies and the last result IDs per query for one mode
+type modeResult struct {
+	latencies []time.Duration
+	ids       map[string][]string // query -> ranked story IDs
+}
+
+// Run executes the bench-search subcommand with the given arguments
+func Run(args []string, out io.Writer) error {
+	opts, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	queries := defaultQueries
+	if opts.queryFile != "" {
+		queries, err = readQueries(opts.queryFile)
+		if err != nil {
+			return err
+		}
+	}
+	if len(queries) == 0 {
+		return errors.New("no queries to run")
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	// Vector and hybrid modes need query embeddings; skip them if unavailable
+	var embeddings map[string][]float32
+	var embedLatencies []time.Duration
+	if needsEmbeddings(opts.modes) {
+		client, err := embed.New()
+		if err != nil {
+			fmt.Fprintf(out, "Warning: %v, running text mode only\n\n", err)
+			opts.modes = []string{modeText}
+		} else {
+			embeddings = make(map[string][]float32, len(queries))
+			for _, q := range queries {
+				start := time.Now()
+				vec, err := client.Embed(ctx, q)
+				if err != nil {
+					return fmt.Errorf("failed to embed %q: %w", q, err)
+				}
+				embedLatencies = append(embedLatencies, time.Since(start))
+				embeddings[q] = vec
+			}
+		}
+	}
+
+	results := make(map[string]*modeResult, len(opts.modes))
+	for _, mode := range opts.modes {
+		res := &modeResult{ids: make(map[string][]string, len(queries))}
+		for run := 0; run < opts.runs; run++ {
+			for _, q := range queries {
+				start := time.Now()
+				ids, err := runQuery(ctx, database, mode, q, embeddings[q], opts)
+				if err != nil {
+					return fmt.Errorf("%s search for %q failed: %w", mode, q, err)
+				}
+				res.latencies = append(res.latencies, time.Since(start))
+				res.ids[q] = ids
+			}
+		}
+		results[mode] = res
+	}
+
+	fmt.Fprintf(out, "Search benchmark: %d queries × %d runs, limit %d\n\n", len(queries), opts.runs, opts.limit)
+	printLatencies(out, opts.modes, results, embedLatencies)
+	printOverlap(out, opts.modes, results, queries)
+
+	return nil
+}
+
+func parseFlags(args []string) (options, error) {
+	fs := flag.NewFlagSet("bench-search", flag.ContinueOnError)
+	queryFile := fs.String("queries", "", "file with one query per line (default: built-in suite)")
+	modes := fs.String("modes", "text,vector,hybrid", "comma-separated search modes to run")
+	runs := fs.Int("runs", 5, "number of times to run each query per mode")
+	limit := fs.Int("limit", 20, "results per query")
+	alpha := fs.Float64("alpha", 0.7, "hybrid blend factor: 1.0=vector only, 0.0=text only")
+
+	if err := fs.Parse(args); err != nil {
+		return options{}, err
+	}
+	if *runs < 1 {
+		return options{}, errors.New("--runs must be at least 1")
+	}
+	if *limit < 1 {
+		return options{}, errors.New("--limit must be at least 1")
+	}
+
+	opts := options{
+		queryFile: *queryFile,
+		runs:      *runs,
+		limit:     *limit,
+		alpha:     *alpha,
+	}
+	for _, m := range strings.Split(*modes, ",") {
+		m = strings.TrimSpace(m)
+		switch m {
+		case modeText, modeVector, modeHybrid:
+			opts.modes = append(opts.modes, m)
+		case "":
+		default:
+			return options{}, fmt.Errorf("unknown search mode %q", m)
+		}
+	}
+	if len(opts.modes) == 0 {
+		return options{}, errors.New("no search modes selected")
+	}
+
+	return opts, nil
+}
+
+func readQueries(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open query file: %w", err)
+	}
+	defer f.Close()
+
+	var queries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		queries = append(queries, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read query file: %w", err)
+	}
+
+	return queries, nil
+}
+
+func needsEmbeddings(modes []string) bool {
+	for _, m := range modes {
+		if m == modeVector || m == modeHybrid {
+			return true
+		}
+	}
+	return false
+}
+
+// runQuery executes one search and returns the ranked story IDs
+func runQuery(ctx context.Context, database *db.DB, mode, query string, embedding []float32, opts options) ([]string, error) {
+	var ids []string
+
+	switch mode {
+	case modeText:
+		stories, err := database.TextSearch(ctx, query, opts.limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stories {
+			ids = append(ids, s.ID)
+		}
+	case modeVector:
+		stories, err := database.VectorSearch(ctx, embedding, opts.limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stories {
+			ids = append(ids, s.ID)
+		}
+	case modeHybrid:
+		results, err := database.HybridSearch(ctx, query, embedding, opts.limit, opts.alpha)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			ids = append(ids, r.Story.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func printLatencies(out io.Writer, modes []string, results map[string]*modeResult, embedLatencies []time.Duration) {
+	fmt.Fprintln(out, "Latency")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "mode\tn\tmean\tp50\tp90\tp99\tmax\t")
+	for _, mode := range modes {
+		writeLatencyRow(w, mode, results[mode].latencies)
+	}
+	if len(embedLatencies) > 0 {
+		writeLatencyRow(w, "embed", embedLatencies)
+	}
+	w.Flush()
+	fmt.Fprintln(out)
+}
+
+func writeLatencyRow(w io.Writer, label string, latencies []time.Duration) {
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+	mean := total / time.Duration(len(sorted))
+
+	fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t\n",
+		label, len(sorted),
+		formatDuration(mean),
+		formatDuration(percentile(sorted, 50)),
+		formatDuration(percentile(sorted, 90)),
+		formatDuration(percentile(sorted, 99)),
+		formatDuration(sorted[len(sorted)-1]),
+	)
+}
+
+// percentile returns the nearest-rank percentile of sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (p*len(sorted)+99)/100 - 1
+	if idx < 0 {
+		idx = 0
+	}
+	if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	return sorted[idx]
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("%.1fms", float64(d.Microseconds())/1000)
+}
+
+// printOverlap reports the mean Jaccard overlap of result sets between each pair of modes
+func printOverlap(out io.Writer, modes []string, results map[string]*modeResult, queries []string) {
+	if len(modes) < 2 {
+		return
+	}
+
+	fmt.Fprintln(out, "Result overlap (mean Jaccard of top-k IDs)")
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for i := 0; i < len(modes); i++ {
+		for j := i + 1; j < len(modes); j++ {
+			a, b := results[modes[i]], results[modes[j]]
+			sum := 0.0
+			for _, q := range queries {
+				sum += jaccard(a.ids[q], b.ids[q])
+			}
+			fmt.Fprintf(w, "%s ↔ %s\t%.2f\n", modes[i], modes[j], sum/float64(len(queries)))
+		}
+	}
+	w.Flush()
+}
+
+func jaccard(a, b []string) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	set := make(map[string]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	shared := 0
+	union := len(set)
+	for _, id := range b {
+		if set[id] {
+			shared++
+			delete(set, id)
+		} else {
+			union++
+		}
+	}
+
+	return float64(shared) / float64(union)
+}
@@ -0,0 +1,194 @@
+// Package casefile typesets stories as printable case files: a title page,
+// then for each story a metadata table, quotes pulled from the telling,
+// the full account, and its citation. The document is Typst source,
+// compiled to PDF with the typst command.
+package casefile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/pack"
+)
+
+//go:embed casefile.typ
+var template string
+
+// maxQuotes is how many quotes are pulled from a story
+const maxQuotes = 3
+
+// quotePattern finds speech in straight or curly quotation marks, long
+// enough to say something and short enough to stand alone
+var quotePattern = regexp.MustCompile(`"([^"\n]{40,280})"|“([^”\n]{40,280})”`)
+
+// Case is a story in the file
+type Case struct {
+	Story    db.Story
+	Citation *db.Citation // nil if the story has no episode
+}
+
+// File is the stories to typeset
+type File struct {
+	Title    string
+	Subtitle string // What the stories are, e.g. the collection's name
+	Cases    []Case
+}
+
+// Quotes returns the first few passages of quoted speech in text
+func Quotes(text string) []string {
+	var quotes []string
+	for _, m := range quotePattern.FindAllStringSubmatch(text, -1) {
+		q := m[1] + m[2]
+		quotes = append(quotes, strings.Join(strings.Fields(q), " "))
+		if len(quotes) == maxQuotes {
+			break
+		}
+	}
+	return quotes
+}
+
+// WriteTypst writes the file as a self-contained Typst document
+func (f *File) WriteTypst(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	b.WriteString(template)
+
+	count := fmt.Sprintf("%d stories", len(f.Cases))
+	if len(f.Cases) == 1 {
+		count = "1 story"
+	}
+	fmt.Fprintf(b, "\n#show: case-file.with(\n  title: %s,\n  subtitle: %s,\n  generated: %s,\n  contents: %t,\n)\n",
+		typstString(f.Title), typstString(f.Subtitle),
+		typstString(count+" · "+time.Now().Format("January 2, 2006")),
+		len(f.Cases) > 1)
+
+	for i, c := range f.Cases {
+		s := c.Story
+		fmt.Fprintf(b, "\n#case(\n  number: %s,\n  title: %s,\n", typstString(strconv.Itoa(i+1)), typstString(s.Title))
+		b.WriteString("  meta: (\n")
+		for _, row := range metadata(&s, c.Citation) {
+			fmt.Fprintf(b, "    (%s, %s),\n", typstString(row[0]), typstString(row[1]))
+		}
+		b.WriteString("  ),\n")
+		fmt.Fprintf(b, "  summary: %s,\n", typstString(strings.TrimSpace(s.Summary.String)))
+		fmt.Fprintf(b, "  quotes: %s,\n", typstArray(Quotes(s.Content)))
+		fmt.Fprintf(b, "  paragraphs: %s,\n", typstArray(paragraphs(s.Content)))
+		fmt.Fprintf(b, "  citation: %s,\n)\n", typstString(pack.Citation(&s, c.Citation)))
+	}
+	return b.Flush()
+}
+
+// metadata is the rows of a story's table, leaving out what isn't known
+func metadata(s *db.Story, c *db.Citation) [][2]string {
+	rows := [][2]string{{"Story", s.ID}, {"Show", s.FormattedShow()}}
+	if c != nil {
+		episode := c.EpisodeTitle
+		if c.EpisodeNumber != "" {
+			episode = c.EpisodeNumber + ": " + episode
+		}
+		rows = append(rows, [2]string{"Episode", episode})
+		if c.Start != nil {
+			at := pack.Timestamp(*c.Start)
+			if c.End != nil {
+				at += "–" + pack.Timestamp(*c.End)
+			}
+			rows = append(rows, [2]string{"Timestamp", at})
+		}
+	}
+	rows = append(rows,
+		[2]string{"Aired", s.FormattedDate()},
+		[2]string{"Type", strings.ReplaceAll(s.FormattedType(), "_", " ")},
+		[2]string{"Location", s.FormattedLocation()},
+		[2]string{"Length", fmt.Sprintf("%d words", s.WordCount())},
+	)
+	if s.Latitude.Valid && s.Longitude.Valid {
+		rows = append(rows, [2]string{"Coordinates", fmt.Sprintf("%.4f, %.4f", s.Latitude.Float64, s.Longitude.Float64)})
+	}
+	if s.ClusterID != nil {
+		rows = append(rows, [2]string{"Cluster", strconv.Itoa(*s.ClusterID)})
+	}
+	if s.GeoClusterID != nil {
+		rows = append(rows, [2]string{"Hotspot", strconv.Itoa(*s.GeoClusterID)})
+	}
+	if s.Rating > 0 {
+		rows = append(rows, [2]string{"My rating", strings.Repeat("★", s.Rating) + strings.Repeat("☆", 5-s.Rating)})
+	}
+	return rows
+}
+
+// paragraphs splits a story at its line breaks, dropping blank lines
+func paragraphs(content string) []string {
+	var ps []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			ps = append(ps, line)
+		}
+	}
+	return ps
+}
+
+// typstString quotes s as a Typst string literal
+func typstString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\r", "", "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// typstArray writes strings as a Typst array; the trailing comma keeps a
+// single element an array rather than a parenthesized string
+func typstArray(items []string) string {
+	if len(items) == 0 {
+		return "()"
+	}
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = typstString(item)
+	}
+	return "(" + strings.Join(quoted, ", ") + ",)"
+}
+
+// Compile typesets the file to a PDF at path with the typst command
+func (f *File) Compile(ctx context.Context, path string) error {
+	typst, err := exec.LookPath("typst")
+	if err != nil {
+		return errors.New("typst is not installed (https://typst.app); write a .typ file to compile elsewhere")
+	}
+
+	dir, err := os.MkdirTemp("", "casefile-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	source := filepath.Join(dir, "casefile.typ")
+	out, err := os.Create(source)
+	if err != nil {
+		return fmt.Errorf("failed to create typst source: %w", err)
+	}
+	if err := f.WriteTypst(out); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to write typst source: %w", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write typst source: %w", err)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, typst, "compile", source, path)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("typst failed: %v: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Case file layout. The document after this template calls case-file once
+// with the front matter and case once per story. Every value is a plain
+// string, so nothing in a story's text is read as markup.
+
+#let case-file(title: "", subtitle: "", generated: "", contents: false, body) = {
+  set document(title: title)
+  set page(paper: "us-letter", margin: (x: 2.2cm, y: 2.4cm))
+  set text(size: 10.5pt)
+  set par(justify: true)
+  show heading.where(level: 1): set text(size: 18pt)
+  show heading.where(level: 2): set text(size: 11pt, fill: luma(70))
+
+  page(align(center + horizon, stack(
+    spacing: 1.2em,
+    text(size: 24pt, weight: "bold", title),
+    text(size: 12pt, subtitle),
+    text(size: 9pt, fill: luma(100), generated),
+  )))
+
+  set page(numbering: "1")
+  if contents {
+    outline(title: "Cases", depth: 1)
+  }
+  body
+}
+
+#let case(number: "", title: "", meta: (), summary: "", quotes: (), paragraphs: (), citation: "") = {
+  pagebreak(weak: true)
+  text(size: 9pt, fill: luma(110), upper("Case " + number))
+  heading(level: 1, title)
+  table(
+    columns: (auto, 1fr),
+    stroke: 0.5pt + luma(180),
+    inset: 6pt,
+    fill: (col, row) => if col == 0 { luma(240) },
+    ..meta.map(((name, value)) => (text(weight: "bold", name), value)).flatten(),
+  )
+
+  if summary != "" {
+    heading(level: 2, "Summary")
+    par(summary)
+  }
+  if quotes.len() > 0 {
+    heading(level: 2, "In their words")
+    for q in quotes {
+      quote(block: true, q)
+    }
+  }
+
+  heading(level: 2, "Account")
+  for p in paragraphs {
+    par(p)
+  }
+
+  heading(level: 2, "Source")
+  text(size: 9pt, citation)
+}
@@ -0,0 +1,193 @@
+// Package chapters splits long stories into sections where the subject
+// changes. The text is cut into blocks of a few paragraphs, each block is
+// embedded, and sections break at the deepest dips in similarity between
+// neighbouring blocks (TextTiling, with embeddings in place of word
+// counts).
+package chapters
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"paranormal-tui/internal/db"
+)
+
+const (
+	// BlockWords is roughly how many words go into each embedded block.
+	// Transcript paragraphs are often a single utterance, too short to
+	// embed on their own.
+	BlockWords = 150
+
+	// DefaultMinWords is the shortest section worth its own entry in the
+	// table of contents
+	DefaultMinWords = 600
+
+	titleLen = 60
+)
+
+var speakerLabel = regexp.MustCompile(`\[Speaker[^\]]*\]`)
+
+// Block is a run of paragraphs to embed together
+type Block struct {
+	StartLine int // Line of the story content the block starts on
+	Words     int
+	Text      string
+}
+
+// Blocks groups the story's non-blank lines into blocks of at least
+// BlockWords words. A short remainder joins the block before it.
+func Blocks(content string) []Block {
+	var blocks []Block
+	var cur *Block
+	var text []string
+
+	flush := func() {
+		if cur != nil {
+			cur.Text = strings.Join(text, "\n")
+			blocks = append(blocks, *cur)
+		}
+		cur, text = nil, nil
+	}
+
+	for i, line := range strings.Split(content, "\n") {
+		words := len(strings.Fields(line))
+		if words == 0 {
+			continue
+		}
+		if cur == nil {
+			cur = &Block{StartLine: i}
+		}
+		cur.Words += words
+		text = append(text, line)
+		if cur.Words >= BlockWords {
+			flush()
+		}
+	}
+
+	if cur != nil && len(blocks) > 0 {
+		last := &blocks[len(blocks)-1]
+		last.Words += cur.Words
+		last.Text += "\n" + strings.Join(text, "\n")
+		cur = nil
+	}
+	flush()
+
+	return blocks
+}
+
+// Segment picks where the sections start, given the blocks and their
+// embeddings. The first section always starts at the first block; others
+// start at the gaps where similarity dips deepest, so long as every section
+// keeps at least minWords words.
+func Segment(blocks []Block, embeddings [][]float32, minWords int) []db.Chapter {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	// Similarity across each gap: gap i lies between block i and i+1
+	sims := make([]float64, len(blocks)-1)
+	for i := range sims {
+		sims[i] = cosine(embeddings[i], embeddings[i+1])
+	}
+
+	// Depth of each dip: how far similarity climbs again on either side
+	depths := make([]float64, len(sims))
+	for i, s := range sims {
+		left, right := s, s
+		for j := i; j >= 0 && sims[j] >= left; j-- {
+			left = sims[j]
+		}
+		for j := i; j < len(sims) && sims[j] >= right; j++ {
+			right = sims[j]
+		}
+		depths[i] = (left - s) + (right - s)
+	}
+
+	// Only dips clearly deeper than usual count as a change of subject
+	mean, std := meanStd(depths)
+	cutoff := mean + std/2
+
+	gaps := make([]int, len(depths))
+	for i := range gaps {
+		gaps[i] = i
+	}
+	sort.SliceStable(gaps, func(a, b int) bool { return depths[gaps[a]] > depths[gaps[b]] })
+
+	// prefix[i] is the words in blocks before block i
+	prefix := make([]int, len(blocks)+1)
+	for i, b := range blocks {
+		prefix[i+1] = prefix[i] + b.Words
+	}
+
+	starts := []int{0, len(blocks)} // Section starts by block, plus the end
+	for _, g := range gaps {
+		if depths[g] <= 0 || depths[g] < cutoff {
+			break
+		}
+		start := g + 1
+		pos := sort.SearchInts(starts, start)
+		if prefix[start]-prefix[starts[pos-1]] < minWords || prefix[starts[pos]]-prefix[start] < minWords {
+			continue
+		}
+		starts = append(starts[:pos], append([]int{start}, starts[pos:]...)...)
+	}
+
+	chapters := make([]db.Chapter, 0, len(starts)-1)
+	for _, start := range starts[:len(starts)-1] {
+		chapters = append(chapters, db.Chapter{StartLine: blocks[start].StartLine, Title: Title(blocks[start].Text)})
+	}
+	return chapters
+}
+
+// Title makes a table-of-contents entry from a section's opening text: its
+// first sentence, without speaker labels, cut short at a word boundary
+func Title(text string) string {
+	line := strings.Join(strings.Fields(speakerLabel.ReplaceAllString(text, " ")), " ")
+	if end := strings.IndexAny(line, ".?!"); end > 0 {
+		line = line[:end+1]
+	}
+
+	runes := []rune(line)
+	if len(runes) <= titleLen {
+		return line
+	}
+	cut := titleLen
+	for cut > titleLen/2 && !unicode.IsSpace(runes[cut]) {
+		cut--
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + "…"
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func meanStd(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	var sq float64
+	for _, x := range xs {
+		sq += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(xs)))
+}
@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// ankiSnippet is how much of a story stands in for a missing summary
+const ankiSnippet = 400
+
+// ankiOwnFlags are Anki's flags that don't choose stories
+var ankiOwnFlags = map[string]bool{"glossary": true, "deck": true, "out": true}
+
+// Anki writes flashcards for Anki's File > Import: the story type glossary
+// (type on the front, definition on the back) and notable stories (title
+// on the front, summary on the back). The stories are a --collection's, or
+// those matching the filters, or without either the bookmarked ones.
+func Anki(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("anki", flag.ContinueOnError)
+	filterArgs := addFilterFlags(fs)
+	collection := fs.String("collection", "", "cards for the stories in this collection")
+	glossary := fs.Bool("glossary", true, "include a card for each story type")
+	deck := fs.String("deck", "Paranormal", "deck to import the cards into")
+	outPath := fs.String("out", "", "write the cards to this file (.txt or .csv) instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.EqualFold(filepath.Ext(*outPath), ".apkg") {
+		return errors.New("can't write .apkg packages; write a .txt file and import it with File > Import in Anki")
+	}
+
+	filters, err := filterArgs.filters()
+	if err != nil {
+		return err
+	}
+	chosen := false
+	fs.Visit(func(f *flag.Flag) {
+		if !ankiOwnFlags[f.Name] {
+			chosen = true
+		}
+	})
+	if !chosen {
+		filters.BookmarkedOnly = true
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := filterArgs.resolveNear(ctx, database, &filters); err != nil {
+		return err
+	}
+	if *collection != "" {
+		ids, err := database.CollectionStoryIDs(ctx, *collection)
+		if err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return fmt.Errorf("collection %q is empty", *collection)
+		}
+		filters.IDs = ids
+	}
+
+	var stories []db.Story
+	sort := db.BrowseSort{Field: "title", Ascending: true}
+	if err := database.StreamStories(ctx, &filters, &sort, 0, true, func(s *db.Story) error {
+		stories = append(stories, *s)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(stories) == 0 && !*glossary {
+		return errors.New("no stories match")
+	}
+
+	w := out
+	var f *os.File
+	if *outPath != "" {
+		if f, err = os.Create(*outPath); err != nil {
+			return fmt.Errorf("failed to create deck file: %w", err)
+		}
+		w = f
+	}
+	cards, err := writeAnki(w, *deck, *glossary, stories)
+	if f != nil {
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write deck: %w", err)
+	}
+	if f != nil {
+		fmt.Fprintf(out, "wrote %d cards to %s; import it in Anki with File > Import\n", cards, *outPath)
+	}
+	return nil
+}
+
+// writeAnki writes the cards as Anki's tab-separated text, its header
+// lines saying how to import them, and reports how many it wrote
+func writeAnki(w io.Writer, deck string, glossary bool, stories []db.Story) (int, error) {
+	b := bufio.NewWriter(w)
+	b.WriteString("#separator:tab\n#html:true\n#notetype:Basic\n")
+	fmt.Fprintf(b, "#deck:%s\n", ankiField(deck))
+	b.WriteString("#tags column:3\n")
+
+	cards := 0
+	card := func(front, back, tags string) {
+		fmt.Fprintf(b, "%s\t%s\t%s\n", front, back, tags)
+		cards++
+	}
+
+	if glossary {
+		for _, t := range db.StoryTypes {
+			card(ankiField(strings.ReplaceAll(t, "_", " ")), ankiField(db.StoryTypeGlossary[t]), "glossary")
+		}
+	}
+	for i := range stories {
+		s := &stories[i]
+		back := strings.TrimSpace(s.Summary.String)
+		if back == "" {
+			back = strings.Join(strings.Fields(s.Snippet(ankiSnippet)), " ")
+		}
+		source := fmt.Sprintf("%s • %s • %s • %s", s.FormattedType(), s.FormattedLocation(), s.FormattedShow(), s.FormattedDate())
+		card(ankiField(s.Title),
+			ankiField(back)+"<br><br><small>"+ankiField(source)+"</small>",
+			"story "+strings.ReplaceAll(s.FormattedType(), " ", "_"))
+	}
+	return cards, b.Flush()
+}
+
+// ankiField escapes text for an HTML field, with line breaks as <br> as
+// tabs and newlines would end the field or the card
+func ankiField(s string) string {
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, "\t", " ")
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
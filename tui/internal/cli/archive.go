@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"paranormal-tui/internal/archive"
+	"paranormal-tui/internal/db"
+)
+
+// defaultKeyEnv names the signing key file when --key isn't given
+const defaultKeyEnv = "PARANORMAL_ARCHIVE_KEY"
+
+// Archive packages stories into signed zips for long-term preservation:
+//
+//	archive [--dir] [--key] [--audio-dir] <id>...   write story-<id>.zip per story
+//	archive keygen [--key]                          create a signing key
+//	archive verify [--pub] <zip>...                 check checksums and signature
+func Archive(args []string, out io.Writer) error {
+	action := "create"
+	if len(args) > 0 && (args[0] == "keygen" || args[0] == "verify") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("archive "+action, flag.ContinueOnError)
+	key := fs.String("key", os.Getenv(defaultKeyEnv), "signing key file (default $"+defaultKeyEnv+")")
+	dir := fs.String("dir", ".", "directory to write archives to")
+	audioDir := fs.String("audio-dir", "episodes", "directory of downloaded episode audio, for the story's clip")
+	pub := fs.String("pub", "", "public key the archive must be signed with (default: trust the embedded key)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch action {
+	case "keygen":
+		if *key == "" {
+			return fmt.Errorf("--key is required")
+		}
+		publicKey, err := archive.GenerateKey(*key)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "wrote signing key to %s\npublic key: %x\n", *key, publicKey)
+		return nil
+
+	case "verify":
+		if fs.NArg() == 0 {
+			return fmt.Errorf("usage: archive verify [--pub key] <zip>...")
+		}
+		return archiveVerify(fs.Args(), *pub, out)
+	}
+
+	if fs.NArg() == 0 {
+		return fmt.Errorf("usage: archive [--dir dir] [--key file] <story-id>...")
+	}
+	if *key == "" {
+		return fmt.Errorf("--key or $%s is required (create one with `archive keygen`)", defaultKeyEnv)
+	}
+	signingKey, err := archive.LoadKey(*key)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	for _, id := range fs.Args() {
+		rec, err := database.GetArchiveRecord(ctx, id)
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(*dir, "story-"+id+".zip")
+		manifest, err := writeArchive(path, rec, archive.Options{AudioDir: *audioDir}, signingKey)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "%s: %d files\n", path, len(manifest.Files))
+		for _, o := range manifest.Omitted {
+			fmt.Fprintf(out, "  omitted %s: %s\n", o.Item, o.Reason)
+		}
+	}
+	return nil
+}
+
+// writeArchive writes to a temp file first so a failure never leaves a
+// partial archive behind
+func writeArchive(path string, rec *db.ArchiveRecord, opts archive.Options, key ed25519.PrivateKey) (*archive.Manifest, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".archive-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	manifest, err := archive.Write(tmp, rec, opts, key)
+	if err != nil {
+		tmp.Close()
+		return nil, err
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return nil, fmt.Errorf("failed to write archive: %w", err)
+	}
+	return manifest, nil
+}
+
+func archiveVerify(paths []string, pub string, out io.Writer) error {
+	var trusted ed25519.PublicKey
+	if pub != "" {
+		key, err := archive.ParsePublicKey(pub)
+		if err != nil {
+			return err
+		}
+		trusted = key
+	}
+
+	failed := 0
+	for _, path := range paths {
+		manifest, signer, err := verifyFile(path, trusted)
+		if err != nil {
+			fmt.Fprintf(out, "%s: FAILED: %v\n", path, err)
+			failed++
+			continue
+		}
+		fmt.Fprintf(out, "%s: OK (story %s, %d files, signed %s by %x)\n",
+			path, manifest.StoryID, len(manifest.Files), manifest.CreatedAt.Format("2006-01-02"), signer)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d archives failed verification", failed, len(paths))
+	}
+	return nil
+}
+
+func verifyFile(path string, trusted ed25519.PublicKey) (*archive.Manifest, ed25519.PublicKey, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open: %w", err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to stat: %w", err)
+	}
+	return archive.Verify(f, info.Size(), trusted)
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// Cat prints full stories: the IDs given, or every story matching the
+// filters. Rows are streamed, so exporting the whole corpus as jsonl
+// runs in constant memory.
+func Cat(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("cat", flag.ContinueOnError)
+	filterArgs := addFilterFlags(fs)
+	sortField := fs.String("sort", "date", "sort field: "+strings.Join(db.SortFields, ", "))
+	asc := fs.Bool("asc", false, "sort ascending")
+	limit := fs.Int("limit", 0, "maximum stories to print (0 for all)")
+	format := fs.String("format", "text", "output format: text or jsonl")
+	all := fs.Bool("all", false, "print every story matching the filters (required without IDs)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "text" && *format != "jsonl" {
+		return fmt.Errorf("unknown format %q (want text or jsonl)", *format)
+	}
+	ids := fs.Args()
+	if len(ids) == 0 && !*all {
+		return errors.New("usage: cat [flags] <story-id>... (or --all with filters)")
+	}
+
+	filters, err := filterArgs.filters()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	w := bufio.NewWriter(out)
+	defer w.Flush()
+
+	write := func(s *db.Story) error {
+		return writeStoryText(w, s)
+	}
+	if *format == "jsonl" {
+		enc := json.NewEncoder(w)
+		write = func(s *db.Story) error {
+			return enc.Encode(storyRecord(s, true))
+		}
+	}
+
+	if len(ids) > 0 {
+		return database.StreamStoriesByID(ctx, ids, write)
+	}
+
+	if err := filterArgs.resolveNear(ctx, database, &filters); err != nil {
+		return err
+	}
+	sort := db.BrowseSort{Field: *sortField, Ascending: *asc}
+	return database.StreamStories(ctx, &filters, &sort, *limit, true, write)
+}
+
+// writeStoryText prints a story as a header block followed by its content
+func writeStoryText(w io.Writer, s *db.Story) error {
+	_, err := fmt.Fprintf(w, "# %s\n\nID:       %s\nShow:     %s\nDate:     %s\nType:     %s\nLocation: %s\n\n%s\n\n",
+		s.Title, s.ID, s.FormattedShow(), s.FormattedDate(), s.FormattedType(), s.FormattedLocation(), s.Content)
+	return err
+}
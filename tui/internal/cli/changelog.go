@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/export"
+)
+
+// Changelog prints how the corpus changed week by week, newest first:
+// stories added, submissions approved, field corrections from the audit
+// log, and merges. Markdown suits a dataset's release notes; --format json
+// is for other tools.
+func Changelog(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("changelog", flag.ContinueOnError)
+	weeks := fs.Int("weeks", 12, "how many weeks back to cover")
+	format := fs.String("format", export.Markdown, "markdown or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *weeks <= 0 {
+		return fmt.Errorf("--weeks must be positive")
+	}
+	if *format != export.Markdown && *format != export.JSON {
+		return fmt.Errorf("unknown changelog format %q (want markdown or json)", *format)
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	changelog, err := database.Changelog(ctx, *weeks)
+	if err != nil {
+		return err
+	}
+
+	data := export.FormatChangelogMarkdown(changelog, time.Now())
+	if *format == export.JSON {
+		if data, err = export.FormatChangelogJSON(changelog, time.Now()); err != nil {
+			return err
+		}
+	}
+	_, err = out.Write(data)
+	return err
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"paranormal-tui/internal/chapters"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+)
+
+// chapterBatch is how many blocks are embedded per request
+const chapterBatch = 32
+
+// Chapters splits long stories into sections for the table of contents in
+// the detail view:
+//
+//	chapters [status]                       how many long stories have chapters
+//	chapters build [--limit] [--all]        find chapters for those that lack them
+//
+// Finding chapters embeds each story in blocks with the configured
+// embedding provider (Voyage by default, which needs VOYAGE_API_KEY).
+func Chapters(args []string, out io.Writer) error {
+	action := "status"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("chapters "+action, flag.ContinueOnError)
+	longWords := fs.Int("long", 2500, "stories of at least this many words get chapters")
+	minWords := fs.Int("min-words", chapters.DefaultMinWords, "shortest section, in words")
+	limit := fs.Int("limit", 0, "most stories to chapter in this run (0 = all)")
+	all := fs.Bool("all", false, "find chapters again for stories that already have them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "status":
+		return chaptersStatus(ctx, database, *longWords, out)
+
+	case "build":
+		if *minWords <= 0 {
+			return fmt.Errorf("--min-words must be positive")
+		}
+		client, err := embed.New()
+		if err != nil {
+			return err
+		}
+		sources, err := database.StoriesToChapter(ctx, *longWords, *limit, *all)
+		if err != nil {
+			return err
+		}
+		for i, s := range sources {
+			found, err := findChapters(ctx, client, s.Content, *minWords)
+			if err != nil {
+				return fmt.Errorf("failed to chapter %q: %w", s.Title, err)
+			}
+			if err := database.SaveChapters(ctx, s.ID, s.Content, found); err != nil {
+				return err
+			}
+			fmt.Fprintf(out, "[%d/%d] %s: %d sections\n", i+1, len(sources), s.Title, len(found))
+		}
+		return chaptersStatus(ctx, database, *longWords, out)
+
+	default:
+		return fmt.Errorf("unknown chapters action %q (want status or build)", action)
+	}
+}
+
+// findChapters embeds a story's blocks and splits it where they change
+// subject
+func findChapters(ctx context.Context, client embed.Embedder, content string, minWords int) ([]db.Chapter, error) {
+	blocks := chapters.Blocks(content)
+	embeddings := make([][]float32, 0, len(blocks))
+	for start := 0; start < len(blocks); start += chapterBatch {
+		texts := make([]string, 0, chapterBatch)
+		for _, b := range blocks[start:min(start+chapterBatch, len(blocks))] {
+			texts = append(texts, b.Text)
+		}
+		batch, err := client.EmbedBatch(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return chapters.Segment(blocks, embeddings, minWords), nil
+}
+
+func chaptersStatus(ctx context.Context, database *db.DB, longWords int, out io.Writer) error {
+	chaptered, missing, err := database.ChapterStatus(ctx, longWords)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d long stories have chapters, %d missing", chaptered, missing)
+	if missing > 0 {
+		fmt.Fprint(out, " (run `chapters build`)")
+	}
+	fmt.Fprintln(out)
+	return nil
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"paranormal-tui/internal/db"
+)
+
+// Content reports on and changes how transcript text is stored:
+//
+//	content [status]                        stored vs. raw size per column and compression method
+//	content compress [--method lz4]         compress new values with method and rewrite existing ones
+//
+// PostgreSQL decompresses transparently, so nothing reading the tables
+// (the TUI, scripts, the web backend) needs to change.
+func Content(args []string, out io.Writer) error {
+	action := "status"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("content "+action, flag.ContinueOnError)
+	method := fs.String("method", "lz4", "compression method: lz4 or pglz")
+	batch := fs.Int("batch", 500, "rows checked per batch; each batch commits on its own")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "status":
+		return contentStatus(ctx, database, out)
+
+	case "compress":
+		if *batch <= 0 {
+			return fmt.Errorf("--batch must be positive")
+		}
+		if err := database.SetContentCompression(ctx, *method); err != nil {
+			return err
+		}
+		rewritten, err := database.RecompressContent(ctx, *method, *batch, func(table string, scanned, rewritten int64) {
+			fmt.Fprintf(out, "\r%s: %d scanned, %d rewritten", table, scanned, rewritten)
+		})
+		fmt.Fprintln(out)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "rewrote %d values with %s; autovacuum reclaims the old versions (see `maintenance status`)\n", rewritten, *method)
+		return contentStatus(ctx, database, out)
+
+	default:
+		return fmt.Errorf("unknown content action %q (want status or compress)", action)
+	}
+}
+
+func contentStatus(ctx context.Context, database *db.DB, out io.Writer) error {
+	storage, err := database.GetContentStorage(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "column\tsetting\tstored as\trows\tstored\traw\tratio")
+	for _, s := range storage {
+		setting := s.Setting
+		if setting == "" {
+			setting = "default"
+		}
+		fmt.Fprintf(w, "%s.%s\t%s\t%s\t%d\t%s\t%s\t%.0f%%\n",
+			s.Table, s.Column, setting, s.Method, s.Rows,
+			db.FormatBytes(s.Bytes), db.FormatBytes(s.Raw), 100*s.Ratio())
+	}
+	return w.Flush()
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// filterFlags are the story filter flags shared by list, cat, and pack
+type filterFlags struct {
+	storyTypes *string
+	location   *string
+	from       *string
+	to         *string
+	dates      *string
+	near       *string
+	withinKm   *float64
+	minWords   *int
+	maxWords   *int
+	unread     *bool
+	bookmarked *bool
+	cluster    *int
+}
+
+func addFilterFlags(fs *flag.FlagSet) filterFlags {
+	return filterFlags{
+		storyTypes: fs.String("type", "", "only stories of these types (comma-separated)"),
+		location:   fs.String("location", "", "location substring to match"),
+		from:       fs.String("from", "", "earliest air date (YYYY-MM-DD)"),
+		to:         fs.String("to", "", "latest air date (YYYY-MM-DD)"),
+		dates:      fs.String("dates", "", `relative air date range instead of --from/--to, e.g. "last 30 days" or "this year"`),
+		near:       fs.String("near", "", "place name or lat,lng for --within-km"),
+		withinKm:   fs.Float64("within-km", 0, "only stories within this many km of --near"),
+		minWords:   fs.Int("min-words", 0, "only stories at least this many words long"),
+		maxWords:   fs.Int("max-words", 0, "only stories at most this many words long"),
+		unread:     fs.Bool("unread", false, "only stories never opened in the TUI"),
+		bookmarked: fs.Bool("bookmarked", false, "only bookmarked stories"),
+		cluster:    fs.Int("cluster", -1, "only stories in this semantic cluster"),
+	}
+}
+
+// filters validates the flags. The --near place is resolved separately
+// by resolveNear once there is a database connection.
+func (f filterFlags) filters() (db.BrowseFilters, error) {
+	filters := db.BrowseFilters{
+		Location:       *f.location,
+		MinWords:       *f.minWords,
+		MaxWords:       *f.maxWords,
+		UnreadOnly:     *f.unread,
+		BookmarkedOnly: *f.bookmarked,
+	}
+	for _, t := range strings.Split(*f.storyTypes, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			filters.StoryTypes = append(filters.StoryTypes, t)
+		}
+	}
+	if *f.cluster >= 0 {
+		cluster := *f.cluster
+		filters.ClusterID = &cluster
+	}
+	var err error
+	if filters.DateFrom, err = parseDate(*f.from); err != nil {
+		return filters, err
+	}
+	if filters.DateTo, err = parseDate(*f.to); err != nil {
+		return filters, err
+	}
+	if *f.dates != "" {
+		if *f.from != "" || *f.to != "" {
+			return filters, errors.New("--dates can't be combined with --from or --to")
+		}
+		if filters.DateRange, err = db.ParseDateRange(*f.dates); err != nil {
+			return filters, err
+		}
+	}
+	if (*f.near == "") != (*f.withinKm <= 0) {
+		return filters, errors.New("--near and --within-km must be used together")
+	}
+	return filters, nil
+}
+
+// resolveNear fills in the distance filter from --near and --within-km
+func (f filterFlags) resolveNear(ctx context.Context, database *db.DB, filters *db.BrowseFilters) error {
+	if *f.near == "" {
+		return nil
+	}
+	point, err := db.ParseGeoPoint(*f.near)
+	if err != nil {
+		if point, err = database.ResolvePlace(ctx, *f.near); err != nil {
+			return err
+		}
+	}
+	filters.Near = point
+	filters.NearLabel = *f.near
+	filters.RadiusKm = *f.withinKm
+	return nil
+}
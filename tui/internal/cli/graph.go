@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/graph"
+)
+
+// Graph writes the network of stories as GraphML or GEXF for Gephi: a node
+// per story, joined by the edge kinds chosen with --edges, each weighted as
+// given there ("similar=2,location"). Narrow the stories with the usual
+// filters.
+func Graph(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("graph", flag.ContinueOnError)
+	filterArgs := addFilterFlags(fs)
+	edges := fs.String("edges", "similar,location,cluster", "edge kinds to include, each with an optional weight (kind=weight): similar, location, hotspot, cluster")
+	neighbors := fs.Int("neighbors", 5, "similar edges from each story to its nearest neighbors by embedding")
+	minSimilarity := fs.Float64("min-similarity", 0.5, "leave out similar edges below this cosine similarity")
+	maxShared := fs.Int("max-shared", 50, "leave out locations and hotspots shared by more stories than this (0: no limit)")
+	format := fs.String("format", "", "graphml or gexf (default: from the --out extension, else graphml)")
+	outPath := fs.String("out", "", "write the graph to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	weights, err := graph.ParseWeights(*edges)
+	if err != nil {
+		return err
+	}
+	if *neighbors <= 0 {
+		return errors.New("--neighbors must be positive")
+	}
+	write, err := graphWriter(*format, *outPath)
+	if err != nil {
+		return err
+	}
+	filters, err := filterArgs.filters()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := filterArgs.resolveNear(ctx, database, &filters); err != nil {
+		return err
+	}
+
+	var stories []db.Story
+	if err := database.StreamStories(ctx, &filters, nil, 0, false, func(s *db.Story) error {
+		stories = append(stories, *s)
+		return nil
+	}); err != nil {
+		return err
+	}
+	if len(stories) == 0 {
+		return errors.New("no stories match")
+	}
+
+	var similar []db.SimilarPair
+	if _, ok := weights[graph.Similar]; ok {
+		ids := make([]string, len(stories))
+		for i, s := range stories {
+			ids[i] = s.ID
+		}
+		if similar, err = database.SimilarPairs(ctx, ids, *neighbors, *minSimilarity); err != nil {
+			return err
+		}
+	}
+
+	g := graph.Build(stories, similar, graph.Options{Weights: weights, MaxShared: *maxShared})
+
+	if *outPath == "" {
+		return write(out, g)
+	}
+	f, err := os.Create(*outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create graph file: %w", err)
+	}
+	if err := write(f, g); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+	fmt.Fprintf(out, "wrote %d nodes and %d edges to %s\n", len(g.Nodes), len(g.Edges), *outPath)
+	return nil
+}
+
+// graphWriter picks the format by name, or GEXF for a .gexf file and
+// GraphML for anything else
+func graphWriter(format, path string) (func(io.Writer, graph.Graph) error, error) {
+	if format == "" && strings.EqualFold(filepath.Ext(path), ".gexf") {
+		format = "gexf"
+	}
+	switch format {
+	case "", "graphml":
+		return graph.WriteGraphML, nil
+	case "gexf":
+		return graph.WriteGEXF, nil
+	}
+	return nil, fmt.Errorf("unknown graph format %q (want graphml or gexf)", format)
+}
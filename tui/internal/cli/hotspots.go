@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"paranormal-tui/internal/db"
+)
+
+// Hotspots prints the geographic hotspots found by scripts/cluster_locations.py
+func Hotspots(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("hotspots", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	hotspots, err := database.GetGeoHotspots(ctx)
+	if err != nil {
+		return err
+	}
+	if len(hotspots) == 0 {
+		fmt.Fprintln(out, "No hotspots. Run scripts/geocode_stories.py then scripts/cluster_locations.py.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "hotspot\tstories\tcenter\tplace")
+	for _, h := range hotspots {
+		fmt.Fprintf(w, "#%d\t%d\t%.3f, %.3f\t%s\n", h.ID, h.Count, h.Center.Lat, h.Center.Lng, h.Place)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// maxTitle is how much of the first line an untitled story is named by
+const maxTitle = 80
+
+// Ingest adds a one-off story, such as a friend's written account, read
+// from standard input:
+//
+//	cat account.txt | paranormal-tui ingest --show "Otherworld" --date 2024-05-01 --stdin
+//
+// It goes under the show's episode for that date, as loaded segments do.
+// The text is kept verbatim; its hash is recorded so the same text isn't
+// added twice. Like an approved submission, it has no embedding until the
+// pipeline computes one.
+func Ingest(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("ingest", flag.ContinueOnError)
+	stdin := fs.Bool("stdin", false, "read the story's text from standard input")
+	show := fs.String("show", "", "show the story is filed under (required)")
+	date := fs.String("date", "", "air date, YYYY-MM-DD; empty for undated")
+	title := fs.String("title", "", "title; empty uses the first line of the text")
+	storyType := fs.String("type", "", "story type: "+strings.Join(db.StoryTypes, ", "))
+	location := fs.String("location", "", "where it happened")
+	period := fs.String("period", "", "when it happened, e.g. \"summer 1994\"")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if !*stdin || *show == "" || fs.NArg() > 0 {
+		return errors.New("usage: ingest --stdin --show <show> [--date YYYY-MM-DD] [--title ...] < story.txt")
+	}
+	airDate, err := parseDate(*date)
+	if err != nil {
+		return err
+	}
+	if *storyType != "" && !slices.Contains(db.StoryTypes, *storyType) {
+		return fmt.Errorf("unknown type %q (want one of %s)", *storyType, strings.Join(db.StoryTypes, ", "))
+	}
+
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to read standard input: %w", err)
+	}
+	content := strings.TrimSpace(strings.ReplaceAll(string(data), "\r\n", "\n"))
+	if content == "" {
+		return errors.New("no text on standard input")
+	}
+	if *title == "" {
+		*title = firstLine(content)
+	}
+	sum := sha256.Sum256([]byte(content))
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	id, err := database.AddStory(ctx, db.NewStory{
+		Show:       *show,
+		AirDate:    airDate,
+		Title:      *title,
+		Content:    content,
+		StoryType:  *storyType,
+		Location:   *location,
+		TimePeriod: *period,
+		Source:     "stdin",
+		SourceID:   hex.EncodeToString(sum[:]),
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "added %q as story %s\n", *title, id)
+	return nil
+}
+
+// firstLine is the text's first line, cut at a word to maxTitle characters
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	line = strings.TrimSpace(line)
+	if r := []rune(line); len(r) > maxTitle {
+		line = string(r[:maxTitle])
+		if i := strings.LastIndex(line, " "); i > maxTitle/2 {
+			line = line[:i]
+		}
+		line += "…"
+	}
+	return line
+}
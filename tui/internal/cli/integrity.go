@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"paranormal-tui/internal/archive"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/integrity"
+)
+
+// Integrity keeps the corpus tamper-evident. A seal records each story's
+// content hash and signs a manifest of them; verify recomputes the hashes
+// and reports any story whose transcript changed without being re-sealed.
+//
+//	integrity seal [--key] [--out] [--accept id,...|--accept-all]
+//	integrity verify [--pub] [--manifest]
+//
+// Run seal on a schedule (e.g. nightly from cron) and keep the --out
+// copies somewhere the database can't reach; verify --manifest against
+// one of them catches edits made by someone who could also re-sign.
+func Integrity(args []string, out io.Writer) error {
+	action := "verify"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("integrity "+action, flag.ContinueOnError)
+	key := fs.String("key", os.Getenv(defaultKeyEnv), "signing key file (default $"+defaultKeyEnv+")")
+	outPath := fs.String("out", "", "also write the signed manifest to this file")
+	accept := fs.String("accept", "", "story IDs (comma-separated) whose changed content to re-seal")
+	acceptAll := fs.Bool("accept-all", false, "re-seal every changed or vanished story")
+	pub := fs.String("pub", "", "public key the manifest must be signed with (default: trust the embedded key)")
+	manifestPath := fs.String("manifest", "", "verify against this manifest file instead of the latest in the database")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	switch action {
+	case "seal":
+		if *key == "" {
+			return fmt.Errorf("--key or $%s is required (create one with `archive keygen`)", defaultKeyEnv)
+		}
+		signingKey, err := archive.LoadKey(*key)
+		if err != nil {
+			return err
+		}
+		return integritySeal(signingKey, *acceptAll, splitIDs(*accept), *outPath, out)
+
+	case "verify":
+		var trusted ed25519.PublicKey
+		if *pub != "" {
+			k, err := archive.ParsePublicKey(*pub)
+			if err != nil {
+				return err
+			}
+			trusted = k
+		}
+		return integrityVerify(trusted, *manifestPath, out)
+
+	default:
+		return fmt.Errorf("unknown integrity action %q (want seal or verify)", action)
+	}
+}
+
+func integritySeal(key ed25519.PrivateKey, acceptAll bool, accept []string, outPath string, out io.Writer) error {
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	result, err := database.Seal(ctx, acceptAll, accept, func(entries []db.HashEntry) (*db.CorpusManifest, error) {
+		return integrity.Sign(entries, key), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	m := result.Manifest
+	fmt.Fprintf(out, "sealed %d stories (%d new, %d accepted), root %s\n", m.StoryCount, result.Added, result.Accepted, m.Root)
+	if outPath != "" {
+		if err := integrity.Save(outPath, m); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "wrote manifest to %s\n", outPath)
+	}
+	return nil
+}
+
+func integrityVerify(trusted ed25519.PublicKey, manifestPath string, out io.Writer) error {
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	var m *db.CorpusManifest
+	if manifestPath != "" {
+		m, err = integrity.Load(manifestPath)
+	} else {
+		m, err = database.LatestCorpusManifest(ctx)
+		if errors.Is(err, db.ErrNotFound) {
+			return fmt.Errorf("the corpus has never been sealed (run `integrity seal`)")
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if err := integrity.Verify(m, trusted); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "manifest of %s: %d stories, signed by %s\n", m.CreatedAt.Format("2006-01-02 15:04"), m.StoryCount, m.PublicKey)
+
+	hashes, err := database.StoryHashes(ctx)
+	if err != nil {
+		return err
+	}
+	signed := make(map[string]string, len(m.Entries))
+	for _, e := range m.Entries {
+		signed[e.StoryID] = e.SHA256
+	}
+
+	problems, unsealed := 0, 0
+	for _, h := range hashes {
+		want, ok := signed[h.StoryID]
+		delete(signed, h.StoryID)
+		switch {
+		case !ok && h.Current != "":
+			unsealed++
+			continue
+		case !ok:
+			continue
+		case h.Current == "":
+			fmt.Fprintf(out, "MISSING   %s\n", h.StoryID)
+			problems++
+		case h.Current != want:
+			fmt.Fprintf(out, "MODIFIED  %s\n", h.StoryID)
+			problems++
+		}
+		// The hash table should mirror the manifest; an edit to both the
+		// story and its sealed hash shows up here
+		if h.Sealed != want && manifestPath == "" {
+			fmt.Fprintf(out, "RESEALED  %s (sealed hash differs from the signed manifest)\n", h.StoryID)
+			problems++
+		}
+	}
+
+	// Whatever is left was removed from the stories and the hash table alike
+	for id := range signed {
+		fmt.Fprintf(out, "MISSING   %s\n", id)
+		problems++
+	}
+
+	if unsealed > 0 {
+		fmt.Fprintf(out, "%d stories added since the seal are not covered\n", unsealed)
+	}
+	if problems > 0 {
+		return fmt.Errorf("%d integrity problems found", problems)
+	}
+	fmt.Fprintln(out, "OK: every sealed story matches its signed hash")
+	return nil
+}
+
+// splitIDs splits a comma-separated list of story IDs, dropping blanks
+func splitIDs(value string) []string {
+	var ids []string
+	for _, id := range strings.Split(value, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
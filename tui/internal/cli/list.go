@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// tableFlushRows is how many table rows are aligned and written at a time,
+// so listing everything doesn't buffer the whole table
+const tableFlushRows = 200
+
+// List prints stories matching the given filters, one per line
+func List(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("list", flag.ContinueOnError)
+	filterArgs := addFilterFlags(fs)
+	sortField := fs.String("sort", "date", "sort field: "+strings.Join(db.SortFields, ", "))
+	asc := fs.Bool("asc", false, "sort ascending")
+	limit := fs.Int("limit", 50, "maximum stories to print (0 for all)")
+	format := fs.String("format", "table", "output format: table or jsonl")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "table" && *format != "jsonl" {
+		return fmt.Errorf("unknown format %q (want table or jsonl)", *format)
+	}
+
+	filters, err := filterArgs.filters()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := filterArgs.resolveNear(ctx, database, &filters); err != nil {
+		return err
+	}
+
+	sort := db.BrowseSort{Field: *sortField, Ascending: *asc}
+
+	if *format == "jsonl" {
+		enc := json.NewEncoder(out)
+		return database.StreamStories(ctx, &filters, &sort, *limit, false, func(s *db.Story) error {
+			return enc.Encode(storyRecord(s, false))
+		})
+	}
+
+	total, err := database.CountStories(ctx, &filters)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	printed := 0
+	err = database.StreamStories(ctx, &filters, &sort, *limit, false, func(s *db.Story) error {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			s.ID, s.FormattedDate(), s.FormattedType(),
+			strings.ReplaceAll(s.Title, "\t", " "), s.FormattedLocation())
+		printed++
+		if printed%tableFlushRows == 0 {
+			return w.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	w.Flush()
+	fmt.Fprintf(out, "\n%d of %d stories\n", printed, total)
+
+	return nil
+}
+
+// storyJSON is one line of jsonl output
+type storyJSON struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	Show      string   `json:"show,omitempty"`
+	AirDate   string   `json:"air_date,omitempty"`
+	StoryType string   `json:"story_type,omitempty"`
+	Location  string   `json:"location,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+	Words     *int     `json:"words,omitempty"`
+	Summary   string   `json:"summary,omitempty"`
+	Content   string   `json:"content,omitempty"`
+}
+
+// storyRecord converts a story for jsonl output, with content if asked
+func storyRecord(s *db.Story, content bool) storyJSON {
+	r := storyJSON{
+		ID:        s.ID,
+		Title:     s.Title,
+		Show:      s.ShowName.String,
+		StoryType: s.StoryType.String,
+		Location:  s.Location.String,
+		Summary:   s.Summary.String,
+	}
+	if s.AirDate.Valid {
+		// Machine-readable, so ISO whatever the display date format
+		r.AirDate = s.AirDate.Time.Format("2006-01-02")
+	}
+	if s.Latitude.Valid && s.Longitude.Valid {
+		r.Latitude, r.Longitude = &s.Latitude.Float64, &s.Longitude.Float64
+	}
+	if s.Words.Valid {
+		words := int(s.Words.Int32)
+		r.Words = &words
+	}
+	if content {
+		r.Content = s.Content
+	}
+	return r
+}
+
+func parseDate(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", value)
+	}
+	return &t, nil
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"paranormal-tui/internal/db"
+)
+
+// Maintenance reports on and maintains the story tables:
+//
+//	maintenance [status]          table sizes, dead rows, vacuum/analyze times, index health
+//	maintenance analyze           refresh planner statistics
+//	maintenance reindex [TABLE]   rebuild indexes (default: stories)
+func Maintenance(args []string, out io.Writer) error {
+	action := "status"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("maintenance "+action, flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "status":
+		return maintenanceStatus(ctx, database, out)
+
+	case "analyze":
+		if err := database.AnalyzeTables(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "analyzed %s\n", strings.Join(db.MaintainedTables, ", "))
+
+	case "reindex":
+		table := "stories"
+		if fs.NArg() > 0 {
+			table = fs.Arg(0)
+		}
+		if err := database.ReindexTable(ctx, table); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "reindexed %s\n", table)
+
+	default:
+		return fmt.Errorf("unknown maintenance action %q (want status, analyze, or reindex)", action)
+	}
+	return nil
+}
+
+func maintenanceStatus(ctx context.Context, database *db.DB, out io.Writer) error {
+	tables, err := database.GetTableHealth(ctx)
+	if err != nil {
+		return err
+	}
+	indexes, err := database.GetIndexHealth(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "table\ttotal\ttable\tindexes\tlive\tdead\tvacuumed\tanalyzed")
+	for _, t := range tables {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%d (%.0f%%)\t%s\t%s\n",
+			t.Name, db.FormatBytes(t.TotalBytes), db.FormatBytes(t.TableBytes), db.FormatBytes(t.IndexBytes),
+			t.LiveRows, t.DeadRows, 100*t.DeadRatio(), db.FormatAge(t.LastVacuum), db.FormatAge(t.LastAnalyze))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out)
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "index\ttable\tsize\tscans\tleaf density")
+	for _, ix := range indexes {
+		density := "-"
+		if ix.LeafDensity != nil {
+			density = fmt.Sprintf("%.0f%%", *ix.LeafDensity)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", ix.Name, ix.Table, db.FormatBytes(ix.Bytes), ix.Scans, density)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"paranormal-tui/internal/db"
+)
+
+// Migrate brings the database schema up to date and prints its version.
+// Read-only sessions and exports never migrate, so run this first when
+// they use a role that can't alter tables.
+func Migrate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	version, err := database.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "schema is at version %d of %d\n", version, db.SchemaLatest())
+	return nil
+}
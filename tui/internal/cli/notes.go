@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/notes"
+)
+
+// Notes syncs my story notes with a directory of Markdown files, both
+// ways, so they can be written in any editor. Story IDs given start a file
+// for each, and --watch keeps syncing until interrupted.
+func Notes(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("notes", flag.ContinueOnError)
+	dir := fs.String("dir", "", "directory of note files, one per story (required)")
+	watch := fs.Bool("watch", false, "keep syncing as files or notes change, until interrupted")
+	interval := fs.Duration("interval", 2*time.Second, "how often --watch looks for changes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return errors.New("usage: notes --dir <directory> [--watch] [story-id...]")
+	}
+	if *interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	if err := os.MkdirAll(*dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create notes directory: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if ids := fs.Args(); len(ids) > 0 {
+		paths, err := notes.Start(ctx, database, *dir, ids)
+		for _, path := range paths {
+			fmt.Fprintln(out, path)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	// Watching reports a sync only if it changed something, or its
+	// problems aren't the ones already reported
+	var reported string
+	sync := func(quiet bool) error {
+		result, err := notes.Sync(ctx, database, *dir)
+		if err != nil {
+			return err
+		}
+		problems := strings.Join(result.Problems, "\n")
+		if quiet && !result.Changed() && problems == reported {
+			return nil
+		}
+		reported = problems
+		prefix := ""
+		if *watch {
+			prefix = time.Now().Format("15:04:05") + " "
+		}
+		fmt.Fprintf(out, "%s%s\n", prefix, result)
+		for _, p := range result.Problems {
+			fmt.Fprintf(out, "%s  %s\n", prefix, p)
+		}
+		return nil
+	}
+
+	if err := sync(false); err != nil || !*watch {
+		return err
+	}
+	// Polled rather than watched, which needs no platform support and
+	// catches notes changed in the database too
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := sync(true); err != nil {
+				return err
+			}
+		}
+	}
+}
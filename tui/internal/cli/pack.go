@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+	"paranormal-tui/internal/pack"
+)
+
+// packCandidates is how many search results are fetched per story wanted,
+// so there are enough left after the filters narrow them
+const packCandidates = 10
+
+// Pack writes a Markdown story pack for podcast hosts: the top N stories
+// for a search (--query) or, without one, the first N matching the filters
+// in sort order. Pick a theme with --query, --cluster, --type, and so on.
+func Pack(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("pack", flag.ContinueOnError)
+	filterArgs := addFilterFlags(fs)
+	query := fs.String("query", "", "search for stories on this theme")
+	mode := fs.String("mode", "text", "search mode for --query: text, vector, or hybrid")
+	alpha := fs.Float64("alpha", 0.7, "hybrid blend factor: 1.0=vector only, 0.0=text only")
+	sortField := fs.String("sort", "date", "sort field without --query: "+strings.Join(db.SortFields, ", "))
+	asc := fs.Bool("asc", false, "sort ascending")
+	n := fs.Int("n", 5, "number of stories in the pack")
+	wpm := fs.Int("wpm", pack.DefaultWPM, "reading pace for read-time estimates, in words per minute")
+	title := fs.String("title", "Story pack", "pack title")
+	outPath := fs.String("out", "", "write the pack to this file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *n <= 0 {
+		return errors.New("-n must be positive")
+	}
+	if *mode != "text" && *mode != "vector" && *mode != "hybrid" {
+		return fmt.Errorf("unknown mode %q (want text, vector, or hybrid)", *mode)
+	}
+
+	filters, err := filterArgs.filters()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	if err := filterArgs.resolveNear(ctx, database, &filters); err != nil {
+		return err
+	}
+
+	var stories []db.Story
+	if *query != "" {
+		stories, err = searchPack(ctx, database, *query, *mode, *alpha, filters, *n)
+	} else {
+		sort := db.BrowseSort{Field: *sortField, Ascending: *asc}
+		err = database.StreamStories(ctx, &filters, &sort, *n, true, func(s *db.Story) error {
+			stories = append(stories, *s)
+			return nil
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if len(stories) == 0 {
+		return errors.New("no stories match")
+	}
+
+	ids := make([]string, len(stories))
+	for i, s := range stories {
+		ids[i] = s.ID
+	}
+	citations, err := database.Citations(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	p := pack.Pack{Title: *title, Theme: packTheme(*query, filters)}
+	for _, s := range stories {
+		var c *db.Citation
+		if found, ok := citations[s.ID]; ok {
+			c = &found
+		}
+		p.Entries = append(p.Entries, pack.NewEntry(s, c, *wpm))
+	}
+
+	if *outPath == "" {
+		return p.WriteMarkdown(out)
+	}
+	f, err := os.Create(*outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create pack file: %w", err)
+	}
+	if err := p.WriteMarkdown(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write pack: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write pack: %w", err)
+	}
+	fmt.Fprintf(out, "wrote %d stories (about %d min) to %s\n", len(p.Entries), int(p.Total().Minutes()), *outPath)
+	return nil
+}
+
+// searchPack returns the best n search results that also match the filters,
+// in rank order
+func searchPack(ctx context.Context, database *db.DB, query, mode string, alpha float64, filters db.BrowseFilters, n int) ([]db.Story, error) {
+	var embedding []float32
+	if mode != "text" {
+		client, err := embed.New()
+		if err != nil {
+			return nil, err
+		}
+		if embedding, err = client.Embed(ctx, query); err != nil {
+			return nil, fmt.Errorf("failed to embed query: %w", err)
+		}
+	}
+
+	limit := n * packCandidates
+	var ranked []string
+	switch mode {
+	case "text":
+		stories, err := database.TextSearch(ctx, query, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stories {
+			ranked = append(ranked, s.ID)
+		}
+	case "vector":
+		stories, err := database.VectorSearch(ctx, embedding, limit)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range stories {
+			ranked = append(ranked, s.ID)
+		}
+	case "hybrid":
+		results, err := database.HybridSearch(ctx, query, embedding, limit, alpha)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range results {
+			ranked = append(ranked, r.Story.ID)
+		}
+	}
+	if len(ranked) == 0 {
+		return nil, nil
+	}
+
+	// Search ignores the filters, so run the candidates through them and
+	// put the survivors back in rank order
+	filters.IDs = ranked
+	matched := make(map[string]db.Story, len(ranked))
+	if err := database.StreamStories(ctx, &filters, nil, 0, true, func(s *db.Story) error {
+		matched[s.ID] = *s
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var stories []db.Story
+	for _, id := range ranked {
+		if s, ok := matched[id]; ok && len(stories) < n {
+			stories = append(stories, s)
+		}
+	}
+	return stories, nil
+}
+
+// packTheme describes how the stories were picked, for the pack header
+func packTheme(query string, filters db.BrowseFilters) string {
+	var parts []string
+	if query != "" {
+		parts = append(parts, fmt.Sprintf("%q", query))
+	}
+	if len(filters.StoryTypes) > 0 {
+		parts = append(parts, strings.Join(filters.StoryTypes, ", ")+" stories")
+	}
+	if filters.ClusterID != nil {
+		parts = append(parts, fmt.Sprintf("cluster %d", *filters.ClusterID))
+	}
+	if filters.Location != "" {
+		parts = append(parts, "in "+filters.Location)
+	}
+	if filters.NearLabel != "" {
+		parts = append(parts, fmt.Sprintf("within %g km of %s", filters.RadiusKm, filters.NearLabel))
+	}
+	return strings.Join(parts, "; ")
+}
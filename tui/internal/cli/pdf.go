@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"paranormal-tui/internal/casefile"
+	"paranormal-tui/internal/db"
+)
+
+// PDF typesets case files for printing: the stories given by ID, a
+// --collection's, or with --all every story matching the filters. It needs
+// the typst command for a .pdf; a .typ file is the Typst source, to
+// compile elsewhere or adjust first.
+func PDF(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("pdf", flag.ContinueOnError)
+	filterArgs := addFilterFlags(fs)
+	collection := fs.String("collection", "", "case files for the stories in this collection")
+	all := fs.Bool("all", false, "case files for every story matching the filters")
+	sortField := fs.String("sort", "date", "sort field with --all: "+strings.Join(db.SortFields, ", "))
+	asc := fs.Bool("asc", false, "sort ascending")
+	limit := fs.Int("limit", 0, "maximum stories with --all (0 for all)")
+	title := fs.String("title", "Case files", "title on the cover page")
+	outPath := fs.String("out", "", "write to this file: .pdf, or .typ for the Typst source")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids := fs.Args()
+	if len(ids) == 0 && *collection == "" && !*all {
+		return errors.New("usage: pdf --out <file.pdf> [flags] <story-id>... (or --collection, or --all with filters)")
+	}
+	ext := strings.ToLower(filepath.Ext(*outPath))
+	if ext != ".pdf" && ext != ".typ" {
+		return errors.New("--out must name a .pdf or .typ file")
+	}
+
+	filters, err := filterArgs.filters()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.NewReadOnly(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	var stories []db.Story
+	collect := func(s *db.Story) error {
+		stories = append(stories, *s)
+		return nil
+	}
+	subtitle := ""
+	switch {
+	case len(ids) > 0:
+		err = database.StreamStoriesByID(ctx, ids, collect)
+	case *collection != "":
+		subtitle = fmt.Sprintf("From the collection %q", *collection)
+		if ids, err = database.CollectionStoryIDs(ctx, *collection); err == nil {
+			err = database.StreamStoriesByID(ctx, ids, collect)
+		}
+	default:
+		if err = filterArgs.resolveNear(ctx, database, &filters); err == nil {
+			subtitle = packTheme("", filters)
+			sort := db.BrowseSort{Field: *sortField, Ascending: *asc}
+			err = database.StreamStories(ctx, &filters, &sort, *limit, true, collect)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(stories) == 0 {
+		return errors.New("no stories match")
+	}
+
+	ids = make([]string, len(stories))
+	for i, s := range stories {
+		ids[i] = s.ID
+	}
+	citations, err := database.Citations(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	file := casefile.File{Title: *title, Subtitle: subtitle}
+	for _, s := range stories {
+		var c *db.Citation
+		if found, ok := citations[s.ID]; ok {
+			c = &found
+		}
+		file.Cases = append(file.Cases, casefile.Case{Story: s, Citation: c})
+	}
+
+	if ext == ".pdf" {
+		if err := file.Compile(ctx, *outPath); err != nil {
+			return err
+		}
+	} else {
+		f, err := os.Create(*outPath)
+		if err != nil {
+			return fmt.Errorf("failed to create typst file: %w", err)
+		}
+		if err := file.WriteTypst(f); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write typst file: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to write typst file: %w", err)
+		}
+	}
+	fmt.Fprintf(out, "wrote %d case files to %s\n", len(file.Cases), *outPath)
+	return nil
+}
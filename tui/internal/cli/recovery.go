@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+)
+
+// Recovery undoes destructive batch jobs. Merging, purging the trash,
+// re-clustering, and re-projecting each copy the rows they change to a
+// recovery snapshot first:
+//
+//	recovery [list]        list snapshots, newest first
+//	recovery restore ID    put a snapshot's rows back
+//	recovery prune         delete snapshots older than --older-than
+//
+// Restoring sets rows that still exist back to their copied values and
+// inserts rows that were deleted, so a purge is undone, trashed stories
+// and all, and a re-cluster puts every story back in its old cluster.
+// Later changes to the same columns are overwritten; other rows are left
+// alone. A snapshot restores once.
+func Recovery(args []string, out io.Writer) error {
+	action := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("recovery "+action, flag.ContinueOnError)
+	olderThan := fs.Duration("older-than", db.DefaultTrashRetention, "prune snapshots taken longer ago than this")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "list":
+		return listSnapshots(ctx, database, out)
+
+	case "restore":
+		if fs.NArg() != 1 {
+			return fmt.Errorf("usage: recovery restore ID")
+		}
+		id, err := strconv.ParseInt(fs.Arg(0), 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid snapshot ID %q", fs.Arg(0))
+		}
+		n, err := database.RestoreSnapshot(ctx, id)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "restored %d rows from snapshot %d\n", n, id)
+
+	case "prune":
+		n, err := database.PruneSnapshots(ctx, *olderThan)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "pruned %d snapshots taken more than %s ago\n", n, *olderThan)
+
+	default:
+		return fmt.Errorf("unknown recovery action %q (want list, restore, or prune)", action)
+	}
+	return nil
+}
+
+func listSnapshots(ctx context.Context, database *db.DB, out io.Writer) error {
+	snapshots, err := database.ListSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+	if len(snapshots) == 0 {
+		fmt.Fprintln(out, "No snapshots.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "id\ttaken\tjob\trows\trestored\tnote")
+	for _, s := range snapshots {
+		restored := "-"
+		if s.RestoredAt != nil {
+			restored = dates.Time(*s.RestoredAt)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d\t%s\t%s\n", s.ID, dates.Time(s.TakenAt), s.Job, s.Rows, restored, s.Note)
+	}
+	return w.Flush()
+}
+
+// undoHint is printed after a job that took a snapshot
+const undoHint = "undo with `paranormal-tui recovery restore ID`; see `recovery list` for the ID"
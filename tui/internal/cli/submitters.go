@@ -0,0 +1,82 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+)
+
+// Submitters manages who may submit accounts through the web backend's
+// POST /api/submissions. Submissions wait in the Review view.
+//
+//	submitters [list]          list submitters and their submission counts
+//	submitters add NAME        register a submitter and print their token
+//	submitters revoke NAME     stop accepting a submitter's token
+func Submitters(args []string, out io.Writer) error {
+	action := "list"
+	if len(args) > 0 {
+		action, args = args[0], args[1:]
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "list":
+		return listSubmitters(ctx, database, out)
+
+	case "add":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: submitters add NAME")
+		}
+		token, err := database.AddSubmitter(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "added %s\ntoken: %s\n(send it as \"Authorization: Bearer <token>\"; it can't be shown again)\n", args[0], token)
+
+	case "revoke":
+		if len(args) != 1 {
+			return fmt.Errorf("usage: submitters revoke NAME")
+		}
+		if err := database.RevokeSubmitter(ctx, args[0]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "revoked %s\n", args[0])
+
+	default:
+		return fmt.Errorf("unknown submitters action %q (want list, add, or revoke)", action)
+	}
+	return nil
+}
+
+func listSubmitters(ctx context.Context, database *db.DB, out io.Writer) error {
+	submitters, err := database.ListSubmitters(ctx)
+	if err != nil {
+		return err
+	}
+	if len(submitters) == 0 {
+		fmt.Fprintln(out, "No submitters. Add one with `submitters add NAME`.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "name\tadded\trevoked\tpending\tapproved\trejected")
+	for _, s := range submitters {
+		revoked := "-"
+		if s.RevokedAt != nil {
+			revoked = dates.Time(*s.RevokedAt)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%d\t%d\n",
+			s.Name, dates.Time(s.CreatedAt), revoked, s.Pending, s.Approved, s.Rejected)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+)
+
+// Trash manages trashed stories:
+//
+//	trash [list]            list trashed stories
+//	trash rm ID...          move stories to the trash
+//	trash merge ID INTO     trash a duplicate, moving its external IDs to INTO
+//	trash restore ID...     take stories back out of the trash
+//	trash purge             delete stories trashed longer than the retention
+//
+// purge is meant to run from cron; --retention overrides the config file.
+// merge and purge snapshot what they change first (see Recovery).
+func Trash(args []string, out io.Writer) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	action := "list"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("trash "+action, flag.ContinueOnError)
+	retention := fs.Duration("retention", cfg.Trash.Retention(), "purge stories trashed longer ago than this")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	ids := fs.Args()
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "list":
+		return listTrash(ctx, database, out, *retention)
+
+	case "rm":
+		for _, id := range ids {
+			if err := database.TrashStory(ctx, id, "deleted"); err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+			fmt.Fprintf(out, "trashed %s\n", id)
+		}
+
+	case "merge":
+		if len(ids) != 2 {
+			return fmt.Errorf("usage: trash merge ID INTO")
+		}
+		if err := database.MergeStory(ctx, ids[0], ids[1]); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "merged %s into %s (%s)\n", ids[0], ids[1], undoHint)
+
+	case "restore":
+		for _, id := range ids {
+			if err := database.RestoreStory(ctx, id); err != nil {
+				return fmt.Errorf("%s: %w", id, err)
+			}
+			fmt.Fprintf(out, "restored %s\n", id)
+		}
+
+	case "purge":
+		n, err := database.PurgeTrash(ctx, *retention)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "purged %d stories trashed more than %s ago\n", n, *retention)
+		if n > 0 {
+			fmt.Fprintln(out, undoHint)
+		}
+
+	default:
+		return fmt.Errorf("unknown trash action %q (want list, rm, merge, restore, or purge)", action)
+	}
+	return nil
+}
+
+func listTrash(ctx context.Context, database *db.DB, out io.Writer, retention time.Duration) error {
+	trashed, err := database.ListTrash(ctx)
+	if err != nil {
+		return err
+	}
+	if len(trashed) == 0 {
+		fmt.Fprintln(out, "Trash is empty.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "id\ttrashed\tpurge\treason\ttitle")
+	for _, t := range trashed {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+			t.ID, dates.Time(t.DeletedAt), dates.Time(t.DeletedAt.Add(retention)), t.Reason, t.Title)
+	}
+	return w.Flush()
+}
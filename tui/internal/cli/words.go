@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// Words manages the stored story word counts used by length filters
+// and the length sort:
+//
+//	words [status]             how many stories are counted
+//	words backfill [--batch]   count stories from before the column existed
+func Words(args []string, out io.Writer) error {
+	action := "status"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		action, args = args[0], args[1:]
+	}
+
+	fs := flag.NewFlagSet("words "+action, flag.ContinueOnError)
+	batch := fs.Int("batch", 500, "stories counted per batch; each batch commits on its own")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	database, err := db.New(ctx)
+	if err != nil {
+		return err
+	}
+	defer database.Close()
+
+	switch action {
+	case "status":
+		return wordsStatus(ctx, database, out)
+
+	case "backfill":
+		if *batch <= 0 {
+			return fmt.Errorf("--batch must be positive")
+		}
+		done, err := database.BackfillWordCounts(ctx, *batch, func(done int64) {
+			fmt.Fprintf(out, "\rcounted %d stories", done)
+		})
+		fmt.Fprintln(out)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "backfilled %d word counts\n", done)
+		return wordsStatus(ctx, database, out)
+
+	default:
+		return fmt.Errorf("unknown words action %q (want status or backfill)", action)
+	}
+}
+
+func wordsStatus(ctx context.Context, database *db.DB, out io.Writer) error {
+	counted, missing, err := database.WordCountStatus(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%d stories counted, %d missing", counted, missing)
+	if missing > 0 {
+		fmt.Fprint(out, " (run `words backfill`)")
+	}
+	fmt.Fprintln(out)
+	return nil
+}
@@ -0,0 +1,324 @@
+// Package cluster finds clusters of stories by density with HDBSCAN, as
+// scripts/cluster_stories.py does with the hdbscan library, so the TUI can
+// re-run it without Python. Distances are computed as needed rather than
+// stored, keeping memory linear in the number of stories.
+package cluster
+
+import (
+	"math"
+	"sort"
+)
+
+// minDistance stands in for a distance of zero, between duplicate points,
+// whose density would otherwise be infinite
+const minDistance = 1e-10
+
+// HDBSCAN labels points by density: each gets the index of its cluster,
+// largest first, or -1 for noise. minClusterSize is the fewest points a
+// cluster can have; minSamples is how many neighbors (the point included)
+// make a point dense, higher marking more of the sparse ones as noise.
+func HDBSCAN(points [][]float64, minClusterSize, minSamples int) []int {
+	n := len(points)
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = -1
+	}
+	minClusterSize = max(2, minClusterSize)
+	minSamples = max(1, min(minSamples, n))
+	if n < minClusterSize {
+		return labels
+	}
+
+	core := coreDistances(points, minSamples)
+	tree := singleLinkage(n, spanningTree(points, core))
+	condensed, clusters := condense(tree, n, minClusterSize)
+	selected := selectClusters(condensed, n, clusters)
+	return label(condensed, n, clusters, selected, labels)
+}
+
+// Normalize scales each vector to unit length in place, after which
+// Euclidean distance orders pairs as cosine distance does
+func Normalize(vectors [][]float64) {
+	for _, v := range vectors {
+		var norm float64
+		for _, x := range v {
+			norm += x * x
+		}
+		if norm = math.Sqrt(norm); norm > 0 {
+			for i := range v {
+				v[i] /= norm
+			}
+		}
+	}
+}
+
+func distance(a, b []float64) float64 {
+	var sum float64
+	for i := range a {
+		d := a[i] - b[i]
+		sum += d * d
+	}
+	return math.Sqrt(sum)
+}
+
+// coreDistances is each point's distance to its kth nearest neighbor,
+// counting itself as the first
+func coreDistances(points [][]float64, k int) []float64 {
+	core := make([]float64, len(points))
+	dists := make([]float64, len(points))
+	for i, p := range points {
+		for j, q := range points {
+			dists[j] = distance(p, q)
+		}
+		sort.Float64s(dists)
+		core[i] = dists[k-1]
+	}
+	return core
+}
+
+// edge joins two points, or two nodes of the linkage tree
+type edge struct {
+	a, b   int
+	weight float64
+}
+
+// spanningTree is the minimum spanning tree under mutual reachability
+// distance (the larger of two points' core distances and the distance
+// between them), by Prim's algorithm. Its edges come sorted by weight.
+func spanningTree(points [][]float64, core []float64) []edge {
+	n := len(points)
+	inTree := make([]bool, n)
+	best := make([]float64, n)
+	from := make([]int, n)
+	for i := range best {
+		best[i] = math.Inf(1)
+	}
+
+	edges := make([]edge, 0, n-1)
+	current := 0
+	inTree[current] = true
+	for len(edges) < n-1 {
+		next, nextWeight := -1, math.Inf(1)
+		for j := range points {
+			if inTree[j] {
+				continue
+			}
+			d := max(distance(points[current], points[j]), core[current], core[j])
+			if d < best[j] {
+				best[j], from[j] = d, current
+			}
+			if best[j] < nextWeight {
+				next, nextWeight = j, best[j]
+			}
+		}
+		edges = append(edges, edge{from[next], next, nextWeight})
+		inTree[next] = true
+		current = next
+	}
+
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].weight < edges[j].weight })
+	return edges
+}
+
+// linkage is the single-linkage tree: nodes below n are points, and node
+// n+i is the merge made by the ith spanning tree edge
+type linkage struct {
+	left, right []int
+	dist        []float64
+	size        []int
+}
+
+func singleLinkage(n int, edges []edge) linkage {
+	nodes := 2*n - 1
+	t := linkage{
+		left:  make([]int, nodes),
+		right: make([]int, nodes),
+		dist:  make([]float64, nodes),
+		size:  make([]int, nodes),
+	}
+	for i := 0; i < n; i++ {
+		t.size[i] = 1
+	}
+
+	// Union-find over the points, with the tree node each set is now
+	parent := make([]int, n)
+	node := make([]int, n)
+	for i := range parent {
+		parent[i], node[i] = i, i
+	}
+	find := func(i int) int {
+		for parent[i] != i {
+			parent[i] = parent[parent[i]]
+			i = parent[i]
+		}
+		return i
+	}
+
+	for i, e := range edges {
+		ra, rb := find(e.a), find(e.b)
+		merged := n + i
+		t.left[merged], t.right[merged] = node[ra], node[rb]
+		t.dist[merged] = e.weight
+		t.size[merged] = t.size[node[ra]] + t.size[node[rb]]
+		parent[rb] = ra
+		node[ra] = merged
+	}
+	return t
+}
+
+// condensedEdge is a cluster splitting off from its parent, or a point
+// falling out of one, at density lambda. Clusters are numbered from n, the
+// root, so they never clash with points.
+type condensedEdge struct {
+	parent, child int
+	lambda        float64
+	size          int
+}
+
+// condense walks the linkage tree from the root, keeping only the splits
+// where both sides have at least minClusterSize points. Smaller sides fall
+// out of the cluster as points. It returns the edges and the number of
+// clusters.
+func condense(t linkage, n, minClusterSize int) ([]condensedEdge, int) {
+	root := 2*n - 2
+	relabel := make([]int, 2*n-1)
+	relabel[root] = n
+	next := n + 1
+
+	var edges []condensedEdge
+	fallOut := func(node, cluster int, lambda float64) {
+		stack := []int{node}
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if top < n {
+				edges = append(edges, condensedEdge{cluster, top, lambda, 1})
+				continue
+			}
+			stack = append(stack, t.left[top], t.right[top])
+		}
+	}
+
+	queue := []int{root}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		cluster := relabel[node]
+		left, right := t.left[node], t.right[node]
+		lambda := 1 / max(t.dist[node], minDistance)
+		bigLeft, bigRight := t.size[left] >= minClusterSize, t.size[right] >= minClusterSize
+
+		switch {
+		case bigLeft && bigRight:
+			for _, child := range []int{left, right} {
+				relabel[child] = next
+				edges = append(edges, condensedEdge{cluster, next, lambda, t.size[child]})
+				next++
+				queue = append(queue, child)
+			}
+		case bigLeft:
+			relabel[left] = cluster
+			queue = append(queue, left)
+			fallOut(right, cluster, lambda)
+		case bigRight:
+			relabel[right] = cluster
+			queue = append(queue, right)
+			fallOut(left, cluster, lambda)
+		default:
+			fallOut(left, cluster, lambda)
+			fallOut(right, cluster, lambda)
+		}
+	}
+	return edges, next - n
+}
+
+// selectClusters picks the clusters with the most excess of mass: a
+// cluster is kept over its descendants when it is more stable than they
+// are together. The root is never kept, so there are always either no
+// clusters or at least two.
+func selectClusters(edges []condensedEdge, n, clusters int) []bool {
+	birth := make([]float64, clusters)
+	stability := make([]float64, clusters)
+	children := make([][]int, clusters)
+	for _, e := range edges {
+		if e.child >= n {
+			birth[e.child-n] = e.lambda
+			children[e.parent-n] = append(children[e.parent-n], e.child-n)
+		}
+	}
+	for _, e := range edges {
+		stability[e.parent-n] += (e.lambda - birth[e.parent-n]) * float64(e.size)
+	}
+
+	selected := make([]bool, clusters)
+	var deselect func(c int)
+	deselect = func(c int) {
+		for _, child := range children[c] {
+			selected[child] = false
+			deselect(child)
+		}
+	}
+	// Children are numbered after their parents, so this goes bottom up
+	for c := clusters - 1; c > 0; c-- {
+		var childStability float64
+		for _, child := range children[c] {
+			childStability += stability[child]
+		}
+		if len(children[c]) > 0 && childStability > stability[c] {
+			stability[c] = childStability
+			continue
+		}
+		selected[c] = true
+		deselect(c)
+	}
+	return selected
+}
+
+// label gives each point the selected cluster it fell out of, or one
+// holding that, numbered by size
+func label(edges []condensedEdge, n, clusters int, selected []bool, labels []int) []int {
+	parent := make([]int, clusters)
+	for _, e := range edges {
+		if e.child >= n {
+			parent[e.child-n] = e.parent - n
+		}
+	}
+
+	owner := make([]int, n)
+	sizes := make(map[int]int)
+	for _, e := range edges {
+		if e.child >= n {
+			continue
+		}
+		c := e.parent - n
+		for c > 0 && !selected[c] {
+			c = parent[c]
+		}
+		owner[e.child] = c
+		if c > 0 {
+			sizes[c]++
+		}
+	}
+
+	order := make([]int, 0, len(sizes))
+	for c := range sizes {
+		order = append(order, c)
+	}
+	sort.Slice(order, func(i, j int) bool {
+		if sizes[order[i]] != sizes[order[j]] {
+			return sizes[order[i]] > sizes[order[j]]
+		}
+		return order[i] < order[j]
+	})
+	index := make(map[int]int, len(order))
+	for i, c := range order {
+		index[c] = i
+	}
+
+	for i, c := range owner {
+		if c > 0 {
+			labels[i] = index[c]
+		}
+	}
+	return labels
+}
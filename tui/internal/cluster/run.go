@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+
+	"paranormal-tui/internal/db"
+)
+
+// Defaults match scripts/cluster_stories.py
+const (
+	DefaultMinClusterSize = 5
+	DefaultMinSamples     = 2
+)
+
+// Params are what a clustering run is made with
+type Params struct {
+	MinClusterSize int
+	MinSamples     int
+	Embeddings     bool // Cluster the embeddings (by cosine distance) rather than the UMAP coordinates
+}
+
+// Result sums up a clustering run
+type Result struct {
+	Stories  int
+	Clusters int
+	Noise    int
+}
+
+// Run clusters the stories and saves each one's cluster_id
+func Run(ctx context.Context, database *db.DB, p Params) (Result, error) {
+	ids, points, err := database.ClusterInputs(ctx, p.Embeddings)
+	if err != nil {
+		return Result{}, err
+	}
+	if len(ids) == 0 {
+		if p.Embeddings {
+			return Result{}, errors.New("no stories have embeddings")
+		}
+		return Result{}, errors.New("no stories have UMAP coordinates")
+	}
+	if p.Embeddings {
+		Normalize(points)
+	}
+
+	labels := HDBSCAN(points, p.MinClusterSize, p.MinSamples)
+	if err := database.SaveClusters(ctx, ids, labels); err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Stories: len(ids)}
+	for _, l := range labels {
+		if l < 0 {
+			result.Noise++
+		}
+		result.Clusters = max(result.Clusters, l+1)
+	}
+	return result, nil
+}
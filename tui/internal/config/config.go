@@ -0,0 +1,201 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// Config holds user preferences loaded from the config file
+type Config struct {
+	Startup   Startup   `json:"startup"`
+	Browse    Browse    `json:"browse"`
+	Visualize Visualize `json:"visualize"`
+	Trash     Trash     `json:"trash"`
+	Display   Display   `json:"display"`
+	Audio     Audio     `json:"audio"`
+	Serve     Serve     `json:"serve"`
+	Embedding Embedding `json:"embedding"`
+	LLM       LLM       `json:"llm"`
+	ReadLater ReadLater `json:"read_later"`
+	Export    Export    `json:"export"`
+}
+
+// Display controls how dates are shown in the TUI and CLI output
+type Display struct {
+	DateFormat string `json:"date_format"` // "iso" (default), "us", or "relative"
+	Timezone   string `json:"timezone"`    // IANA name, e.g. "Europe/London"; empty uses the system zone
+	RowColor   string `json:"row_color"`   // Color list titles by "rating" or "read" status; empty for neither
+	Fidelity   string `json:"fidelity"`    // "auto" (default) draws less once the terminal is slow; "full" or "reduced" fixes it
+}
+
+// Embedding chooses what turns queries and chapter text into vectors.
+// Queries only find stories embedded by the same model, at 1024 dimensions.
+type Embedding struct {
+	Provider string `json:"provider"` // "voyage" (default; VOYAGE_API_KEY), "openai" (OPENAI_API_KEY), "ollama", or "sentence-transformers"
+	Model    string `json:"model"`    // Empty uses voyage-4-large, text-embedding-3-large, or mxbai-embed-large; sentence-transformers servers choose their own
+	URL      string `json:"url"`      // Ollama or sentence-transformers server; empty uses http://localhost:11434 or http://localhost:8080
+}
+
+// LLM chooses the model R in the story view writes a new summary with.
+// Empty values use the environment variables the pipeline's scripts do.
+type LLM struct {
+	Provider string `json:"provider"` // "anthropic" (ANTHROPIC_API_KEY) or "ollama"; empty uses LLM_PROVIDER, then anthropic
+	Model    string `json:"model"`    // Empty uses LLM_MODEL_SUMMARIES or LLM_MODEL, then claude-3-haiku or llama3.1:8b
+	URL      string `json:"url"`      // Ollama server; empty uses OLLAMA_URL, then http://localhost:11434
+}
+
+// ReadLater is where s in the story view sends the story, to read away
+// from the terminal. Secrets are read from WALLABAG_CLIENT_SECRET and
+// WALLABAG_PASSWORD, POCKET_ACCESS_TOKEN, or SMTP_PASSWORD.
+type ReadLater struct {
+	Service  string `json:"service"`   // "wallabag", "pocket" (saves the episode's page), or "email"; empty for none
+	URL      string `json:"url"`       // Wallabag server, or a Pocket-compatible API; empty uses getpocket.com for pocket
+	ClientID string `json:"client_id"` // Wallabag API client ID, or Pocket consumer key
+	Username string `json:"username"`  // Wallabag user, or SMTP login; empty uses from for SMTP
+	SMTP     string `json:"smtp"`      // Mail server as host:port, e.g. "smtp.example.com:587"
+	From     string `json:"from"`
+	To       string `json:"to"` // e.g. a Send-to-Kindle address, which must approve from
+}
+
+// Export is where E in the story view writes the story, as Markdown or
+// JSON
+type Export struct {
+	Dir      string `json:"dir"`       // Empty uses "exports"
+	FileName string `json:"file_name"` // Template of .Date, .Slug, .Title, .Show, .Type, and .ID; empty uses "{{.Date}} {{.Slug}}"
+}
+
+// Directory returns the export directory
+func (e Export) Directory() string {
+	if e.Dir == "" {
+		return "exports"
+	}
+	return e.Dir
+}
+
+// Startup controls what the TUI shows when it opens
+type Startup struct {
+	View      string `json:"view"`       // "search", "browse", or "visualize"
+	Query     string `json:"query"`      // Search to pre-run
+	StoryType string `json:"story_type"` // Browse filter to pre-apply; comma-separated for several
+	Story     string `json:"story"`      // ID of a story to open, usually given by a deep link with --story
+}
+
+// Browse controls the Browse story list
+type Browse struct {
+	Columns    []Column `json:"columns"`    // In display order; empty uses the default layout
+	Preview    bool     `json:"preview"`    // Show the summary preview pane on startup
+	Continuous bool     `json:"continuous"` // Scroll one list instead of paging
+}
+
+// Visualize controls the UMAP scatter plot
+type Visualize struct {
+	Blocks         bool     `json:"blocks"`          // One symbol per cell, for fonts without braille glyphs
+	ProjectCommand []string `json:"project_command"` // Recomputes the projection; empty runs python3 scripts/project_umap.py
+	Graphics       string   `json:"graphics"`        // Points as an image: "auto" (default) detects Kitty or Sixel support; "kitty", "sixel", or "off" fixes it
+}
+
+// Audio locates episode audio, and plays it, for hearing a story from a
+// timestamp in its text or from where it starts in its episode
+type Audio struct {
+	Dir    string `json:"dir"`    // Where download_rss.py saved the episodes; empty uses "episodes"
+	Player string `json:"player"` // "mpv" or "ffplay", or a path to either; empty uses whichever is installed, mpv first
+}
+
+// Directory returns the audio directory
+func (a Audio) Directory() string {
+	if a.Dir == "" {
+		return "episodes"
+	}
+	return a.Dir
+}
+
+// Serve controls the TUI served over SSH by the serve subcommand
+type Serve struct {
+	Address     string      `json:"address"`      // Where to listen; empty uses ":2222"
+	HostKey     string      `json:"host_key"`     // Host private key file, created if missing; empty uses ssh_host_key beside the config file
+	Anonymous   bool        `json:"anonymous"`    // Let anyone without a listed key in, read-only
+	MaxSessions int         `json:"max_sessions"` // 0 uses 32
+	Users       []ServeUser `json:"users"`
+	Quotas      Quotas      `json:"quotas"`
+}
+
+// Quotas limit how often each user can start expensive work, so a busy
+// session doesn't swamp the shared database or the embedding API. Anonymous
+// connections share a quota per address. -1 is no limit.
+type Quotas struct {
+	VectorSearches int `json:"vector_searches"` // Per minute, counting compares that embed the query; 0 uses 10
+	Projections    int `json:"projections"`     // Per hour; 0 uses 2
+	Clusterings    int `json:"clusterings"`     // Per hour; 0 uses 6
+	Summaries      int `json:"summaries"`       // Per hour, counting regenerated summaries; 0 uses 20
+}
+
+// ServeUser is someone who connects with one of their keys, whatever user
+// name they give
+type ServeUser struct {
+	Name  string   `json:"name"`
+	Scope string   `json:"scope"` // "read" (default), "write", or "admin" (write, and list and kick sessions)
+	Keys  []string `json:"keys"`  // Public keys, as in authorized_keys
+}
+
+// Column is one Browse list column
+type Column struct {
+	Name  string `json:"name"`            // title, type, date, show, location, cluster, words, rating, or read
+	Width int    `json:"width,omitempty"` // Cells; 0 uses the column's default
+}
+
+// Trash controls how long trashed stories are kept
+type Trash struct {
+	RetentionDays int `json:"retention_days"` // 0 uses db.DefaultTrashRetention
+}
+
+// Retention returns the trash retention period
+func (t Trash) Retention() time.Duration {
+	if t.RetentionDays <= 0 {
+		return db.DefaultTrashRetention
+	}
+	return time.Duration(t.RetentionDays) * 24 * time.Hour
+}
+
+// Path returns the config file location, honoring PARANORMAL_TUI_CONFIG
+func Path() (string, error) {
+	if p := os.Getenv("PARANORMAL_TUI_CONFIG"); p != "" {
+		return p, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate config directory: %w", err)
+	}
+	return filepath.Join(dir, "paranormal-tui", "config.json"), nil
+}
+
+// Load reads the config file. A missing file yields an empty config.
+func Load() (Config, error) {
+	var cfg Config
+
+	path, err := Path()
+	if err != nil {
+		return cfg, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("failed to parse config %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// State is what the TUI remembers between runs. It lives beside the config
+// file rather than in it, so the config is only ever written by hand.
+type State struct {
+	TourDone  bool                `json:"tour_done"`           // The onboarding tour was finished or dismissed
+	HintsSeen []string            `json:"hints_seen"`          // IDs of one-time hints already shown
+	Viewports map[string]Viewport `json:"viewports,omitempty"` // Where Visualize was left, by database (see db.DB.Key)
+	Reading   Reading             `json:"reading"`             // How the story view lays out a story's text
+}
+
+// Reading is the story view's reading layout, set with w, P, and L
+type Reading struct {
+	Width        string `json:"width"`         // "narrow", "wide", or empty for the whole window
+	Spacing      bool   `json:"spacing"`       // A blank line between paragraphs
+	HideSpeakers bool   `json:"hide_speakers"` // Leave out the [Speaker N] labels
+}
+
+// Viewport is where the Visualize plot was left on quitting
+type Viewport struct {
+	Zoom    float64     `json:"zoom"`
+	OffsetX float64     `json:"offset_x"`
+	OffsetY float64     `json:"offset_y"`
+	Color   string      `json:"color"`            // How points were colored, e.g. "by cluster"
+	Cursor  *[2]float64 `json:"cursor,omitempty"` // In data coordinates, so it outlasts a resize
+}
+
+// Store holds the state for a running TUI. Changes are made and saved
+// under one lock, so a save always writes every change made before it.
+type Store struct {
+	mu     sync.Mutex
+	state  State
+	memory bool // Never written to the state file
+}
+
+// NewStore wraps a loaded state
+func NewStore(state State) *Store {
+	return &Store{state: state}
+}
+
+// NewMemoryStore wraps a state that Save leaves unwritten, for runs that
+// shouldn't touch the user's state file
+func NewMemoryStore(state State) *Store {
+	return &Store{state: state, memory: true}
+}
+
+// Get returns a copy of the current state
+func (s *Store) Get() State {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.state
+	state.HintsSeen = append([]string(nil), s.state.HintsSeen...)
+	if s.state.Viewports != nil {
+		state.Viewports = make(map[string]Viewport, len(s.state.Viewports))
+		for k, v := range s.state.Viewports {
+			state.Viewports[k] = v
+		}
+	}
+	return state
+}
+
+// Update changes the state in memory; Save writes it
+func (s *Store) Update(fn func(*State)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.state)
+}
+
+// Save writes the current state to the state file
+func (s *Store) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.memory {
+		return nil
+	}
+	return SaveState(s.state)
+}
+
+// statePath returns the state file location, next to the config file
+func statePath() (string, error) {
+	path, err := Path()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "state.json"), nil
+}
+
+// LoadState reads the state file. A missing file yields an empty state.
+func LoadState() (State, error) {
+	var state State
+
+	path, err := statePath()
+	if err != nil {
+		return state, err
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("failed to read state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("failed to parse state %s: %w", path, err)
+	}
+
+	return state, nil
+}
+
+// SaveState writes the state file, creating its directory if needed
+func SaveState(state State) error {
+	path, err := statePath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,103 @@
+package dates
+
+import (
+	"fmt"
+	"time"
+)
+
+var (
+	format   = "iso"
+	location = time.Local
+)
+
+// Configure sets the date format ("iso", "us" or "relative"; empty means
+// iso) and the IANA timezone timestamps are shown in (empty means the
+// system zone)
+func Configure(dateFormat, timezone string) error {
+	switch dateFormat {
+	case "":
+		format = "iso"
+	case "iso", "us", "relative":
+		format = dateFormat
+	default:
+		return fmt.Errorf("unknown date format %q (want iso, us, or relative)", dateFormat)
+	}
+
+	location = time.Local
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return fmt.Errorf("failed to load timezone: %w", err)
+		}
+		location = loc
+	}
+	return nil
+}
+
+// Date formats a calendar date such as an air date. Calendar dates have
+// no zone, so only "relative" looks at the timezone, to find today.
+func Date(t time.Time) string {
+	switch format {
+	case "us":
+		return t.Format("01/02/2006")
+	case "relative":
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return relative(int(Today().Sub(day).Hours() / 24))
+	}
+	return t.Format("2006-01-02")
+}
+
+// Today is the current date in the configured timezone, as midnight UTC
+// like the calendar dates it's compared with
+func Today() time.Time {
+	now := time.Now().In(location)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// Time formats an instant, such as when a story was trashed, as a date
+// in the configured timezone
+func Time(t time.Time) string {
+	return Date(t.In(location))
+}
+
+// Width is the most cells a formatted date takes
+func Width() int {
+	if format == "relative" {
+		return len("11 months ago")
+	}
+	return len("2006-01-02")
+}
+
+// relative describes a day count: "today", "3 days ago", "in 2 months"
+func relative(days int) string {
+	if days == 0 {
+		return "today"
+	}
+	ago := days > 0
+	if !ago {
+		days = -days
+	}
+
+	var n int
+	var unit string
+	switch {
+	case days == 1:
+		if ago {
+			return "yesterday"
+		}
+		return "tomorrow"
+	case days < 31:
+		n, unit = days, "day"
+	case days < 365:
+		n, unit = days/30, "month"
+	default:
+		n, unit = days/365, "year"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	if ago {
+		return fmt.Sprintf("%d %s ago", n, unit)
+	}
+	return fmt.Sprintf("in %d %s", n, unit)
+}
@@ -0,0 +1,40 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// ArchiveRecord is everything stored about a story, for archiving. The
+// rows are kept as JSON so columns added later are archived too.
+type ArchiveRecord struct {
+	Story       *Story
+	Row         json.RawMessage // stories row, without content, embedding and search_vector
+	Episode     json.RawMessage // episodes row, or null
+	ExternalIDs json.RawMessage // external_ids rows, oldest first
+	Edits       json.RawMessage // story_edits rows, oldest first
+}
+
+// GetArchiveRecord loads a story with its episode, external IDs and edit
+// history. Trashed stories can be archived too.
+func (db *DB) GetArchiveRecord(ctx context.Context, id string) (*ArchiveRecord, error) {
+	rec := &ArchiveRecord{Story: &Story{}}
+
+	err := scanStory(db.pool.QueryRow(ctx, `
+		SELECT`+storyColumns+`,
+			to_jsonb(s) - 'content' - 'embedding' - 'search_vector',
+			to_jsonb(e),
+			(SELECT COALESCE(jsonb_agg(to_jsonb(x) - 'story_id' ORDER BY x.created_at), '[]')
+				FROM external_ids x WHERE x.story_id = s.id),
+			(SELECT COALESCE(jsonb_agg(to_jsonb(x) - 'story_id' ORDER BY x.id), '[]')
+				FROM story_edits x WHERE x.story_id = s.id)
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		WHERE s.id = $1
+	`, id), rec.Story, &rec.Row, &rec.Episode, &rec.ExternalIDs, &rec.Edits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load story %s: %w", id, err)
+	}
+	return rec, nil
+}
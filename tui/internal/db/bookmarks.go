@@ -0,0 +1,27 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// ToggleBookmark stars a story, or unstars it if already starred, and
+// reports whether it is now bookmarked
+func (db *DB) ToggleBookmark(ctx context.Context, id string) (bool, error) {
+	tag, err := db.pool.Exec(ctx, `DELETE FROM bookmarks WHERE story_id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove bookmark: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return false, nil
+	}
+
+	_, err = db.pool.Exec(ctx, `
+		INSERT INTO bookmarks (story_id) VALUES ($1)
+		ON CONFLICT (story_id) DO NOTHING
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to add bookmark: %w", err)
+	}
+	return true, nil
+}
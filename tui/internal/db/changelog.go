@@ -0,0 +1,116 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ChangelogWeek is how the corpus changed in one week
+type ChangelogWeek struct {
+	Week     time.Time      // Start of the week, a Monday
+	Added    int            // Stories ingested or entered
+	Approved int            // Community submissions approved into the corpus
+	Edits    int            // Field corrections in the audit log
+	Fields   map[string]int // Edits by field ("title", "location", ...)
+	Merged   int            // Duplicates merged into another story
+}
+
+// Quiet reports whether nothing changed that week
+func (w ChangelogWeek) Quiet() bool {
+	return w.Added == 0 && w.Approved == 0 && w.Edits == 0 && w.Merged == 0
+}
+
+// Changelog summarizes the last weeks of changes to the corpus, newest
+// first, quiet weeks included. It draws on when stories were created,
+// the story_edits audit log, submission reviews, and merges, whether
+// still in the trash or kept in a recovery snapshot; stories purged
+// without a snapshot are gone from it.
+func (db *DB) Changelog(ctx context.Context, weeks int) ([]ChangelogWeek, error) {
+	if weeks < 1 {
+		weeks = 1
+	}
+	rows, err := db.pool.Query(ctx, `
+		WITH weeks AS (
+			SELECT generate_series(
+				date_trunc('week', now()) - ($1::int - 1) * interval '1 week',
+				date_trunc('week', now()),
+				interval '1 week'
+			) AS week
+		),
+		added AS (
+			SELECT date_trunc('week', created_at) AS week, count(*) AS n
+			FROM stories WHERE created_at IS NOT NULL
+			GROUP BY 1
+		),
+		approved AS (
+			SELECT date_trunc('week', reviewed_at) AS week, count(*) AS n
+			FROM submissions WHERE status = 'approved'
+			GROUP BY 1
+		),
+		edits AS (
+			SELECT week, sum(n)::int AS n, jsonb_object_agg(field, n) AS fields
+			FROM (
+				SELECT date_trunc('week', changed_at) AS week, field, count(*) AS n
+				FROM story_edits
+				GROUP BY 1, 2
+			) e
+			GROUP BY week
+		),
+		merged AS (
+			SELECT date_trunc('week', at) AS week, count(DISTINCT id) AS n
+			FROM (
+				SELECT id::text AS id, deleted_at AS at FROM stories
+				WHERE deleted_reason LIKE 'merged into %'
+				UNION
+				SELECT split_part(note, ' ', 1), taken_at FROM recovery_snapshots
+				WHERE job = 'merge'
+			) m
+			GROUP BY 1
+		)
+		SELECT w.week, COALESCE(a.n, 0), COALESCE(s.n, 0),
+			COALESCE(e.n, 0), COALESCE(e.fields, '{}'::jsonb), COALESCE(m.n, 0)
+		FROM weeks w
+		LEFT JOIN added a ON a.week = w.week
+		LEFT JOIN approved s ON s.week = w.week
+		LEFT JOIN edits e ON e.week = w.week
+		LEFT JOIN merged m ON m.week = w.week
+		ORDER BY w.week DESC
+	`, weeks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load changelog: %w", err)
+	}
+	defer rows.Close()
+
+	var changelog []ChangelogWeek
+	for rows.Next() {
+		var w ChangelogWeek
+		if err := rows.Scan(&w.Week, &w.Added, &w.Approved, &w.Edits, &w.Fields, &w.Merged); err != nil {
+			return nil, fmt.Errorf("failed to scan changelog week: %w", err)
+		}
+		changelog = append(changelog, w)
+	}
+	return changelog, rows.Err()
+}
+
+// FieldSummary lists the week's edits by field, most edited first, e.g.
+// "title 3, location 2"; "" if there were none
+func (w ChangelogWeek) FieldSummary() string {
+	fields := make([]string, 0, len(w.Fields))
+	for f := range w.Fields {
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		if w.Fields[fields[i]] != w.Fields[fields[j]] {
+			return w.Fields[fields[i]] > w.Fields[fields[j]]
+		}
+		return fields[i] < fields[j]
+	})
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = fmt.Sprintf("%s %d", f, w.Fields[f])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Chapter is one section of a long story
+type Chapter struct {
+	StartLine int    // Line of the story content the section starts on
+	Title     string // Opening words of the section
+}
+
+// ChapterSource is a story to be split into chapters
+type ChapterSource struct {
+	ID      string
+	Title   string
+	Content string
+}
+
+// chaptersCurrent matches stories whose chapters were found in their
+// present content
+const chaptersCurrent = `EXISTS (
+	SELECT 1 FROM story_chapters c
+	WHERE c.story_id = s.id AND c.content_md5 = md5(s.content)
+)`
+
+// ChapterStatus reports how many stories of at least minWords words have
+// current chapters and how many need them found
+func (db *DB) ChapterStatus(ctx context.Context, minWords int) (chaptered, missing int64, err error) {
+	err = db.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE `+chaptersCurrent+`),
+		       COUNT(*) FILTER (WHERE NOT `+chaptersCurrent+`)
+		FROM stories s
+		WHERE s.deleted_at IS NULL AND s.word_count >= $1
+	`, minWords).Scan(&chaptered, &missing)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count chaptered stories: %w", err)
+	}
+	return chaptered, missing, nil
+}
+
+// StoriesToChapter returns stories of at least minWords words, longest
+// first: only those without current chapters unless all is set. A limit of
+// 0 returns every one.
+func (db *DB) StoriesToChapter(ctx context.Context, minWords, limit int, all bool) ([]ChapterSource, error) {
+	query := `
+		SELECT s.id, s.title, s.content
+		FROM stories s
+		WHERE s.deleted_at IS NULL AND s.word_count >= $1`
+	if !all {
+		query += ` AND NOT ` + chaptersCurrent
+	}
+	query += ` ORDER BY s.word_count DESC`
+	args := []any{minWords}
+	if limit > 0 {
+		query += ` LIMIT $2`
+		args = append(args, limit)
+	}
+
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stories to chapter: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []ChapterSource
+	for rows.Next() {
+		var s ChapterSource
+		if err := rows.Scan(&s.ID, &s.Title, &s.Content); err != nil {
+			return nil, fmt.Errorf("failed to scan story: %w", err)
+		}
+		sources = append(sources, s)
+	}
+	return sources, rows.Err()
+}
+
+// SaveChapters replaces a story's chapters with ones found in content
+func (db *DB) SaveChapters(ctx context.Context, id, content string, chapters []Chapter) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin saving chapters: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM story_chapters WHERE story_id = $1`, id); err != nil {
+		return fmt.Errorf("failed to clear chapters: %w", err)
+	}
+	for i, c := range chapters {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO story_chapters (story_id, position, start_line, title, content_md5)
+			VALUES ($1, $2, $3, $4, md5($5))
+		`, id, i, c.StartLine, c.Title, content)
+		if err != nil {
+			return fmt.Errorf("failed to save chapter: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit chapters: %w", err)
+	}
+	return nil
+}
+
+// Chapters returns a story's chapters in order, or none if the story has
+// been edited since they were found
+func (db *DB) Chapters(ctx context.Context, id string) ([]Chapter, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT c.start_line, c.title
+		FROM story_chapters c
+		JOIN stories s ON s.id = c.story_id
+		WHERE c.story_id = $1 AND c.content_md5 = md5(s.content)
+		ORDER BY c.position
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load chapters: %w", err)
+	}
+	defer rows.Close()
+
+	var chapters []Chapter
+	for rows.Next() {
+		var c Chapter
+		if err := rows.Scan(&c.StartLine, &c.Title); err != nil {
+			return nil, fmt.Errorf("failed to scan chapter: %w", err)
+		}
+		chapters = append(chapters, c)
+	}
+	return chapters, rows.Err()
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// ClusterInputs returns the stories to cluster with their positions: UMAP
+// coordinates, or embeddings when embeddings is set. Trashed stories are
+// included, so they keep a meaningful cluster if restored. It reads from
+// the primary, as a projection saved a moment ago may not have reached a
+// replica.
+func (db *DB) ClusterInputs(ctx context.Context, embeddings bool) ([]string, [][]float64, error) {
+	query := `
+		SELECT id::text, ARRAY[umap_x, umap_y]
+		FROM stories
+		WHERE umap_x IS NOT NULL AND umap_y IS NOT NULL
+		ORDER BY id
+	`
+	if embeddings {
+		query = `
+			SELECT id::text, embedding::real[]::float8[]
+			FROM stories
+			WHERE embedding IS NOT NULL
+			ORDER BY id
+		`
+	}
+
+	rows, err := db.pool.Query(ctx, query)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get stories to cluster: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	var points [][]float64
+	for rows.Next() {
+		var id string
+		var point []float64
+		if err := rows.Scan(&id, &point); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan story position: %w", err)
+		}
+		ids = append(ids, id)
+		points = append(points, point)
+	}
+	return ids, points, rows.Err()
+}
+
+// SaveClusters replaces every story's cluster: labels[i] for ids[i], with
+// a negative label for noise, and none for stories not clustered. The old
+// clusters are snapshotted, for RestoreSnapshot.
+func (db *DB) SaveClusters(ctx context.Context, ids []string, labels []int) error {
+	clusters := make([]*int32, len(labels))
+	for i, l := range labels {
+		if l >= 0 {
+			c := int32(l)
+			clusters[i] = &c
+		}
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := takeSnapshot(ctx, tx, "recluster", fmt.Sprintf("%d stories", len(ids)), snapshotPart{
+		table:   "stories",
+		columns: []string{"id", "cluster_id"},
+		where:   "t.cluster_id IS NOT NULL OR t.id::text = ANY($3)",
+		args:    []any{ids},
+	}); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE stories SET cluster_id = NULL WHERE cluster_id IS NOT NULL`); err != nil {
+		return fmt.Errorf("failed to clear clusters: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE stories s
+		SET cluster_id = c.cluster
+		FROM unnest($1::text[], $2::int[]) AS c(id, cluster)
+		WHERE s.id = c.id::uuid AND c.cluster IS NOT NULL
+	`, ids, clusters); err != nil {
+		return fmt.Errorf("failed to save clusters: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit clusters: %w", err)
+	}
+	return nil
+}
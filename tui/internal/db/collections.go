@@ -0,0 +1,76 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AddToCollection adds stories to the named collection, creating it if it
+// doesn't exist, and reports how many were new to it
+func (db *DB) AddToCollection(ctx context.Context, name string, ids []string) (int64, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin saving collection: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var collectionID int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO collections (name) VALUES ($1)
+		ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+		RETURNING id
+	`, name).Scan(&collectionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create collection: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO collection_stories (collection_id, story_id)
+		SELECT $1, unnest($2::uuid[])
+		ON CONFLICT DO NOTHING
+	`, collectionID, ids)
+	if err != nil {
+		return 0, fmt.Errorf("failed to add stories to collection: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit collection: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// CollectionStoryIDs returns the stories in the named collection, in the
+// order they were added
+func (db *DB) CollectionStoryIDs(ctx context.Context, name string) ([]string, error) {
+	var collectionID int
+	err := db.pool.QueryRow(ctx, `SELECT id FROM collections WHERE name = $1`, name).Scan(&collectionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("no collection named %q", name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find collection: %w", err)
+	}
+
+	rows, err := db.pool.Query(ctx, `
+		SELECT story_id::text FROM collection_stories
+		WHERE collection_id = $1
+		ORDER BY added_at, story_id
+	`, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load collection: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan collection story: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,201 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CompressionMethods are the TOAST compression methods content can use.
+// lz4 is much faster to decompress; pglz is PostgreSQL's built-in default.
+var CompressionMethods = []string{"lz4", "pglz"}
+
+// minCompressBytes is where PostgreSQL starts compressing a value (the
+// TOAST threshold); smaller values are stored as is whatever the setting
+const minCompressBytes = 2000
+
+// ContentColumn is a large text/json column whose storage can be compressed
+type ContentColumn struct {
+	Table  string
+	Column string
+	// rewrite is a no-op expression that makes PostgreSQL store the value
+	// afresh, recompressing it with the column's current method
+	rewrite string
+}
+
+// ContentColumns are the columns holding transcript text
+var ContentColumns = []ContentColumn{
+	{Table: "stories", Column: "content", rewrite: "content || ''"},
+	{Table: "story_chunks", Column: "content", rewrite: "content || ''"},
+	{Table: "transcripts", Column: "raw_json", rewrite: "(raw_json::text)::jsonb"},
+}
+
+// ContentStorage is how one content column's values are currently stored
+type ContentStorage struct {
+	Table   string
+	Column  string
+	Setting string // Compression for new values; "" is the server default
+	Method  string // How these values are stored: lz4, pglz, or "none"
+	Rows    int64
+	Bytes   int64 // Stored (possibly compressed) size
+	Raw     int64 // Uncompressed size
+}
+
+// Ratio is stored size over uncompressed size
+func (s ContentStorage) Ratio() float64 {
+	if s.Raw == 0 {
+		return 1
+	}
+	return float64(s.Bytes) / float64(s.Raw)
+}
+
+// GetContentStorage reports, for each content column, how many values are
+// stored with each compression method and how much space they take
+func (db *DB) GetContentStorage(ctx context.Context) ([]ContentStorage, error) {
+	columns, err := db.existingContentColumns(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var storage []ContentStorage
+	for _, c := range columns {
+		var setting string
+		err := db.pool.QueryRow(ctx, `
+			SELECT CASE a.attcompression WHEN 'l' THEN 'lz4' WHEN 'p' THEN 'pglz' ELSE '' END
+			FROM pg_attribute a
+			WHERE a.attrelid = $1::regclass AND a.attname = $2
+		`, c.Table, c.Column).Scan(&setting)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get compression setting for %s.%s: %w", c.Table, c.Column, err)
+		}
+
+		col := pgx.Identifier{c.Column}.Sanitize()
+		query := fmt.Sprintf(`
+			SELECT COALESCE(pg_column_compression(%[1]s)::text, 'none'), COUNT(*),
+				COALESCE(SUM(pg_column_size(%[1]s)), 0), COALESCE(SUM(octet_length(%[1]s::text)), 0)
+			FROM %[2]s
+			WHERE %[1]s IS NOT NULL
+			GROUP BY 1
+			ORDER BY 1
+		`, col, pgx.Identifier{c.Table}.Sanitize())
+
+		rows, err := db.pool.Query(ctx, query)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get storage for %s.%s: %w", c.Table, c.Column, err)
+		}
+		for rows.Next() {
+			s := ContentStorage{Table: c.Table, Column: c.Column, Setting: setting}
+			if err := rows.Scan(&s.Method, &s.Rows, &s.Bytes, &s.Raw); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan storage: %w", err)
+			}
+			storage = append(storage, s)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, fmt.Errorf("failed to get storage for %s.%s: %w", c.Table, c.Column, err)
+		}
+	}
+	return storage, nil
+}
+
+// SetContentCompression makes new content values use method. Existing
+// values keep their old storage until RecompressContent rewrites them.
+func (db *DB) SetContentCompression(ctx context.Context, method string) error {
+	if !validCompression(method) {
+		return fmt.Errorf("unknown compression method %q (want lz4 or pglz)", method)
+	}
+	columns, err := db.existingContentColumns(ctx)
+	if err != nil {
+		return err
+	}
+	for _, c := range columns {
+		stmt := fmt.Sprintf("ALTER TABLE %s ALTER COLUMN %s SET COMPRESSION %s",
+			pgx.Identifier{c.Table}.Sanitize(), pgx.Identifier{c.Column}.Sanitize(), method)
+		if _, err := db.pool.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to set compression on %s.%s: %w", c.Table, c.Column, err)
+		}
+	}
+	return nil
+}
+
+// RecompressContent rewrites content values not yet stored with method,
+// batch rows at a time in id order so it can be interrupted and resumed.
+// progress, if set, is called after each batch with the running totals.
+// Only values rewritten change; readers never see a difference.
+func (db *DB) RecompressContent(ctx context.Context, method string, batch int, progress func(table string, scanned, rewritten int64)) (int64, error) {
+	if !validCompression(method) {
+		return 0, fmt.Errorf("unknown compression method %q (want lz4 or pglz)", method)
+	}
+
+	columns, err := db.existingContentColumns(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, c := range columns {
+		col := pgx.Identifier{c.Column}.Sanitize()
+		query := fmt.Sprintf(`
+			WITH batch AS (
+				SELECT id FROM %[1]s WHERE id > $1 ORDER BY id LIMIT $2
+			), rewritten AS (
+				UPDATE %[1]s t SET %[2]s = %[3]s
+				FROM batch
+				WHERE t.id = batch.id
+					AND pg_column_compression(t.%[2]s) IS DISTINCT FROM $3
+					AND octet_length(t.%[2]s::text) > $4
+				RETURNING t.id
+			)
+			SELECT (SELECT id FROM batch ORDER BY id DESC LIMIT 1), (SELECT COUNT(*) FROM batch), (SELECT COUNT(*) FROM rewritten)
+		`, pgx.Identifier{c.Table}.Sanitize(), col, c.rewrite)
+
+		after := "00000000-0000-0000-0000-000000000000"
+		var scanned, rewritten int64
+		for {
+			var last *string
+			var n, r int64
+			err := db.pool.QueryRow(ctx, query, after, batch, method, minCompressBytes).Scan(&last, &n, &r)
+			if err != nil {
+				return total, fmt.Errorf("failed to recompress %s.%s: %w", c.Table, c.Column, err)
+			}
+			if last == nil {
+				break
+			}
+			after = *last
+			scanned += n
+			rewritten += r
+			total += r
+			if progress != nil {
+				progress(c.Table, scanned, rewritten)
+			}
+		}
+	}
+	return total, nil
+}
+
+// existingContentColumns skips ContentColumns whose table this database
+// doesn't have (older schemas lack story_chunks or transcripts)
+func (db *DB) existingContentColumns(ctx context.Context) ([]ContentColumn, error) {
+	var columns []ContentColumn
+	for _, c := range ContentColumns {
+		var exists bool
+		if err := db.pool.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, c.Table).Scan(&exists); err != nil {
+			return nil, fmt.Errorf("failed to look up %s: %w", c.Table, err)
+		}
+		if exists {
+			columns = append(columns, c)
+		}
+	}
+	return columns, nil
+}
+
+func validCompression(method string) bool {
+	for _, m := range CompressionMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
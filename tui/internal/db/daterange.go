@@ -0,0 +1,71 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateRangeExamples are relative air-date ranges offered as hints
+var DateRangeExamples = []string{"last 30 days", "this month", "this year", "last year"}
+
+// ParseDateRange normalizes a relative air-date range such as
+// "Last 30 days", "this month" or "last-year", and checks it resolves
+func ParseDateRange(value string) (string, error) {
+	r := strings.Join(strings.Fields(strings.ToLower(strings.ReplaceAll(value, "-", " "))), " ")
+	if _, _, err := ResolveDateRange(r, time.Now()); err != nil {
+		return "", err
+	}
+	return r, nil
+}
+
+// ResolveDateRange turns a normalized relative range into inclusive
+// dates as of today: "last N days/weeks/months/years" counts back from
+// today, "this week/month/year" is the calendar period so far, and
+// "last week/month/year" is the whole previous one. Weeks start on Monday.
+func ResolveDateRange(r string, today time.Time) (time.Time, time.Time, error) {
+	today = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC)
+	words := strings.Fields(r)
+
+	if len(words) == 3 && words[0] == "last" {
+		n, err := strconv.Atoi(words[1])
+		if err != nil || n < 1 {
+			return today, today, fmt.Errorf("invalid date range %q (want a count, e.g. last 30 days)", r)
+		}
+		switch strings.TrimSuffix(words[2], "s") {
+		case "day":
+			return today.AddDate(0, 0, -n), today, nil
+		case "week":
+			return today.AddDate(0, 0, -7*n), today, nil
+		case "month":
+			return today.AddDate(0, -n, 0), today, nil
+		case "year":
+			return today.AddDate(-n, 0, 0), today, nil
+		}
+	}
+
+	if len(words) == 2 && (words[0] == "this" || words[0] == "last") {
+		var start time.Time
+		var years, months, days int // Length of the period
+		switch words[1] {
+		case "week":
+			start = today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+			days = 7
+		case "month":
+			start = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+			months = 1
+		case "year":
+			start = time.Date(today.Year(), 1, 1, 0, 0, 0, 0, time.UTC)
+			years = 1
+		default:
+			return today, today, fmt.Errorf("invalid date range %q (want week, month, or year)", r)
+		}
+		if words[0] == "this" {
+			return start, today, nil
+		}
+		return start.AddDate(-years, -months, -days), start.AddDate(0, 0, -1), nil
+	}
+
+	return today, today, fmt.Errorf("invalid date range %q (e.g. %s)", r, strings.Join(DateRangeExamples, ", "))
+}
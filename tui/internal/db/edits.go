@@ -0,0 +1,90 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EditableFields maps the story fields curators can edit to their columns
+var EditableFields = map[string]string{
+	"title":    "title",
+	"summary":  "summary",
+	"type":     "story_type",
+	"location": "location",
+}
+
+// ErrEditConflict is returned when a field being edited no longer has the
+// value the edit started from, because something else changed it since
+var ErrEditConflict = errors.New("edit conflict")
+
+// FieldChange is one edited field, before and after
+type FieldChange struct {
+	Field string // Key of EditableFields
+	Old   string
+	New   string
+}
+
+// UpdateStory applies changes to a story and records each in the audit
+// log, all in one transaction. Empty values are stored as NULL, except
+// for the title. A new location clears the story's geocode so
+// geocode_stories.py picks it up again. Each field must still hold its Old
+// value, or nothing is saved and the error wraps ErrEditConflict, so two
+// people editing a story at once can't overwrite each other unawares.
+func (db *DB) UpdateStory(ctx context.Context, id string, changes []FieldChange) error {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin edit: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, c := range changes {
+		column, ok := EditableFields[c.Field]
+		if !ok {
+			return fmt.Errorf("field %q is not editable", c.Field)
+		}
+		if c.Field == "title" && c.New == "" {
+			return fmt.Errorf("title must not be empty")
+		}
+
+		var value any = c.New
+		if c.New == "" {
+			value = nil
+		}
+		ident := pgx.Identifier{column}.Sanitize()
+		stmt := fmt.Sprintf("UPDATE stories SET %s = $1 WHERE id = $2 AND COALESCE(%s, '') = $3", ident, ident)
+		tag, err := tx.Exec(ctx, stmt, value, id, c.Old)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", c.Field, err)
+		}
+		if tag.RowsAffected() == 0 {
+			return fmt.Errorf("%w: %s was changed elsewhere since the story was opened; reopen it and edit again", ErrEditConflict, c.Field)
+		}
+
+		if c.Field == "location" {
+			_, err := tx.Exec(ctx, `
+				UPDATE stories
+				SET latitude = NULL, longitude = NULL, geocoded_at = NULL, geo_cluster_id = NULL
+				WHERE id = $1
+			`, id)
+			if err != nil {
+				return fmt.Errorf("failed to clear geocode: %w", err)
+			}
+		}
+
+		_, err = tx.Exec(ctx, `
+			INSERT INTO story_edits (story_id, field, old_value, new_value)
+			VALUES ($1, $2, $3, $4)
+		`, id, c.Field, c.Old, c.New)
+		if err != nil {
+			return fmt.Errorf("failed to record edit: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit edit: %w", err)
+	}
+	return nil
+}
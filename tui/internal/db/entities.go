@@ -0,0 +1,50 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Entity kinds
+const (
+	EntityPerson = "person"
+	EntityPlace  = "place"
+	EntityDate   = "date"
+)
+
+// Entity is a person, place, or date a story names, as found by
+// scripts/extract_entities.py
+type Entity struct {
+	Kind     string
+	Name     string
+	Mentions int // Times the story names it
+	Others   int // Other stories that name it too
+}
+
+// StoryEntities returns the entities a story names, by kind, most
+// mentioned first within each
+func (db *DB) StoryEntities(ctx context.Context, id string) ([]Entity, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT x.kind, x.name, x.mentions,
+			(SELECT count(DISTINCT o.story_id) FROM story_entities o
+			 JOIN stories s ON s.id = o.story_id AND s.deleted_at IS NULL
+			 WHERE o.kind = x.kind AND lower(o.name) = lower(x.name) AND o.story_id <> x.story_id)
+		FROM story_entities x
+		WHERE x.story_id = $1
+		ORDER BY array_position(ARRAY['person', 'place', 'date'], x.kind), x.mentions DESC, x.name
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load entities: %w", err)
+	}
+	defer rows.Close()
+
+	var entities []Entity
+	for rows.Next() {
+		var e Entity
+		if err := rows.Scan(&e.Kind, &e.Name, &e.Mentions, &e.Others); err != nil {
+			return nil, fmt.Errorf("failed to scan entity: %w", err)
+		}
+		entities = append(entities, e)
+	}
+	return entities, rows.Err()
+}
@@ -0,0 +1,159 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// EpisodeRef identifies an episode
+type EpisodeRef struct {
+	ID     string
+	Title  string
+	Number string
+}
+
+// Label names the episode for display, e.g. "#12 The Bell Witch"
+func (e EpisodeRef) Label() string {
+	if e.Number == "" {
+		return e.Title
+	}
+	return "#" + e.Number + " " + e.Title
+}
+
+// FindEpisode finds the episode with the given number, preferring one from
+// the same podcast as the story. It returns nil if there is none.
+func (db *DB) FindEpisode(ctx context.Context, storyID, number string) (*EpisodeRef, error) {
+	var e EpisodeRef
+	err := db.pool.QueryRow(ctx, `
+		SELECT e.id::text, e.title, e.episode_number
+		FROM episodes e
+		LEFT JOIN stories s ON s.id = $1
+		LEFT JOIN episodes own ON own.id = s.episode_id
+		WHERE ltrim(e.episode_number, '0') = ltrim($2, '0')
+		ORDER BY (e.podcast_name IS NOT DISTINCT FROM own.podcast_name) DESC, e.air_date
+		LIMIT 1
+	`, storyID, number).Scan(&e.ID, &e.Title, &e.Number)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find episode %s: %w", number, err)
+	}
+	return &e, nil
+}
+
+// EpisodeAudio returns the audio filename of the story's episode, or "" if
+// it has none
+func (db *DB) EpisodeAudio(ctx context.Context, storyID string) (string, error) {
+	var filename string
+	err := db.pool.QueryRow(ctx, `
+		SELECT COALESCE(e.audio_filename, '')
+		FROM stories s
+		JOIN episodes e ON e.id = s.episode_id
+		WHERE s.id = $1
+	`, storyID).Scan(&filename)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get episode audio: %w", err)
+	}
+	return filename, nil
+}
+
+// AudioSegment is where a story is in its episode's audio
+type AudioSegment struct {
+	Filename string  // In the audio directory
+	Start    float64 // Seconds into the episode
+	End      float64 // 0 if the story's end isn't known
+}
+
+// StoryAudioSegment returns where the story is in its episode's audio, or
+// nil if its episode has no audio file or it has no start time
+func (db *DB) StoryAudioSegment(ctx context.Context, storyID string) (*AudioSegment, error) {
+	var a AudioSegment
+	err := db.pool.QueryRow(ctx, `
+		SELECT e.audio_filename, s.start_time_seconds, COALESCE(s.end_time_seconds, 0)
+		FROM stories s
+		JOIN episodes e ON e.id = s.episode_id
+		WHERE s.id = $1 AND e.audio_filename IS NOT NULL AND s.start_time_seconds IS NOT NULL
+	`, storyID).Scan(&a.Filename, &a.Start, &a.End)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get story audio: %w", err)
+	}
+	return &a, nil
+}
+
+// Utterance is where a paragraph of a story is in its episode's audio
+type Utterance struct {
+	Line  int     // Line of the story's content the paragraph starts on
+	Start float64 // Seconds into the episode
+	End   float64
+}
+
+// StoryUtterances returns where each paragraph of the story is in its
+// episode's audio, in order; none if its segment didn't record them
+func (db *DB) StoryUtterances(ctx context.Context, storyID string) ([]Utterance, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT line, start_seconds, end_seconds
+		FROM story_utterances
+		WHERE story_id = $1
+		ORDER BY line
+	`, storyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load utterances: %w", err)
+	}
+	defer rows.Close()
+
+	var utterances []Utterance
+	for rows.Next() {
+		var u Utterance
+		if err := rows.Scan(&u.Line, &u.Start, &u.End); err != nil {
+			return nil, fmt.Errorf("failed to scan utterance: %w", err)
+		}
+		utterances = append(utterances, u)
+	}
+	return utterances, rows.Err()
+}
+
+// EpisodeLinks is where a story's episode can be found online
+type EpisodeLinks struct {
+	EpisodeRef
+	PageURL  string
+	AudioURL string
+}
+
+// URL is the episode page, or the audio if there's no page; "" if neither
+// is known
+func (e EpisodeLinks) URL() string {
+	if e.PageURL != "" {
+		return e.PageURL
+	}
+	return e.AudioURL
+}
+
+// StoryEpisodeLinks returns the story's episode and its URLs, or nil if
+// the story has no episode
+func (db *DB) StoryEpisodeLinks(ctx context.Context, storyID string) (*EpisodeLinks, error) {
+	var e EpisodeLinks
+	err := db.pool.QueryRow(ctx, `
+		SELECT e.id::text, e.title, COALESCE(e.episode_number, ''),
+			COALESCE(e.source_url, ''), COALESCE(e.audio_url, '')
+		FROM stories s
+		JOIN episodes e ON e.id = s.episode_id
+		WHERE s.id = $1
+	`, storyID).Scan(&e.ID, &e.Title, &e.Number, &e.PageURL, &e.AudioURL)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get episode links: %w", err)
+	}
+	return &e, nil
+}
@@ -0,0 +1,44 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// SimilarPair is a story and one of its nearest neighbors by embedding
+type SimilarPair struct {
+	From, To   string
+	Similarity float64
+}
+
+// SimilarPairs returns each embedded story's k nearest neighbors, at least
+// minSimilarity alike, among the given stories. Each pair appears once per
+// direction it was found in.
+func (db *DB) SimilarPairs(ctx context.Context, ids []string, k int, minSimilarity float64) ([]SimilarPair, error) {
+	rows, err := db.analytics().Query(ctx, `
+		SELECT s.id, n.id, n.similarity
+		FROM stories s
+		CROSS JOIN LATERAL (
+			SELECT t.id, 1 - (t.embedding <=> s.embedding) AS similarity
+			FROM stories t
+			WHERE t.id <> s.id AND t.embedding IS NOT NULL AND t.id::text = ANY($1)
+			ORDER BY t.embedding <=> s.embedding
+			LIMIT $2
+		) n
+		WHERE s.embedding IS NOT NULL AND s.id::text = ANY($1) AND n.similarity >= $3
+	`, ids, k, minSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find similar stories: %w", err)
+	}
+	defer rows.Close()
+
+	var pairs []SimilarPair
+	for rows.Next() {
+		var p SimilarPair
+		if err := rows.Scan(&p.From, &p.To, &p.Similarity); err != nil {
+			return nil, fmt.Errorf("failed to scan similar pair: %w", err)
+		}
+		pairs = append(pairs, p)
+	}
+	return pairs, rows.Err()
+}
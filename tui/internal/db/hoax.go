@@ -0,0 +1,27 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// ToggleHoax flags a story as a hoax, or unflags it if already flagged,
+// and reports whether it is now flagged
+func (db *DB) ToggleHoax(ctx context.Context, id string) (bool, error) {
+	tag, err := db.pool.Exec(ctx, `DELETE FROM hoax_flags WHERE story_id = $1`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove hoax flag: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return false, nil
+	}
+
+	_, err = db.pool.Exec(ctx, `
+		INSERT INTO hoax_flags (story_id) VALUES ($1)
+		ON CONFLICT (story_id) DO NOTHING
+	`, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to flag hoax: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NewStory is a story added by hand, such as a friend's written account,
+// rather than loaded from segment files
+type NewStory struct {
+	Show       string
+	AirDate    *time.Time // nil if undated
+	Title      string
+	Content    string
+	StoryType  string
+	Location   string
+	TimePeriod string
+
+	// Source and SourceID identify where the story came from, recorded as
+	// an external ID so the same story isn't added twice
+	Source   string
+	SourceID string
+}
+
+// AddStory files a story under its show's episode for the day, creating
+// the episode as load_segments.py does, and returns the new story's ID
+func (db *DB) AddStory(ctx context.Context, s NewStory) (string, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin adding story: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var existing string
+	err = tx.QueryRow(ctx, `
+		SELECT story_id::text FROM external_ids WHERE source_system = $1 AND external_id = $2
+	`, s.Source, s.SourceID).Scan(&existing)
+	if err == nil {
+		return "", fmt.Errorf("already added as story %s", existing)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("failed to check for the story: %w", err)
+	}
+
+	var episodeID string
+	err = tx.QueryRow(ctx, `
+		SELECT id::text FROM episodes
+		WHERE podcast_name = $1 AND air_date IS NOT DISTINCT FROM $2::date
+		ORDER BY created_at LIMIT 1
+	`, s.Show, s.AirDate).Scan(&episodeID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		day := "undated"
+		if s.AirDate != nil {
+			day = s.AirDate.Format("2006-01-02")
+		}
+		err = tx.QueryRow(ctx, `
+			INSERT INTO episodes (title, podcast_name, air_date) VALUES ($1, $2, $3::date)
+			RETURNING id::text
+		`, s.Show+" - "+day, s.Show, s.AirDate).Scan(&episodeID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find or create episode: %w", err)
+	}
+
+	var storyID string
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO stories (episode_id, title, content, story_type, location, time_period, is_first_person)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), true)
+		RETURNING id::text
+	`, episodeID, s.Title, s.Content, s.StoryType, s.Location, s.TimePeriod).Scan(&storyID); err != nil {
+		return "", fmt.Errorf("failed to create story: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO external_ids (source_system, external_id, story_id) VALUES ($1, $2, $3)
+	`, s.Source, s.SourceID, storyID); err != nil {
+		return "", fmt.Errorf("failed to record source ID: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit story: %w", err)
+	}
+	return storyID, nil
+}
+
+// SetEmbedding stores a story's embedding, with the method and token count
+// load_segments.py records alongside it
+func (db *DB) SetEmbedding(ctx context.Context, id string, embedding []float32, method string, tokens int) error {
+	if _, err := db.pool.Exec(ctx, `
+		UPDATE stories SET embedding = $2::vector, embedding_method = $3, token_count = $4, updated_at = now()
+		WHERE id = $1
+	`, id, vectorLiteral(embedding), method, tokens); err != nil {
+		return fmt.Errorf("failed to save embedding: %w", err)
+	}
+	return nil
+}
+
+// classifyNeighbors is how many nearest stories vote on an untyped one's type
+const classifyNeighbors = 10
+
+// ClassifyStory gives a story without a type the one most common among
+// its nearest neighbors by embedding, and returns it. A story that has a
+// type keeps it, and one whose neighbors have none stays untyped ("").
+func (db *DB) ClassifyStory(ctx context.Context, id string) (string, error) {
+	var storyType string
+	err := db.pool.QueryRow(ctx, `
+		WITH votes AS (
+			SELECT n.story_type, count(*) AS votes, max(n.similarity) AS best
+			FROM stories s
+			CROSS JOIN LATERAL (
+				SELECT t.story_type, 1 - (t.embedding <=> s.embedding) AS similarity
+				FROM stories t
+				WHERE t.id <> s.id AND t.deleted_at IS NULL AND t.embedding IS NOT NULL
+				ORDER BY t.embedding <=> s.embedding
+				LIMIT $2
+			) n
+			WHERE s.id = $1 AND s.story_type IS NULL AND n.story_type IS NOT NULL
+			GROUP BY n.story_type
+			ORDER BY votes DESC, best DESC
+			LIMIT 1
+		)
+		UPDATE stories SET story_type = votes.story_type, updated_at = now()
+		FROM votes
+		WHERE stories.id = $1
+		RETURNING stories.story_type
+	`, id, classifyNeighbors).Scan(&storyType)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to classify story: %w", err)
+	}
+	return storyType, nil
+}
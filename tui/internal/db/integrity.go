@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// contentHash is the SQL for a story's content hash
+const contentHash = `encode(sha256(convert_to(s.content, 'UTF8')), 'hex')`
+
+// HashEntry is one story's sealed content hash
+type HashEntry struct {
+	StoryID string `json:"story_id"`
+	SHA256  string `json:"sha256"`
+}
+
+// CorpusManifest is a signed snapshot of every sealed content hash
+type CorpusManifest struct {
+	CreatedAt  time.Time   `json:"created_at"`
+	StoryCount int         `json:"story_count"`
+	Root       string      `json:"root_sha256"`
+	PublicKey  string      `json:"public_key"`
+	Signature  string      `json:"signature"`
+	Entries    []HashEntry `json:"entries"`
+}
+
+// StoryHash compares a story's content now with its sealed hash. Current
+// is empty when the story no longer exists, Sealed when it was never
+// sealed.
+type StoryHash struct {
+	StoryID string
+	Current string
+	Sealed  string
+}
+
+// SealResult reports what a seal changed
+type SealResult struct {
+	Added    int64 // stories sealed for the first time
+	Accepted int64 // changed or vanished stories whose new state was accepted
+	Manifest *CorpusManifest
+}
+
+// StoryHashes hashes every story's content, trashed ones included, next
+// to the hash recorded when it was last sealed
+func (db *DB) StoryHashes(ctx context.Context) ([]StoryHash, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT COALESCE(s.id, h.story_id)::text,
+			COALESCE(`+contentHash+`, ''),
+			COALESCE(h.sha256, '')
+		FROM stories s
+		FULL JOIN content_hashes h ON h.story_id = s.id
+		ORDER BY 1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash stories: %w", err)
+	}
+	defer rows.Close()
+
+	var hashes []StoryHash
+	for rows.Next() {
+		var h StoryHash
+		if err := rows.Scan(&h.StoryID, &h.Current, &h.Sealed); err != nil {
+			return nil, fmt.Errorf("failed to scan story hash: %w", err)
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes, rows.Err()
+}
+
+// Seal records hashes for stories not sealed before, then has sign sign
+// a manifest of every sealed hash and stores it. Changed and vanished
+// stories keep their old hash, so they go on failing verification,
+// unless acceptAll is set or their IDs are in accept.
+func (db *DB) Seal(ctx context.Context, acceptAll bool, accept []string, sign func([]HashEntry) (*CorpusManifest, error)) (*SealResult, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin seal: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Serialize seals so two can't interleave their manifests
+	if _, err := tx.Exec(ctx, `LOCK TABLE content_hashes IN EXCLUSIVE MODE`); err != nil {
+		return nil, fmt.Errorf("failed to lock content hashes: %w", err)
+	}
+
+	var result SealResult
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO content_hashes (story_id, sha256)
+		SELECT s.id, `+contentHash+`
+		FROM stories s
+		WHERE NOT EXISTS (SELECT 1 FROM content_hashes h WHERE h.story_id = s.id)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal new stories: %w", err)
+	}
+	result.Added = tag.RowsAffected()
+
+	if acceptAll || len(accept) > 0 {
+		tag, err := tx.Exec(ctx, `
+			UPDATE content_hashes h SET sha256 = `+contentHash+`, sealed_at = now()
+			FROM stories s
+			WHERE s.id = h.story_id AND h.sha256 <> `+contentHash+`
+				AND ($1 OR h.story_id::text = ANY($2))
+		`, acceptAll, accept)
+		if err != nil {
+			return nil, fmt.Errorf("failed to accept changed stories: %w", err)
+		}
+		result.Accepted = tag.RowsAffected()
+
+		tag, err = tx.Exec(ctx, `
+			DELETE FROM content_hashes h
+			WHERE NOT EXISTS (SELECT 1 FROM stories s WHERE s.id = h.story_id)
+				AND ($1 OR h.story_id::text = ANY($2))
+		`, acceptAll, accept)
+		if err != nil {
+			return nil, fmt.Errorf("failed to accept vanished stories: %w", err)
+		}
+		result.Accepted += tag.RowsAffected()
+	}
+
+	rows, err := tx.Query(ctx, `SELECT story_id::text, sha256 FROM content_hashes ORDER BY story_id::text`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list content hashes: %w", err)
+	}
+	var entries []HashEntry
+	for rows.Next() {
+		var e HashEntry
+		if err := rows.Scan(&e.StoryID, &e.SHA256); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan content hash: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list content hashes: %w", err)
+	}
+
+	manifest, err := sign(entries)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := json.Marshal(manifest.Entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest entries: %w", err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO corpus_manifests (created_at, story_count, root_sha256, public_key, signature, entries)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, manifest.CreatedAt, manifest.StoryCount, manifest.Root, manifest.PublicKey, manifest.Signature, encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save manifest: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit seal: %w", err)
+	}
+	result.Manifest = manifest
+	return &result, nil
+}
+
+// LatestCorpusManifest returns the most recent signed manifest, or
+// ErrNotFound if the corpus was never sealed
+func (db *DB) LatestCorpusManifest(ctx context.Context) (*CorpusManifest, error) {
+	var m CorpusManifest
+	var entries []byte
+	err := db.pool.QueryRow(ctx, `
+		SELECT created_at, story_count, root_sha256, public_key, signature, entries
+		FROM corpus_manifests
+		ORDER BY id DESC
+		LIMIT 1
+	`).Scan(&m.CreatedAt, &m.StoryCount, &m.Root, &m.PublicKey, &m.Signature, &entries)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+	if err := json.Unmarshal(entries, &m.Entries); err != nil {
+		return nil, fmt.Errorf("failed to decode manifest entries: %w", err)
+	}
+	return &m, nil
+}
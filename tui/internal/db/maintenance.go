@@ -0,0 +1,207 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MaintainedTables are the tables the maintenance tools report on and act on
+var MaintainedTables = []string{"stories", "story_chunks", "episodes", "transcripts", "external_ids"}
+
+// TableHealth summarizes a table's size and vacuum/analyze history
+type TableHealth struct {
+	Name        string
+	TotalBytes  int64 // Table, indexes and TOAST
+	TableBytes  int64
+	IndexBytes  int64
+	LiveRows    int64
+	DeadRows    int64
+	LastVacuum  *time.Time // Latest of manual and auto vacuum
+	LastAnalyze *time.Time // Latest of manual and auto analyze
+}
+
+// DeadRatio is the fraction of rows that are dead tuples awaiting vacuum
+func (t TableHealth) DeadRatio() float64 {
+	if t.LiveRows+t.DeadRows == 0 {
+		return 0
+	}
+	return float64(t.DeadRows) / float64(t.LiveRows+t.DeadRows)
+}
+
+// IndexHealth summarizes an index's size, use and bloat
+type IndexHealth struct {
+	Name  string
+	Table string
+	Bytes int64
+	Scans int64 // Index scans since statistics were reset; 0 suggests it's unused
+	// LeafDensity is the average fill of btree leaf pages (pgstattuple);
+	// nil when the extension is missing or the index isn't a btree.
+	// Freshly built indexes sit near 90%; much lower means bloat.
+	LeafDensity *float64
+}
+
+// GetTableHealth reports on MaintainedTables that exist
+func (db *DB) GetTableHealth(ctx context.Context) ([]TableHealth, error) {
+	query := `
+		SELECT relname,
+			pg_total_relation_size(relid), pg_relation_size(relid), pg_indexes_size(relid),
+			n_live_tup, n_dead_tup,
+			GREATEST(last_vacuum, last_autovacuum),
+			GREATEST(last_analyze, last_autoanalyze)
+		FROM pg_stat_user_tables
+		WHERE relname = ANY($1)
+		ORDER BY pg_total_relation_size(relid) DESC
+	`
+
+	rows, err := db.pool.Query(ctx, query, MaintainedTables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get table health: %w", err)
+	}
+	defer rows.Close()
+
+	var tables []TableHealth
+	for rows.Next() {
+		var t TableHealth
+		err := rows.Scan(&t.Name, &t.TotalBytes, &t.TableBytes, &t.IndexBytes,
+			&t.LiveRows, &t.DeadRows, &t.LastVacuum, &t.LastAnalyze)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan table health: %w", err)
+		}
+		tables = append(tables, t)
+	}
+
+	return tables, nil
+}
+
+// GetIndexHealth reports on the indexes of MaintainedTables, largest first.
+// Leaf density is filled in when the pgstattuple extension is installed.
+func (db *DB) GetIndexHealth(ctx context.Context) ([]IndexHealth, error) {
+	query := `
+		SELECT i.indexrelname, i.relname, pg_relation_size(i.indexrelid), i.idx_scan,
+			am.amname = 'btree'
+		FROM pg_stat_user_indexes i
+		JOIN pg_class c ON c.oid = i.indexrelid
+		JOIN pg_am am ON am.oid = c.relam
+		WHERE i.relname = ANY($1)
+		ORDER BY pg_relation_size(i.indexrelid) DESC
+	`
+
+	rows, err := db.pool.Query(ctx, query, MaintainedTables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get index health: %w", err)
+	}
+
+	var indexes []IndexHealth
+	var btree []bool
+	for rows.Next() {
+		var ix IndexHealth
+		var isBtree bool
+		if err := rows.Scan(&ix.Name, &ix.Table, &ix.Bytes, &ix.Scans, &isBtree); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan index health: %w", err)
+		}
+		indexes = append(indexes, ix)
+		btree = append(btree, isBtree)
+	}
+	rows.Close()
+
+	var hasPgstattuple bool
+	err = db.pool.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pgstattuple')`).Scan(&hasPgstattuple)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for pgstattuple: %w", err)
+	}
+	if !hasPgstattuple {
+		return indexes, nil
+	}
+
+	for i := range indexes {
+		if !btree[i] {
+			continue
+		}
+		var density float64
+		err := db.pool.QueryRow(ctx, `SELECT avg_leaf_density FROM pgstatindex($1::regclass)`,
+			pgx.Identifier{indexes[i].Name}.Sanitize()).Scan(&density)
+		if err != nil {
+			// Empty or unusual indexes can't be inspected; leave them unknown
+			continue
+		}
+		indexes[i].LeafDensity = &density
+	}
+
+	return indexes, nil
+}
+
+// AnalyzeTables refreshes planner statistics for MaintainedTables
+func (db *DB) AnalyzeTables(ctx context.Context) error {
+	for _, table := range MaintainedTables {
+		if err := db.maintainTable(ctx, "ANALYZE", table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReindexTable rebuilds a maintained table's indexes without blocking
+// reads or writes. table must be one of MaintainedTables.
+func (db *DB) ReindexTable(ctx context.Context, table string) error {
+	return db.maintainTable(ctx, "REINDEX TABLE CONCURRENTLY", table)
+}
+
+// maintainTable runs command on a table, refusing anything outside
+// MaintainedTables and skipping tables this database doesn't have
+func (db *DB) maintainTable(ctx context.Context, command, table string) error {
+	known := false
+	for _, t := range MaintainedTables {
+		known = known || t == table
+	}
+	if !known {
+		return fmt.Errorf("not a maintained table: %s", table)
+	}
+
+	var exists bool
+	if err := db.pool.QueryRow(ctx, `SELECT to_regclass($1) IS NOT NULL`, table).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to look up %s: %w", table, err)
+	}
+	if !exists {
+		return nil
+	}
+
+	if _, err := db.pool.Exec(ctx, command+" "+pgx.Identifier{table}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to %s %s: %w", command, table, err)
+	}
+	return nil
+}
+
+// FormatBytes renders a size like "12.3 MB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatAge renders how long ago t was, or "never"
+func FormatAge(t *time.Time) string {
+	if t == nil {
+		return "never"
+	}
+	d := time.Since(*t)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// ToggleMark marks a line of a story's content, or unmarks it if already
+// marked, and reports whether it is now marked
+func (db *DB) ToggleMark(ctx context.Context, id string, line int) (bool, error) {
+	tag, err := db.pool.Exec(ctx, `DELETE FROM story_marks WHERE story_id = $1 AND line = $2`, id, line)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove mark: %w", err)
+	}
+	if tag.RowsAffected() > 0 {
+		return false, nil
+	}
+
+	_, err = db.pool.Exec(ctx, `
+		INSERT INTO story_marks (story_id, line) VALUES ($1, $2)
+		ON CONFLICT (story_id, line) DO NOTHING
+	`, id, line)
+	if err != nil {
+		return false, fmt.Errorf("failed to add mark: %w", err)
+	}
+	return true, nil
+}
+
+// Marks returns the marked lines of a story's content, in order
+func (db *DB) Marks(ctx context.Context, id string) ([]int, error) {
+	rows, err := db.pool.Query(ctx, `SELECT line FROM story_marks WHERE story_id = $1 ORDER BY line`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load marks: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []int
+	for rows.Next() {
+		var line int
+		if err := rows.Scan(&line); err != nil {
+			return nil, fmt.Errorf("failed to scan mark: %w", err)
+		}
+		lines = append(lines, line)
+	}
+	return lines, rows.Err()
+}
@@ -0,0 +1,329 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// migrations bring databases created from older versions of schema.sql up to
+// date. Each is applied once, in order, and the number applied is recorded
+// in schema_version, so add new ones at the end and never change or reorder
+// those already released. Statements must still be idempotent: databases
+// from before schema_version apply them all again.
+var migrations = []string{
+	// Semantic clusters (populated by scripts/analyze_embeddings.py)
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS cluster_id INTEGER`,
+
+	// Geocoded story coordinates (populated by scripts/geocode_stories.py)
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS latitude FLOAT`,
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS longitude FLOAT`,
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS geocoded_at TIMESTAMPTZ`,
+	`CREATE INDEX IF NOT EXISTS idx_stories_geo ON stories(latitude, longitude)`,
+
+	// Geographic hotspots (populated by scripts/cluster_locations.py)
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS geo_cluster_id INTEGER`,
+	`CREATE INDEX IF NOT EXISTS idx_stories_geo_cluster ON stories(geo_cluster_id)`,
+
+	// Ingestion provenance (populated by scripts/load_segments.py)
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS source_path TEXT`,
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS content_sha256 TEXT`,
+	`CREATE UNIQUE INDEX IF NOT EXISTS idx_stories_source_path ON stories(source_path) WHERE source_path IS NOT NULL`,
+
+	// Identifiers other systems use for a story (populated by scripts/load_segments.py)
+	`CREATE TABLE IF NOT EXISTS external_ids (
+		source_system TEXT NOT NULL,
+		external_id TEXT NOT NULL,
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ DEFAULT now(),
+		PRIMARY KEY (source_system, external_id)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_external_ids_story ON external_ids(story_id)`,
+
+	// Trash: trashed stories are hidden everywhere and purged after retention
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ`,
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS deleted_reason TEXT`,
+	`CREATE INDEX IF NOT EXISTS idx_stories_deleted ON stories(deleted_at) WHERE deleted_at IS NOT NULL`,
+
+	// Stories opened in the detail view; anything absent is unread
+	`CREATE TABLE IF NOT EXISTS story_reads (
+		story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+		read_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+
+	// Don't bump updated_at for no-op updates, such as rewriting content
+	// to recompress it (paranormal-tui content compress)
+	`CREATE OR REPLACE FUNCTION update_updated_at()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		IF NEW IS DISTINCT FROM OLD THEN
+			NEW.updated_at = now();
+		END IF;
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql`,
+
+	// Audit log of curator edits to story metadata
+	`CREATE TABLE IF NOT EXISTS story_edits (
+		id BIGSERIAL PRIMARY KEY,
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		field TEXT NOT NULL,
+		old_value TEXT,
+		new_value TEXT,
+		changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_story_edits_story ON story_edits(story_id, changed_at)`,
+
+	// Starred stories
+	`CREATE TABLE IF NOT EXISTS bookmarks (
+		story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+
+	// Star ratings; anything absent is unrated
+	`CREATE TABLE IF NOT EXISTS story_ratings (
+		story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+		rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+		rated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+
+	// Places marked within a story's text (detail view m)
+	`CREATE TABLE IF NOT EXISTS story_marks (
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		line INTEGER NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		PRIMARY KEY (story_id, line)
+	)`,
+
+	// My notes on a story, kept in step with a Markdown directory by
+	// paranormal-tui notes
+	`CREATE TABLE IF NOT EXISTS story_notes (
+		story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+		body TEXT NOT NULL,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+
+	// Sections of long stories (paranormal-tui chapters), for the contents
+	// in the detail view
+	`CREATE TABLE IF NOT EXISTS story_chapters (
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		position SMALLINT NOT NULL,
+		start_line INTEGER NOT NULL,
+		title TEXT NOT NULL,
+		content_md5 TEXT NOT NULL,
+		PRIMARY KEY (story_id, position)
+	)`,
+
+	// Word count for length filters and sorting, kept current by a
+	// trigger; older rows are filled by the words backfill command
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS word_count INTEGER`,
+	`CREATE INDEX IF NOT EXISTS idx_stories_word_count ON stories(word_count)`,
+	`CREATE OR REPLACE FUNCTION story_word_count(content TEXT)
+	RETURNS INTEGER AS $$
+		SELECT count(*)::int FROM regexp_split_to_table(content, '\s+') w WHERE w <> ''
+	$$ LANGUAGE sql IMMUTABLE`,
+	`CREATE OR REPLACE FUNCTION set_word_count()
+	RETURNS TRIGGER AS $$
+	BEGIN
+		NEW.word_count = story_word_count(NEW.content);
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql`,
+	// One statement (PostgreSQL 14+), so inserts are never left uncounted
+	// between dropping the trigger and creating it again
+	`CREATE OR REPLACE TRIGGER stories_word_count
+	BEFORE INSERT OR UPDATE OF content ON stories
+	FOR EACH ROW EXECUTE FUNCTION set_word_count()`,
+
+	// Named Browse filter + sort combinations
+	`CREATE TABLE IF NOT EXISTS filter_presets (
+		name TEXT PRIMARY KEY,
+		filters JSONB NOT NULL,
+		sort_field TEXT NOT NULL DEFAULT 'date',
+		sort_ascending BOOLEAN NOT NULL DEFAULT false,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+
+	// Named hand-picked sets of stories (Visualize box select)
+	`CREATE TABLE IF NOT EXISTS collections (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS collection_stories (
+		collection_id INTEGER NOT NULL REFERENCES collections(id) ON DELETE CASCADE,
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		PRIMARY KEY (collection_id, story_id)
+	)`,
+
+	// Tamper evidence: content hashes as of the last seal, and signed
+	// snapshots of them (paranormal-tui integrity)
+	`CREATE TABLE IF NOT EXISTS content_hashes (
+		story_id UUID PRIMARY KEY,
+		sha256 TEXT NOT NULL,
+		sealed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+	`CREATE TABLE IF NOT EXISTS corpus_manifests (
+		id BIGSERIAL PRIMARY KEY,
+		created_at TIMESTAMPTZ NOT NULL,
+		story_count INTEGER NOT NULL,
+		root_sha256 TEXT NOT NULL,
+		public_key TEXT NOT NULL,
+		signature TEXT NOT NULL,
+		entries JSONB NOT NULL
+	)`,
+
+	// Peer instances for federated search (managed by the web backend)
+	`CREATE TABLE IF NOT EXISTS federation_peers (
+		name TEXT PRIMARY KEY,
+		base_url TEXT NOT NULL,
+		enabled BOOLEAN NOT NULL DEFAULT true,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+
+	// Community submissions awaiting review (web backend intake, Review view)
+	`CREATE TABLE IF NOT EXISTS submitters (
+		id SERIAL PRIMARY KEY,
+		name TEXT NOT NULL UNIQUE,
+		token_sha256 TEXT NOT NULL UNIQUE,
+		created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		revoked_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS submissions (
+		id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+		submitter_id INTEGER NOT NULL REFERENCES submitters(id),
+		title TEXT NOT NULL,
+		content TEXT NOT NULL,
+		location TEXT,
+		time_period TEXT,
+		story_type TEXT,
+		status TEXT NOT NULL DEFAULT 'pending',
+		review_note TEXT,
+		story_id UUID REFERENCES stories(id) ON DELETE SET NULL,
+		submitted_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		reviewed_at TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_submissions_pending ON submissions(submitted_at) WHERE status = 'pending'`,
+
+	// Rows destructive batch jobs changed, copied just before, for
+	// paranormal-tui recovery restore
+	`CREATE TABLE IF NOT EXISTS recovery_snapshots (
+		id BIGSERIAL PRIMARY KEY,
+		job TEXT NOT NULL,
+		note TEXT,
+		taken_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+		restored_at TIMESTAMPTZ
+	)`,
+	`CREATE TABLE IF NOT EXISTS recovery_rows (
+		snapshot_id BIGINT NOT NULL REFERENCES recovery_snapshots(id) ON DELETE CASCADE,
+		part SMALLINT NOT NULL,
+		table_name TEXT NOT NULL,
+		row JSONB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_recovery_rows_snapshot ON recovery_rows(snapshot_id, part)`,
+
+	// The feed's audio enclosure, beside source_url for the episode page
+	`ALTER TABLE episodes ADD COLUMN IF NOT EXISTS audio_url TEXT`,
+
+	// People, places, and dates named in stories, from
+	// scripts/extract_entities.py
+	`CREATE TABLE IF NOT EXISTS story_entities (
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		kind TEXT NOT NULL CHECK (kind IN ('person', 'place', 'date')),
+		name TEXT NOT NULL,
+		mentions INTEGER NOT NULL DEFAULT 1,
+		source TEXT,
+		PRIMARY KEY (story_id, kind, name)
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_story_entities_name ON story_entities(kind, lower(name))`,
+	`ALTER TABLE stories ADD COLUMN IF NOT EXISTS entities_extracted_at TIMESTAMPTZ`,
+
+	// Where each utterance of a story is in its episode's audio, from the
+	// segment files scripts/load_segments.py loads
+	`CREATE TABLE IF NOT EXISTS story_utterances (
+		story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+		line INTEGER NOT NULL,
+		start_seconds FLOAT NOT NULL,
+		end_seconds FLOAT NOT NULL,
+		PRIMARY KEY (story_id, line)
+	)`,
+
+	// Stories I've flagged as hoaxes, ranked down in weighted search
+	`CREATE TABLE IF NOT EXISTS hoax_flags (
+		story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+		flagged_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`,
+}
+
+// migrationLock is the advisory lock held while migrating, so sessions
+// starting together don't both apply the same migrations
+const migrationLock = 0x70617261
+
+// SchemaVersion returns how many migrations the database has had; 0 if it
+// has never been migrated
+func (db *DB) SchemaVersion(ctx context.Context) (int, error) {
+	var version int
+	err := db.pool.QueryRow(ctx, `SELECT version FROM schema_version`).Scan(&version)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows), errors.As(err, &pgErr) && pgErr.Code == "42P01": // undefined_table
+		return 0, nil
+	case err != nil:
+		return 0, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	return version, nil
+}
+
+// SchemaLatest is the schema version this build expects
+func SchemaLatest() int {
+	return len(migrations)
+}
+
+// migrate applies the migrations the database hasn't had yet, in one
+// transaction. An up-to-date database costs one query, without taking any
+// locks on its tables.
+func (db *DB) migrate(ctx context.Context) error {
+	version, err := db.SchemaVersion(ctx)
+	if err != nil || version >= len(migrations) {
+		return err
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock($1)`, migrationLock); err != nil {
+		return fmt.Errorf("failed to lock schema: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return fmt.Errorf("failed to migrate schema: %w", err)
+	}
+	// Read again under the lock, in case another session just migrated
+	err = tx.QueryRow(ctx, `SELECT version FROM schema_version`).Scan(&version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		version = 0
+		_, err = tx.Exec(ctx, `INSERT INTO schema_version (version) VALUES (0)`)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	for _, stmt := range migrations[min(version, len(migrations)):] {
+		if _, err := tx.Exec(ctx, stmt); err != nil {
+			return fmt.Errorf("failed to migrate schema: %w", err)
+		}
+	}
+	if _, err := tx.Exec(ctx, `UPDATE schema_version SET version = $1`, max(version, len(migrations))); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit migration: %w", err)
+	}
+	return nil
+}
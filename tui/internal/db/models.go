@@ -1,8 +1,13 @@
 package db
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
+	"paranormal-tui/internal/dates"
+
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -17,13 +22,40 @@ type Story struct {
 	AirDate   pgtype.Date
 	ShowName  pgtype.Text
 
-	// Scores from search
+	// Scores from search: text rank, vector similarity, and the score the
+	// search ranked by (one of those, or a hybrid search's blend)
 	Rank       float64
 	Similarity float64
+	Score      float64
 
 	// UMAP coordinates for visualization
 	UmapX pgtype.Float8
 	UmapY pgtype.Float8
+
+	// Geocoded coordinates of the story location
+	Latitude  pgtype.Float8
+	Longitude pgtype.Float8
+
+	// Geographic hotspot (nil = not geocoded or not in a hotspot)
+	GeoClusterID *int
+
+	// Discovered semantic cluster (nil = noise or not clustered)
+	ClusterID *int
+
+	// Persisted word count (invalid until backfilled)
+	Words pgtype.Int4
+
+	// Read is true once the story has been opened in the detail view
+	Read bool
+
+	// Bookmarked is true for starred stories
+	Bookmarked bool
+
+	// Rating is my 1-5 star rating (0 = unrated)
+	Rating int
+
+	// Hoax is true for stories I've flagged as hoaxes
+	Hoax bool
 }
 
 // StoryTypes defines all valid story types for filtering
@@ -45,6 +77,25 @@ var StoryTypes = []string{
 	"other",
 }
 
+// StoryTypeGlossary defines each story type, for the glossary in exports
+var StoryTypeGlossary = map[string]string{
+	"ghost":           "An apparition or spirit of someone who has died, seen, heard, or felt",
+	"shadow_person":   "A dark human-shaped figure, often seen at the edge of vision; includes the \"hat man\"",
+	"cryptid":         "An animal or creature whose existence is unproven, such as Bigfoot or the dogman",
+	"ufo":             "An unidentified object or light seen in the sky",
+	"alien_encounter": "Contact with non-human beings, including abduction and missing time",
+	"haunting":        "Recurring activity tied to a place rather than a person",
+	"poltergeist":     "Physical disturbances such as objects moving, thrown, or broken with no visible cause",
+	"precognition":    "Knowing of a future event, by dream, vision, or feeling, before it happens",
+	"nde":             "Near-death experience: what someone perceived while close to death or clinically dead",
+	"obe":             "Out-of-body experience: perceiving the world from outside one's own body",
+	"time_slip":       "Seeming to pass into another time, or losing or gaining time inexplicably",
+	"doppelganger":    "Seeing an exact double of oneself or of someone else who was elsewhere",
+	"sleep_paralysis": "Waking unable to move, often sensing a presence or seeing a figure in the room",
+	"possession":      "A person seemingly taken over by another entity",
+	"other":           "An experience that fits none of the other types",
+}
+
 // StoryTypeColors maps story types to terminal colors
 var StoryTypeColors = map[string]string{
 	"ghost":           "#8B8BFF", // Light blue
@@ -95,7 +146,7 @@ func (s *Story) FormattedDate() string {
 	if !s.AirDate.Valid {
 		return "Unknown"
 	}
-	return s.AirDate.Time.Format("2006-01-02")
+	return dates.Date(s.AirDate.Time)
 }
 
 // FormattedType returns the story type or "unknown"
@@ -122,6 +173,15 @@ func (s *Story) FormattedShow() string {
 	return s.ShowName.String
 }
 
+// WordCount returns the number of words in the content, counting them
+// if the stored count hasn't been backfilled yet
+func (s *Story) WordCount() int {
+	if s.Words.Valid {
+		return int(s.Words.Int32)
+	}
+	return len(strings.Fields(s.Content))
+}
+
 // Snippet returns a truncated version of the content
 func (s *Story) Snippet(maxLen int) string {
 	if len(s.Content) <= maxLen {
@@ -135,7 +195,10 @@ type UmapPoint struct {
 	ID        string
 	Title     string
 	StoryType string
-	ClusterID *int // Discovered cluster (nil = noise/outlier)
+	Location  string
+	ClusterID *int       // Discovered cluster (nil = noise/outlier)
+	AirDate   *time.Time // Episode's air date (nil = unknown)
+	WordCount *int       // nil until counted (paranormal-tui words)
 	X         float64
 	Y         float64
 }
@@ -148,16 +211,112 @@ type SearchResult struct {
 	HybridScore float64
 }
 
+// LocationCount is a distinct story location and how many stories mention it
+type LocationCount struct {
+	Location string
+	Count    int
+}
+
+// earthRadiusKm is the mean Earth radius used for distance filters
+const earthRadiusKm = 6371.0
+
+// GeoPoint is a latitude/longitude pair in degrees
+type GeoPoint struct {
+	Lat float64
+	Lng float64
+}
+
+// ParseGeoPoint parses "lat,lng" in decimal degrees
+func ParseGeoPoint(s string) (*GeoPoint, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid point %q (want lat,lng)", s)
+	}
+	lat, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil || lat < -90 || lat > 90 {
+		return nil, fmt.Errorf("invalid latitude in %q", s)
+	}
+	lng, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil || lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("invalid longitude in %q", s)
+	}
+	return &GeoPoint{Lat: lat, Lng: lng}, nil
+}
+
+// GeoHotspot is a geographic cluster of stories
+type GeoHotspot struct {
+	ID     int
+	Count  int
+	Center GeoPoint
+	Place  string // Most common location string among its stories
+}
+
+// GeoStory is a geocoded story with a known air date
+type GeoStory struct {
+	ID       string
+	Location string
+	Point    GeoPoint
+	AirDate  time.Time
+}
+
 // BrowseFilters holds filters for the browse view
 type BrowseFilters struct {
-	StoryType string
-	Location  string
-	DateFrom  *time.Time
-	DateTo    *time.Time
+	StoryTypes []string // Any of these types; empty means all
+	Location   string
+	DateFrom   *time.Time
+	DateTo     *time.Time
+
+	// DateRange is a relative range such as "last 30 days", resolved when
+	// the query runs so saved presets stay current. Overrides DateFrom/DateTo.
+	DateRange string
+
+	// Distance filter: stories within RadiusKm of Near
+	Near      *GeoPoint
+	NearLabel string // Place name or point as entered, for display
+	RadiusKm  float64
+
+	// Length filter in words; 0 means no bound
+	MinWords int
+	MaxWords int
+
+	UnreadOnly     bool // Only stories never opened
+	BookmarkedOnly bool // Only starred stories
+
+	ClusterID *int // Only stories in this semantic cluster
+
+	// Only stories from this episode
+	EpisodeID    string
+	EpisodeLabel string // Episode title and number, for display
+
+	// Only stories naming this entity, of an EntityPerson, EntityPlace,
+	// or EntityDate kind; matched ignoring case
+	EntityKind string
+	EntityName string
+
+	// IDs, if set, restricts matches to these stories. It narrows search
+	// results and is never saved with a preset.
+	IDs []string `json:"-"`
 }
 
 // BrowseSort defines sorting options
 type BrowseSort struct {
-	Field     string // "date", "title", "type"
+	Field     string // One of SortFields
 	Ascending bool
 }
+
+// SortFields are the fields stories can be sorted by, in the order the
+// Browse sort key cycles through them. "length" is the word count;
+// "episode" keeps each episode's stories together in the
+// order they were told.
+var SortFields = []string{"date", "title", "type", "location", "show", "length", "cluster", "episode"}
+
+// StoryCursor is a position in (air_date, id) order for keyset pagination
+type StoryCursor struct {
+	AirDate pgtype.Date // Invalid for stories without an air date
+	ID      string
+}
+
+// CursorAfter returns the cursor positioned just after story
+func CursorAfter(story Story) *StoryCursor {
+	return &StoryCursor{AirDate: story.AirDate, ID: story.ID}
+}
@@ -0,0 +1,95 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Note is what I've written about a story
+type Note struct {
+	StoryID   string
+	Title     string // The story's
+	Body      string
+	UpdatedAt time.Time
+}
+
+// Notes returns every story's note, oldest story first
+func (db *DB) Notes(ctx context.Context) ([]Note, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT n.story_id::text, s.title, n.body, n.updated_at
+		FROM story_notes n
+		JOIN stories s ON s.id = n.story_id
+		ORDER BY s.created_at, n.story_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []Note
+	for rows.Next() {
+		var n Note
+		if err := rows.Scan(&n.StoryID, &n.Title, &n.Body, &n.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan note: %w", err)
+		}
+		notes = append(notes, n)
+	}
+	return notes, rows.Err()
+}
+
+// Note returns a story's note; "" if it has none
+func (db *DB) Note(ctx context.Context, id string) (string, error) {
+	var body string
+	err := db.pool.QueryRow(ctx, `SELECT body FROM story_notes WHERE story_id = $1`, id).Scan(&body)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to load note: %w", err)
+	}
+	return body, nil
+}
+
+// SetNote saves a story's note; a blank one deletes it
+func (db *DB) SetNote(ctx context.Context, id, body string) error {
+	if strings.TrimSpace(body) == "" {
+		if _, err := db.pool.Exec(ctx, `DELETE FROM story_notes WHERE story_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to delete note: %w", err)
+		}
+		return nil
+	}
+
+	_, err := db.pool.Exec(ctx, `
+		INSERT INTO story_notes (story_id, body) VALUES ($1, $2)
+		ON CONFLICT (story_id) DO UPDATE SET body = EXCLUDED.body, updated_at = now()
+	`, id, body)
+	if err != nil {
+		return fmt.Errorf("failed to save note: %w", err)
+	}
+	return nil
+}
+
+// StoryTitles returns the titles of the given stories, keyed by ID;
+// missing IDs are left out
+func (db *DB) StoryTitles(ctx context.Context, ids []string) (map[string]string, error) {
+	rows, err := db.pool.Query(ctx, `SELECT id::text, title FROM stories WHERE id = ANY($1::uuid[])`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load titles: %w", err)
+	}
+	defer rows.Close()
+
+	titles := make(map[string]string, len(ids))
+	for rows.Next() {
+		var id, title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, fmt.Errorf("failed to scan title: %w", err)
+		}
+		titles[id] = title
+	}
+	return titles, rows.Err()
+}
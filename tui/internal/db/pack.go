@@ -0,0 +1,41 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// Citation is where in its episode a story was told
+type Citation struct {
+	EpisodeTitle  string
+	EpisodeNumber string
+	SourceURL     string
+	Start, End    *float64 // Seconds into the episode, if known
+}
+
+// Citations returns the episode details for each of the given stories that
+// has an episode, keyed by story ID
+func (db *DB) Citations(ctx context.Context, ids []string) (map[string]Citation, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT s.id::text, e.title, COALESCE(e.episode_number, ''), COALESCE(e.source_url, ''),
+			s.start_time_seconds, s.end_time_seconds
+		FROM stories s
+		JOIN episodes e ON e.id = s.episode_id
+		WHERE s.id = ANY($1::uuid[])
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get citations: %w", err)
+	}
+	defer rows.Close()
+
+	citations := make(map[string]Citation, len(ids))
+	for rows.Next() {
+		var id string
+		var c Citation
+		if err := rows.Scan(&id, &c.EpisodeTitle, &c.EpisodeNumber, &c.SourceURL, &c.Start, &c.End); err != nil {
+			return nil, fmt.Errorf("failed to scan citation: %w", err)
+		}
+		citations[id] = c
+	}
+	return citations, rows.Err()
+}
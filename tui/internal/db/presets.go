@@ -0,0 +1,70 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Preset is a named Browse filter and sort
+type Preset struct {
+	Name    string
+	Filters BrowseFilters
+	Sort    BrowseSort
+}
+
+// ListPresets returns saved presets in the order they were created
+func (db *DB) ListPresets(ctx context.Context) ([]Preset, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT name, filters, sort_field, sort_ascending
+		FROM filter_presets
+		ORDER BY created_at, name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list presets: %w", err)
+	}
+	defer rows.Close()
+
+	var presets []Preset
+	for rows.Next() {
+		var p Preset
+		var filters []byte
+		if err := rows.Scan(&p.Name, &filters, &p.Sort.Field, &p.Sort.Ascending); err != nil {
+			return nil, fmt.Errorf("failed to scan preset: %w", err)
+		}
+		if err := json.Unmarshal(filters, &p.Filters); err != nil {
+			return nil, fmt.Errorf("failed to decode preset %q: %w", p.Name, err)
+		}
+		presets = append(presets, p)
+	}
+	return presets, rows.Err()
+}
+
+// SavePreset stores a preset, replacing any with the same name
+func (db *DB) SavePreset(ctx context.Context, p Preset) error {
+	filters, err := json.Marshal(p.Filters)
+	if err != nil {
+		return fmt.Errorf("failed to encode preset filters: %w", err)
+	}
+
+	_, err = db.pool.Exec(ctx, `
+		INSERT INTO filter_presets (name, filters, sort_field, sort_ascending)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO UPDATE
+		SET filters = EXCLUDED.filters,
+			sort_field = EXCLUDED.sort_field,
+			sort_ascending = EXCLUDED.sort_ascending
+	`, p.Name, filters, p.Sort.Field, p.Sort.Ascending)
+	if err != nil {
+		return fmt.Errorf("failed to save preset: %w", err)
+	}
+	return nil
+}
+
+// DeletePreset removes a preset by name
+func (db *DB) DeletePreset(ctx context.Context, name string) error {
+	if _, err := db.pool.Exec(ctx, `DELETE FROM filter_presets WHERE name = $1`, name); err != nil {
+		return fmt.Errorf("failed to delete preset: %w", err)
+	}
+	return nil
+}
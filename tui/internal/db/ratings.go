@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// SetRating rates a story 1-5 stars; 0 clears its rating
+func (db *DB) SetRating(ctx context.Context, id string, rating int) error {
+	if rating < 0 || rating > 5 {
+		return fmt.Errorf("rating must be 0-5, got %d", rating)
+	}
+
+	if rating == 0 {
+		if _, err := db.pool.Exec(ctx, `DELETE FROM story_ratings WHERE story_id = $1`, id); err != nil {
+			return fmt.Errorf("failed to clear rating: %w", err)
+		}
+		return nil
+	}
+
+	_, err := db.pool.Exec(ctx, `
+		INSERT INTO story_ratings (story_id, rating) VALUES ($1, $2)
+		ON CONFLICT (story_id) DO UPDATE SET rating = EXCLUDED.rating, rated_at = now()
+	`, id, rating)
+	if err != nil {
+		return fmt.Errorf("failed to set rating: %w", err)
+	}
+	return nil
+}
+
+// RatingWeight is how far a rating moves a search result when results are
+// weighted by my judgment: a 5-star story's score counts for
+// 1+RatingWeight times as much, a 1-star story's for 1-RatingWeight.
+// Unrated and 3-star stories are left where they are.
+const RatingWeight = 0.5
+
+// HoaxFactor scales the score of a story I've flagged as a hoax when
+// results are weighted by my judgment, on top of its rating's factor
+const HoaxFactor = 0.25
+
+// judgmentFactor is what a story's search score is multiplied by for its
+// rating and hoax flag
+func judgmentFactor(story *Story) float64 {
+	factor := 1.0
+	if story.Rating != 0 {
+		factor += RatingWeight * float64(story.Rating-3) / 2
+	}
+	if story.Hoax {
+		factor *= HoaxFactor
+	}
+	return factor
+}
+
+// WeightByJudgment re-orders search results by their Score times their
+// judgment factor, so the stories I've rated highly rise and those I've
+// rated low or flagged as hoaxes sink. The scores themselves are left as
+// found.
+func WeightByJudgment(stories []Story) {
+	sort.SliceStable(stories, func(i, j int) bool {
+		return stories[i].Score*judgmentFactor(&stories[i]) > stories[j].Score*judgmentFactor(&stories[j])
+	})
+}
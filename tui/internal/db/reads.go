@@ -0,0 +1,18 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// MarkRead records that a story has been opened
+func (db *DB) MarkRead(ctx context.Context, id string) error {
+	_, err := db.pool.Exec(ctx, `
+		INSERT INTO story_reads (story_id) VALUES ($1)
+		ON CONFLICT (story_id) DO UPDATE SET read_at = now()
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark story read: %w", err)
+	}
+	return nil
+}
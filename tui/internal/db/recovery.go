@@ -0,0 +1,272 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Snapshot is a copy of the rows a destructive job was about to change,
+// taken in the job's own transaction, so it can be put back with
+// RestoreSnapshot (paranormal-tui recovery restore ID)
+type Snapshot struct {
+	ID         int64
+	Job        string // e.g. "merge", "purge", "recluster", "reproject"
+	Note       string
+	TakenAt    time.Time
+	RestoredAt *time.Time
+	Rows       int
+}
+
+// snapshotPart is the rows of one table a snapshot copies: where is a
+// condition on the table, aliased t, with arguments from $3 on. Columns
+// names the columns copied, for jobs that only change some; nil copies
+// whole rows.
+type snapshotPart struct {
+	table   string
+	columns []string
+	where   string
+	args    []any
+}
+
+// takeSnapshot copies the rows parts name into the recovery tables, in tx
+// so the copy is of exactly what the job changes. Parts are restored in
+// the order given, so stories must come before the rows that reference
+// them.
+func takeSnapshot(ctx context.Context, tx pgx.Tx, job, note string, parts ...snapshotPart) error {
+	var id int64
+	if err := tx.QueryRow(ctx, `
+		INSERT INTO recovery_snapshots (job, note) VALUES ($1, $2) RETURNING id
+	`, job, note).Scan(&id); err != nil {
+		return fmt.Errorf("failed to take %s snapshot: %w", job, err)
+	}
+
+	for i, p := range parts {
+		row := "to_jsonb(t)"
+		if p.columns != nil {
+			pairs := make([]string, len(p.columns))
+			for j, c := range p.columns {
+				pairs[j] = fmt.Sprintf("'%s', t.%s", c, pgx.Identifier{c}.Sanitize())
+			}
+			row = "jsonb_build_object(" + strings.Join(pairs, ", ") + ")"
+		}
+		stmt := fmt.Sprintf(`
+			INSERT INTO recovery_rows (snapshot_id, part, table_name, row)
+			SELECT $1, %d, $2, %s FROM %s t WHERE %s
+		`, i, row, pgx.Identifier{p.table}.Sanitize(), p.where)
+		if _, err := tx.Exec(ctx, stmt, append([]any{id, p.table}, p.args...)...); err != nil {
+			return fmt.Errorf("failed to snapshot %s: %w", p.table, err)
+		}
+	}
+	return nil
+}
+
+// storyDependents are the rows deleted with some stories: those of every
+// table whose foreign key to stories cascades, as parts of a snapshot
+func storyDependents(ctx context.Context, tx pgx.Tx, where string, args ...any) ([]snapshotPart, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT c.conrelid::regclass::text, a.attname
+		FROM pg_constraint c
+		JOIN pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = c.conkey[1]
+		WHERE c.contype = 'f' AND c.confrelid = 'stories'::regclass AND c.confdeltype = 'c'
+		ORDER BY 1
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find tables referencing stories: %w", err)
+	}
+	defer rows.Close()
+
+	var parts []snapshotPart
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, fmt.Errorf("failed to scan referencing table: %w", err)
+		}
+		parts = append(parts, snapshotPart{
+			table: table,
+			where: fmt.Sprintf("t.%s IN (SELECT id FROM stories WHERE %s)", pgx.Identifier{column}.Sanitize(), where),
+			args:  args,
+		})
+	}
+	return parts, rows.Err()
+}
+
+// ListSnapshots returns recovery snapshots, newest first
+func (db *DB) ListSnapshots(ctx context.Context) ([]Snapshot, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT s.id, s.job, COALESCE(s.note, ''), s.taken_at, s.restored_at,
+			(SELECT count(*) FROM recovery_rows r WHERE r.snapshot_id = s.id)
+		FROM recovery_snapshots s
+		ORDER BY s.taken_at DESC, s.id DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []Snapshot
+	for rows.Next() {
+		var s Snapshot
+		if err := rows.Scan(&s.ID, &s.Job, &s.Note, &s.TakenAt, &s.RestoredAt, &s.Rows); err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		snapshots = append(snapshots, s)
+	}
+	return snapshots, rows.Err()
+}
+
+// RestoreSnapshot puts back the rows a snapshot copied: rows that still
+// exist get the copied columns' old values, and rows that were deleted are
+// inserted again. It returns how many rows were restored. Anything the
+// job's table didn't have then, such as stories added since, is untouched.
+func (db *DB) RestoreSnapshot(ctx context.Context, id int64) (int64, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin restore: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var restoredAt *time.Time
+	err = tx.QueryRow(ctx, `SELECT restored_at FROM recovery_snapshots WHERE id = $1 FOR UPDATE`, id).Scan(&restoredAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("snapshot %d: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up snapshot: %w", err)
+	}
+	if restoredAt != nil {
+		return 0, fmt.Errorf("snapshot %d was already restored %s", id, restoredAt.Format(time.DateTime))
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT DISTINCT part, table_name FROM recovery_rows WHERE snapshot_id = $1 ORDER BY part
+	`, id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	type tablePart struct {
+		part  int
+		table string
+	}
+	var parts []tablePart
+	for rows.Next() {
+		var p tablePart
+		if err := rows.Scan(&p.part, &p.table); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan snapshot part: %w", err)
+		}
+		parts = append(parts, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var restored int64
+	for _, p := range parts {
+		n, err := restorePart(ctx, tx, id, p.part, p.table)
+		if err != nil {
+			return 0, err
+		}
+		restored += n
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE recovery_snapshots SET restored_at = now() WHERE id = $1`, id); err != nil {
+		return 0, fmt.Errorf("failed to mark snapshot restored: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit restore: %w", err)
+	}
+	return restored, nil
+}
+
+// restorePart writes one table's copied rows back, matching them to
+// current rows by the table's primary key. Deleted rows are only inserted
+// again from whole-row copies; a few columns can't make a row.
+func restorePart(ctx context.Context, tx pgx.Tx, id int64, part int, table string) (int64, error) {
+	var columns, key []string
+	var total int
+	err := tx.QueryRow(ctx, `
+		WITH copied AS (
+			SELECT DISTINCT jsonb_object_keys(row) AS name
+			FROM recovery_rows WHERE snapshot_id = $1 AND part = $2
+		)
+		SELECT
+			COALESCE(array_agg(a.attname::text ORDER BY a.attnum) FILTER (WHERE a.attname IN (SELECT name FROM copied)), '{}'),
+			COALESCE(array_agg(a.attname::text ORDER BY a.attnum) FILTER (WHERE a.attnum = ANY(i.indkey)), '{}'),
+			count(*)
+		FROM pg_attribute a
+		LEFT JOIN pg_index i ON i.indrelid = a.attrelid AND i.indisprimary
+		WHERE a.attrelid = $3::regclass AND a.attnum > 0 AND NOT a.attisdropped AND a.attgenerated = ''
+	`, id, part, table).Scan(&columns, &key, &total)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the columns of %s: %w", table, err)
+	}
+	if len(columns) == 0 {
+		return 0, nil
+	}
+
+	quoted := make([]string, len(columns))
+	for i, c := range columns {
+		quoted[i] = pgx.Identifier{c}.Sanitize()
+	}
+	name := pgx.Identifier{table}.Sanitize()
+	copied := fmt.Sprintf(
+		"(SELECT r.* FROM recovery_rows, jsonb_populate_record(NULL::%s, row) r WHERE snapshot_id = $1 AND part = $2)",
+		name)
+
+	// Without a primary key there's nothing to match on, so rows are only
+	// ever put back, not updated
+	if len(key) == 0 {
+		tag, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s c ON CONFLICT DO NOTHING",
+			name, strings.Join(quoted, ", "), strings.Join(quoted, ", "), copied), id, part)
+		if err != nil {
+			return 0, fmt.Errorf("failed to restore %s: %w", table, err)
+		}
+		return tag.RowsAffected(), nil
+	}
+
+	var match, set []string
+	for _, k := range key {
+		q := pgx.Identifier{k}.Sanitize()
+		match = append(match, fmt.Sprintf("t.%s = c.%s", q, q))
+	}
+	for _, q := range quoted {
+		set = append(set, fmt.Sprintf("%s = c.%s", q, q))
+	}
+	updated, err := tx.Exec(ctx, fmt.Sprintf("UPDATE %s t SET %s FROM %s c WHERE %s",
+		name, strings.Join(set, ", "), copied, strings.Join(match, " AND ")), id, part)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore %s: %w", table, err)
+	}
+	if len(columns) < total {
+		return updated.RowsAffected(), nil
+	}
+	inserted, err := tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM %s c WHERE NOT EXISTS (SELECT 1 FROM %s t WHERE %s)",
+		name, strings.Join(quoted, ", "), strings.Join(prefixed("c.", quoted), ", "), copied, name, strings.Join(match, " AND ")), id, part)
+	if err != nil {
+		return 0, fmt.Errorf("failed to restore deleted rows of %s: %w", table, err)
+	}
+	return updated.RowsAffected() + inserted.RowsAffected(), nil
+}
+
+// PruneSnapshots deletes snapshots taken longer than age ago
+func (db *DB) PruneSnapshots(ctx context.Context, age time.Duration) (int64, error) {
+	tag, err := db.pool.Exec(ctx, `DELETE FROM recovery_snapshots WHERE taken_at < $1`, time.Now().Add(-age))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune snapshots: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+func prefixed(prefix string, names []string) []string {
+	out := make([]string, len(names))
+	for i, n := range names {
+		out[i] = prefix + n
+	}
+	return out
+}
@@ -0,0 +1,86 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// relatedCandidates is how many nearest neighbors SimilarStories ranks,
+// enough to find ones in the same cluster while still using the index
+const relatedCandidates = 50
+
+// RelatedStory is a story like another, by embedding
+type RelatedStory struct {
+	ID          string
+	Title       string
+	StoryType   string
+	Similarity  float64
+	SameCluster bool
+}
+
+// SimilarStories returns up to limit stories like the given one: its
+// nearest neighbors by embedding, those in its cluster first. A story
+// without an embedding has none.
+func (db *DB) SimilarStories(ctx context.Context, id string, limit int) ([]RelatedStory, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT n.id::text, n.title, COALESCE(n.story_type, ''), n.similarity,
+			s.cluster_id IS NOT NULL AND n.cluster_id IS NOT DISTINCT FROM s.cluster_id AS same_cluster
+		FROM stories s
+		CROSS JOIN LATERAL (
+			SELECT t.id, t.title, t.story_type, t.cluster_id, 1 - (t.embedding <=> s.embedding) AS similarity
+			FROM stories t
+			WHERE t.id <> s.id AND t.deleted_at IS NULL AND t.embedding IS NOT NULL
+			ORDER BY t.embedding <=> s.embedding
+			LIMIT $2
+		) n
+		WHERE s.id = $1 AND s.embedding IS NOT NULL
+		ORDER BY same_cluster DESC, n.similarity DESC
+		LIMIT $3
+	`, id, relatedCandidates, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find related stories: %w", err)
+	}
+	return scanRelated(rows)
+}
+
+// NearestStories returns the limit stories nearest the given one by
+// embedding, most similar first, whatever their cluster. Set beside the
+// cluster each is in, they show whether the clustering agrees with the
+// embeddings. A story without an embedding has none.
+func (db *DB) NearestStories(ctx context.Context, id string, limit int) ([]RelatedStory, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT n.id::text, n.title, COALESCE(n.story_type, ''), n.similarity,
+			s.cluster_id IS NOT NULL AND n.cluster_id IS NOT DISTINCT FROM s.cluster_id
+		FROM stories s
+		CROSS JOIN LATERAL (
+			SELECT t.id, t.title, t.story_type, t.cluster_id, 1 - (t.embedding <=> s.embedding) AS similarity
+			FROM stories t
+			WHERE t.id <> s.id AND t.deleted_at IS NULL AND t.embedding IS NOT NULL
+			ORDER BY t.embedding <=> s.embedding
+			LIMIT $2
+		) n
+		WHERE s.id = $1 AND s.embedding IS NOT NULL
+		ORDER BY n.similarity DESC
+	`, id, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find nearest stories: %w", err)
+	}
+	return scanRelated(rows)
+}
+
+// scanRelated reads the rows of SimilarStories or NearestStories
+func scanRelated(rows pgx.Rows) ([]RelatedStory, error) {
+	defer rows.Close()
+
+	var related []RelatedStory
+	for rows.Next() {
+		var r RelatedStory
+		if err := rows.Scan(&r.ID, &r.Title, &r.StoryType, &r.Similarity, &r.SameCluster); err != nil {
+			return nil, fmt.Errorf("failed to scan related story: %w", err)
+		}
+		related = append(related, r)
+	}
+	return related, rows.Err()
+}
@@ -3,27 +3,54 @@ package db
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
+
+	"paranormal-tui/internal/dates"
 )
 
+// storyColumns is the SELECT list scanned by scanStory. Queries using it
+// must alias stories as s and LEFT JOIN episodes as e.
+const storyColumns = `
+			s.id, s.title, s.content, s.summary, s.story_type, s.location,
+			e.air_date, e.podcast_name,
+			s.umap_x, s.umap_y,
+			s.latitude, s.longitude, s.geo_cluster_id, s.cluster_id, s.word_count,
+			EXISTS (SELECT 1 FROM story_reads r WHERE r.story_id = s.id),
+			EXISTS (SELECT 1 FROM bookmarks b WHERE b.story_id = s.id),
+			COALESCE((SELECT sr.rating::int FROM story_ratings sr WHERE sr.story_id = s.id), 0),
+			EXISTS (SELECT 1 FROM hoax_flags h WHERE h.story_id = s.id)`
+
+// rowScanner is satisfied by pgx.Row and pgx.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanStory scans storyColumns, followed by any extra destinations
+func scanStory(row rowScanner, story *Story, extra ...any) error {
+	dest := []any{
+		&story.ID, &story.Title, &story.Content, &story.Summary,
+		&story.StoryType, &story.Location, &story.AirDate, &story.ShowName,
+		&story.UmapX, &story.UmapY,
+		&story.Latitude, &story.Longitude, &story.GeoClusterID, &story.ClusterID, &story.Words,
+		&story.Read, &story.Bookmarked, &story.Rating, &story.Hoax,
+	}
+	return row.Scan(append(dest, extra...)...)
+}
+
 // GetStoryByID retrieves a single story by ID
 func (db *DB) GetStoryByID(ctx context.Context, id string) (*Story, error) {
 	query := `
-		SELECT
-			s.id, s.title, s.content, s.summary, s.story_type, s.location,
-			e.air_date, e.podcast_name,
-			s.umap_x, s.umap_y
+		SELECT` + storyColumns + `
 		FROM stories s
 		LEFT JOIN episodes e ON s.episode_id = e.id
 		WHERE s.id = $1
 	`
 
 	var story Story
-	err := db.pool.QueryRow(ctx, query, id).Scan(
-		&story.ID, &story.Title, &story.Content, &story.Summary,
-		&story.StoryType, &story.Location, &story.AirDate, &story.ShowName,
-		&story.UmapX, &story.UmapY,
-	)
+	err := scanStory(db.pool.QueryRow(ctx, query, id), &story)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get story: %w", err)
 	}
@@ -33,15 +60,98 @@ func (db *DB) GetStoryByID(ctx context.Context, id string) (*Story, error) {
 
 // ListStories retrieves stories with pagination and optional filters
 func (db *DB) ListStories(ctx context.Context, limit, offset int, filters *BrowseFilters, sort *BrowseSort) ([]Story, int, error) {
-	// Build WHERE clause
-	var conditions []string
+	whereClause, args := filterClause(filters)
+	argNum := len(args) + 1
+	orderClause := orderClause(sort)
+
+	total, err := db.CountStories(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Get stories
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		%s
+		%s
+		LIMIT $%d OFFSET $%d
+	`, storyColumns, whereClause, orderClause, argNum, argNum+1)
+
+	args = append(args, limit, offset)
+
+	stories, err := db.queryStories(ctx, query, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return stories, total, nil
+}
+
+// CountStories counts stories matching the filters
+func (db *DB) CountStories(ctx context.Context, filters *BrowseFilters) (int, error) {
+	whereClause, args := filterClause(filters)
+	query := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		%s
+	`, whereClause)
+
+	var total int
+	if err := db.pool.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to count stories: %w", err)
+	}
+	return total, nil
+}
+
+// ListStoriesAfter retrieves the page of stories following after in
+// (air_date, id) order, or the first page if after is nil. Unlike
+// ListStories it seeks instead of skipping rows, so deep pages cost the
+// same as the first. Stories without an air date sort last either way.
+func (db *DB) ListStoriesAfter(ctx context.Context, limit int, after *StoryCursor, filters *BrowseFilters, ascending bool) ([]Story, error) {
+	whereClause, args := filterClause(filters)
+	argNum := len(args) + 1
+
+	direction, cmp, missing := "DESC", "<", "-infinity"
+	if ascending {
+		direction, cmp, missing = "ASC", ">", "infinity"
+	}
+	sortKey := fmt.Sprintf("COALESCE(e.air_date, '%s'::date)", missing)
+
+	if after != nil {
+		seek := fmt.Sprintf("(%s, s.id) %s (COALESCE($%d::date, '%s'::date), $%d::uuid)",
+			sortKey, cmp, argNum, missing, argNum+1)
+		whereClause += " AND " + seek
+		args = append(args, after.AirDate, after.ID)
+		argNum += 2
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		%s
+		ORDER BY %s %s, s.id %s
+		LIMIT $%d
+	`, storyColumns, whereClause, sortKey, direction, direction, argNum)
+
+	args = append(args, limit)
+
+	return db.queryStories(ctx, query, args...)
+}
+
+// filterClause builds the WHERE clause for browse filters, with parameters
+// numbered from $1. Trashed stories are always excluded.
+func filterClause(filters *BrowseFilters) (string, []interface{}) {
+	conditions := []string{"s.deleted_at IS NULL"}
 	var args []interface{}
 	argNum := 1
 
 	if filters != nil {
-		if filters.StoryType != "" {
-			conditions = append(conditions, fmt.Sprintf("s.story_type = $%d", argNum))
-			args = append(args, filters.StoryType)
+		if len(filters.StoryTypes) > 0 {
+			conditions = append(conditions, fmt.Sprintf("s.story_type = ANY($%d)", argNum))
+			args = append(args, filters.StoryTypes)
 			argNum++
 		}
 		if filters.Location != "" {
@@ -49,103 +159,229 @@ func (db *DB) ListStories(ctx context.Context, limit, offset int, filters *Brows
 			args = append(args, "%"+filters.Location+"%")
 			argNum++
 		}
-		if filters.DateFrom != nil {
+		from, to := filters.DateFrom, filters.DateTo
+		if filters.DateRange != "" {
+			// Ranges are validated by ParseDateRange when entered
+			if f, t, err := ResolveDateRange(filters.DateRange, dates.Today()); err == nil {
+				from, to = &f, &t
+			}
+		}
+		if from != nil {
 			conditions = append(conditions, fmt.Sprintf("e.air_date >= $%d", argNum))
-			args = append(args, filters.DateFrom)
+			args = append(args, from)
 			argNum++
 		}
-		if filters.DateTo != nil {
+		if to != nil {
 			conditions = append(conditions, fmt.Sprintf("e.air_date <= $%d", argNum))
-			args = append(args, filters.DateTo)
+			args = append(args, to)
+			argNum++
+		}
+		if filters.MinWords > 0 {
+			conditions = append(conditions, fmt.Sprintf("s.word_count >= $%d", argNum))
+			args = append(args, filters.MinWords)
+			argNum++
+		}
+		if filters.MaxWords > 0 {
+			conditions = append(conditions, fmt.Sprintf("s.word_count <= $%d", argNum))
+			args = append(args, filters.MaxWords)
+			argNum++
+		}
+		if filters.Near != nil && filters.RadiusKm > 0 {
+			conditions = append(conditions, fmt.Sprintf("%s <= $%d",
+				distanceKmSQL(argNum, argNum+1), argNum+2))
+			args = append(args, filters.Near.Lat, filters.Near.Lng, filters.RadiusKm)
+			argNum += 3
+		}
+		if filters.UnreadOnly {
+			conditions = append(conditions, "NOT EXISTS (SELECT 1 FROM story_reads r WHERE r.story_id = s.id)")
+		}
+		if filters.BookmarkedOnly {
+			conditions = append(conditions, "EXISTS (SELECT 1 FROM bookmarks b WHERE b.story_id = s.id)")
+		}
+		if filters.ClusterID != nil {
+			conditions = append(conditions, fmt.Sprintf("s.cluster_id = $%d", argNum))
+			args = append(args, *filters.ClusterID)
+			argNum++
+		}
+		if filters.EpisodeID != "" {
+			conditions = append(conditions, fmt.Sprintf("s.episode_id = $%d", argNum))
+			args = append(args, filters.EpisodeID)
+			argNum++
+		}
+		if filters.EntityName != "" {
+			conditions = append(conditions, fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM story_entities x WHERE x.story_id = s.id AND x.kind = $%d AND lower(x.name) = lower($%d))",
+				argNum, argNum+1))
+			args = append(args, filters.EntityKind, filters.EntityName)
+			argNum += 2
+		}
+		if filters.IDs != nil {
+			conditions = append(conditions, fmt.Sprintf("s.id = ANY($%d::uuid[])", argNum))
+			args = append(args, filters.IDs)
 			argNum++
 		}
 	}
 
-	whereClause := ""
-	if len(conditions) > 0 {
-		whereClause = "WHERE " + strings.Join(conditions, " AND ")
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// orderClause builds the ORDER BY for a browse sort. It ends with the
+// story ID, so ties always come in the same order and OFFSET pages
+// neither repeat nor skip stories.
+func orderClause(sort *BrowseSort) string {
+	return "ORDER BY " + orderKeys(sort) + ", s.id"
+}
+
+// orderKeys lists what a browse sort orders by
+func orderKeys(sort *BrowseSort) string {
+	if sort == nil {
+		return "e.air_date DESC NULLS LAST, s.title"
+	}
+	direction := "DESC"
+	if sort.Ascending {
+		direction = "ASC"
 	}
+	switch sort.Field {
+	case "date":
+		return fmt.Sprintf("e.air_date %s NULLS LAST", direction)
+	case "title":
+		return fmt.Sprintf("s.title %s", direction)
+	case "type":
+		return fmt.Sprintf("s.story_type %s NULLS LAST", direction)
+	case "location":
+		return fmt.Sprintf("s.location %s NULLS LAST", direction)
+	case "show":
+		return fmt.Sprintf("e.podcast_name %s NULLS LAST, e.air_date %s NULLS LAST", direction, direction)
+	case "length":
+		return fmt.Sprintf("s.word_count %s NULLS LAST", direction)
+	case "cluster":
+		return fmt.Sprintf("s.cluster_id %s NULLS LAST", direction)
+	case "episode":
+		return fmt.Sprintf("e.air_date %s NULLS LAST, e.podcast_name, s.episode_id, s.start_time_seconds NULLS LAST", direction)
+	}
+	return "e.air_date DESC NULLS LAST, s.title"
+}
 
-	// Build ORDER BY clause
-	orderClause := "ORDER BY e.air_date DESC NULLS LAST, s.title"
-	if sort != nil {
-		direction := "DESC"
-		if sort.Ascending {
-			direction = "ASC"
-		}
-		switch sort.Field {
-		case "date":
-			orderClause = fmt.Sprintf("ORDER BY e.air_date %s NULLS LAST", direction)
-		case "title":
-			orderClause = fmt.Sprintf("ORDER BY s.title %s", direction)
-		case "type":
-			orderClause = fmt.Sprintf("ORDER BY s.story_type %s NULLS LAST", direction)
+// queryStories runs a query selecting storyColumns and scans every row
+func (db *DB) queryStories(ctx context.Context, query string, args ...interface{}) ([]Story, error) {
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stories: %w", err)
+	}
+	defer rows.Close()
+
+	var stories []Story
+	for rows.Next() {
+		var story Story
+		if err := scanStory(rows, &story); err != nil {
+			return nil, fmt.Errorf("failed to scan story: %w", err)
 		}
+		stories = append(stories, story)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list stories: %w", err)
 	}
 
-	// Get total count
-	countQuery := fmt.Sprintf(`
-		SELECT COUNT(*)
-		FROM stories s
-		LEFT JOIN episodes e ON s.episode_id = e.id
-		%s
-	`, whereClause)
+	return stories, nil
+}
 
-	var total int
-	err := db.pool.QueryRow(ctx, countQuery, args...).Scan(&total)
+// distanceKmSQL returns a great-circle distance expression (in km) between
+// the story's coordinates and the point bound to the given parameters.
+// Plain haversine, so neither PostGIS nor earthdistance is required.
+func distanceKmSQL(latParam, lngParam int) string {
+	return fmt.Sprintf(`(%f * acos(least(1.0,
+		cos(radians($%d)) * cos(radians(s.latitude)) * cos(radians(s.longitude) - radians($%d)) +
+		sin(radians($%d)) * sin(radians(s.latitude)))))`,
+		earthRadiusKm, latParam, lngParam, latParam)
+}
+
+// ResolvePlace finds coordinates for a place name by averaging the
+// geocoded stories whose location matches it
+func (db *DB) ResolvePlace(ctx context.Context, place string) (*GeoPoint, error) {
+	query := `
+		SELECT AVG(latitude), AVG(longitude), COUNT(*)
+		FROM stories
+		WHERE deleted_at IS NULL AND location ILIKE $1 AND latitude IS NOT NULL AND longitude IS NOT NULL
+	`
+
+	var lat, lng *float64
+	var n int
+	err := db.pool.QueryRow(ctx, query, "%"+place+"%").Scan(&lat, &lng, &n)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to count stories: %w", err)
+		return nil, fmt.Errorf("failed to resolve place: %w", err)
+	}
+	if n == 0 || lat == nil || lng == nil {
+		return nil, fmt.Errorf("no geocoded stories match %q", place)
 	}
 
-	// Get stories
-	query := fmt.Sprintf(`
+	return &GeoPoint{Lat: *lat, Lng: *lng}, nil
+}
+
+// GetGeoHotspots summarizes each geographic hotspot, largest first
+func (db *DB) GetGeoHotspots(ctx context.Context) ([]GeoHotspot, error) {
+	query := `
 		SELECT
-			s.id, s.title, s.content, s.summary, s.story_type, s.location,
-			e.air_date, e.podcast_name,
-			s.umap_x, s.umap_y
-		FROM stories s
-		LEFT JOIN episodes e ON s.episode_id = e.id
-		%s
-		%s
-		LIMIT $%d OFFSET $%d
-	`, whereClause, orderClause, argNum, argNum+1)
+			geo_cluster_id, COUNT(*), AVG(latitude), AVG(longitude),
+			MODE() WITHIN GROUP (ORDER BY location)
+		FROM stories
+		WHERE deleted_at IS NULL AND geo_cluster_id IS NOT NULL
+		GROUP BY geo_cluster_id
+		ORDER BY COUNT(*) DESC, geo_cluster_id
+	`
 
-	args = append(args, limit, offset)
+	rows, err := db.analytics().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get hotspots: %w", err)
+	}
+	defer rows.Close()
 
-	rows, err := db.pool.Query(ctx, query, args...)
+	var hotspots []GeoHotspot
+	for rows.Next() {
+		var h GeoHotspot
+		if err := rows.Scan(&h.ID, &h.Count, &h.Center.Lat, &h.Center.Lng, &h.Place); err != nil {
+			return nil, fmt.Errorf("failed to scan hotspot: %w", err)
+		}
+		hotspots = append(hotspots, h)
+	}
+
+	return hotspots, nil
+}
+
+// GetGeoStories returns every story with both coordinates and an air date
+func (db *DB) GetGeoStories(ctx context.Context) ([]GeoStory, error) {
+	query := `
+		SELECT s.id, COALESCE(s.location, ''), s.latitude, s.longitude, e.air_date
+		FROM stories s
+		JOIN episodes e ON s.episode_id = e.id
+		WHERE s.deleted_at IS NULL AND s.latitude IS NOT NULL AND s.longitude IS NOT NULL AND e.air_date IS NOT NULL
+	`
+
+	rows, err := db.analytics().Query(ctx, query)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to list stories: %w", err)
+		return nil, fmt.Errorf("failed to get geocoded stories: %w", err)
 	}
 	defer rows.Close()
 
-	var stories []Story
+	var stories []GeoStory
 	for rows.Next() {
-		var story Story
-		err := rows.Scan(
-			&story.ID, &story.Title, &story.Content, &story.Summary,
-			&story.StoryType, &story.Location, &story.AirDate, &story.ShowName,
-			&story.UmapX, &story.UmapY,
-		)
-		if err != nil {
-			return nil, 0, fmt.Errorf("failed to scan story: %w", err)
+		var g GeoStory
+		if err := rows.Scan(&g.ID, &g.Location, &g.Point.Lat, &g.Point.Lng, &g.AirDate); err != nil {
+			return nil, fmt.Errorf("failed to scan geocoded story: %w", err)
 		}
-		stories = append(stories, story)
+		stories = append(stories, g)
 	}
 
-	return stories, total, nil
+	return stories, nil
 }
 
 // TextSearch performs full-text search
 func (db *DB) TextSearch(ctx context.Context, query string, limit int) ([]Story, error) {
 	sqlQuery := `
-		SELECT
-			s.id, s.title, s.content, s.summary, s.story_type, s.location,
-			e.air_date, e.podcast_name,
-			s.umap_x, s.umap_y,
+		SELECT` + storyColumns + `,
 			ts_rank(s.search_vector, plainto_tsquery('english', $1)) as rank
 		FROM stories s
 		LEFT JOIN episodes e ON s.episode_id = e.id
-		WHERE s.search_vector @@ plainto_tsquery('english', $1)
+		WHERE s.deleted_at IS NULL AND s.search_vector @@ plainto_tsquery('english', $1)
 		ORDER BY rank DESC
 		LIMIT $2
 	`
@@ -159,14 +395,11 @@ func (db *DB) TextSearch(ctx context.Context, query string, limit int) ([]Story,
 	var stories []Story
 	for rows.Next() {
 		var story Story
-		err := rows.Scan(
-			&story.ID, &story.Title, &story.Content, &story.Summary,
-			&story.StoryType, &story.Location, &story.AirDate, &story.ShowName,
-			&story.UmapX, &story.UmapY, &story.Rank,
-		)
+		err := scanStory(rows, &story, &story.Rank)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan story: %w", err)
 		}
+		story.Score = story.Rank
 		stories = append(stories, story)
 	}
 
@@ -176,12 +409,13 @@ func (db *DB) TextSearch(ctx context.Context, query string, limit int) ([]Story,
 // GetUmapPoints retrieves all stories with UMAP coordinates
 func (db *DB) GetUmapPoints(ctx context.Context) ([]UmapPoint, error) {
 	query := `
-		SELECT id, title, COALESCE(story_type, 'other'), cluster_id, umap_x, umap_y
-		FROM stories
-		WHERE umap_x IS NOT NULL AND umap_y IS NOT NULL
+		SELECT s.id, s.title, COALESCE(s.story_type, 'other'), COALESCE(s.location, ''), s.cluster_id, e.air_date, s.word_count, s.umap_x, s.umap_y
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		WHERE s.deleted_at IS NULL AND s.umap_x IS NOT NULL AND s.umap_y IS NOT NULL
 	`
 
-	rows, err := db.pool.Query(ctx, query)
+	rows, err := db.analytics().Query(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get UMAP points: %w", err)
 	}
@@ -190,7 +424,7 @@ func (db *DB) GetUmapPoints(ctx context.Context) ([]UmapPoint, error) {
 	var points []UmapPoint
 	for rows.Next() {
 		var p UmapPoint
-		err := rows.Scan(&p.ID, &p.Title, &p.StoryType, &p.ClusterID, &p.X, &p.Y)
+		err := rows.Scan(&p.ID, &p.Title, &p.StoryType, &p.Location, &p.ClusterID, &p.AirDate, &p.WordCount, &p.X, &p.Y)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan point: %w", err)
 		}
@@ -200,12 +434,69 @@ func (db *DB) GetUmapPoints(ctx context.Context) ([]UmapPoint, error) {
 	return points, nil
 }
 
+// ProjectedPoint is a story's place in a new UMAP projection
+type ProjectedPoint struct {
+	ID string  `json:"id"`
+	X  float64 `json:"x"`
+	Y  float64 `json:"y"`
+}
+
+// SaveProjection replaces the UMAP coordinates with a new projection.
+// Stories left out of it lose theirs, as positions from the old one
+// would be meaningless among the new. The old coordinates are
+// snapshotted, for RestoreSnapshot.
+func (db *DB) SaveProjection(ctx context.Context, points []ProjectedPoint) (int64, error) {
+	ids := make([]string, len(points))
+	xs := make([]float64, len(points))
+	ys := make([]float64, len(points))
+	for i, p := range points {
+		ids[i], xs[i], ys[i] = p.ID, p.X, p.Y
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := takeSnapshot(ctx, tx, "reproject", fmt.Sprintf("%d stories", len(points)), snapshotPart{
+		table:   "stories",
+		columns: []string{"id", "umap_x", "umap_y", "umap_computed_at"},
+		where:   "t.umap_x IS NOT NULL OR t.id::text = ANY($3)",
+		args:    []any{ids},
+	}); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE stories s
+		SET umap_x = p.x, umap_y = p.y, umap_computed_at = NOW()
+		FROM unnest($1::text[], $2::float8[], $3::float8[]) AS p(id, x, y)
+		WHERE s.id = p.id::uuid
+	`, ids, xs, ys)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save projection: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE stories
+		SET umap_x = NULL, umap_y = NULL, umap_computed_at = NULL
+		WHERE umap_x IS NOT NULL AND NOT (id::text = ANY($1))
+	`, ids); err != nil {
+		return 0, fmt.Errorf("failed to clear old coordinates: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit projection: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
 // GetStoryTypes returns all distinct story types in the database
 func (db *DB) GetStoryTypes(ctx context.Context) ([]string, error) {
 	query := `
 		SELECT DISTINCT story_type
 		FROM stories
-		WHERE story_type IS NOT NULL
+		WHERE deleted_at IS NULL AND story_type IS NOT NULL
 		ORDER BY story_type
 	`
 
@@ -227,9 +518,140 @@ func (db *DB) GetStoryTypes(ctx context.Context) ([]string, error) {
 	return types, nil
 }
 
+// GetLocations returns all distinct story locations with counts, most common first
+func (db *DB) GetLocations(ctx context.Context) ([]LocationCount, error) {
+	query := `
+		SELECT location, COUNT(*)
+		FROM stories
+		WHERE deleted_at IS NULL AND location IS NOT NULL AND location <> ''
+		GROUP BY location
+		ORDER BY COUNT(*) DESC, location
+	`
+
+	rows, err := db.analytics().Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locations: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []LocationCount
+	for rows.Next() {
+		var lc LocationCount
+		if err := rows.Scan(&lc.Location, &lc.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan location: %w", err)
+		}
+		locations = append(locations, lc)
+	}
+
+	return locations, nil
+}
+
 // GetStoryCount returns the total number of stories
 func (db *DB) GetStoryCount(ctx context.Context) (int, error) {
 	var count int
-	err := db.pool.QueryRow(ctx, "SELECT COUNT(*) FROM stories").Scan(&count)
+	err := db.pool.QueryRow(ctx, "SELECT COUNT(*) FROM stories WHERE deleted_at IS NULL").Scan(&count)
 	return count, err
 }
+
+// VectorSearch performs embedding similarity search using pgvector
+func (db *DB) VectorSearch(ctx context.Context, embedding []float32, limit int) ([]Story, error) {
+	sqlQuery := `
+		SELECT` + storyColumns + `,
+			1 - (s.embedding <=> $1::vector) as similarity
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		WHERE s.deleted_at IS NULL AND s.embedding IS NOT NULL
+		ORDER BY s.embedding <=> $1::vector
+		LIMIT $2
+	`
+
+	rows, err := db.pool.Query(ctx, sqlQuery, vectorLiteral(embedding), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to vector search: %w", err)
+	}
+	defer rows.Close()
+
+	var stories []Story
+	for rows.Next() {
+		var story Story
+		err := scanStory(rows, &story, &story.Similarity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan story: %w", err)
+		}
+		story.Score = story.Similarity
+		stories = append(stories, story)
+	}
+
+	return stories, nil
+}
+
+// HybridSearch blends text and vector results.
+// Alpha controls the blend: 1.0 = all vector, 0.0 = all text.
+func (db *DB) HybridSearch(ctx context.Context, query string, embedding []float32, limit int, alpha float64) ([]SearchResult, error) {
+	textResults, err := db.TextSearch(ctx, query, limit*2)
+	if err != nil {
+		return nil, err
+	}
+	vectorResults, err := db.VectorSearch(ctx, embedding, limit*2)
+	if err != nil {
+		return nil, err
+	}
+
+	// Normalize each score set by its maximum
+	maxText, maxVector := 0.0, 0.0
+	for _, s := range textResults {
+		maxText = math.Max(maxText, s.Rank)
+	}
+	for _, s := range vectorResults {
+		maxVector = math.Max(maxVector, s.Similarity)
+	}
+
+	combined := make(map[string]*SearchResult)
+	var order []string
+	for _, s := range textResults {
+		r := &SearchResult{Story: s}
+		if maxText > 0 {
+			r.TextScore = s.Rank / maxText
+		}
+		combined[s.ID] = r
+		order = append(order, s.ID)
+	}
+	for _, s := range vectorResults {
+		r, ok := combined[s.ID]
+		if !ok {
+			r = &SearchResult{Story: s}
+			combined[s.ID] = r
+			order = append(order, s.ID)
+		}
+		r.Story.Similarity = s.Similarity
+		if maxVector > 0 {
+			r.VectorScore = s.Similarity / maxVector
+		}
+	}
+
+	results := make([]SearchResult, 0, len(order))
+	for _, id := range order {
+		r := combined[id]
+		r.HybridScore = alpha*r.VectorScore + (1-alpha)*r.TextScore
+		r.Story.Score = r.HybridScore
+		results = append(results, *r)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].HybridScore > results[j].HybridScore
+	})
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// vectorLiteral formats an embedding as a pgvector text literal
+func vectorLiteral(embedding []float32) string {
+	parts := make([]string, len(embedding))
+	for i, v := range embedding {
+		parts[i] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
@@ -0,0 +1,82 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// storyColumnsNoContent is storyColumns with content left empty, for
+// listings that don't show it; content is most of a story's bytes
+var storyColumnsNoContent = strings.Replace(storyColumns, "s.content", "''", 1)
+
+// StreamStories calls fn for each story matching the filters, in sort
+// order, without holding the result set in memory: rows are read off the
+// connection one at a time. A limit of 0 streams every match. Without
+// content, Story.Content is left empty and never leaves the server.
+// Returning an error from fn stops the stream and is returned as is.
+func (db *DB) StreamStories(ctx context.Context, filters *BrowseFilters, sort *BrowseSort, limit int, content bool, fn func(*Story) error) error {
+	whereClause, args := filterClause(filters)
+
+	limitClause := ""
+	if limit > 0 {
+		limitClause = fmt.Sprintf("LIMIT $%d", len(args)+1)
+		args = append(args, limit)
+	}
+
+	columns := storyColumns
+	if !content {
+		columns = storyColumnsNoContent
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		%s
+		%s
+		%s
+	`, columns, whereClause, orderClause(sort), limitClause)
+
+	return db.streamStories(ctx, query, args, fn)
+}
+
+// StreamStoriesByID calls fn for each of the given stories, in the order
+// given. Missing IDs are skipped; trashed stories are included.
+func (db *DB) StreamStoriesByID(ctx context.Context, ids []string, fn func(*Story) error) error {
+	query := `
+		SELECT` + storyColumns + `
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS want(id, n)
+		JOIN stories s ON s.id = want.id
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		ORDER BY want.n
+	`
+
+	return db.streamStories(ctx, query, []interface{}{ids}, fn)
+}
+
+func (db *DB) streamStories(ctx context.Context, query string, args []interface{}, fn func(*Story) error) error {
+	rows, err := db.pool.Query(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to stream stories: %w", err)
+	}
+	defer rows.Close()
+
+	// One Story is reused for every row so memory stays flat however
+	// many rows there are
+	var story Story
+	for rows.Next() {
+		story = Story{}
+		if err := scanStory(rows, &story); err != nil {
+			return fmt.Errorf("failed to scan story: %w", err)
+		}
+		if err := fn(&story); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to stream stories: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package db
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Submission is a first-hand account submitted through the web backend
+type Submission struct {
+	ID          string
+	Submitter   string
+	Title       string
+	Content     string
+	Location    string
+	TimePeriod  string
+	StoryType   string // The submitter's suggestion
+	SubmittedAt time.Time
+}
+
+// Submitter is a community member allowed to submit accounts
+type Submitter struct {
+	Name      string
+	CreatedAt time.Time
+	RevokedAt *time.Time
+	Pending   int
+	Approved  int
+	Rejected  int
+}
+
+// ListPendingSubmissions returns submissions awaiting review, oldest first
+func (db *DB) ListPendingSubmissions(ctx context.Context) ([]Submission, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT s.id::text, u.name, s.title, s.content,
+			COALESCE(s.location, ''), COALESCE(s.time_period, ''), COALESCE(s.story_type, ''),
+			s.submitted_at
+		FROM submissions s
+		JOIN submitters u ON u.id = s.submitter_id
+		WHERE s.status = 'pending'
+		ORDER BY s.submitted_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submissions: %w", err)
+	}
+	defer rows.Close()
+
+	var subs []Submission
+	for rows.Next() {
+		var s Submission
+		if err := rows.Scan(&s.ID, &s.Submitter, &s.Title, &s.Content,
+			&s.Location, &s.TimePeriod, &s.StoryType, &s.SubmittedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan submission: %w", err)
+		}
+		subs = append(subs, s)
+	}
+	return subs, rows.Err()
+}
+
+// ApproveSubmission turns a pending submission into a story of the given
+// type and returns the new story's ID. The submission ID is recorded as an
+// external ID so the story can be traced back to it.
+func (db *DB) ApproveSubmission(ctx context.Context, id, storyType string) (string, error) {
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to begin approval: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var storyID string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO stories (title, content, story_type, location, time_period, is_first_person)
+		SELECT title, content, $2, location, time_period, true
+		FROM submissions
+		WHERE id = $1 AND status = 'pending'
+		RETURNING id::text
+	`, id, storyType).Scan(&storyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", fmt.Errorf("submission %s is not pending", id)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create story: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO external_ids (source_system, external_id, story_id)
+		VALUES ('submission', $1, $2)
+	`, id, storyID); err != nil {
+		return "", fmt.Errorf("failed to record submission ID: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE submissions SET status = 'approved', story_id = $2, reviewed_at = now()
+		WHERE id = $1
+	`, id, storyID); err != nil {
+		return "", fmt.Errorf("failed to mark submission approved: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return "", fmt.Errorf("failed to commit approval: %w", err)
+	}
+	return storyID, nil
+}
+
+// RejectSubmission declines a pending submission, keeping it for the
+// record with the reviewer's note
+func (db *DB) RejectSubmission(ctx context.Context, id, note string) error {
+	tag, err := db.pool.Exec(ctx, `
+		UPDATE submissions SET status = 'rejected', review_note = NULLIF($2, ''), reviewed_at = now()
+		WHERE id = $1 AND status = 'pending'
+	`, id, note)
+	if err != nil {
+		return fmt.Errorf("failed to reject submission: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("submission %s is not pending", id)
+	}
+	return nil
+}
+
+// AddSubmitter registers a community member and returns their token. Only
+// its hash is stored, so the token can't be shown again.
+func (db *DB) AddSubmitter(ctx context.Context, name string) (string, error) {
+	raw := make([]byte, 24)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	token := hex.EncodeToString(raw)
+	hash := sha256.Sum256([]byte(token))
+
+	tag, err := db.pool.Exec(ctx, `
+		INSERT INTO submitters (name, token_sha256) VALUES ($1, $2)
+		ON CONFLICT (name) DO NOTHING
+	`, name, hex.EncodeToString(hash[:]))
+	if err != nil {
+		return "", fmt.Errorf("failed to add submitter: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return "", fmt.Errorf("submitter %q already exists", name)
+	}
+	return token, nil
+}
+
+// RevokeSubmitter stops a submitter's token from being accepted. Their
+// pending submissions stay in the queue.
+func (db *DB) RevokeSubmitter(ctx context.Context, name string) error {
+	tag, err := db.pool.Exec(ctx, `
+		UPDATE submitters SET revoked_at = now()
+		WHERE name = $1 AND revoked_at IS NULL
+	`, name)
+	if err != nil {
+		return fmt.Errorf("failed to revoke submitter: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("no active submitter %q", name)
+	}
+	return nil
+}
+
+// ListSubmitters returns every submitter with counts of their submissions
+func (db *DB) ListSubmitters(ctx context.Context) ([]Submitter, error) {
+	rows, err := db.pool.Query(ctx, `
+		SELECT u.name, u.created_at, u.revoked_at,
+			COUNT(*) FILTER (WHERE s.status = 'pending'),
+			COUNT(*) FILTER (WHERE s.status = 'approved'),
+			COUNT(*) FILTER (WHERE s.status = 'rejected')
+		FROM submitters u
+		LEFT JOIN submissions s ON s.submitter_id = u.id
+		GROUP BY u.id
+		ORDER BY u.name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list submitters: %w", err)
+	}
+	defer rows.Close()
+
+	var submitters []Submitter
+	for rows.Next() {
+		var s Submitter
+		if err := rows.Scan(&s.Name, &s.CreatedAt, &s.RevokedAt, &s.Pending, &s.Approved, &s.Rejected); err != nil {
+			return nil, fmt.Errorf("failed to scan submitter: %w", err)
+		}
+		submitters = append(submitters, s)
+	}
+	return submitters, rows.Err()
+}
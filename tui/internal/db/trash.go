@@ -0,0 +1,178 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// DefaultTrashRetention is how long trashed stories are kept before purging
+const DefaultTrashRetention = 30 * 24 * time.Hour
+
+// ErrNotFound is returned when a story to trash or restore doesn't exist
+var ErrNotFound = errors.New("story not found")
+
+// TrashedStory is a story in the trash
+type TrashedStory struct {
+	Story
+	DeletedAt time.Time
+	Reason    string // "deleted" or "merged into <id>"
+}
+
+// TrashStory moves a story to the trash. It disappears from lists, search
+// and visualizations but can be restored until purged.
+func (db *DB) TrashStory(ctx context.Context, id, reason string) error {
+	tag, err := db.pool.Exec(ctx, `
+		UPDATE stories SET deleted_at = now(), deleted_reason = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, id, reason)
+	if err != nil {
+		return fmt.Errorf("failed to trash story: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// MergeStory trashes a duplicate and points its external IDs at the story
+// it was merged into, so references through either keep working. What it
+// changes is snapshotted first, for RestoreSnapshot.
+func (db *DB) MergeStory(ctx context.Context, fromID, intoID string) error {
+	if fromID == intoID {
+		return fmt.Errorf("cannot merge a story into itself")
+	}
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin merge: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var live bool
+	err = tx.QueryRow(ctx, `SELECT deleted_at IS NULL FROM stories WHERE id = $1`, intoID).Scan(&live)
+	if errors.Is(err, pgx.ErrNoRows) || (err == nil && !live) {
+		return fmt.Errorf("merge target %s: %w", intoID, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up merge target: %w", err)
+	}
+
+	if err := takeSnapshot(ctx, tx, "merge", fromID+" into "+intoID,
+		snapshotPart{table: "stories", where: "t.id = $3", args: []any{fromID}},
+		snapshotPart{table: "external_ids", where: "t.story_id = $3", args: []any{fromID}},
+	); err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE stories SET deleted_at = now(), deleted_reason = $2
+		WHERE id = $1 AND deleted_at IS NULL
+	`, fromID, "merged into "+intoID)
+	if err != nil {
+		return fmt.Errorf("failed to trash merged story: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE external_ids SET story_id = $2 WHERE story_id = $1`, fromID, intoID); err != nil {
+		return fmt.Errorf("failed to move external IDs: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit merge: %w", err)
+	}
+	return nil
+}
+
+// RestoreStory takes a story back out of the trash. External IDs moved by
+// a merge stay with the merge target.
+func (db *DB) RestoreStory(ctx context.Context, id string) error {
+	tag, err := db.pool.Exec(ctx, `
+		UPDATE stories SET deleted_at = NULL, deleted_reason = NULL
+		WHERE id = $1 AND deleted_at IS NOT NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to restore story: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListTrash returns trashed stories, most recently trashed first
+func (db *DB) ListTrash(ctx context.Context) ([]TrashedStory, error) {
+	query := `
+		SELECT` + storyColumns + `, s.deleted_at, COALESCE(s.deleted_reason, 'deleted')
+		FROM stories s
+		LEFT JOIN episodes e ON s.episode_id = e.id
+		WHERE s.deleted_at IS NOT NULL
+		ORDER BY s.deleted_at DESC
+	`
+
+	rows, err := db.pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trash: %w", err)
+	}
+	defer rows.Close()
+
+	var trashed []TrashedStory
+	for rows.Next() {
+		var t TrashedStory
+		if err := scanStory(rows, &t.Story, &t.DeletedAt, &t.Reason); err != nil {
+			return nil, fmt.Errorf("failed to scan trashed story: %w", err)
+		}
+		trashed = append(trashed, t)
+	}
+
+	return trashed, nil
+}
+
+// PurgeTrash permanently deletes stories trashed longer than retention ago.
+// They and the rows deleted with them are snapshotted first, for
+// RestoreSnapshot.
+func (db *DB) PurgeTrash(ctx context.Context, retention time.Duration) (int64, error) {
+	const purged = "deleted_at IS NOT NULL AND deleted_at < $3"
+	cutoff := time.Now().Add(-retention)
+
+	tx, err := db.pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin purge: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var n int
+	if err := tx.QueryRow(ctx, `
+		SELECT count(*) FROM stories WHERE deleted_at IS NOT NULL AND deleted_at < $1
+	`, cutoff).Scan(&n); err != nil {
+		return 0, fmt.Errorf("failed to count stories to purge: %w", err)
+	}
+	if n == 0 {
+		return 0, nil
+	}
+	dependents, err := storyDependents(ctx, tx, purged, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	parts := append([]snapshotPart{{table: "stories", where: purged, args: []any{cutoff}}}, dependents...)
+	if err := takeSnapshot(ctx, tx, "purge", fmt.Sprintf("%d stories trashed before %s", n, cutoff.Format(time.DateOnly)), parts...); err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `
+		DELETE FROM stories
+		WHERE deleted_at IS NOT NULL AND deleted_at < $1
+	`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge trash: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit purge: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"context"
+	"fmt"
+)
+
+// WordCountStatus reports how many stories have a stored word count
+// and how many still need the backfill
+func (db *DB) WordCountStatus(ctx context.Context) (counted, missing int64, err error) {
+	err = db.pool.QueryRow(ctx, `
+		SELECT COUNT(word_count), COUNT(*) - COUNT(word_count)
+		FROM stories
+	`).Scan(&counted, &missing)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to count word counts: %w", err)
+	}
+	return counted, missing, nil
+}
+
+// BackfillWordCounts stores word counts for stories that lack one, batch
+// rows at a time, each batch committing on its own. New and edited
+// stories are counted by the stories_word_count trigger.
+func (db *DB) BackfillWordCounts(ctx context.Context, batch int, progress func(done int64)) (int64, error) {
+	var done int64
+	for {
+		tag, err := db.pool.Exec(ctx, `
+			WITH batch AS (
+				SELECT id FROM stories WHERE word_count IS NULL ORDER BY id LIMIT $1
+			)
+			UPDATE stories s SET word_count = story_word_count(s.content)
+			FROM batch
+			WHERE s.id = batch.id
+		`, batch)
+		if err != nil {
+			return done, fmt.Errorf("failed to backfill word counts: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return done, nil
+		}
+		done += tag.RowsAffected()
+		if progress != nil {
+			progress(done)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package embed turns text into vectors for vector search and chaptering.
+// The provider is chosen in the config: Voyage AI (the default) or OpenAI
+// over their APIs, or a local model served by Ollama or a
+// sentence-transformers server, for setups without an API key or a
+// network. Queries only find stories embedded by the same model, and the
+// database stores Dimensions-long vectors, so a provider must be set up to
+// match whatever embedded the corpus.
+package embed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// Dimensions is the length of the vectors stored in the database
+const Dimensions = 1024
+
+const (
+	requestTimeout = 30 * time.Second
+	maxRetries     = 5
+)
+
+// Providers
+const (
+	Voyage               = "voyage"
+	OpenAI               = "openai"
+	Ollama               = "ollama"
+	SentenceTransformers = "sentence-transformers"
+)
+
+// ErrNoAPIKey is returned when the provider's API key is not set
+var ErrNoAPIKey = errors.New("API key environment variable not set")
+
+// Embedder turns text into vectors
+type Embedder interface {
+	// Embed returns the embedding for a single query
+	Embed(ctx context.Context, text string) ([]float32, error)
+	// EmbedBatch returns an embedding for each text, in order, from a
+	// single request. Keep batches small; providers limit their inputs.
+	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+var (
+	provider = Voyage
+	model    string
+	url      string
+)
+
+// Configure chooses the provider (empty means voyage), its model (empty
+// means the provider's default), and the URL of a local server (empty
+// means its usual local address)
+func Configure(name, modelName, serverURL string) error {
+	switch name {
+	case "":
+		provider = Voyage
+	case Voyage, OpenAI, Ollama, SentenceTransformers:
+		provider = name
+	default:
+		return fmt.Errorf("unknown embedding provider %q (want voyage, openai, ollama, or sentence-transformers)", name)
+	}
+	model, url = modelName, serverURL
+	return nil
+}
+
+// New creates an embedder for the configured provider
+func New() (Embedder, error) {
+	switch provider {
+	case OpenAI:
+		return newOpenAI(model)
+	case Ollama:
+		return newOllama(model, url), nil
+	case SentenceTransformers:
+		return newSentenceTransformers(url), nil
+	}
+	return newVoyage(model)
+}
+
+// embedOne embeds a single text with e's batch request
+func embedOne(ctx context.Context, e Embedder, text string) ([]float32, error) {
+	embeddings, err := e.EmbedBatch(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	return embeddings[0], nil
+}
+
+// apiKey reads a provider's key from the environment
+func apiKey(variable string) (string, error) {
+	key := os.Getenv(variable)
+	if key == "" {
+		return "", fmt.Errorf("%w: %s", ErrNoAPIKey, variable)
+	}
+	return key, nil
+}
+
+// postJSON sends body to endpoint and decodes the response into result,
+// backing off and retrying while the provider says it's rate limited
+func postJSON(ctx context.Context, client *http.Client, endpoint, bearer string, body, result any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	for attempt := 0; attempt < maxRetries; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("failed to build request: %w", err)
+		}
+		if bearer != "" {
+			req.Header.Set("Authorization", "Bearer "+bearer)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("failed to request embedding: %w", err)
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			resp.Body.Close()
+			// Back off exponentially like the Python scripts do
+			select {
+			case <-time.After(time.Duration(1<<attempt) * time.Second):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			// Local servers explain what's wrong, e.g. a model not pulled
+			detail, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+			resp.Body.Close()
+			if len(bytes.TrimSpace(detail)) > 0 {
+				return fmt.Errorf("embedding request failed: %s: %s", resp.Status, bytes.TrimSpace(detail))
+			}
+			return fmt.Errorf("embedding request failed: %s", resp.Status)
+		}
+		err = json.NewDecoder(resp.Body).Decode(result)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode embedding: %w", err)
+		}
+		return nil
+	}
+
+	return errors.New("max retries exceeded")
+}
+
+// check makes sure there's an embedding for every input, each as long as
+// the database's
+func check(embeddings [][]float32, inputs int) ([][]float32, error) {
+	if len(embeddings) != inputs {
+		return nil, fmt.Errorf("embedding response had %d embeddings for %d inputs", len(embeddings), inputs)
+	}
+	for _, e := range embeddings {
+		if len(e) != Dimensions {
+			return nil, fmt.Errorf("the embedding model returns %d dimensions but the database stores %d; choose a model (or dimensions) to match", len(e), Dimensions)
+		}
+	}
+	return embeddings, nil
+}
@@ -0,0 +1,59 @@
+package embed
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	// mxbai-embed-large is 1024-dimensional, as the database is
+	ollamaModel = "mxbai-embed-large"
+	ollamaURL   = "http://localhost:11434"
+)
+
+// ollamaClient requests embeddings from a local Ollama server
+type ollamaClient struct {
+	url   string
+	model string
+	http  *http.Client
+}
+
+func newOllama(model, url string) *ollamaClient {
+	if model == "" {
+		model = ollamaModel
+	}
+	if url == "" {
+		url = ollamaURL
+	}
+	// Local models can be slow to load on first use
+	return &ollamaClient{
+		url:   strings.TrimSuffix(url, "/"),
+		model: model,
+		http:  &http.Client{Timeout: 4 * requestTimeout},
+	}
+}
+
+type ollamaRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type ollamaResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+// Embed returns the embedding for a single query
+func (c *ollamaClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return embedOne(ctx, c, text)
+}
+
+// EmbedBatch returns an embedding for each text, in order, from a single
+// request
+func (c *ollamaClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	var result ollamaResponse
+	if err := postJSON(ctx, c.http, c.url+"/api/embed", "", ollamaRequest{Model: c.model, Input: texts}, &result); err != nil {
+		return nil, err
+	}
+	return check(result.Embeddings, len(texts))
+}
@@ -0,0 +1,58 @@
+package embed
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const (
+	openAIModel  = "text-embedding-3-large"
+	openAIAPIURL = "https://api.openai.com/v1/embeddings"
+)
+
+// openAIClient requests embeddings from OpenAI
+type openAIClient struct {
+	apiKey string
+	model  string
+	http   *http.Client
+}
+
+// newOpenAI creates an OpenAI client from the OPENAI_API_KEY environment
+// variable
+func newOpenAI(model string) (*openAIClient, error) {
+	apiKey, err := apiKey("OPENAI_API_KEY")
+	if err != nil {
+		return nil, err
+	}
+	if model == "" {
+		model = openAIModel
+	}
+	return &openAIClient{
+		apiKey: apiKey,
+		model:  model,
+		http:   &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// Embed returns the embedding for a single query, retrying on rate limits
+func (c *openAIClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return embedOne(ctx, c, text)
+}
+
+// EmbedBatch returns an embedding for each text, in order, from a single
+// request
+func (c *openAIClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	req := embeddingRequest{Model: c.model, Input: texts}
+	// The text-embedding-3 models can be shortened to fit the database;
+	// older ones are a fixed length and are rejected if asked
+	if strings.HasPrefix(c.model, "text-embedding-3") {
+		req.Dimensions = Dimensions
+	}
+
+	var result embeddingResponse
+	if err := postJSON(ctx, c.http, openAIAPIURL, c.apiKey, req, &result); err != nil {
+		return nil, err
+	}
+	return check(result.embeddings(), len(texts))
+}
@@ -0,0 +1,50 @@
+package embed
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// sentenceURL is where Hugging Face's text-embeddings-inference server
+// usually listens when run locally
+const sentenceURL = "http://localhost:8080"
+
+// sentenceClient requests embeddings from a server running a
+// sentence-transformers model, such as text-embeddings-inference, that
+// takes {"inputs": [...]} at /embed and returns one vector per input. The
+// server decides the model.
+type sentenceClient struct {
+	url  string
+	http *http.Client
+}
+
+func newSentenceTransformers(url string) *sentenceClient {
+	if url == "" {
+		url = sentenceURL
+	}
+	return &sentenceClient{
+		url:  strings.TrimSuffix(url, "/"),
+		http: &http.Client{Timeout: 4 * requestTimeout},
+	}
+}
+
+type sentenceRequest struct {
+	Inputs    []string `json:"inputs"`
+	Normalize bool     `json:"normalize"`
+}
+
+// Embed returns the embedding for a single query
+func (c *sentenceClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return embedOne(ctx, c, text)
+}
+
+// EmbedBatch returns an embedding for each text, in order, from a single
+// request
+func (c *sentenceClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	var result [][]float32
+	if err := postJSON(ctx, c.http, c.url+"/embed", "", sentenceRequest{Inputs: texts, Normalize: true}, &result); err != nil {
+		return nil, err
+	}
+	return check(result, len(texts))
+}
@@ -0,0 +1,82 @@
+package embed
+
+import (
+	"context"
+	"strings"
+)
+
+// Story embedding methods, as recorded in stories.embedding_method
+const (
+	MethodFull       = "full"
+	MethodMeanPooled = "mean_pooled"
+)
+
+// As in load_segments.py: stories shorter than fullTokens are embedded
+// whole, longer ones in chunks of about chunkTokens that are averaged
+const (
+	fullTokens  = 4000
+	chunkTokens = 500
+	chunkBatch  = 16
+)
+
+// EstimateTokens is load_segments.py's rough token count, words × 1.3
+func EstimateTokens(text string) int {
+	return int(float64(len(strings.Fields(text))) * 1.3)
+}
+
+// Story embeds a story's text the way load_segments.py does, returning the
+// embedding and the method recorded with it
+func Story(ctx context.Context, e Embedder, text string) ([]float32, string, error) {
+	if EstimateTokens(text) < fullTokens {
+		embedding, err := embedOne(ctx, e, text)
+		return embedding, MethodFull, err
+	}
+
+	chunks := chunk(text)
+	var embeddings [][]float32
+	for start := 0; start < len(chunks); start += chunkBatch {
+		batch, err := e.EmbedBatch(ctx, chunks[start:min(start+chunkBatch, len(chunks))])
+		if err != nil {
+			return nil, "", err
+		}
+		embeddings = append(embeddings, batch...)
+	}
+	return meanPool(embeddings), MethodMeanPooled, nil
+}
+
+// chunk splits text at paragraphs into pieces of about chunkTokens; a
+// paragraph longer than that is a chunk of its own
+func chunk(text string) []string {
+	var chunks, current []string
+	tokens := 0
+	for _, para := range strings.Split(text, "\n\n") {
+		if para = strings.TrimSpace(para); para == "" {
+			continue
+		}
+		n := EstimateTokens(para)
+		if tokens+n > chunkTokens && len(current) > 0 {
+			chunks = append(chunks, strings.Join(current, "\n\n"))
+			current, tokens = nil, 0
+		}
+		current = append(current, para)
+		tokens += n
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, strings.Join(current, "\n\n"))
+	}
+	return chunks
+}
+
+// meanPool averages embeddings
+func meanPool(embeddings [][]float32) []float32 {
+	pooled := make([]float32, len(embeddings[0]))
+	for _, e := range embeddings {
+		for i, v := range e {
+			pooled[i] += v
+		}
+	}
+	for i := range pooled {
+		pooled[i] /= float32(len(embeddings))
+	}
+	return pooled
+}
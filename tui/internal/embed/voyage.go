@@ -0,0 +1,71 @@
+package embed
+
+import (
+	"context"
+	"net/http"
+)
+
+const (
+	voyageModel  = "voyage-4-large"
+	voyageAPIURL = "https://api.voyageai.com/v1/embeddings"
+)
+
+// voyageClient requests embeddings from Voyage AI
+type voyageClient struct {
+	apiKey string
+	model  string
+	http   *http.Client
+}
+
+// newVoyage creates a Voyage client from the VOYAGE_API_KEY environment
+// variable
+func newVoyage(model string) (*voyageClient, error) {
+	apiKey, err := apiKey("VOYAGE_API_KEY")
+	if err != nil {
+		return nil, err
+	}
+	if model == "" {
+		model = voyageModel
+	}
+	return &voyageClient{
+		apiKey: apiKey,
+		model:  model,
+		http:   &http.Client{Timeout: requestTimeout},
+	}, nil
+}
+
+// embeddingRequest and embeddingResponse are shared by Voyage and OpenAI
+type embeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"` // OpenAI's text-embedding-3 models only
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (r embeddingResponse) embeddings() [][]float32 {
+	embeddings := make([][]float32, len(r.Data))
+	for i, d := range r.Data {
+		embeddings[i] = d.Embedding
+	}
+	return embeddings
+}
+
+// Embed returns the embedding for a single query, retrying on rate limits
+func (c *voyageClient) Embed(ctx context.Context, text string) ([]float32, error) {
+	return embedOne(ctx, c, text)
+}
+
+// EmbedBatch returns an embedding for each text, in order, from a single
+// request. Keep batches well under Voyage's input limits.
+func (c *voyageClient) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	var result embeddingResponse
+	if err := postJSON(ctx, c.http, voyageAPIURL, c.apiKey, embeddingRequest{Model: c.model, Input: texts}, &result); err != nil {
+		return nil, err
+	}
+	return check(result.embeddings(), len(texts))
+}
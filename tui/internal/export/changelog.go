@@ -0,0 +1,91 @@
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// ChangelogWeek is a week of the changelog as exported to JSON
+type ChangelogWeek struct {
+	Week     string         `json:"week"`
+	Added    int            `json:"added"`
+	Approved int            `json:"approved"`
+	Edits    int            `json:"edits"`
+	Fields   map[string]int `json:"fields,omitempty"`
+	Merged   int            `json:"merged"`
+}
+
+// WriteChangelog exports the changelog to a new file in dir, named for
+// the day it was generated, and returns its path
+func WriteChangelog(weeks []db.ChangelogWeek, format, dir string, now time.Time) (string, error) {
+	var data []byte
+	var ext string
+	var err error
+	switch format {
+	case Markdown:
+		data, ext = FormatChangelogMarkdown(weeks, now), ".md"
+	case JSON:
+		data, err = FormatChangelogJSON(weeks, now)
+		ext = ".json"
+	default:
+		return "", fmt.Errorf("unknown export format %q (want markdown or json)", format)
+	}
+	if err != nil {
+		return "", err
+	}
+	return create(dir, "changelog "+now.Format("2006-01-02"), ext, data)
+}
+
+// FormatChangelogMarkdown is the changelog as a Markdown table, a row a
+// week, newest first, with totals below
+func FormatChangelogMarkdown(weeks []db.ChangelogWeek, now time.Time) []byte {
+	var b bytes.Buffer
+	b.WriteString("# Corpus changelog\n\n")
+	fmt.Fprintf(&b, "Generated %s, covering %d weeks.\n\n", now.Format("2006-01-02"), len(weeks))
+	b.WriteString("| Week of | Added | Approved | Corrections | Merged |\n")
+	b.WriteString("|---|---:|---:|---|---:|\n")
+
+	var total db.ChangelogWeek
+	for _, w := range weeks {
+		edits := fmt.Sprint(w.Edits)
+		if w.Edits > 0 {
+			edits += " (" + w.FieldSummary() + ")"
+		}
+		fmt.Fprintf(&b, "| %s | %d | %d | %s | %d |\n", w.Week.Format("2006-01-02"), w.Added, w.Approved, edits, w.Merged)
+		total.Added += w.Added
+		total.Approved += w.Approved
+		total.Edits += w.Edits
+		total.Merged += w.Merged
+	}
+
+	fmt.Fprintf(&b, "\n**Total:** %d stories added, %d submissions approved, %d corrections, %d duplicates merged.\n",
+		total.Added, total.Approved, total.Edits, total.Merged)
+	return b.Bytes()
+}
+
+// FormatChangelogJSON is the changelog as an indented JSON object
+func FormatChangelogJSON(weeks []db.ChangelogWeek, now time.Time) ([]byte, error) {
+	out := struct {
+		Generated string          `json:"generated"`
+		Weeks     []ChangelogWeek `json:"weeks"`
+	}{Generated: now.Format(time.RFC3339), Weeks: []ChangelogWeek{}}
+	for _, w := range weeks {
+		out.Weeks = append(out.Weeks, ChangelogWeek{
+			Week:     w.Week.Format("2006-01-02"),
+			Added:    w.Added,
+			Approved: w.Approved,
+			Edits:    w.Edits,
+			Fields:   w.Fields,
+			Merged:   w.Merged,
+		})
+	}
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode changelog: %w", err)
+	}
+	return append(data, '\n'), nil
+}
@@ -0,0 +1,239 @@
+// Package export writes a single story to a file, as Markdown to read or
+// quote from, or as JSON for other tools: its title and details, summary,
+// and transcript, and the episode it's from. The corpus changelog is
+// exported the same two ways, to publish alongside a shared dataset.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/pack"
+)
+
+// Formats
+const (
+	Markdown = "markdown"
+	JSON     = "json"
+)
+
+// DefaultFileName names exported files unless the config says otherwise
+const DefaultFileName = "{{.Date}} {{.Slug}}"
+
+// Name is what a file name template can use
+type Name struct {
+	ID    string
+	Title string
+	Slug  string // The title in lower case, words joined by hyphens
+	Show  string
+	Date  string // Air date as YYYY-MM-DD, or "undated"
+	Type  string
+}
+
+// Record is a story as exported to JSON
+type Record struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Show       string   `json:"show,omitempty"`
+	AirDate    string   `json:"air_date,omitempty"`
+	StoryType  string   `json:"story_type,omitempty"`
+	Location   string   `json:"location,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	Rating     int      `json:"rating,omitempty"`
+	Words      int      `json:"words"`
+	Summary    string   `json:"summary,omitempty"`
+	Episode    string   `json:"episode,omitempty"`
+	EpisodeURL string   `json:"episode_url,omitempty"`
+	Start      *float64 `json:"start_seconds,omitempty"`
+	Citation   string   `json:"citation"`
+	Content    string   `json:"content"`
+}
+
+// Write exports a story to a new file in dir, named by the template, and
+// returns its path. c is nil if the story has no episode. An existing file
+// is never overwritten; a number is added to the name instead.
+func Write(s *db.Story, c *db.Citation, format, dir, nameTemplate string) (string, error) {
+	var data []byte
+	var ext string
+	var err error
+	switch format {
+	case Markdown:
+		data, ext = FormatMarkdown(s, c), ".md"
+	case JSON:
+		data, err = FormatJSON(s, c)
+		ext = ".json"
+	default:
+		return "", fmt.Errorf("unknown export format %q (want markdown or json)", format)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	base, err := FileName(nameTemplate, s)
+	if err != nil {
+		return "", err
+	}
+	return create(dir, base, ext, data)
+}
+
+// create writes data to a new file in dir named base plus ext, adding a
+// number to the name rather than overwriting a file already there, and
+// returns its path
+func create(dir, base, ext string, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	for n := 1; ; n++ {
+		name := base + ext
+		if n > 1 {
+			name = fmt.Sprintf("%s-%d%s", base, n, ext)
+		}
+		path := filepath.Join(dir, name)
+		f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		return path, nil
+	}
+}
+
+// FileName fills in a file name template for a story, without extension.
+// Anything that would make it a path, or that file systems disallow, is
+// replaced.
+func FileName(nameTemplate string, s *db.Story) (string, error) {
+	if nameTemplate == "" {
+		nameTemplate = DefaultFileName
+	}
+	t, err := template.New("file name").Option("missingkey=error").Parse(nameTemplate)
+	if err != nil {
+		return "", fmt.Errorf("invalid export file name template: %w", err)
+	}
+	name := Name{ID: s.ID, Title: s.Title, Slug: slug(s.Title), Show: s.ShowName.String, Date: "undated", Type: s.StoryType.String}
+	if s.AirDate.Valid {
+		name.Date = s.AirDate.Time.Format("2006-01-02")
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, name); err != nil {
+		return "", fmt.Errorf("invalid export file name template: %w", err)
+	}
+
+	clean := strings.Map(func(r rune) rune {
+		switch {
+		case r < ' ', strings.ContainsRune(`/\:*?"<>|`, r):
+			return '_'
+		}
+		return r
+	}, b.String())
+	clean = strings.Trim(strings.TrimSpace(clean), ".")
+	if clean == "" {
+		clean = s.ID
+	}
+	if r := []rune(clean); len(r) > 150 {
+		clean = string(r[:150])
+	}
+	return clean, nil
+}
+
+// slug is a title in lower case, its words joined by hyphens
+func slug(title string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			b.WriteRune(r)
+			hyphen = false
+		case r == '\'' || r == '’':
+			// "Grandma's" is one word
+		default:
+			hyphen = true
+		}
+	}
+	return b.String()
+}
+
+// FormatMarkdown is a story as a Markdown document: a heading, its details
+// as a list, the summary quoted, then the transcript and where it's from
+func FormatMarkdown(s *db.Story, c *db.Citation) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s\n\n", s.Title)
+	fmt.Fprintf(&b, "- **Show:** %s\n", s.FormattedShow())
+	fmt.Fprintf(&b, "- **Date:** %s\n", s.FormattedDate())
+	fmt.Fprintf(&b, "- **Type:** %s\n", strings.ReplaceAll(s.FormattedType(), "_", " "))
+	fmt.Fprintf(&b, "- **Location:** %s\n", s.FormattedLocation())
+	if s.Latitude.Valid && s.Longitude.Valid {
+		fmt.Fprintf(&b, "- **Coordinates:** %.4f, %.4f\n", s.Latitude.Float64, s.Longitude.Float64)
+	}
+	if s.Rating > 0 {
+		fmt.Fprintf(&b, "- **Rating:** %s\n", strings.Repeat("★", s.Rating))
+	}
+	fmt.Fprintf(&b, "- **Words:** %d\n", s.WordCount())
+	if c != nil && c.SourceURL != "" {
+		fmt.Fprintf(&b, "- **Episode:** [%s](%s)\n", c.EpisodeTitle, c.SourceURL)
+	}
+	fmt.Fprintf(&b, "- **ID:** `%s`\n", s.ID)
+
+	if summary := strings.TrimSpace(s.Summary.String); summary != "" {
+		b.WriteString("\n## Summary\n\n")
+		for _, line := range strings.Split(summary, "\n") {
+			fmt.Fprintf(&b, "> %s\n", line)
+		}
+	}
+
+	b.WriteString("\n## Transcript\n\n")
+	b.WriteString(strings.TrimSpace(s.Content))
+	fmt.Fprintf(&b, "\n\n---\n\n%s\n", pack.Citation(s, c))
+	return b.Bytes()
+}
+
+// FormatJSON is a story as an indented JSON object
+func FormatJSON(s *db.Story, c *db.Citation) ([]byte, error) {
+	r := Record{
+		ID:        s.ID,
+		Title:     s.Title,
+		Show:      s.ShowName.String,
+		StoryType: s.StoryType.String,
+		Location:  s.Location.String,
+		Rating:    s.Rating,
+		Words:     s.WordCount(),
+		Summary:   s.Summary.String,
+		Citation:  pack.Citation(s, c),
+		Content:   s.Content,
+	}
+	if s.AirDate.Valid {
+		r.AirDate = s.AirDate.Time.Format("2006-01-02")
+	}
+	if s.Latitude.Valid && s.Longitude.Valid {
+		r.Latitude, r.Longitude = &s.Latitude.Float64, &s.Longitude.Float64
+	}
+	if c != nil {
+		r.Episode, r.EpisodeURL, r.Start = c.EpisodeTitle, c.SourceURL, c.Start
+	}
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode story: %w", err)
+	}
+	return append(data, '\n'), nil
+}
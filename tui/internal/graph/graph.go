@@ -0,0 +1,236 @@
+// Package graph builds a network of stories and what connects them (being
+// alike, sharing a location or a hotspot, belonging to a cluster) and
+// writes it as GraphML or GEXF for network analysis in Gephi and the like.
+package graph
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// Kind is a reason two nodes are joined
+type Kind string
+
+const (
+	Similar  Kind = "similar"  // Nearest neighbors by embedding
+	Location Kind = "location" // The same location text
+	Hotspot  Kind = "hotspot"  // The same geographic hotspot
+	Cluster  Kind = "cluster"  // A story to its semantic cluster's node
+)
+
+// Kinds are the edge kinds, in the order they're documented
+var Kinds = []Kind{Similar, Location, Hotspot, Cluster}
+
+// Node is a story, or a cluster when cluster edges are included
+type Node struct {
+	ID       string
+	Label    string
+	Kind     string // "story" or "cluster"
+	Type     string
+	Location string
+	Cluster  *int
+	Hotspot  *int
+	AirDate  string
+	Rating   int
+	Words    *int
+}
+
+// Edge joins two nodes, weighted by the sum of what joins them
+type Edge struct {
+	Source, Target string
+	Weight         float64
+	Kinds          []Kind
+}
+
+// Graph is the nodes and undirected edges to write
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Options chooses the edges: each kind in Weights is included, its edges
+// weighted by its value (similar edges by that times the similarity).
+// Locations and hotspots shared by more than MaxShared stories are too
+// broad to say much ("Texas", a city's hotspot) and would swamp the graph
+// with edges, so they're left out.
+type Options struct {
+	Weights   map[Kind]float64
+	MaxShared int
+}
+
+// ParseWeights reads a comma-separated list of edge kinds, each with an
+// optional weight ("similar,location=0.5"); a kind without one weighs 1
+func ParseWeights(spec string) (map[Kind]float64, error) {
+	weights := make(map[Kind]float64)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, hasWeight := strings.Cut(part, "=")
+		kind := Kind(strings.TrimSpace(name))
+		if !known(kind) {
+			return nil, fmt.Errorf("unknown edge kind %q (want %s)", kind, kindList())
+		}
+		weight := 1.0
+		if hasWeight {
+			w, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || w <= 0 {
+				return nil, fmt.Errorf("invalid weight for %s: %q", kind, value)
+			}
+			weight = w
+		}
+		weights[kind] = weight
+	}
+	if len(weights) == 0 {
+		return nil, fmt.Errorf("no edge kinds given (want %s)", kindList())
+	}
+	return weights, nil
+}
+
+func known(kind Kind) bool {
+	for _, k := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func kindList() string {
+	names := make([]string, len(Kinds))
+	for i, k := range Kinds {
+		names[i] = string(k)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Build makes the graph of stories, joined by similar (the pairs found by
+// db.SimilarPairs) and by whatever else opts includes
+func Build(stories []db.Story, similar []db.SimilarPair, opts Options) Graph {
+	var g Graph
+	edges := make(map[[2]string]*Edge)
+	var order [][2]string
+	join := func(a, b string, kind Kind, weight float64) {
+		if a == b {
+			return
+		}
+		key := [2]string{a, b}
+		if b < a {
+			key = [2]string{b, a}
+		}
+		e, ok := edges[key]
+		if !ok {
+			e = &Edge{Source: key[0], Target: key[1]}
+			edges[key] = e
+			order = append(order, key)
+		}
+		for _, k := range e.Kinds {
+			if k == kind {
+				// Found from both ends; count it once
+				return
+			}
+		}
+		e.Kinds = append(e.Kinds, kind)
+		e.Weight += weight
+	}
+
+	byLocation := make(map[string][]string)
+	byHotspot := make(map[int][]string)
+	clusters := make(map[int]bool)
+	for _, s := range stories {
+		n := Node{
+			ID:       s.ID,
+			Label:    s.Title,
+			Kind:     "story",
+			Type:     s.StoryType.String,
+			Location: s.Location.String,
+			Cluster:  s.ClusterID,
+			Hotspot:  s.GeoClusterID,
+			Rating:   s.Rating,
+		}
+		if s.AirDate.Valid {
+			// ISO, whatever the display format, so Gephi can read it
+			n.AirDate = s.AirDate.Time.Format("2006-01-02")
+		}
+		if s.Words.Valid {
+			words := int(s.Words.Int32)
+			n.Words = &words
+		}
+		g.Nodes = append(g.Nodes, n)
+
+		if place := strings.ToLower(strings.TrimSpace(s.Location.String)); place != "" {
+			byLocation[place] = append(byLocation[place], s.ID)
+		}
+		if s.GeoClusterID != nil {
+			byHotspot[*s.GeoClusterID] = append(byHotspot[*s.GeoClusterID], s.ID)
+		}
+		if s.ClusterID != nil {
+			clusters[*s.ClusterID] = true
+		}
+	}
+
+	if weight, ok := opts.Weights[Similar]; ok {
+		for _, p := range similar {
+			join(p.From, p.To, Similar, weight*p.Similarity)
+		}
+	}
+	if weight, ok := opts.Weights[Location]; ok {
+		for _, place := range sortedKeys(byLocation) {
+			joinAll(byLocation[place], opts.MaxShared, func(a, b string) { join(a, b, Location, weight) })
+		}
+	}
+	if weight, ok := opts.Weights[Hotspot]; ok {
+		for _, id := range sortedKeys(byHotspot) {
+			joinAll(byHotspot[id], opts.MaxShared, func(a, b string) { join(a, b, Hotspot, weight) })
+		}
+	}
+	if weight, ok := opts.Weights[Cluster]; ok {
+		// A node per cluster rather than edges between all its stories,
+		// which for a big cluster would be most of the file
+		for _, id := range sortedKeys(clusters) {
+			id := id
+			g.Nodes = append(g.Nodes, Node{ID: clusterNode(id), Label: fmt.Sprintf("cluster %d", id), Kind: "cluster", Cluster: &id})
+		}
+		for _, s := range stories {
+			if s.ClusterID != nil {
+				join(s.ID, clusterNode(*s.ClusterID), Cluster, weight)
+			}
+		}
+	}
+
+	for _, key := range order {
+		g.Edges = append(g.Edges, *edges[key])
+	}
+	return g
+}
+
+// joinAll joins every pair of ids, unless there are more than max of them
+func joinAll(ids []string, max int, join func(a, b string)) {
+	if max > 0 && len(ids) > max {
+		return
+	}
+	for i := range ids {
+		for j := i + 1; j < len(ids); j++ {
+			join(ids[i], ids[j])
+		}
+	}
+}
+
+func clusterNode(id int) string {
+	return fmt.Sprintf("cluster-%d", id)
+}
+
+// sortedKeys keeps the output the same from run to run
+func sortedKeys[K int | string, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,146 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// attribute is a value written for every node or edge that has one
+type attribute[T any] struct {
+	name  string
+	kind  string // GraphML's type; GEXF's is the same but "integer"
+	value func(T) (string, bool)
+}
+
+func optionalInt(v *int) (string, bool) {
+	if v == nil {
+		return "", false
+	}
+	return strconv.Itoa(*v), true
+}
+
+func nonEmpty(s string) (string, bool) {
+	return s, s != ""
+}
+
+var nodeAttributes = []attribute[Node]{
+	{"kind", "string", func(n Node) (string, bool) { return n.Kind, true }},
+	{"type", "string", func(n Node) (string, bool) { return nonEmpty(n.Type) }},
+	{"location", "string", func(n Node) (string, bool) { return nonEmpty(n.Location) }},
+	{"cluster", "int", func(n Node) (string, bool) { return optionalInt(n.Cluster) }},
+	{"hotspot", "int", func(n Node) (string, bool) { return optionalInt(n.Hotspot) }},
+	{"air_date", "string", func(n Node) (string, bool) { return nonEmpty(n.AirDate) }},
+	{"rating", "int", func(n Node) (string, bool) { return strconv.Itoa(n.Rating), n.Rating > 0 }},
+	{"words", "int", func(n Node) (string, bool) { return optionalInt(n.Words) }},
+}
+
+var edgeAttributes = []attribute[Edge]{
+	{"kinds", "string", func(e Edge) (string, bool) {
+		kinds := make([]string, len(e.Kinds))
+		for i, k := range e.Kinds {
+			kinds[i] = string(k)
+		}
+		return strings.Join(kinds, ","), true
+	}},
+}
+
+func weight(e Edge) string {
+	return strconv.FormatFloat(e.Weight, 'f', -1, 64)
+}
+
+// WriteGraphML writes the graph as GraphML, with the weight as an edge
+// attribute named "weight", which Gephi and networkx both pick up
+func WriteGraphML(w io.Writer, g Graph) error {
+	b := bufio.NewWriter(w)
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	b.WriteString(`  <key id="label" for="node" attr.name="label" attr.type="string"/>` + "\n")
+	for _, a := range nodeAttributes {
+		fmt.Fprintf(b, `  <key id="%s" for="node" attr.name="%s" attr.type="%s"/>`+"\n", a.name, a.name, a.kind)
+	}
+	b.WriteString(`  <key id="weight" for="edge" attr.name="weight" attr.type="double"/>` + "\n")
+	for _, a := range edgeAttributes {
+		fmt.Fprintf(b, `  <key id="%s" for="edge" attr.name="%s" attr.type="%s"/>`+"\n", a.name, a.name, a.kind)
+	}
+
+	b.WriteString(`  <graph id="stories" edgedefault="undirected">` + "\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(b, `    <node id="%s">`+"\n", html.EscapeString(n.ID))
+		fmt.Fprintf(b, `      <data key="label">%s</data>`+"\n", html.EscapeString(n.Label))
+		for _, a := range nodeAttributes {
+			if v, ok := a.value(n); ok {
+				fmt.Fprintf(b, `      <data key="%s">%s</data>`+"\n", a.name, html.EscapeString(v))
+			}
+		}
+		b.WriteString("    </node>\n")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(b, `    <edge source="%s" target="%s">`+"\n", html.EscapeString(e.Source), html.EscapeString(e.Target))
+		fmt.Fprintf(b, `      <data key="weight">%s</data>`+"\n", weight(e))
+		for _, a := range edgeAttributes {
+			if v, ok := a.value(e); ok {
+				fmt.Fprintf(b, `      <data key="%s">%s</data>`+"\n", a.name, html.EscapeString(v))
+			}
+		}
+		b.WriteString("    </edge>\n")
+	}
+	b.WriteString("  </graph>\n</graphml>\n")
+	return b.Flush()
+}
+
+// WriteGEXF writes the graph as GEXF 1.3, Gephi's own format
+func WriteGEXF(w io.Writer, g Graph) error {
+	gexfType := func(kind string) string {
+		if kind == "int" {
+			return "integer"
+		}
+		return kind
+	}
+
+	b := bufio.NewWriter(w)
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<gexf xmlns="http://gexf.net/1.3" version="1.3">` + "\n")
+	b.WriteString(`  <graph mode="static" defaultedgetype="undirected">` + "\n")
+	b.WriteString(`    <attributes class="node">` + "\n")
+	for i, a := range nodeAttributes {
+		fmt.Fprintf(b, `      <attribute id="%d" title="%s" type="%s"/>`+"\n", i, a.name, gexfType(a.kind))
+	}
+	b.WriteString("    </attributes>\n")
+	b.WriteString(`    <attributes class="edge">` + "\n")
+	for i, a := range edgeAttributes {
+		fmt.Fprintf(b, `      <attribute id="%d" title="%s" type="%s"/>`+"\n", i, a.name, gexfType(a.kind))
+	}
+	b.WriteString("    </attributes>\n")
+
+	b.WriteString("    <nodes>\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(b, `      <node id="%s" label="%s">`+"\n", html.EscapeString(n.ID), html.EscapeString(n.Label))
+		b.WriteString("        <attvalues>\n")
+		for i, a := range nodeAttributes {
+			if v, ok := a.value(n); ok {
+				fmt.Fprintf(b, `          <attvalue for="%d" value="%s"/>`+"\n", i, html.EscapeString(v))
+			}
+		}
+		b.WriteString("        </attvalues>\n      </node>\n")
+	}
+	b.WriteString("    </nodes>\n")
+
+	b.WriteString("    <edges>\n")
+	for i, e := range g.Edges {
+		fmt.Fprintf(b, `      <edge id="%d" source="%s" target="%s" weight="%s">`+"\n",
+			i, html.EscapeString(e.Source), html.EscapeString(e.Target), weight(e))
+		b.WriteString("        <attvalues>\n")
+		for j, a := range edgeAttributes {
+			if v, ok := a.value(e); ok {
+				fmt.Fprintf(b, `          <attvalue for="%d" value="%s"/>`+"\n", j, html.EscapeString(v))
+			}
+		}
+		b.WriteString("        </attvalues>\n      </edge>\n")
+	}
+	b.WriteString("    </edges>\n  </graph>\n</gexf>\n")
+	return b.Flush()
+}
@@ -0,0 +1,176 @@
+// Package graphics draws images over the TUI's text, for terminals that
+// speak the Kitty graphics protocol or Sixel. Bubble Tea only writes text,
+// so the image is written after each frame of it, from the writer Bubble
+// Tea is given as its output.
+package graphics
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// Protocol is how the terminal is sent images
+type Protocol int
+
+const (
+	None  Protocol = iota // Text only
+	Kitty                 // Kitty graphics protocol: Kitty, Ghostty, WezTerm
+	Sixel                 // DEC Sixel: foot, mlterm, iTerm2, xterm -ti vt340
+)
+
+func (p Protocol) String() string {
+	return [...]string{"off", "kitty", "sixel"}[p]
+}
+
+// Parse reads the visualize.graphics config value, detecting the terminal
+// from its environment for "auto"
+func Parse(s string, getenv func(string) string) (Protocol, error) {
+	switch s {
+	case "", "auto":
+		return Detect(getenv), nil
+	case "kitty":
+		return Kitty, nil
+	case "sixel":
+		return Sixel, nil
+	case "off":
+		return None, nil
+	}
+	return None, fmt.Errorf("unknown graphics %q (want auto, kitty, sixel, or off)", s)
+}
+
+// Detect guesses the protocol from the environment. Terminals can be asked,
+// but their replies arrive as input, which Bubble Tea would read as keys.
+func Detect(getenv func(string) string) Protocol {
+	term, program := getenv("TERM"), getenv("TERM_PROGRAM")
+	switch {
+	case getenv("TMUX") != "" || strings.HasPrefix(term, "screen"):
+		// Neither is passed through without configuring the multiplexer
+		return None
+	case getenv("KITTY_WINDOW_ID") != "", term == "xterm-kitty", term == "xterm-ghostty",
+		program == "ghostty", program == "WezTerm":
+		return Kitty
+	case strings.HasPrefix(term, "foot"), strings.Contains(term, "mlterm"), strings.Contains(term, "sixel"),
+		program == "iTerm.app":
+		return Sixel
+	}
+	return None
+}
+
+// Frame is an image to draw over a block of cells. Transparent pixels
+// leave the text under them showing.
+type Frame struct {
+	Image      *image.RGBA
+	Col, Row   int // Top-left cell, from 0
+	Cols, Rows int
+}
+
+// Defaults for a cell's size in pixels when the terminal doesn't say
+const (
+	defaultCellWidth  = 8
+	defaultCellHeight = 16
+)
+
+// Screen holds the frame to draw over the text. It is shared by the model,
+// which sets the frame from View, and the output, which draws it from
+// Bubble Tea's renderer goroutine.
+type Screen struct {
+	protocol Protocol
+	tty      *os.File
+
+	mu      sync.Mutex
+	frame   *Frame
+	encoded []byte // The frame's image as sent, re-encoded when it changes
+	sent    bool   // Kitty: the encoded image has been transmitted
+	placed  bool   // Kitty: an image is on screen, to delete when hidden
+}
+
+// NewScreen draws with protocol on the terminal tty, which is asked for
+// its cell size
+func NewScreen(protocol Protocol, tty *os.File) *Screen {
+	return &Screen{protocol: protocol, tty: tty}
+}
+
+// Protocol is how images are drawn; None for a nil Screen
+func (s *Screen) Protocol() Protocol {
+	if s == nil {
+		return None
+	}
+	return s.protocol
+}
+
+// CellSize is a cell's size in pixels, to draw frames at. Kitty scales
+// images to the cells given, so a guess only costs sharpness; Sixel draws
+// pixel for pixel.
+func (s *Screen) CellSize() (width, height int) {
+	if w, h, ok := cellSize(s.tty); ok {
+		return w, h
+	}
+	return defaultCellWidth, defaultCellHeight
+}
+
+// Show sets the frame drawn after each frame of text; nil hides it
+func (s *Screen) Show(f *Frame) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f != nil && s.frame != nil && bytes.Equal(f.Image.Pix, s.frame.Image.Pix) &&
+		f.Image.Rect == s.frame.Image.Rect {
+		// Only moved, if that
+		f.Image = s.frame.Image
+	} else {
+		s.encoded = nil
+		s.sent = false
+	}
+	s.frame = f
+}
+
+// Draw writes the frame over the text just written to w, putting the
+// cursor back where Bubble Tea left it
+func (s *Screen) Draw(w io.Writer) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var b bytes.Buffer
+	switch {
+	case s.frame == nil && s.placed:
+		deleteKitty(&b)
+		s.placed = false
+	case s.frame == nil:
+		return nil
+	case s.protocol == Kitty:
+		if !s.sent {
+			if s.encoded == nil {
+				encoded, err := encodeKitty(s.frame.Image)
+				if err != nil {
+					return err
+				}
+				s.encoded = encoded
+			}
+			b.Write(s.encoded)
+			s.sent = true
+		}
+		// Placed again every frame, as clearing the screen removes it
+		at(&b, s.frame, func() { placeKitty(&b, s.frame) })
+		s.placed = true
+	case s.protocol == Sixel:
+		// Text written over a sixel replaces it, so it's drawn every frame
+		if s.encoded == nil {
+			s.encoded = encodeSixel(s.frame.Image)
+		}
+		at(&b, s.frame, func() { b.Write(s.encoded) })
+	}
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// at moves the cursor to the frame's corner for draw, saving and restoring
+// it around
+func at(b *bytes.Buffer, f *Frame, draw func()) {
+	fmt.Fprintf(b, "\x1b7\x1b[%d;%dH", f.Row+1, f.Col+1)
+	draw()
+	b.WriteString("\x1b8")
+}
@@ -0,0 +1,55 @@
+package graphics
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/png"
+)
+
+// kittyImage is the ID the image is sent under, so each frame replaces the
+// last
+const kittyImage = 1
+
+// kittyChunk is the most base64 a Kitty escape code may carry
+const kittyChunk = 4096
+
+// encodeKitty transmits img as a PNG, without placing it. q=2 keeps the
+// terminal from replying, which would arrive as input.
+func encodeKitty(img *image.RGBA) ([]byte, error) {
+	var data bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := encoder.Encode(&data, img); err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	payload := base64.StdEncoding.EncodeToString(data.Bytes())
+
+	var b bytes.Buffer
+	for first := true; first || payload != ""; first = false {
+		chunk := payload[:min(kittyChunk, len(payload))]
+		payload = payload[len(chunk):]
+		more := 0
+		if payload != "" {
+			more = 1
+		}
+		if first {
+			fmt.Fprintf(&b, "\x1b_Ga=t,f=100,i=%d,q=2,m=%d;%s\x1b\\", kittyImage, more, chunk)
+		} else {
+			fmt.Fprintf(&b, "\x1b_Gm=%d;%s\x1b\\", more, chunk)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// placeKitty shows the image at the cursor, scaled to the frame's cells.
+// z=-1 puts it under the text but over cell backgrounds, so the cursor and
+// any overlay stay on top. C=1 leaves the cursor where it is.
+func placeKitty(b *bytes.Buffer, f *Frame) {
+	fmt.Fprintf(b, "\x1b_Ga=p,i=%d,p=1,c=%d,r=%d,z=-1,C=1,q=2\x1b\\", kittyImage, f.Cols, f.Rows)
+}
+
+// deleteKitty removes the image from the screen and frees it
+func deleteKitty(b *bytes.Buffer) {
+	fmt.Fprintf(b, "\x1b_Ga=d,d=I,i=%d,q=2\x1b\\", kittyImage)
+}
@@ -0,0 +1,121 @@
+package graphics
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+)
+
+// sixelColors is how many color registers a sixel can count on
+const sixelColors = 256
+
+// encodeSixel writes img as a sixel. Pixels less than half opaque aren't
+// drawn, and with the background left alone (P2=1) the text under them
+// shows through. An image with more colors than registers is reduced to
+// 3-3-2 bits of red, green, and blue.
+func encodeSixel(img *image.RGBA) []byte {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	// Each pixel's color register; -1 for transparent
+	index := make([]int, width*height)
+	palette := map[[3]uint8]int{}
+	var colors [][3]uint8
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			i := img.PixOffset(bounds.Min.X+x, bounds.Min.Y+y)
+			if img.Pix[i+3] < 0x80 {
+				index[y*width+x] = -1
+				continue
+			}
+			c := [3]uint8{img.Pix[i], img.Pix[i+1], img.Pix[i+2]}
+			n, ok := palette[c]
+			if !ok {
+				n = len(colors)
+				palette[c] = n
+				colors = append(colors, c)
+			}
+			index[y*width+x] = n
+		}
+	}
+	if len(colors) > sixelColors {
+		colors = make([][3]uint8, sixelColors)
+		for n := range colors {
+			colors[n] = [3]uint8{uint8((n >> 5) * 255 / 7), uint8((n >> 2 & 7) * 255 / 7), uint8((n & 3) * 255 / 3)}
+		}
+		for c := range palette {
+			palette[c] = int(c[0]>>5)<<5 | int(c[1]>>5)<<2 | int(c[2]>>6)
+		}
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				if n := index[y*width+x]; n >= 0 {
+					i := img.PixOffset(bounds.Min.X+x, bounds.Min.Y+y)
+					index[y*width+x] = palette[[3]uint8{img.Pix[i], img.Pix[i+1], img.Pix[i+2]}]
+				}
+			}
+		}
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "\x1bP0;1;0q\"1;1;%d;%d", width, height)
+	for n, c := range colors {
+		fmt.Fprintf(&b, "#%d;2;%d;%d;%d", n, int(c[0])*100/255, int(c[1])*100/255, int(c[2])*100/255)
+	}
+
+	// Six rows at a time, a pass over the band for each color in it
+	bits := make(map[int][]byte)
+	var order []int
+	for top := 0; top < height; top += 6 {
+		clear(bits)
+		order = order[:0]
+		for dy := 0; dy < 6 && top+dy < height; dy++ {
+			for x := 0; x < width; x++ {
+				n := index[(top+dy)*width+x]
+				if n < 0 {
+					continue
+				}
+				row, ok := bits[n]
+				if !ok {
+					row = make([]byte, width)
+					bits[n] = row
+					order = append(order, n)
+				}
+				row[x] |= 1 << dy
+			}
+		}
+		for i, n := range order {
+			if i > 0 {
+				b.WriteByte('$')
+			}
+			fmt.Fprintf(&b, "#%d", n)
+			writeSixels(&b, bits[n])
+		}
+		b.WriteByte('-')
+	}
+	b.WriteString("\x1b\\")
+	return b.Bytes()
+}
+
+// writeSixels writes a band's row for one color, run-length encoded, and
+// leaving off the blank end
+func writeSixels(b *bytes.Buffer, row []byte) {
+	end := len(row)
+	for end > 0 && row[end-1] == 0 {
+		end--
+	}
+	for x := 0; x < end; {
+		run := 1
+		for x+run < end && row[x+run] == row[x] {
+			run++
+		}
+		ch := '?' + row[x]
+		if run > 3 {
+			fmt.Fprintf(b, "!%d%c", run, ch)
+		} else {
+			for i := 0; i < run; i++ {
+				b.WriteByte(ch)
+			}
+		}
+		x += run
+	}
+}
@@ -0,0 +1,10 @@
+//go:build !unix
+
+package graphics
+
+import "os"
+
+// cellSize can't ask the terminal here
+func cellSize(tty *os.File) (width, height int, ok bool) {
+	return 0, 0, false
+}
@@ -0,0 +1,22 @@
+//go:build unix
+
+package graphics
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// cellSize asks the terminal's window size in pixels, which not every
+// terminal reports
+func cellSize(tty *os.File) (width, height int, ok bool) {
+	if tty == nil {
+		return 0, 0, false
+	}
+	ws, err := unix.IoctlGetWinsize(int(tty.Fd()), unix.TIOCGWINSZ)
+	if err != nil || ws.Col == 0 || ws.Row == 0 || ws.Xpixel == 0 || ws.Ypixel == 0 {
+		return 0, 0, false
+	}
+	return int(ws.Xpixel / ws.Col), int(ws.Ypixel / ws.Row), true
+}
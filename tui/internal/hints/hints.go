@@ -0,0 +1,59 @@
+// Package hints holds one-time contextual tips. Each is shown the first
+// time its situation comes up and then never again; what has been shown is
+// remembered in the TUI's state file.
+package hints
+
+import (
+	"slices"
+
+	"paranormal-tui/internal/config"
+)
+
+// Hint IDs, as stored in the state file. Renaming one shows it again.
+const (
+	VisualizeOverlap  = "visualize-overlap"
+	VisualizeClusters = "visualize-clusters"
+	BrowseNoMatches   = "browse-no-matches"
+	SearchNoResults   = "search-no-results"
+	DetailActions     = "detail-actions"
+	CompareModes      = "compare-modes"
+)
+
+var texts = map[string]string{
+	VisualizeOverlap:  "Several stories share this spot: press [ and ] to cycle through them",
+	VisualizeClusters: "c colors stories by cluster; i isolates the selected story's cluster",
+	BrowseNoMatches:   "Nothing matches these filters: c clears them",
+	SearchNoResults:   "No text matches: Tab switches to vector search, which matches by meaning",
+	DetailActions:     "b bookmarks this story, e edits its details",
+	CompareModes:      "m changes the focused side's search mode, ←→ switches side",
+}
+
+// Engine hands out each hint once
+type Engine struct {
+	store *config.Store
+}
+
+// New creates an engine that records shown hints in store
+func New(store *config.Store) *Engine {
+	return &Engine{store: store}
+}
+
+// Take returns a hint's text and marks it shown, or "" if it has been shown
+// before. The caller saves the store.
+func (e *Engine) Take(id string) string {
+	text, ok := texts[id]
+	if !ok {
+		return ""
+	}
+	fresh := false
+	e.store.Update(func(s *config.State) {
+		if !slices.Contains(s.HintsSeen, id) {
+			s.HintsSeen = append(s.HintsSeen, id)
+			fresh = true
+		}
+	})
+	if !fresh {
+		return ""
+	}
+	return text
+}
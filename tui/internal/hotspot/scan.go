@@ -0,0 +1,307 @@
+package hotspot
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// Params tunes the scan
+type Params struct {
+	RadiiKm    []float64 // Candidate cylinder radii
+	WindowDays []int     // Candidate time window lengths
+	MinCount   int       // Ignore cylinders with fewer stories than this
+	MinLLR     float64   // Report only clusters at least this unusual
+	MaxResults int
+}
+
+// DefaultParams are tuned for a corpus of a few thousand stories
+var DefaultParams = Params{
+	RadiiKm:    []float64{50, 150, 400},
+	WindowDays: []int{30, 90, 365},
+	MinCount:   4,
+	MinLLR:     6,
+	MaxResults: 10,
+}
+
+// Flap is a space-time cluster: more stories near Center between From and
+// To than the overall spatial and temporal distributions predict
+type Flap struct {
+	Center   db.GeoPoint
+	Place    string // Location string of the center story
+	RadiusKm float64
+	From     time.Time
+	To       time.Time
+	Count    int
+	Expected float64
+	LLR      float64 // Log-likelihood ratio; higher is more unusual
+	StoryIDs []string
+}
+
+// cache holds the last scan's flaps, since the geocoded stories rarely
+// change between one scan and the next (a reload, another SSH session)
+var cache struct {
+	sync.Mutex
+	key   uint64
+	flaps []Flap
+}
+
+// Scan runs a space-time permutation scan (Kulldorff 2005) over events.
+// Each candidate cylinder is centered on an event's location, with the
+// time window ending on an event's date. Expected counts come from the
+// product of the cylinder's spatial and temporal marginals, so a region
+// that always has many stories, or a month when every region had many,
+// isn't flagged on its own. Overlapping flaps are pruned, keeping the
+// most unusual. The result for the same events and params is cached.
+func Scan(events []db.GeoStory, p Params) []Flap {
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Sorted by date, the stories in each window are a contiguous run
+	// that slides along as the window's end moves later
+	events = slices.Clone(events)
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].AirDate.Equal(events[j].AirDate) {
+			return events[i].AirDate.Before(events[j].AirDate)
+		}
+		return events[i].ID < events[j].ID
+	})
+
+	key := fingerprint(events, p)
+	cache.Lock()
+	defer cache.Unlock()
+	if cache.flaps != nil && cache.key == key {
+		return cache.flaps
+	}
+	flaps := scan(events, p)
+	cache.key, cache.flaps = key, flaps
+	return flaps
+}
+
+// scan is Scan over events sorted by date. Each center costs one pass to
+// measure distances, then one slide along the dates per radius and window.
+func scan(events []db.GeoStory, p Params) []Flap {
+	total := len(events)
+
+	// Candidate centers: one per distinct point
+	type center struct {
+		point db.GeoPoint
+		place string
+	}
+	seen := make(map[db.GeoPoint]bool)
+	var centers []center
+	for _, e := range events {
+		if !seen[e.Point] {
+			seen[e.Point] = true
+			centers = append(centers, center{e.Point, e.Location})
+		}
+	}
+
+	// Candidate window end dates: one per distinct day, in order. Times are
+	// compared as nanoseconds from here on.
+	at := make([]int64, total)
+	var ends []time.Time
+	for i, e := range events {
+		at[i] = e.AirDate.UnixNano()
+		day := e.AirDate.Truncate(24 * time.Hour)
+		if len(ends) == 0 || !ends[len(ends)-1].Equal(day) {
+			ends = append(ends, day)
+		}
+	}
+	endAt := make([]int64, len(ends))
+	for j, end := range ends {
+		endAt[j] = end.UnixNano()
+	}
+	fromAt := make(map[int][]int64, len(p.WindowDays))
+	for _, days := range p.WindowDays {
+		fromAt[days] = make([]int64, len(ends))
+		for j, end := range ends {
+			fromAt[days][j] = end.AddDate(0, 0, -days+1).UnixNano()
+		}
+	}
+
+	// Temporal marginals: events in each window regardless of place
+	all := make([]int, total)
+	for i := range all {
+		all[i] = i
+	}
+	inWindow := make(map[int][][2]int, len(p.WindowDays))
+	for _, days := range p.WindowDays {
+		inWindow[days] = slide(nil, at, all, endAt, fromAt[days])
+	}
+
+	// The LLR is at most count·log(count/expected), so a window can only
+	// reach MinLLR if expected is at most this for its count
+	maxExpected := make([]float64, total+1)
+	for count := 1; count <= total; count++ {
+		maxExpected[count] = math.Inf(1)
+		if p.MinLLR > 0 {
+			maxExpected[count] = float64(count) * math.Exp(-p.MinLLR/float64(count))
+		}
+	}
+
+	maxRadius := 0.0
+	for _, radius := range p.RadiiKm {
+		maxRadius = math.Max(maxRadius, radius)
+	}
+
+	var flaps []Flap
+	dist := make([]float64, total)
+	inCircle := make([]int, 0, total)
+	windows := make([][2]int, len(ends))
+	for _, c := range centers {
+		for i, e := range events {
+			// A degree of latitude is at least 110 km, so most events can
+			// be ruled out without the haversine
+			if math.Abs(e.Point.Lat-c.point.Lat)*110 > maxRadius {
+				dist[i] = math.Inf(1)
+				continue
+			}
+			dist[i] = DistanceKm(c.point, e.Point)
+		}
+
+		for _, radius := range p.RadiiKm {
+			// Spatial marginal: all events in the circle regardless of
+			// time, still in date order
+			inCircle = inCircle[:0]
+			for i, d := range dist {
+				if d <= radius {
+					inCircle = append(inCircle, i)
+				}
+			}
+			if len(inCircle) < p.MinCount {
+				continue
+			}
+
+			for _, days := range p.WindowDays {
+				windows = slide(windows, at, inCircle, endAt, fromAt[days])
+				for j, end := range ends {
+					lo, hi := windows[j][0], windows[j][1]
+					count := hi - lo
+					if count < p.MinCount {
+						continue
+					}
+
+					marginal := inWindow[days][j]
+					expected := float64(len(inCircle)) * float64(marginal[1]-marginal[0]) / float64(total)
+					if expected > maxExpected[count] {
+						continue
+					}
+					llr := logLikelihoodRatio(count, expected, total)
+					if llr < p.MinLLR {
+						continue
+					}
+
+					ids := make([]string, 0, count)
+					for _, i := range inCircle[lo:hi] {
+						ids = append(ids, events[i].ID)
+					}
+					flaps = append(flaps, Flap{
+						Center:   c.point,
+						Place:    c.place,
+						RadiusKm: radius,
+						From:     end.AddDate(0, 0, -days+1),
+						To:       end,
+						Count:    count,
+						Expected: expected,
+						LLR:      llr,
+						StoryIDs: ids,
+					})
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(flaps, func(i, j int) bool { return flaps[i].LLR > flaps[j].LLR })
+	return prune(flaps, p.MaxResults)
+}
+
+// slide finds, for each window (ending at ends[j], starting at froms[j]),
+// the run of indexes whose times at[index] fall in it, as [lo, hi)
+// positions in indexes, reusing windows if it's long enough. Indexes must
+// be in time order, and windows in order of their ends.
+func slide(windows [][2]int, at []int64, indexes []int, ends, froms []int64) [][2]int {
+	if len(windows) < len(ends) {
+		windows = make([][2]int, len(ends))
+	}
+	lo, hi := 0, 0
+	for j, end := range ends {
+		for hi < len(indexes) && at[indexes[hi]] <= end {
+			hi++
+		}
+		for lo < hi && at[indexes[lo]] < froms[j] {
+			lo++
+		}
+		windows[j] = [2]int{lo, hi}
+	}
+	return windows
+}
+
+// fingerprint identifies a scan's input: the params and every event, in
+// date order
+func fingerprint(events []db.GeoStory, p Params) uint64 {
+	h := fnv.New64a()
+	fmt.Fprint(h, p)
+	for _, e := range events {
+		fmt.Fprint(h, e.ID, e.Point.Lat, e.Point.Lng, e.AirDate.Unix())
+	}
+	return h.Sum64()
+}
+
+// logLikelihoodRatio is the Poisson LLR for observing count where expected
+// was predicted, out of total events. Zero unless count exceeds expected.
+func logLikelihoodRatio(count int, expected float64, total int) float64 {
+	c, e, n := float64(count), expected, float64(total)
+	if c <= e || e <= 0 {
+		return 0
+	}
+	llr := c * math.Log(c/e)
+	if n > c {
+		llr += (n - c) * math.Log((n-c)/(n-e))
+	}
+	return llr
+}
+
+// prune drops flaps that share stories with a more unusual flap already kept
+func prune(sorted []Flap, limit int) []Flap {
+	claimed := make(map[string]bool)
+	var kept []Flap
+	for _, f := range sorted {
+		overlaps := false
+		for _, id := range f.StoryIDs {
+			if claimed[id] {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+		for _, id := range f.StoryIDs {
+			claimed[id] = true
+		}
+		kept = append(kept, f)
+		if limit > 0 && len(kept) == limit {
+			break
+		}
+	}
+	return kept
+}
+
+// DistanceKm is the haversine distance between two points
+func DistanceKm(a, b db.GeoPoint) float64 {
+	const earthRadiusKm = 6371.0
+	lat1, lat2 := a.Lat*math.Pi/180, b.Lat*math.Pi/180
+	dLat := lat2 - lat1
+	dLng := (b.Lng - a.Lng) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
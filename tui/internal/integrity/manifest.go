@@ -0,0 +1,102 @@
+package integrity
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// Format identifies the manifest file layout
+const Format = "paranormal-corpus-manifest/1"
+
+// file is a manifest as written for safekeeping outside the database
+type file struct {
+	Format string `json:"format"`
+	*db.CorpusManifest
+}
+
+// Root hashes the entries, one "story_id sha256" line each, in order
+func Root(entries []db.HashEntry) string {
+	h := sha256.New()
+	for _, e := range entries {
+		fmt.Fprintf(h, "%s %s\n", e.StoryID, e.SHA256)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// signed is the message a manifest's signature covers. The entries are
+// covered through the root.
+func signed(m *db.CorpusManifest) []byte {
+	return fmt.Appendf(nil, "%s\n%s\n%d\n%s\n", Format, m.CreatedAt.UTC().Format(time.RFC3339), m.StoryCount, m.Root)
+}
+
+// Sign builds a manifest of entries, which must be sorted by story ID
+func Sign(entries []db.HashEntry, key ed25519.PrivateKey) *db.CorpusManifest {
+	m := &db.CorpusManifest{
+		CreatedAt:  time.Now().UTC().Truncate(time.Second),
+		StoryCount: len(entries),
+		Root:       Root(entries),
+		PublicKey:  hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Entries:    entries,
+	}
+	m.Signature = hex.EncodeToString(ed25519.Sign(key, signed(m)))
+	return m
+}
+
+// Verify checks that a manifest's entries match its root and that the
+// signature is good. With a trusted key it must also be the signer.
+func Verify(m *db.CorpusManifest, trusted ed25519.PublicKey) error {
+	if m.StoryCount != len(m.Entries) {
+		return fmt.Errorf("manifest lists %d stories but claims %d", len(m.Entries), m.StoryCount)
+	}
+	if Root(m.Entries) != m.Root {
+		return fmt.Errorf("manifest entries do not match its root hash")
+	}
+
+	pub, err := hex.DecodeString(m.PublicKey)
+	if err != nil || len(pub) != ed25519.PublicKeySize {
+		return fmt.Errorf("manifest has an invalid public key")
+	}
+	if trusted != nil && !trusted.Equal(ed25519.PublicKey(pub)) {
+		return fmt.Errorf("manifest was signed by %s, not the trusted key", m.PublicKey)
+	}
+	sig, err := hex.DecodeString(m.Signature)
+	if err != nil || !ed25519.Verify(pub, signed(m), sig) {
+		return fmt.Errorf("manifest signature is invalid")
+	}
+	return nil
+}
+
+// Save writes a manifest to path
+func Save(path string, m *db.CorpusManifest) error {
+	data, err := json.MarshalIndent(file{Format: Format, CorpusManifest: m}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	return nil
+}
+
+// Load reads a manifest written by Save
+func Load(path string) (*db.CorpusManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	f := file{CorpusManifest: &db.CorpusManifest{}}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if f.Format != Format {
+		return nil, fmt.Errorf("unsupported manifest format %q", f.Format)
+	}
+	return f.CorpusManifest, nil
+}
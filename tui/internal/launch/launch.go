@@ -0,0 +1,152 @@
+// Package launch hands links, audio, and copied text to programs outside
+// the TUI. The programs are started in the background with no terminal, so
+// they never draw over the TUI.
+package launch
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// URL opens a link in the default browser
+func URL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return start(cmd)
+}
+
+// Audio plays an audio file from seconds in, with mpv or else ffplay
+func Audio(path string, seconds float64) error {
+	cmd, err := playerCommand("", path, seconds, 0)
+	if err != nil {
+		return err
+	}
+	return start(cmd)
+}
+
+// clipboards are the programs Copy tries, in order, with their arguments
+var clipboards = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+	{"clip.exe"},
+}
+
+// Copy puts text on the system clipboard, with whichever clipboard program
+// is installed
+func Copy(text string) error {
+	for _, args := range clipboards {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("failed to copy with %s: %w", args[0], err)
+		}
+		return nil
+	}
+	return errors.New("no clipboard program found (install wl-copy, xclip, or xsel)")
+}
+
+// Player is audio playing in the background, which can be paused
+type Player struct {
+	cmd  *exec.Cmd
+	done chan struct{}
+}
+
+// Play plays an audio file from start seconds in, stopping at end if it's
+// later. player is "mpv" or "ffplay", or a path to either; "" uses
+// whichever is installed, mpv first.
+func Play(player, path string, start, end float64) (*Player, error) {
+	cmd, err := playerCommand(player, path, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", cmd.Path, err)
+	}
+	p := &Player{cmd: cmd, done: make(chan struct{})}
+	go func() {
+		cmd.Wait()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+// Wait blocks until the audio ends or is stopped
+func (p *Player) Wait() {
+	<-p.done
+}
+
+// Pause holds the audio where it is
+func (p *Player) Pause() error {
+	return pause(p.cmd.Process)
+}
+
+// Resume carries on from where the audio was paused
+func (p *Player) Resume() error {
+	return resume(p.cmd.Process)
+}
+
+// Stop ends the audio, paused or not
+func (p *Player) Stop() {
+	_ = p.cmd.Process.Kill()
+}
+
+// playerCommand is the command playing path from start seconds in to end,
+// if end is later, with the player named (see Play)
+func playerCommand(player, path string, start, end float64) (*exec.Cmd, error) {
+	if player == "" {
+		player = "ffplay"
+		if _, err := exec.LookPath("mpv"); err == nil {
+			player = "mpv"
+		}
+	}
+	bin, err := exec.LookPath(player)
+	if err != nil {
+		if player == "ffplay" {
+			return nil, errors.New("no audio player found (install mpv or ffplay)")
+		}
+		return nil, fmt.Errorf("audio player %s not found", player)
+	}
+
+	from := strconv.FormatFloat(start, 'f', 0, 64)
+	switch strings.TrimSuffix(filepath.Base(player), ".exe") {
+	case "mpv":
+		args := []string{"--no-terminal", "--no-video", "--start=" + from}
+		if end > start {
+			args = append(args, "--end="+strconv.FormatFloat(end, 'f', 0, 64))
+		}
+		return exec.Command(bin, append(args, path)...), nil
+	case "ffplay":
+		args := []string{"-nodisp", "-autoexit", "-loglevel", "quiet", "-ss", from}
+		if end > start {
+			args = append(args, "-t", strconv.FormatFloat(end-start, 'f', 0, 64))
+		}
+		return exec.Command(bin, append(args, path)...), nil
+	}
+	return nil, fmt.Errorf("unknown audio player %s (want mpv or ffplay)", player)
+}
+
+// start runs cmd detached from the terminal and reaps it when it exits
+func start(cmd *exec.Cmd) error {
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start %s: %w", cmd.Path, err)
+	}
+	go cmd.Wait()
+	return nil
+}
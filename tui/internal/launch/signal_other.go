@@ -0,0 +1,18 @@
+//go:build !unix
+
+package launch
+
+import (
+	"errors"
+	"os"
+)
+
+// pause can't stop a process here
+func pause(p *os.Process) error {
+	return errors.New("audio can't be paused on this system")
+}
+
+// resume can't continue a process here
+func resume(p *os.Process) error {
+	return errors.New("audio can't be resumed on this system")
+}
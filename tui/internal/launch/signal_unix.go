@@ -0,0 +1,26 @@
+//go:build unix
+
+package launch
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// pause stops the process where it is, which any player survives
+func pause(p *os.Process) error {
+	if err := p.Signal(unix.SIGSTOP); err != nil {
+		return fmt.Errorf("failed to pause audio: %w", err)
+	}
+	return nil
+}
+
+// resume continues a paused process
+func resume(p *os.Process) error {
+	if err := p.Signal(unix.SIGCONT); err != nil {
+		return fmt.Errorf("failed to resume audio: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+// Package notes keeps my story notes in step with a directory of Markdown
+// files, one per story, so they can be written in any editor. Each file
+// names its story in its front matter:
+//
+//	---
+//	story: 3f2c9a4e-...
+//	title: "The hat man in the hallway"
+//	---
+//
+//	What I made of it...
+//
+// A sync carries each side's changes since the last sync over to the
+// other. The text as last synced is recorded in the directory's
+// .notes-sync.json, which is how a side is known to have changed.
+package notes
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// stateFile records each note's file and text as last synced
+const stateFile = ".notes-sync.json"
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// synced is a note as of the last sync
+type synced struct {
+	File string `json:"file"` // Relative to the directory
+	Hash string `json:"hash"` // Of the text
+}
+
+// file is a note file found in the directory
+type file struct {
+	path    string // Relative to the directory
+	storyID string
+	body    string
+}
+
+// Result is what a sync did
+type Result struct {
+	Saved    int      // Files saved as notes
+	Written  int      // Notes written out as files
+	Deleted  int      // Notes deleted with their files
+	Removed  int      // Files removed with their notes
+	Problems []string // Files skipped, and changes on both sides
+}
+
+// Changed reports whether the sync changed anything
+func (r Result) Changed() bool {
+	return r.Saved+r.Written+r.Deleted+r.Removed > 0
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%d saved from files, %d written to files, %d deleted, %d files removed",
+		r.Saved, r.Written, r.Deleted, r.Removed)
+}
+
+// Parse reads a note file, returning its story ID and text. A file
+// without front matter naming a story isn't a note, and ok is false.
+func Parse(data []byte) (storyID, body string, ok bool, err error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	if !strings.HasPrefix(text, "---\n") {
+		return "", "", false, nil
+	}
+	front, rest, found := strings.Cut(text[4:], "\n---")
+	if !found {
+		return "", "", false, nil
+	}
+	for _, line := range strings.Split(front, "\n") {
+		key, value, _ := strings.Cut(line, ":")
+		if strings.TrimSpace(key) == "story" {
+			storyID = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+	if storyID == "" {
+		return "", "", false, nil
+	}
+	if !uuidPattern.MatchString(storyID) {
+		return "", "", false, fmt.Errorf("story %q is not a story ID", storyID)
+	}
+	// The rest of the closing line, then the text
+	_, body, _ = strings.Cut(rest, "\n")
+	return strings.ToLower(storyID), strings.TrimSpace(body), true, nil
+}
+
+// Format writes a note as a file's contents
+func Format(storyID, title, body string) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "---\nstory: %s\ntitle: %s\n---\n\n", storyID, strconv.Quote(title))
+	if body = strings.TrimSpace(body); body != "" {
+		b.WriteString(body)
+		b.WriteString("\n")
+	}
+	return b.Bytes()
+}
+
+// FileName names a new note file after its story
+func FileName(storyID, title string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z' || r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case !dash && b.Len() > 0:
+			b.WriteByte('-')
+			dash = true
+		}
+		if b.Len() >= 60 {
+			break
+		}
+	}
+	slug := strings.Trim(b.String(), "-")
+	if slug == "" {
+		slug = "note"
+	}
+	return slug + "-" + storyID[:8] + ".md"
+}
+
+func hash(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// Sync carries changes between the notes and the files in dir. A note
+// changed on both sides since the last sync keeps the file's text, as
+// that's where notes are written, and is reported.
+func Sync(ctx context.Context, database *db.DB, dir string) (Result, error) {
+	var result Result
+
+	state, err := loadState(dir)
+	if err != nil {
+		return result, err
+	}
+	files, problems, err := scan(dir)
+	if err != nil {
+		return result, err
+	}
+	result.Problems = problems
+
+	stored, err := database.Notes(ctx)
+	if err != nil {
+		return result, err
+	}
+	notes := make(map[string]db.Note, len(stored))
+	for _, n := range stored {
+		n.Body = strings.TrimSpace(n.Body)
+		notes[n.StoryID] = n
+	}
+
+	// Stories named by files that haven't been synced may not exist
+	var unknown []string
+	for id := range files {
+		if _, ok := notes[id]; !ok {
+			unknown = append(unknown, id)
+		}
+	}
+	titles, err := database.StoryTitles(ctx, unknown)
+	if err != nil {
+		return result, err
+	}
+
+	ids := make(map[string]bool)
+	for id := range files {
+		ids[id] = true
+	}
+	for id := range notes {
+		ids[id] = true
+	}
+	for id := range state {
+		ids[id] = true
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	for _, id := range sorted {
+		last, wasSynced := state[id]
+		f, hasFile := files[id]
+		n, hasNote := notes[id]
+		fileChanged := hasFile != wasSynced || hasFile && hash(f.body) != last.Hash
+		noteChanged := hasNote != wasSynced || hasNote && hash(n.Body) != last.Hash
+
+		switch {
+		case !fileChanged && !noteChanged:
+
+		case !hasFile && !hasNote:
+			// Gone from both
+			delete(state, id)
+
+		case hasFile && fileChanged:
+			if _, ok := titles[id]; !ok && !hasNote {
+				result.Problems = append(result.Problems, fmt.Sprintf("%s: no story %s", f.path, id))
+				continue
+			}
+			if !hasNote && f.body == "" {
+				// Started but not yet written
+				continue
+			}
+			if hasNote && n.Body == f.body {
+				state[id] = synced{File: f.path, Hash: hash(f.body)}
+				continue
+			}
+			if hasNote && noteChanged {
+				result.Problems = append(result.Problems, fmt.Sprintf("%s: changed in the file and the database since the last sync; kept the file", f.path))
+			}
+			if err := database.SetNote(ctx, id, f.body); err != nil {
+				return result, err
+			}
+			result.Saved++
+			if f.body == "" {
+				// A blank note isn't kept, so the file starts it afresh
+				delete(state, id)
+			} else {
+				state[id] = synced{File: f.path, Hash: hash(f.body)}
+			}
+
+		case !hasFile && !noteChanged:
+			// The file was deleted
+			if err := database.SetNote(ctx, id, ""); err != nil {
+				return result, err
+			}
+			result.Deleted++
+			delete(state, id)
+
+		case hasNote:
+			// Written in the database, or the file was deleted after
+			path := last.File
+			if hasFile {
+				path = f.path
+			}
+			if path == "" || !hasFile && exists(filepath.Join(dir, path)) {
+				path = FileName(id, n.Title)
+			}
+			if err := os.WriteFile(filepath.Join(dir, path), Format(id, n.Title, n.Body), 0o644); err != nil {
+				return result, fmt.Errorf("failed to write note: %w", err)
+			}
+			result.Written++
+			state[id] = synced{File: path, Hash: hash(n.Body)}
+
+		default:
+			// Deleted in the database, and the file is as last synced
+			if err := os.Remove(filepath.Join(dir, f.path)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return result, fmt.Errorf("failed to remove note: %w", err)
+			}
+			result.Removed++
+			delete(state, id)
+		}
+	}
+
+	return result, saveState(dir, state)
+}
+
+// Start writes an empty note file for each story that has neither a note
+// nor a file yet, ready to be opened in an editor, and returns their paths
+func Start(ctx context.Context, database *db.DB, dir string, ids []string) ([]string, error) {
+	for _, id := range ids {
+		if !uuidPattern.MatchString(id) {
+			return nil, fmt.Errorf("%q is not a story ID", id)
+		}
+	}
+	titles, err := database.StoryTitles(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	files, _, err := scan(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, id := range ids {
+		id = strings.ToLower(id)
+		title, ok := titles[id]
+		if !ok {
+			return paths, fmt.Errorf("no story %s", id)
+		}
+		if f, ok := files[id]; ok {
+			paths = append(paths, filepath.Join(dir, f.path))
+			continue
+		}
+		path := filepath.Join(dir, FileName(id, title))
+		if err := os.WriteFile(path, Format(id, title, ""), 0o644); err != nil {
+			return paths, fmt.Errorf("failed to write note: %w", err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// scan reads the note files under dir, skipping hidden ones. Files that
+// can't be read, or name a story another file already has, are reported.
+func scan(dir string) (map[string]file, []string, error) {
+	files := make(map[string]file)
+	var problems []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != dir && strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(dir, path)
+		data, err := os.ReadFile(path)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", rel, err))
+			return nil
+		}
+		id, body, ok, err := Parse(data)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s: %v", rel, err))
+		case !ok:
+		case files[id].path != "":
+			problems = append(problems, fmt.Sprintf("%s: story %s already has %s", rel, id, files[id].path))
+		default:
+			files[id] = file{path: rel, storyID: id, body: body}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read notes directory: %w", err)
+	}
+	return files, problems, nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func loadState(dir string) (map[string]synced, error) {
+	state := make(map[string]synced)
+	data, err := os.ReadFile(filepath.Join(dir, stateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sync state: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse sync state %s: %w", stateFile, err)
+	}
+	return state, nil
+}
+
+func saveState(dir string, state map[string]synced) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode sync state: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, stateFile), append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write sync state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,199 @@
+// Package pack formats a set of stories as a read-aloud pack for podcast
+// hosts: one script per story with its citation, estimated read time, and
+// content warnings.
+package pack
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+)
+
+// DefaultWPM is a typical pace for reading aloud
+const DefaultWPM = 150
+
+// warnings maps each content warning to the words that trigger it. Matching
+// is on whole words, so the list errs towards flagging: a host would rather
+// skip a warning than be surprised on air.
+var warnings = []struct {
+	label string
+	words []string
+}{
+	{"death", []string{"died", "dead", "death", "corpse", "funeral", "killed", "murder", "murdered"}},
+	{"suicide or self-harm", []string{"suicide", "hanged", "hanging", "overdose", "self-harm", "killed himself", "killed herself"}},
+	{"violence", []string{"attacked", "stabbed", "shot", "gun", "knife", "beaten", "strangled", "blood"}},
+	{"abuse", []string{"abuse", "abused", "abusive", "assault", "assaulted", "molested"}},
+	{"children in peril", []string{"child died", "baby died", "kidnapped", "abducted", "drowned child"}},
+	{"animal harm", []string{"dog died", "cat died", "mutilated", "slaughtered", "dead animal", "dead animals"}},
+	{"illness", []string{"cancer", "hospital", "dying", "terminal", "miscarriage", "stillborn"}},
+	{"drugs or alcohol", []string{"drunk", "drinking", "drugs", "overdosed", "heroin", "meth"}},
+	{"accidents", []string{"crash", "accident", "wreck", "drowned", "fell to"}},
+}
+
+var warningPatterns = func() []*regexp.Regexp {
+	patterns := make([]*regexp.Regexp, len(warnings))
+	for i, w := range warnings {
+		quoted := make([]string, len(w.words))
+		for j, word := range w.words {
+			quoted[j] = regexp.QuoteMeta(word)
+		}
+		patterns[i] = regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`)
+	}
+	return patterns
+}()
+
+// ContentWarnings returns the warnings whose words appear in text
+func ContentWarnings(text string) []string {
+	var found []string
+	for i, p := range warningPatterns {
+		if p.MatchString(text) {
+			found = append(found, warnings[i].label)
+		}
+	}
+	return found
+}
+
+// ReadTime estimates how long words take to read aloud at wpm, rounded
+// up to the minute
+func ReadTime(words, wpm int) time.Duration {
+	if wpm <= 0 {
+		wpm = DefaultWPM
+	}
+	minutes := (words + wpm - 1) / wpm
+	return time.Duration(max(minutes, 1)) * time.Minute
+}
+
+// Entry is a story in the pack
+type Entry struct {
+	Story    db.Story
+	Citation *db.Citation // nil if the story has no episode
+	Warnings []string
+	ReadTime time.Duration
+}
+
+// Pack is a themed selection of stories
+type Pack struct {
+	Title   string
+	Theme   string // The query or filters the stories were picked by
+	Entries []Entry
+}
+
+// NewEntry works out a story's read time and content warnings
+func NewEntry(s db.Story, c *db.Citation, wpm int) Entry {
+	return Entry{
+		Story:    s,
+		Citation: c,
+		Warnings: ContentWarnings(s.Title + "\n" + s.Content),
+		ReadTime: ReadTime(s.WordCount(), wpm),
+	}
+}
+
+// Total is the read time of the whole pack
+func (p *Pack) Total() time.Duration {
+	var total time.Duration
+	for _, e := range p.Entries {
+		total += e.ReadTime
+	}
+	return total
+}
+
+// Warnings is every content warning in the pack, alphabetically
+func (p *Pack) Warnings() []string {
+	seen := make(map[string]bool)
+	var all []string
+	for _, e := range p.Entries {
+		for _, w := range e.Warnings {
+			if !seen[w] {
+				seen[w] = true
+				all = append(all, w)
+			}
+		}
+	}
+	sort.Strings(all)
+	return all
+}
+
+// WriteMarkdown writes the pack as a Markdown document: a running order,
+// then each story's script with its citation
+func (p *Pack) WriteMarkdown(w io.Writer) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s\n\n", p.Title)
+	if p.Theme != "" {
+		fmt.Fprintf(&b, "Theme: %s  \n", p.Theme)
+	}
+	fmt.Fprintf(&b, "%d stories, about %s read aloud  \n", len(p.Entries), formatDuration(p.Total()))
+	if all := p.Warnings(); len(all) > 0 {
+		fmt.Fprintf(&b, "Content warnings: %s\n", strings.Join(all, ", "))
+	}
+	b.WriteString("\n## Running order\n\n")
+	for i, e := range p.Entries {
+		fmt.Fprintf(&b, "%d. %s (%s)\n", i+1, e.Story.Title, formatDuration(e.ReadTime))
+	}
+
+	for i, e := range p.Entries {
+		s := e.Story
+		fmt.Fprintf(&b, "\n---\n\n## %d. %s\n\n", i+1, s.Title)
+		fmt.Fprintf(&b, "- Type: %s\n", s.FormattedType())
+		fmt.Fprintf(&b, "- Location: %s\n", s.FormattedLocation())
+		fmt.Fprintf(&b, "- Read time: about %s (%d words)\n", formatDuration(e.ReadTime), s.WordCount())
+		if len(e.Warnings) > 0 {
+			fmt.Fprintf(&b, "- Content warnings: %s\n", strings.Join(e.Warnings, ", "))
+		} else {
+			b.WriteString("- Content warnings: none flagged\n")
+		}
+		fmt.Fprintf(&b, "- Source: %s\n\n", Citation(&s, e.Citation))
+		b.WriteString(s.Content)
+		b.WriteString("\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Citation credits the show and episode a story came from
+func Citation(s *db.Story, c *db.Citation) string {
+	parts := []string{s.FormattedShow()}
+	if c != nil {
+		episode := fmt.Sprintf("%q", c.EpisodeTitle)
+		if c.EpisodeNumber != "" {
+			episode = fmt.Sprintf("episode %s, %s", c.EpisodeNumber, episode)
+		}
+		parts = append(parts, episode)
+	}
+	parts = append(parts, "aired "+s.FormattedDate())
+	if c != nil && c.Start != nil {
+		at := Timestamp(*c.Start)
+		if c.End != nil {
+			at += "–" + Timestamp(*c.End)
+		}
+		parts = append(parts, "at "+at)
+	}
+	if c != nil && c.SourceURL != "" {
+		parts = append(parts, c.SourceURL)
+	}
+	parts = append(parts, "story "+s.ID)
+	return strings.Join(parts, ", ")
+}
+
+// Timestamp formats seconds into an episode as h:mm:ss or m:ss
+func Timestamp(seconds float64) string {
+	t := int(seconds)
+	if t >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", t/3600, t/60%60, t%60)
+	}
+	return fmt.Sprintf("%d:%02d", t/60, t%60)
+}
+
+func formatDuration(d time.Duration) string {
+	minutes := int(d.Minutes())
+	if minutes >= 60 {
+		return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+	}
+	return fmt.Sprintf("%d min", minutes)
+}
@@ -0,0 +1,149 @@
+// Package plotimage draws a scatter plot off-screen, as a PNG or SVG image
+// for write-ups: the points in their colors, a legend, and one selected
+// point ringed and labelled. Both formats share one layout, so they look
+// the same.
+package plotimage
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Plot is what to draw
+type Plot struct {
+	Title    string
+	Subtitle string
+	// Aspect is the plot's height over its width
+	Aspect float64
+
+	Points []Point
+	// Selected, if set, is ringed and labelled with SelectedLabel
+	Selected      *Point
+	SelectedLabel string
+
+	LegendTitle string
+	Legend      []Entry
+}
+
+// Point is placed in the plot from 0 (left, top) to 1 (right, bottom)
+type Point struct {
+	X, Y  float64
+	Color string // "#rrggbb"
+}
+
+// Entry is a row of the legend
+type Entry struct {
+	Color string
+	Label string
+	Count int
+}
+
+// Colors of everything but the points
+const (
+	background = "#1a1a2e"
+	frame      = "#626262"
+	text       = "#fafafa"
+	dimText    = "#a0a0a0"
+	highlight  = "#ff6b6b"
+)
+
+// Sizes, in pixels
+const (
+	plotWidth   = 1200
+	margin      = 24
+	legendWidth = 320
+	pointRadius = 3
+	ringRadius  = 9
+	swatch      = 6  // Legend marker radius
+	lineHeight  = 24 // Legend rows and subtitle
+	titleHeight = 40
+)
+
+// layout is where each part of the image goes
+type layout struct {
+	width, height int
+	plot          rect
+	legend        rect
+}
+
+type rect struct {
+	x, y, w, h int
+}
+
+func newLayout(p Plot) layout {
+	aspect := p.Aspect
+	if aspect <= 0 {
+		aspect = 0.75
+	}
+	plotHeight := int(math.Round(plotWidth * math.Max(0.3, math.Min(1.5, aspect))))
+	top := margin + titleHeight + lineHeight + margin/2
+
+	// The legend can run taller than the plot
+	legendHeight := lineHeight * (len(p.Legend) + 2)
+	height := top + max(plotHeight, legendHeight) + margin
+
+	return layout{
+		width:  margin + plotWidth + margin + legendWidth + margin,
+		height: height,
+		plot:   rect{margin, top, plotWidth, plotHeight},
+		legend: rect{margin + plotWidth + margin, top, legendWidth, legendHeight},
+	}
+}
+
+// at converts a point to pixels
+func (l layout) at(pt Point) (x, y int) {
+	return l.plot.x + int(pt.X*float64(l.plot.w)), l.plot.y + int(pt.Y*float64(l.plot.h))
+}
+
+// Save writes the plot to path, as SVG if it ends in .svg and PNG if it
+// ends in .png
+func Save(path string, p Plot) error {
+	var write func(io.Writer, Plot) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".png":
+		write = WritePNG
+	case ".svg":
+		write = WriteSVG
+	default:
+		return fmt.Errorf("can't tell the format of %s; use a .png or .svg name", filepath.Base(path))
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create image: %w", err)
+	}
+	if err := write(f, p); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write image: %w", err)
+	}
+	return nil
+}
+
+// parseColor reads "#rrggbb", falling back to the text color
+func parseColor(hex string) (r, g, b uint8) {
+	v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if err != nil || len(hex) != 7 {
+		return parseColor(text)
+	}
+	return uint8(v >> 16), uint8(v >> 8), uint8(v)
+}
+
+// fit shortens s to at most n characters
+func fit(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(r[:n])
+	}
+	return string(r[:n-3]) + "..."
+}
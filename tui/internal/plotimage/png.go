@@ -0,0 +1,141 @@
+package plotimage
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+)
+
+// Text sizes in the PNG, as multiples of the 5x7 font
+const (
+	titleScale = 3
+	textScale  = 2
+)
+
+// canvas draws onto an image
+type canvas struct {
+	img *image.RGBA
+}
+
+// WritePNG draws the plot as a PNG
+func WritePNG(w io.Writer, p Plot) error {
+	l := newLayout(p)
+	c := canvas{image.NewRGBA(image.Rect(0, 0, l.width, l.height))}
+	c.fill(rect{0, 0, l.width, l.height}, background)
+
+	c.text(margin, margin, p.Title, text, titleScale)
+	c.text(margin, margin+titleHeight, p.Subtitle, dimText, textScale)
+
+	c.frame(rect{l.plot.x - 1, l.plot.y - 1, l.plot.w + 2, l.plot.h + 2}, frame)
+	for _, pt := range p.Points {
+		x, y := l.at(pt)
+		c.disc(x, y, pointRadius, pt.Color)
+	}
+	if p.Selected != nil {
+		x, y := l.at(*p.Selected)
+		c.ring(x, y, ringRadius, 2, highlight)
+		c.label(l, x, y, p.SelectedLabel)
+	}
+
+	c.text(l.legend.x, l.legend.y, p.LegendTitle, text, textScale)
+	for i, e := range p.Legend {
+		y := l.legend.y + lineHeight*(i+2)
+		c.disc(l.legend.x+swatch, y+glyphHeight*textScale/2, swatch, e.Color)
+		c.text(l.legend.x+3*swatch, y, fit(e.Label, 18), text, textScale)
+		count := strconv.Itoa(e.Count)
+		c.text(l.legend.x+l.legend.w-textWidth(count, textScale), y, count, dimText, textScale)
+	}
+
+	if err := png.Encode(w, c.img); err != nil {
+		return fmt.Errorf("failed to encode PNG: %w", err)
+	}
+	return nil
+}
+
+// label writes the selected point's title beside it, on whichever side
+// keeps it inside the plot
+func (c canvas) label(l layout, x, y int, s string) {
+	s = fit(s, (l.plot.w/2)/(glyphAdvance*textScale))
+	width := textWidth(s, textScale)
+	lx := x + ringRadius + 6
+	if lx+width > l.plot.x+l.plot.w {
+		lx = x - ringRadius - 6 - width
+	}
+	ly := y - glyphHeight*textScale/2
+	ly = max(l.plot.y, min(ly, l.plot.y+l.plot.h-glyphHeight*textScale))
+
+	c.fill(rect{lx - 4, ly - 4, width + 8, glyphHeight*textScale + 8}, background)
+	c.text(lx, ly, s, highlight, textScale)
+}
+
+// rgba reads a "#rrggbb" color
+func rgba(hex string) color.RGBA {
+	r, g, b := parseColor(hex)
+	return color.RGBA{r, g, b, 0xff}
+}
+
+func (c canvas) fill(r rect, hex string) {
+	draw.Draw(c.img, image.Rect(r.x, r.y, r.x+r.w, r.y+r.h), image.NewUniform(rgba(hex)), image.Point{}, draw.Src)
+}
+
+func (c canvas) frame(r rect, hex string) {
+	col := rgba(hex)
+	for x := r.x; x < r.x+r.w; x++ {
+		c.img.SetRGBA(x, r.y, col)
+		c.img.SetRGBA(x, r.y+r.h-1, col)
+	}
+	for y := r.y; y < r.y+r.h; y++ {
+		c.img.SetRGBA(r.x, y, col)
+		c.img.SetRGBA(r.x+r.w-1, y, col)
+	}
+}
+
+// disc fills a circle
+func (c canvas) disc(cx, cy, radius int, hex string) {
+	col := rgba(hex)
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				c.img.SetRGBA(cx+x, cy+y, col)
+			}
+		}
+	}
+}
+
+// ring draws a circle's outline, thickness pixels wide
+func (c canvas) ring(cx, cy, radius, thickness int, hex string) {
+	col := rgba(hex)
+	inner := (radius - thickness) * (radius - thickness)
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if d := x*x + y*y; d <= radius*radius && d > inner {
+				c.img.SetRGBA(cx+x, cy+y, col)
+			}
+		}
+	}
+}
+
+// text draws s with its top-left corner at (x, y)
+func (c canvas) text(x, y int, s, hex string, scale int) {
+	for _, r := range s {
+		g := glyph(r)
+		for col := 0; col < glyphWidth; col++ {
+			for row := 0; row < glyphHeight; row++ {
+				if g[col]&(1<<row) == 0 {
+					continue
+				}
+				c.fill(rect{x + col*scale, y + row*scale, scale, scale}, hex)
+			}
+		}
+		x += glyphAdvance * scale
+	}
+}
+
+// textWidth is how wide s is drawn at scale
+func textWidth(s string, scale int) int {
+	return len([]rune(s)) * glyphAdvance * scale
+}
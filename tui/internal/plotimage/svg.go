@@ -0,0 +1,73 @@
+package plotimage
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"io"
+)
+
+// svgFont sizes the SVG's text to take about the room the PNG's does
+const (
+	svgFont      = "font-family=\"DejaVu Sans Mono, Menlo, Consolas, monospace\""
+	svgTitleSize = 24
+	svgTextSize  = 16
+)
+
+// WriteSVG draws the plot as an SVG
+func WriteSVG(w io.Writer, p Plot) error {
+	l := newLayout(p)
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" %s>`+"\n",
+		l.width, l.height, l.width, l.height, svgFont)
+	fmt.Fprintf(b, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", background)
+
+	svgText(b, margin, margin, p.Title, text, svgTitleSize)
+	svgText(b, margin, margin+titleHeight, p.Subtitle, dimText, svgTextSize)
+
+	fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s"/>`+"\n",
+		l.plot.x, l.plot.y, l.plot.w, l.plot.h, frame)
+	b.WriteString("<g>\n")
+	for _, pt := range p.Points {
+		x, y := l.at(pt)
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n", x, y, pointRadius, html.EscapeString(pt.Color))
+	}
+	b.WriteString("</g>\n")
+
+	if p.Selected != nil {
+		x, y := l.at(*p.Selected)
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="%d" fill="none" stroke="%s" stroke-width="2"/>`+"\n",
+			x, y, ringRadius-1, highlight)
+		label := fit(p.SelectedLabel, (l.plot.w/2)/(glyphAdvance*textScale))
+		anchor, lx := "start", x+ringRadius+6
+		if lx+textWidth(label, textScale) > l.plot.x+l.plot.w {
+			anchor, lx = "end", x-ringRadius-6
+		}
+		fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s" font-size="%d" text-anchor="%s" dominant-baseline="middle" `+
+			`stroke="%s" stroke-width="4" paint-order="stroke">%s</text>`+"\n",
+			lx, y, highlight, svgTextSize, anchor, background, html.EscapeString(label))
+	}
+
+	svgText(b, l.legend.x, l.legend.y, p.LegendTitle, text, svgTextSize)
+	for i, e := range p.Legend {
+		y := l.legend.y + lineHeight*(i+2)
+		fmt.Fprintf(b, `<circle cx="%d" cy="%d" r="%d" fill="%s"/>`+"\n",
+			l.legend.x+swatch, y+glyphHeight*textScale/2, swatch, html.EscapeString(e.Color))
+		svgText(b, l.legend.x+3*swatch, y, fit(e.Label, 18), text, svgTextSize)
+		fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s" font-size="%d" text-anchor="end" dominant-baseline="hanging">%d</text>`+"\n",
+			l.legend.x+l.legend.w, y, dimText, svgTextSize, e.Count)
+	}
+
+	b.WriteString("</svg>\n")
+	if err := b.Flush(); err != nil {
+		return fmt.Errorf("failed to write SVG: %w", err)
+	}
+	return nil
+}
+
+// svgText writes s with its top-left corner at (x, y)
+func svgText(b *bufio.Writer, x, y int, s, color string, size int) {
+	fmt.Fprintf(b, `<text x="%d" y="%d" fill="%s" font-size="%d" dominant-baseline="hanging">%s</text>`+"\n",
+		x, y, color, size, html.EscapeString(s))
+}
@@ -0,0 +1,174 @@
+// Package quota rations expensive work per user when the TUI is shared,
+// so one busy session can't swamp the database or the embedding API for
+// everyone else. Each user gets a bucket per operation that holds up to
+// the operation's limit and refills evenly over its window: a user can
+// spend the whole limit at once, then one more each window/limit.
+package quota
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Op is an operation that draws on a quota
+type Op string
+
+const (
+	VectorSearch Op = "vector search" // Embeds the query with the API, then searches by vector
+	Projection   Op = "projection"    // Reprojects every story with UMAP
+	Clustering   Op = "clustering"    // Reclusters every story with HDBSCAN
+	Summary      Op = "summary"       // Has the model rewrite a story's summary
+)
+
+// Limit is how many times an operation can be started per window. A
+// Count below 1 is no limit.
+type Limit struct {
+	Count  int
+	Window time.Duration
+}
+
+// Limiter holds every user's buckets
+type Limiter struct {
+	limits map[Op]Limit
+	log    *log.Logger
+
+	mu    sync.Mutex
+	users map[string]*User
+}
+
+// New creates a limiter. Exceeded quotas are logged to logger if it isn't
+// nil.
+func New(limits map[Op]Limit, logger *log.Logger) *Limiter {
+	return &Limiter{
+		limits: limits,
+		log:    logger,
+		users:  make(map[string]*User),
+	}
+}
+
+// For is name's quota, shared by all their sessions. Users who haven't
+// started anything for longer than the longest window are forgotten, as
+// their buckets would be full again anyway.
+func (l *Limiter) For(name string) *User {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	idle := time.Duration(0)
+	for _, limit := range l.limits {
+		idle = max(idle, limit.Window)
+	}
+	for n, u := range l.users {
+		u.mu.Lock()
+		stale := n != name && now.Sub(u.lastUsed) > idle
+		u.mu.Unlock()
+		if stale {
+			delete(l.users, n)
+		}
+	}
+
+	u, ok := l.users[name]
+	if !ok {
+		u = &User{limiter: l, name: name, buckets: make(map[Op]*bucket), lastUsed: now}
+		l.users[name] = u
+	}
+	return u
+}
+
+// User is one user's quota. A nil User has no limits, for when the TUI
+// isn't shared.
+type User struct {
+	limiter *Limiter
+	name    string
+
+	mu       sync.Mutex
+	buckets  map[Op]*bucket
+	lastUsed time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// Allow takes one of op from the user's quota, or returns an
+// *ExceededError saying when they can try again
+func (u *User) Allow(op Op) error {
+	if u == nil {
+		return nil
+	}
+	limit, ok := u.limiter.limits[op]
+	if !ok || limit.Count < 1 || limit.Window <= 0 {
+		return nil
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	now := time.Now()
+	u.lastUsed = now
+	b, ok := u.buckets[op]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Count), updated: now}
+		u.buckets[op] = b
+	}
+	perToken := limit.Window / time.Duration(limit.Count)
+	b.tokens = min(float64(limit.Count), b.tokens+float64(now.Sub(b.updated))/float64(perToken))
+	b.updated = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return nil
+	}
+	err := &ExceededError{
+		Op:    op,
+		Limit: limit,
+		Wait:  time.Duration((1 - b.tokens) * float64(perToken)),
+	}
+	if u.limiter.log != nil {
+		u.limiter.log.Printf("%s is over their %s quota", u.name, op)
+	}
+	return err
+}
+
+// ExceededError is returned when a user has used up an operation's quota
+type ExceededError struct {
+	Op    Op
+	Limit Limit
+	Wait  time.Duration // Until one more is allowed
+}
+
+func (e *ExceededError) Error() string {
+	noun := string(e.Op) + "s"
+	if e.Op == VectorSearch {
+		noun = "vector searches"
+	}
+	if e.Limit.Count == 1 {
+		noun = string(e.Op)
+	}
+	return fmt.Sprintf("quota exceeded: %d %s %s; try again in %s", e.Limit.Count, noun, per(e.Limit.Window), wait(e.Wait))
+}
+
+// per words a window, e.g. "an hour" or "every 10m"
+func per(window time.Duration) string {
+	switch window {
+	case time.Minute:
+		return "a minute"
+	case time.Hour:
+		return "an hour"
+	case 24 * time.Hour:
+		return "a day"
+	}
+	return "every " + window.String()
+}
+
+// wait rounds a wait up to what's worth showing: seconds under a minute,
+// minutes after
+func wait(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int((d+time.Second-1)/time.Second))
+	}
+	return fmt.Sprintf("%dm", int((d+time.Minute-1)/time.Minute))
+}
@@ -0,0 +1,133 @@
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/config"
+)
+
+// email mails the story as an HTML attachment, which Send-to-Kindle
+// converts for the device. SMTP's STARTTLS is used when the server offers
+// it, as it must for the password to be sent.
+type email struct {
+	server   string // host:port
+	from, to string
+	username string
+}
+
+func newEmail(cfg config.ReadLater) (*email, error) {
+	if cfg.SMTP == "" || cfg.From == "" || cfg.To == "" {
+		return nil, errors.New("email needs read_later smtp, from, and to")
+	}
+	if _, _, err := net.SplitHostPort(cfg.SMTP); err != nil {
+		return nil, fmt.Errorf("read_later smtp must be host:port: %w", err)
+	}
+	for _, address := range []string{cfg.From, cfg.To} {
+		if _, err := mail.ParseAddress(address); err != nil {
+			return nil, fmt.Errorf("invalid email address %q: %w", address, err)
+		}
+	}
+	username := cfg.Username
+	if username == "" {
+		username = cfg.From
+	}
+	return &email{server: cfg.SMTP, from: cfg.From, to: cfg.To, username: username}, nil
+}
+
+func (e *email) Name() string { return e.to }
+
+func (e *email) Send(ctx context.Context, a Article) error {
+	password, err := secret("SMTP_PASSWORD")
+	if err != nil {
+		return err
+	}
+	message, err := e.message(a)
+	if err != nil {
+		return err
+	}
+
+	// net/smtp takes no context, so a send can't be cut short, only
+	// stopped waiting for
+	host, _, _ := net.SplitHostPort(e.server)
+	auth := smtp.PlainAuth("", e.username, password, host)
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(e.server, auth, e.from, []string{e.to}, message)
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// message is the email: a line saying what's attached, and the story as
+// an HTML file named after its title
+func (e *email) message(a Article) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	text, err := parts.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write email: %w", err)
+	}
+	fmt.Fprintf(text, "%s is attached.\r\n", a.Title)
+
+	attachment, err := parts.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/html; charset=utf-8"},
+		"Content-Transfer-Encoding": {"base64"},
+		"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": fileName(a.Title)})},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write email: %w", err)
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(a.HTML))
+	for len(encoded) > 76 {
+		fmt.Fprintf(attachment, "%s\r\n", encoded[:76])
+		encoded = encoded[76:]
+	}
+	fmt.Fprintf(attachment, "%s\r\n", encoded)
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write email: %w", err)
+	}
+
+	var m bytes.Buffer
+	fmt.Fprintf(&m, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%s\r\n\r\n",
+		e.from, e.to, mime.QEncoding.Encode("utf-8", a.Title), time.Now().Format(time.RFC1123Z), parts.Boundary())
+	m.Write(body.Bytes())
+	return m.Bytes(), nil
+}
+
+// fileName names the attachment after the story, keeping to characters
+// any mail client will pass on
+func fileName(title string) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r == ' ' || r == '_':
+			return '-'
+		}
+		return -1
+	}, title)
+	if name = strings.Trim(name, "-"); name == "" {
+		name = "story"
+	}
+	return name + ".html"
+}
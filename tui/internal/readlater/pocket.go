@@ -0,0 +1,78 @@
+package readlater
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"paranormal-tui/internal/config"
+)
+
+// defaultPocketURL is Pocket's own API
+const defaultPocketURL = "https://getpocket.com"
+
+// pocket saves a link with the story's title. The API has no way to send
+// text, so only stories whose episode has a page can go.
+type pocket struct {
+	server      string
+	consumerKey string
+}
+
+func newPocket(cfg config.ReadLater) (*pocket, error) {
+	if cfg.ClientID == "" {
+		return nil, errors.New("pocket needs read_later client_id, the consumer key")
+	}
+	server := cfg.URL
+	if server == "" {
+		server = defaultPocketURL
+	}
+	return &pocket{server: strings.TrimSuffix(server, "/"), consumerKey: cfg.ClientID}, nil
+}
+
+func (p *pocket) Name() string { return "Pocket" }
+
+func (p *pocket) Send(ctx context.Context, a Article) error {
+	if a.URL == "" {
+		return errors.New("pocket only saves links, and this story's episode has no page")
+	}
+	token, err := secret("POCKET_ACCESS_TOKEN")
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(map[string]string{
+		"url":          a.URL,
+		"title":        a.Title,
+		"consumer_key": p.consumerKey,
+		"access_token": token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.server+"/v3/add", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach pocket: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// Pocket explains in a header rather than the body
+		reason := resp.Header.Get("X-Error")
+		if reason == "" {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+			reason = strings.TrimSpace(string(body))
+		}
+		return fmt.Errorf("pocket returned %s: %s", resp.Status, reason)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Package readlater sends stories to a read-later service, for reading long
+// transcripts away from the terminal: a Wallabag server, which keeps the
+// text, a Pocket-compatible API, which only keeps links, so gets the
+// episode's page, or email, such as a Send-to-Kindle address, with the
+// story attached. Passwords and tokens come from the environment rather
+// than the config file.
+package readlater
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/pack"
+)
+
+// Services
+const (
+	Wallabag = "wallabag"
+	Pocket   = "pocket"
+	Email    = "email"
+)
+
+const requestTimeout = 30 * time.Second
+
+// ErrNotSet is returned when a secret's environment variable is not set
+var ErrNotSet = errors.New("environment variable not set")
+
+// Article is a story as sent
+type Article struct {
+	ID    string // The story's
+	Title string
+	URL   string // The episode's page; empty if it has none
+	HTML  string // A complete document
+}
+
+// Service is somewhere to send articles
+type Service interface {
+	// Name is where articles go, e.g. "Wallabag", for notices
+	Name() string
+	Send(ctx context.Context, a Article) error
+}
+
+// New returns the service the config sets up, or nil if none is
+func New(cfg config.ReadLater) (Service, error) {
+	switch cfg.Service {
+	case "":
+		return nil, nil
+	case Wallabag:
+		return newWallabag(cfg)
+	case Pocket:
+		return newPocket(cfg)
+	case Email:
+		return newEmail(cfg)
+	}
+	return nil, fmt.Errorf("unknown read-later service %q (want wallabag, pocket, or email)", cfg.Service)
+}
+
+// FromStory makes an article of a story: its details, then its text, then
+// where it's from. c is nil if the story has no episode.
+func FromStory(s *db.Story, c *db.Citation) Article {
+	a := Article{ID: s.ID, Title: s.Title}
+	if c != nil {
+		a.URL = c.SourceURL
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n</head>\n<body>\n", html.EscapeString(s.Title))
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<p><em>%s · %s · %s · %s</em></p>\n",
+		html.EscapeString(s.Title), html.EscapeString(s.FormattedShow()), html.EscapeString(s.FormattedDate()),
+		html.EscapeString(strings.ReplaceAll(s.FormattedType(), "_", " ")), html.EscapeString(s.FormattedLocation()))
+	if summary := strings.TrimSpace(s.Summary.String); summary != "" {
+		fmt.Fprintf(&b, "<blockquote>%s</blockquote>\n", html.EscapeString(summary))
+	}
+	for _, line := range strings.Split(s.Content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(line))
+		}
+	}
+	fmt.Fprintf(&b, "<hr>\n<p><small>%s</small></p>\n</body>\n</html>\n", html.EscapeString(pack.Citation(s, c)))
+	a.HTML = b.String()
+	return a
+}
+
+// secret reads a password or token from the environment
+func secret(variable string) (string, error) {
+	value := os.Getenv(variable)
+	if value == "" {
+		return "", fmt.Errorf("%w: %s", ErrNotSet, variable)
+	}
+	return value, nil
+}
+
+// client is shared by the services that send over HTTP
+var client = &http.Client{Timeout: requestTimeout}
@@ -0,0 +1,98 @@
+package readlater
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"paranormal-tui/internal/config"
+)
+
+// wallabag saves the story's text as an entry. Wallabag keeps one entry a
+// URL, and stories share their episode's page, so each story gets a URL
+// of its own, on the reserved .invalid domain so it's never fetched.
+type wallabag struct {
+	server   string
+	clientID string
+	username string
+}
+
+func newWallabag(cfg config.ReadLater) (*wallabag, error) {
+	if cfg.URL == "" || cfg.ClientID == "" || cfg.Username == "" {
+		return nil, errors.New("wallabag needs read_later url, client_id, and username")
+	}
+	return &wallabag{server: strings.TrimSuffix(cfg.URL, "/"), clientID: cfg.ClientID, username: cfg.Username}, nil
+}
+
+func (w *wallabag) Name() string { return "Wallabag" }
+
+func (w *wallabag) Send(ctx context.Context, a Article) error {
+	token, err := w.token(ctx)
+	if err != nil {
+		return err
+	}
+	link := "https://paranormal-tracker.invalid/stories/" + a.ID
+	form := url.Values{"url": {link}, "title": {a.Title}, "content": {a.HTML}}
+	_, err = w.post(ctx, "/api/entries.json", token, form)
+	return err
+}
+
+// token logs in with the password grant, as a client of the API
+func (w *wallabag) token(ctx context.Context) (string, error) {
+	clientSecret, err := secret("WALLABAG_CLIENT_SECRET")
+	if err != nil {
+		return "", err
+	}
+	password, err := secret("WALLABAG_PASSWORD")
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{
+		"grant_type":    {"password"},
+		"client_id":     {w.clientID},
+		"client_secret": {clientSecret},
+		"username":      {w.username},
+		"password":      {password},
+	}
+	body, err := w.post(ctx, "/oauth/v2/token", "", form)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(body, &result); err != nil || result.AccessToken == "" {
+		return "", errors.New("wallabag didn't return an access token")
+	}
+	return result.AccessToken, nil
+}
+
+// post sends a form to the server, returning the response body
+func (w *wallabag) post(ctx context.Context, path, token string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.server+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach wallabag: %w", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read wallabag's response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("wallabag returned %s: %s", resp.Status, strings.TrimSpace(string(body[:min(len(body), 300)])))
+	}
+	return body, nil
+}
@@ -0,0 +1,141 @@
+// Package region reduces a story's free-text location to the US state or
+// the country it's in, so stories can be grouped by where they happened:
+// "Tucson, Arizona", "Northern Arizona", and "Mesa, AZ" are all Arizona,
+// and "Barrie, Ontario, Canada" is Canada.
+package region
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// usStates are the US states, and DC, by lowercase name
+var usStates = map[string]string{
+	"alabama": "Alabama", "alaska": "Alaska", "arizona": "Arizona", "arkansas": "Arkansas",
+	"california": "California", "colorado": "Colorado", "connecticut": "Connecticut",
+	"delaware": "Delaware", "florida": "Florida", "georgia": "Georgia", "hawaii": "Hawaii",
+	"idaho": "Idaho", "illinois": "Illinois", "indiana": "Indiana", "iowa": "Iowa",
+	"kansas": "Kansas", "kentucky": "Kentucky", "louisiana": "Louisiana", "maine": "Maine",
+	"maryland": "Maryland", "massachusetts": "Massachusetts", "michigan": "Michigan",
+	"minnesota": "Minnesota", "mississippi": "Mississippi", "missouri": "Missouri",
+	"montana": "Montana", "nebraska": "Nebraska", "nevada": "Nevada",
+	"new hampshire": "New Hampshire", "new jersey": "New Jersey", "new mexico": "New Mexico",
+	"new york": "New York", "north carolina": "North Carolina", "north dakota": "North Dakota",
+	"ohio": "Ohio", "oklahoma": "Oklahoma", "oregon": "Oregon", "pennsylvania": "Pennsylvania",
+	"rhode island": "Rhode Island", "south carolina": "South Carolina",
+	"south dakota": "South Dakota", "tennessee": "Tennessee", "texas": "Texas", "utah": "Utah",
+	"vermont": "Vermont", "virginia": "Virginia", "washington": "Washington",
+	"west virginia": "West Virginia", "wisconsin": "Wisconsin", "wyoming": "Wyoming",
+	"washington dc": "Washington D.C.", "washington d.c.": "Washington D.C.",
+	"district of columbia": "Washington D.C.",
+}
+
+// abbreviations are the US postal codes, and a few others, matched only
+// as a whole part of the location ("Dallas, TX") since "in", "or", and
+// "me" are words
+var abbreviations = map[string]string{
+	"al": "Alabama", "ak": "Alaska", "az": "Arizona", "ar": "Arkansas", "ca": "California",
+	"co": "Colorado", "ct": "Connecticut", "de": "Delaware", "fl": "Florida", "ga": "Georgia",
+	"hi": "Hawaii", "id": "Idaho", "il": "Illinois", "in": "Indiana", "ia": "Iowa",
+	"ks": "Kansas", "ky": "Kentucky", "la": "Louisiana", "me": "Maine", "md": "Maryland",
+	"ma": "Massachusetts", "mi": "Michigan", "mn": "Minnesota", "ms": "Mississippi",
+	"mo": "Missouri", "mt": "Montana", "ne": "Nebraska", "nv": "Nevada", "nh": "New Hampshire",
+	"nj": "New Jersey", "nm": "New Mexico", "ny": "New York", "nc": "North Carolina",
+	"nd": "North Dakota", "oh": "Ohio", "ok": "Oklahoma", "or": "Oregon", "pa": "Pennsylvania",
+	"ri": "Rhode Island", "sc": "South Carolina", "sd": "South Dakota", "tn": "Tennessee",
+	"tx": "Texas", "ut": "Utah", "vt": "Vermont", "va": "Virginia", "wa": "Washington",
+	"wv": "West Virginia", "wi": "Wisconsin", "wy": "Wyoming", "dc": "Washington D.C.",
+	"d.c.": "Washington D.C.", "uk": "United Kingdom", "usa": "", "us": "",
+}
+
+// elsewhere maps countries, and the provinces, states, and nations that
+// stories name without their country, to the country
+var elsewhere = map[string]string{
+	"canada": "Canada", "canadian": "Canada", "ontario": "Canada", "quebec": "Canada", "british columbia": "Canada",
+	"alberta": "Canada", "manitoba": "Canada", "saskatchewan": "Canada", "nova scotia": "Canada",
+	"new brunswick": "Canada", "newfoundland": "Canada", "yukon": "Canada",
+	"mexico": "Mexico", "chihuahua": "Mexico", "durango": "Mexico", "sonora": "Mexico",
+	"baja california": "Mexico", "jalisco": "Mexico", "yucatan": "Mexico",
+	"australia": "Australia", "queensland": "Australia", "new south wales": "Australia",
+	"victoria": "Australia", "tasmania": "Australia",
+	"new zealand": "New Zealand", "aotearoa": "New Zealand",
+	"united kingdom": "United Kingdom", "england": "United Kingdom", "scotland": "United Kingdom",
+	"wales": "United Kingdom", "northern ireland": "United Kingdom", "great britain": "United Kingdom",
+	"ireland": "Ireland", "denmark": "Denmark", "germany": "Germany", "france": "France",
+	"spain": "Spain", "ibiza": "Spain", "italy": "Italy", "netherlands": "Netherlands",
+	"norway": "Norway", "sweden": "Sweden", "finland": "Finland", "poland": "Poland",
+	"philippines": "Philippines", "japan": "Japan", "india": "India", "brazil": "Brazil",
+	"puerto rico": "Puerto Rico", "south africa": "South Africa",
+}
+
+// cities are places named without their state often enough to be worth
+// knowing, as in the web backend's geocoder
+var cities = map[string]string{
+	"new york city": "New York", "nyc": "New York", "los angeles": "California",
+	"san francisco": "California", "san diego": "California", "san jose": "California",
+	"chicago": "Illinois", "chicagoland": "Illinois", "houston": "Texas", "dallas": "Texas",
+	"austin": "Texas", "san antonio": "Texas", "phoenix": "Arizona", "philadelphia": "Pennsylvania",
+	"pittsburgh": "Pennsylvania", "seattle": "Washington", "denver": "Colorado",
+	"boston": "Massachusetts", "nashville": "Tennessee", "detroit": "Michigan",
+	"las vegas": "Nevada", "memphis": "Tennessee", "atlanta": "Georgia", "miami": "Florida",
+	"new orleans": "Louisiana", "minneapolis": "Minnesota", "salt lake city": "Utah",
+	"st louis": "Missouri", "st. louis": "Missouri",
+}
+
+// name is a place to look for, with the region it means
+type name struct {
+	re     *regexp.Regexp
+	region string
+}
+
+// names are matched as whole words, longest first, so "West Virginia" is
+// found before "Virginia" and "Arkansas" never matches "Kansas"
+var names = func() []name {
+	var all []name
+	for _, m := range []map[string]string{usStates, elsewhere, cities} {
+		for place, region := range m {
+			// Not \b, which wouldn't end "d.c."
+			all = append(all, name{regexp.MustCompile(`(?:^|[^a-z])` + regexp.QuoteMeta(place) + `(?:[^a-z]|$)`), region})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool {
+		a, b := all[i].re.String(), all[j].re.String()
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+	return all
+}()
+
+// Of is the US state or country location is in, or "" if it can't be
+// told. The most general part is trusted most: each comma-separated part
+// is tried from the last, so "Nevada, Missouri" is Missouri. Within a
+// part, the first place named wins.
+func Of(location string) string {
+	parts := strings.Split(strings.ToLower(location), ",")
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := strings.TrimSpace(parts[i])
+		if part == "" {
+			continue
+		}
+		if region, ok := abbreviations[part]; ok {
+			if region != "" {
+				return region
+			}
+			continue
+		}
+
+		best, at := "", len(part)
+		for _, n := range names {
+			if loc := n.re.FindStringIndex(part); loc != nil && loc[0] < at {
+				best, at = n.region, loc[0]
+			}
+		}
+		if best != "" {
+			return best
+		}
+	}
+	return ""
+}
@@ -0,0 +1,308 @@
+// Package serve serves the TUI over SSH, so a community can browse the
+// tracker from their own terminals. Each connection runs its own copy of
+// the app, with its own views, selection, and remembered state, over one
+// shared database pool. Who connects decides what they can do: listed
+// users get the scope configured for their key, and anyone else, if
+// anonymous access is on, can only read. Everyone's vector searches,
+// projections, and clusterings are rationed by the configured quotas.
+// Admins can also list and kick sessions by running a command instead of
+// a shell:
+//
+//	ssh -p 2222 host sessions
+//	ssh -p 2222 host kick 3
+package serve
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"paranormal-tui/internal/app"
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/quota"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Defaults for the serve config
+const (
+	DefaultAddress     = ":2222"
+	DefaultMaxSessions = 32
+
+	DefaultVectorSearches = 10 // A minute
+	DefaultProjections    = 2  // An hour
+	DefaultClusterings    = 6  // An hour
+	DefaultSummaries      = 20 // An hour
+)
+
+// Scope is what a connection is allowed to do
+type Scope string
+
+const (
+	ScopeRead  Scope = "read"  // Browse and search; nothing is changed
+	ScopeWrite Scope = "write" // Everything the TUI can do
+	ScopeAdmin Scope = "admin" // Write, and list and kick sessions
+)
+
+// ParseScope reads a user's scope from the config
+func ParseScope(s string) (Scope, error) {
+	switch s {
+	case "", "read":
+		return ScopeRead, nil
+	case "write":
+		return ScopeWrite, nil
+	case "admin":
+		return ScopeAdmin, nil
+	}
+	return ScopeRead, fmt.Errorf("unknown scope %q (want read, write, or admin)", s)
+}
+
+// Extensions set on a connection's permissions once authenticated
+const (
+	extUser  = "paranormal-user"
+	extScope = "paranormal-scope"
+)
+
+// anonymousUser names connections let in without a listed key
+const anonymousUser = "anonymous"
+
+// user is a configured user with their keys parsed
+type user struct {
+	name  string
+	scope Scope
+	keys  [][]byte // Wire format, for comparing
+}
+
+// Server accepts SSH connections and runs a session for each
+type Server struct {
+	cfg         config.Serve
+	opts        app.Options
+	ssh         *ssh.ServerConfig
+	users       []user
+	maxSessions int
+	sessions    registry
+	quotas      *quota.Limiter
+	log         *log.Logger
+
+	mu    sync.Mutex
+	conns map[*ssh.ServerConn]struct{}
+}
+
+// New checks the serve config and loads (or creates) the host key. opts
+// are the app options every session starts from; the database in them is
+// shared by all sessions.
+func New(cfg config.Serve, opts app.Options, logger *log.Logger) (*Server, error) {
+	s := &Server{
+		cfg:         cfg,
+		opts:        opts,
+		maxSessions: cfg.MaxSessions,
+		quotas:      quota.New(limits(cfg.Quotas), logger),
+		log:         logger,
+		conns:       make(map[*ssh.ServerConn]struct{}),
+	}
+	if s.maxSessions <= 0 {
+		s.maxSessions = DefaultMaxSessions
+	}
+
+	for _, u := range cfg.Users {
+		if u.Name == "" {
+			return nil, errors.New("serve: every user needs a name")
+		}
+		scope, err := ParseScope(u.Scope)
+		if err != nil {
+			return nil, fmt.Errorf("serve: user %s: %w", u.Name, err)
+		}
+		parsed := user{name: u.Name, scope: scope}
+		for _, line := range u.Keys {
+			key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(line))
+			if err != nil {
+				return nil, fmt.Errorf("serve: user %s: failed to parse key: %w", u.Name, err)
+			}
+			parsed.keys = append(parsed.keys, key.Marshal())
+		}
+		s.users = append(s.users, parsed)
+	}
+	if len(s.users) == 0 && !cfg.Anonymous {
+		return nil, errors.New("serve: no users are configured and anonymous access is off, so nobody could connect")
+	}
+
+	signer, err := hostKey(cfg.HostKey)
+	if err != nil {
+		return nil, err
+	}
+	s.ssh = &ssh.ServerConfig{
+		PublicKeyCallback: s.publicKey,
+	}
+	if cfg.Anonymous {
+		// Offered after every key has been turned down, so a listed user
+		// is never let in as anonymous because their first key wasn't
+		// the listed one
+		s.ssh.KeyboardInteractiveCallback = func(ssh.ConnMetadata, ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			return permissions(anonymousUser, ScopeRead), nil
+		}
+	}
+	s.ssh.AddHostKey(signer)
+	return s, nil
+}
+
+// limits reads the configured quotas, filling in the defaults
+func limits(q config.Quotas) map[quota.Op]quota.Limit {
+	count := func(n, def int) int {
+		if n == 0 {
+			return def
+		}
+		return n
+	}
+	return map[quota.Op]quota.Limit{
+		quota.VectorSearch: {Count: count(q.VectorSearches, DefaultVectorSearches), Window: time.Minute},
+		quota.Projection:   {Count: count(q.Projections, DefaultProjections), Window: time.Hour},
+		quota.Clustering:   {Count: count(q.Clusterings, DefaultClusterings), Window: time.Hour},
+		quota.Summary:      {Count: count(q.Summaries, DefaultSummaries), Window: time.Hour},
+	}
+}
+
+func permissions(name string, scope Scope) *ssh.Permissions {
+	return &ssh.Permissions{Extensions: map[string]string{extUser: name, extScope: string(scope)}}
+}
+
+// publicKey lets in the user the key is listed for
+func (s *Server) publicKey(_ ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	wire := key.Marshal()
+	for _, u := range s.users {
+		for _, k := range u.keys {
+			if bytes.Equal(k, wire) {
+				return permissions(u.name, u.scope), nil
+			}
+		}
+	}
+	return nil, errors.New("unknown key")
+}
+
+// hostKey loads the server's private key from path, creating an ed25519
+// key there on first run
+func hostKey(path string) (ssh.Signer, error) {
+	if path == "" {
+		configPath, err := config.Path()
+		if err != nil {
+			return nil, err
+		}
+		path = filepath.Join(filepath.Dir(configPath), "ssh_host_key")
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate host key: %w", err)
+		}
+		block, err := ssh.MarshalPrivateKey(key, "paranormal-tui")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode host key: %w", err)
+		}
+		data = pem.EncodeToMemory(block)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create host key directory: %w", err)
+		}
+		if err := os.WriteFile(path, data, 0o600); err != nil {
+			return nil, fmt.Errorf("failed to write host key: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read host key: %w", err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse host key %s: %w", path, err)
+	}
+	return signer, nil
+}
+
+// Serve accepts connections on the configured address until ctx is done,
+// then disconnects everyone
+func (s *Server) Serve(ctx context.Context) error {
+	address := s.cfg.Address
+	if address == "" {
+		address = DefaultAddress
+	}
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	s.log.Printf("serving on %s", listener.Addr())
+
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+		s.mu.Lock()
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.mu.Unlock()
+	}()
+
+	for {
+		nc, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+		go s.handle(nc)
+	}
+}
+
+// handle authenticates a connection and serves its channels
+func (s *Server) handle(nc net.Conn) {
+	conn, chans, reqs, err := ssh.NewServerConn(nc, s.ssh)
+	if err != nil {
+		// Scanners and failed logins; not worth logging each
+		nc.Close()
+		return
+	}
+	defer conn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	s.mu.Lock()
+	s.conns[conn] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+	}()
+
+	name := conn.Permissions.Extensions[extUser]
+	scope := Scope(conn.Permissions.Extensions[extScope])
+	s.log.Printf("%s connected from %s as %s", name, conn.RemoteAddr(), scope)
+	defer s.log.Printf("%s disconnected from %s", name, conn.RemoteAddr())
+
+	var wg sync.WaitGroup
+	for newChan := range chans {
+		if newChan.ChannelType() != "session" {
+			newChan.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		ch, chReqs, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.session(conn, name, scope, ch, chReqs)
+		}()
+	}
+	wg.Wait()
+}
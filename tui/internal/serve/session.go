@@ -0,0 +1,192 @@
+package serve
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"paranormal-tui/internal/app"
+	"paranormal-tui/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/crypto/ssh"
+)
+
+// ptyRequest is the payload of a "pty-req" request (RFC 4254 6.2)
+type ptyRequest struct {
+	Term          string
+	Columns, Rows uint32
+	Width, Height uint32
+	Modes         string
+}
+
+// windowChange is the payload of a "window-change" request (RFC 4254 6.7)
+type windowChange struct {
+	Columns, Rows uint32
+	Width, Height uint32
+}
+
+// session serves one session channel: the TUI if it asks for a shell,
+// or an admin command if it asks to run one
+func (s *Server) session(conn *ssh.ServerConn, name string, scope Scope, ch ssh.Channel, reqs <-chan *ssh.Request) {
+	defer ch.Close()
+
+	var (
+		mu      sync.Mutex
+		pty     bool
+		size    tea.WindowSizeMsg
+		program *tea.Program
+		running bool                   // A shell or command was started
+		done    = make(chan uint32, 1) // Its exit status once it ends
+	)
+
+	for {
+		var req *ssh.Request
+		select {
+		case req = <-reqs:
+		case status := <-done:
+			ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+			return
+		}
+		if req == nil {
+			// The client closed the channel; stop the TUI if it's running
+			mu.Lock()
+			if program != nil {
+				program.Quit()
+			}
+			mu.Unlock()
+			if running {
+				<-done
+			}
+			return
+		}
+
+		switch req.Type {
+		case "pty-req":
+			var p ptyRequest
+			if err := ssh.Unmarshal(req.Payload, &p); err != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			mu.Lock()
+			pty = true
+			size = tea.WindowSizeMsg{Width: int(p.Columns), Height: int(p.Rows)}
+			mu.Unlock()
+			req.Reply(true, nil)
+
+		case "window-change":
+			var w windowChange
+			if err := ssh.Unmarshal(req.Payload, &w); err != nil {
+				continue
+			}
+			mu.Lock()
+			size = tea.WindowSizeMsg{Width: int(w.Columns), Height: int(w.Rows)}
+			if program != nil {
+				// Send blocks until the program takes it
+				go program.Send(size)
+			}
+			mu.Unlock()
+
+		case "env":
+			req.Reply(true, nil)
+
+		case "shell":
+			if running {
+				req.Reply(false, nil)
+				continue
+			}
+			running = true
+			if !pty {
+				req.Reply(true, nil)
+				fmt.Fprint(ch.Stderr(), "The tracker needs a terminal; connect with ssh -t.\r\n")
+				done <- 1
+				continue
+			}
+
+			p := s.program(conn, name, scope, ch)
+			sess, err := s.sessions.add(conn, name, scope, p, s.maxSessions)
+			if err != nil {
+				req.Reply(true, nil)
+				fmt.Fprintf(ch.Stderr(), "%s\r\n", err)
+				done <- 1
+				continue
+			}
+			mu.Lock()
+			program = p
+			initial := size
+			mu.Unlock()
+			req.Reply(true, nil)
+
+			go func() {
+				go p.Send(initial)
+				_, err := p.Run()
+				s.sessions.remove(sess.ID)
+				if sess.kicked() {
+					fmt.Fprint(ch, "Disconnected by an admin.\r\n")
+				}
+				if err != nil && err != tea.ErrProgramKilled {
+					s.log.Printf("session %d (%s): %v", sess.ID, name, err)
+					done <- 1
+					return
+				}
+				done <- 0
+			}()
+
+		case "exec":
+			var command struct{ Command string }
+			if err := ssh.Unmarshal(req.Payload, &command); err != nil {
+				req.Reply(false, nil)
+				continue
+			}
+			if running {
+				req.Reply(false, nil)
+				continue
+			}
+			running = true
+			req.Reply(true, nil)
+			if scope != ScopeAdmin {
+				fmt.Fprint(ch.Stderr(), "Only admins can run commands; connect without one for the tracker.\n")
+				done <- 1
+				continue
+			}
+			s.log.Printf("%s ran %q", name, command.Command)
+			done <- s.command(strings.Fields(command.Command), ch, ch.Stderr())
+
+		default:
+			if req.WantReply {
+				req.Reply(false, nil)
+			}
+		}
+	}
+}
+
+// program is a fresh copy of the app for a connection: its own view state,
+// and its own remembered state that is never written. Read-only users
+// can't change anything. A user's quota is shared by all their sessions;
+// anonymous connections share one per address.
+func (s *Server) program(conn *ssh.ServerConn, name string, scope Scope, ch ssh.Channel) *tea.Program {
+	opts := s.opts
+	opts.Remote = true
+	opts.ReadOnly = opts.ReadOnly || scope == ScopeRead
+	opts.State = config.NewMemoryStore(config.State{})
+
+	quotaName := name
+	if name == anonymousUser {
+		host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+		if err != nil {
+			host = conn.RemoteAddr().String()
+		}
+		quotaName = anonymousUser + "@" + host
+	}
+	opts.Quota = s.quotas.For(quotaName)
+
+	return tea.NewProgram(app.New(opts),
+		tea.WithInput(ch),
+		tea.WithOutput(ch),
+		tea.WithAltScreen(),
+		tea.WithMouseCellMotion(),
+		// Signals are for the server, not for one session
+		tea.WithoutSignalHandler(),
+	)
+}
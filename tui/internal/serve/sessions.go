@@ -0,0 +1,155 @@
+package serve
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/crypto/ssh"
+)
+
+// kickGrace is how long a kicked session has to put the terminal back
+// before its connection is cut
+const kickGrace = 2 * time.Second
+
+// Session is a connection running the TUI
+type Session struct {
+	ID      int
+	User    string
+	Scope   Scope
+	Remote  string
+	Started time.Time
+
+	conn    *ssh.ServerConn
+	program *tea.Program
+
+	mu       sync.Mutex
+	isKicked bool
+}
+
+// kick quits the session's TUI, then closes its connection
+func (s *Session) kick() {
+	s.mu.Lock()
+	s.isKicked = true
+	s.mu.Unlock()
+	s.program.Quit()
+	time.AfterFunc(kickGrace, func() { s.conn.Close() })
+}
+
+func (s *Session) kicked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.isKicked
+}
+
+// registry holds the running sessions
+type registry struct {
+	mu       sync.Mutex
+	next     int
+	sessions map[int]*Session
+}
+
+// add registers a session, unless the server is full
+func (r *registry) add(conn *ssh.ServerConn, user string, scope Scope, program *tea.Program, max int) (*Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.sessions) >= max {
+		return nil, errors.New("the tracker is full; try again later")
+	}
+	if r.sessions == nil {
+		r.sessions = make(map[int]*Session)
+	}
+	r.next++
+	s := &Session{
+		ID:      r.next,
+		User:    user,
+		Scope:   scope,
+		Remote:  conn.RemoteAddr().String(),
+		Started: time.Now(),
+		conn:    conn,
+		program: program,
+	}
+	r.sessions[s.ID] = s
+	return s, nil
+}
+
+func (r *registry) remove(id int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, id)
+}
+
+func (r *registry) get(id int) *Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.sessions[id]
+}
+
+// list returns the sessions, oldest first
+func (r *registry) list() []*Session {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sessions := make([]*Session, 0, len(r.sessions))
+	for _, s := range r.sessions {
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].ID < sessions[j].ID })
+	return sessions
+}
+
+// command runs an admin command and returns its exit status:
+//
+//	sessions        list the running sessions
+//	kick ID...      disconnect sessions
+func (s *Server) command(args []string, out, errOut io.Writer) uint32 {
+	if len(args) == 0 {
+		fmt.Fprintln(errOut, "usage: sessions | kick ID...")
+		return 2
+	}
+
+	switch args[0] {
+	case "sessions":
+		sessions := s.sessions.list()
+		if len(sessions) == 0 {
+			fmt.Fprintln(out, "No sessions")
+			return 0
+		}
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tUSER\tSCOPE\tFROM\tCONNECTED FOR")
+		for _, sess := range sessions {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", sess.ID, sess.User, sess.Scope, sess.Remote,
+				time.Since(sess.Started).Round(time.Second))
+		}
+		w.Flush()
+		return 0
+
+	case "kick":
+		if len(args) < 2 {
+			fmt.Fprintln(errOut, "usage: kick ID...")
+			return 2
+		}
+		var status uint32
+		for _, arg := range args[1:] {
+			id, err := strconv.Atoi(arg)
+			sess := s.sessions.get(id)
+			if err != nil || sess == nil {
+				fmt.Fprintf(errOut, "No session %s\n", arg)
+				status = 1
+				continue
+			}
+			sess.kick()
+			s.log.Printf("kicked session %d (%s from %s)", sess.ID, sess.User, sess.Remote)
+			fmt.Fprintf(out, "Kicked session %d (%s)\n", sess.ID, sess.User)
+		}
+		return status
+	}
+
+	fmt.Fprintf(errOut, "Unknown command %q; try sessions or kick ID...\n", args[0])
+	return 2
+}
@@ -0,0 +1,118 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Recorder writes a session's input to a file as it happens, one JSON
+// event per line after the header, so a crash loses nothing before it
+type Recorder struct {
+	f     *os.File
+	enc   *json.Encoder
+	start time.Time
+	err   error
+}
+
+// Create starts a recording, fingerprinting the stories it is made
+// against first
+func Create(ctx context.Context, path string, settings Settings) (*Recorder, error) {
+	database, err := db.New(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stories, corpus, err := Corpus(ctx, database)
+	database.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	// The recording has the user's state and config, and what they did
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create recording: %w", err)
+	}
+	// A startup query is text typed by the user like any other
+	settings.Config.Startup.Query = mask(settings.Config.Startup.Query)
+
+	r := &Recorder{f: f, enc: json.NewEncoder(f), start: time.Now()}
+	header := Header{
+		Version:  Version,
+		Recorded: r.start,
+		Settings: settings,
+		Stories:  stories,
+		Corpus:   corpus,
+	}
+	if err := r.enc.Encode(header); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write recording: %w", err)
+	}
+	return r, nil
+}
+
+// Filter records each input before the TUI sees it, passing it on
+// unchanged. typing reports whether the model is taking keys into a text
+// field, whose runes are masked in the recording.
+func (r *Recorder) Filter(typing func(tea.Model) bool) func(tea.Model, tea.Msg) tea.Msg {
+	return func(model tea.Model, msg tea.Msg) tea.Msg {
+		e := Event{At: time.Since(r.start).Milliseconds()}
+		switch msg := msg.(type) {
+		case tea.KeyMsg:
+			key := tea.Key(msg)
+			masked := key.Type == tea.KeyRunes && typing(model)
+			if masked {
+				key.Runes = []rune(mask(string(key.Runes)))
+			}
+			e.Key = &Key{
+				Name:   key.String(),
+				Type:   key.Type,
+				Runes:  string(key.Runes),
+				Alt:    key.Alt,
+				Paste:  key.Paste,
+				Masked: masked,
+			}
+		case tea.MouseMsg:
+			e.Mouse = &Mouse{X: msg.X, Y: msg.Y, Button: msg.Button, Action: msg.Action,
+				Shift: msg.Shift, Alt: msg.Alt, Ctrl: msg.Ctrl}
+		case tea.WindowSizeMsg:
+			e.Resize = &Resize{Width: msg.Width, Height: msg.Height}
+		default:
+			return msg
+		}
+		// A failed write stops the recording, not the session
+		if r.err == nil {
+			r.err = r.enc.Encode(e)
+		}
+		return msg
+	}
+}
+
+// Close finishes the recording, reporting any write that failed during it
+func (r *Recorder) Close() error {
+	if err := r.f.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if r.err != nil {
+		return fmt.Errorf("failed to write recording: %w", r.err)
+	}
+	return nil
+}
+
+// mask replaces every character but spaces with x, keeping the length of
+// what was typed but not what it said
+func mask(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' {
+			return r
+		}
+		return 'x'
+	}, text)
+}
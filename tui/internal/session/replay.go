@@ -0,0 +1,42 @@
+package session
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// replayed carries a recorded message, so ReplayFilter can tell it from
+// real input
+type replayed struct {
+	msg tea.Msg
+}
+
+// ReplayFilter passes on recorded input and drops real input, bar ctrl+c
+// to stop the replay. The terminal's own size is dropped too, the
+// recorded one being what the session was laid out for.
+func ReplayFilter(_ tea.Model, msg tea.Msg) tea.Msg {
+	switch msg := msg.(type) {
+	case replayed:
+		return msg.msg
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return msg
+		}
+		return nil
+	case tea.MouseMsg, tea.WindowSizeMsg:
+		return nil
+	}
+	return msg
+}
+
+// Play sends the events to p at their recorded times, sped up by speed,
+// returning once the last is sent. Run p with ReplayFilter.
+func Play(p *tea.Program, events []Event, speed float64) {
+	start := time.Now()
+	for _, e := range events {
+		at := time.Duration(float64(e.At) * float64(time.Millisecond) / speed)
+		time.Sleep(time.Until(start.Add(at)))
+		p.Send(replayed{e.Msg()})
+	}
+}
@@ -0,0 +1,150 @@
+// Package session records the keys, mouse, and resizes of a TUI session
+// to a file and plays them back, so a UI bug can be reproduced from a
+// report. Text typed into fields is masked when recorded; a recording
+// notes the stories it was made against, since a replay only follows the
+// same path through the same data.
+package session
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"paranormal-tui/internal/config"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/integrity"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Version identifies the recording layout
+const Version = 1
+
+// Settings are what the TUI was started with, so a replay starts the same
+// way
+type Settings struct {
+	Config          config.Config `json:"config"`
+	Kiosk           bool          `json:"kiosk"`
+	PresentInterval time.Duration `json:"present_interval"`
+	State           config.State  `json:"state"`
+}
+
+// Header is the first line of a recording
+type Header struct {
+	Version  int       `json:"version"`
+	Recorded time.Time `json:"recorded"`
+	Settings Settings  `json:"settings"`
+	Stories  int       `json:"stories"` // Stories in the database, trashed ones included
+	Corpus   string    `json:"corpus"`  // Root hash of their content
+}
+
+// Event is one input, At milliseconds after the session started. Exactly
+// one of Key, Mouse, and Resize is set.
+type Event struct {
+	At     int64   `json:"at_ms"`
+	Key    *Key    `json:"key,omitempty"`
+	Mouse  *Mouse  `json:"mouse,omitempty"`
+	Resize *Resize `json:"resize,omitempty"`
+}
+
+// Key is a key press. Name is for people reading the file; replay goes by
+// the other fields.
+type Key struct {
+	Name   string      `json:"name"`
+	Type   tea.KeyType `json:"type"`
+	Runes  string      `json:"runes,omitempty"`
+	Alt    bool        `json:"alt,omitempty"`
+	Paste  bool        `json:"paste,omitempty"`
+	Masked bool        `json:"masked,omitempty"` // Typed into a text field; each rune is recorded as x
+}
+
+// Mouse is a click, wheel turn, or motion
+type Mouse struct {
+	X      int             `json:"x"`
+	Y      int             `json:"y"`
+	Button tea.MouseButton `json:"button"`
+	Action tea.MouseAction `json:"action"`
+	Shift  bool            `json:"shift,omitempty"`
+	Alt    bool            `json:"alt,omitempty"`
+	Ctrl   bool            `json:"ctrl,omitempty"`
+}
+
+// Resize is the terminal size
+type Resize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// Msg is the message the event was recorded from
+func (e Event) Msg() tea.Msg {
+	switch {
+	case e.Key != nil:
+		var runes []rune
+		if e.Key.Runes != "" {
+			runes = []rune(e.Key.Runes)
+		}
+		return tea.KeyMsg{Type: e.Key.Type, Runes: runes, Alt: e.Key.Alt, Paste: e.Key.Paste}
+	case e.Mouse != nil:
+		return tea.MouseMsg{X: e.Mouse.X, Y: e.Mouse.Y, Button: e.Mouse.Button, Action: e.Mouse.Action,
+			Shift: e.Mouse.Shift, Alt: e.Mouse.Alt, Ctrl: e.Mouse.Ctrl}
+	case e.Resize != nil:
+		return tea.WindowSizeMsg{Width: e.Resize.Width, Height: e.Resize.Height}
+	}
+	return nil
+}
+
+// Corpus counts and fingerprints the stories in the database
+func Corpus(ctx context.Context, database *db.DB) (int, string, error) {
+	hashes, err := database.StoryHashes(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	var entries []db.HashEntry
+	for _, h := range hashes {
+		if h.Current != "" {
+			entries = append(entries, db.HashEntry{StoryID: h.StoryID, SHA256: h.Current})
+		}
+	}
+	return len(entries), integrity.Root(entries), nil
+}
+
+// Load reads a recording
+func Load(path string) (Header, []Event, error) {
+	var header Header
+	f, err := os.Open(path)
+	if err != nil {
+		return header, nil, fmt.Errorf("failed to open recording: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024) // Pastes can make long lines
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return header, nil, fmt.Errorf("failed to read recording: %w", err)
+		}
+		return header, nil, fmt.Errorf("%s is empty", path)
+	}
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return header, nil, fmt.Errorf("failed to parse recording header: %w", err)
+	}
+	if header.Version != Version {
+		return header, nil, fmt.Errorf("%s is a version %d recording; this build replays version %d", path, header.Version, Version)
+	}
+
+	var events []Event
+	for line := 2; scanner.Scan(); line++ {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return header, nil, fmt.Errorf("failed to parse recording line %d: %w", line, err)
+		}
+		events = append(events, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return header, nil, fmt.Errorf("failed to read recording: %w", err)
+	}
+	return header, events, nil
+}
@@ -2,6 +2,8 @@ package styles
 
 import (
 	"fmt"
+	"math"
+	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -178,6 +180,30 @@ func GetClusterColor(clusterID *int) lipgloss.Color {
 	return clusterColors[idx]
 }
 
+// dateGradient runs from old (blue) to new (yellow), bright enough
+// throughout to read on a dark background
+var dateGradient = [][3]float64{
+	{0x43, 0x63, 0xD8}, // Blue
+	{0x91, 0x5B, 0xD6}, // Violet
+	{0xE0, 0x55, 0x9A}, // Rose
+	{0xF5, 0x82, 0x31}, // Orange
+	{0xFF, 0xE1, 0x19}, // Yellow
+}
+
+// GetDateColor returns the color for a date at f along the range of dates
+// shown, from 0 (oldest) to 1 (newest)
+func GetDateColor(f float64) lipgloss.Color {
+	f = math.Max(0, math.Min(1, f))
+	pos := f * float64(len(dateGradient)-1)
+	i := min(int(pos), len(dateGradient)-2)
+	t := pos - float64(i)
+	a, b := dateGradient[i], dateGradient[i+1]
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X",
+		int(math.Round(a[0]+(b[0]-a[0])*t)),
+		int(math.Round(a[1]+(b[1]-a[1])*t)),
+		int(math.Round(a[2]+(b[2]-a[2])*t))))
+}
+
 // ClusterBadge creates a colored badge for a cluster
 func ClusterBadge(clusterID *int) string {
 	color := GetClusterColor(clusterID)
@@ -191,3 +217,51 @@ func ClusterBadge(clusterID *int) string {
 		Padding(0, 1).
 		Render(label)
 }
+
+// RowColor chooses what, if anything, colors story titles in lists
+type RowColor string
+
+const (
+	RowColorNone   RowColor = ""
+	RowColorRating RowColor = "rating" // Warmer for higher ratings
+	RowColorRead   RowColor = "read"   // Read stories fade
+)
+
+// ParseRowColor validates a row color setting
+func ParseRowColor(name string) (RowColor, error) {
+	switch c := RowColor(name); c {
+	case RowColorNone, RowColorRating, RowColorRead:
+		return c, nil
+	}
+	return RowColorNone, fmt.Errorf("unknown row color %q (want rating or read)", name)
+}
+
+// ratingColors run from dull to gold, indexed by rating
+var ratingColors = [6]lipgloss.Color{
+	"",
+	lipgloss.Color("#8C7A6B"),
+	lipgloss.Color("#A8895A"),
+	lipgloss.Color("#C49B45"),
+	lipgloss.Color("#E0B030"),
+	lipgloss.Color("#FFD700"),
+}
+
+// Title colors a list title by rating or read status
+func (c RowColor) Title(title string, rating int, read bool) string {
+	switch {
+	case c == RowColorRating && rating >= 1 && rating <= 5:
+		return lipgloss.NewStyle().Foreground(ratingColors[rating]).Render(title)
+	case c == RowColorRead && read:
+		return lipgloss.NewStyle().Foreground(TextMuted).Render(title)
+	}
+	return title
+}
+
+// Stars shows a 1-5 rating as filled and empty stars, or "" if unrated
+func Stars(rating int) string {
+	if rating < 1 || rating > 5 {
+		return ""
+	}
+	return lipgloss.NewStyle().Foreground(ratingColors[rating]).Render(
+		strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating))
+}
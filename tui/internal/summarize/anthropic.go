@@ -0,0 +1,90 @@
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+)
+
+const (
+	anthropicModel   = "claude-3-haiku-20240307"
+	anthropicAPIURL  = "https://api.anthropic.com/v1/messages"
+	anthropicVersion = "2023-06-01"
+)
+
+type anthropicRequest struct {
+	Model       string             `json:"model"`
+	MaxTokens   int                `json:"max_tokens"`
+	Temperature float64            `json:"temperature"`
+	Stream      bool               `json:"stream"`
+	Messages    []anthropicMessage `json:"messages"`
+}
+
+type anthropicMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// anthropicEvent is the data of one server-sent event of a streamed
+// message; only text deltas, the end, and errors matter here
+type anthropicEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// streamAnthropic streams a reply to prompt from the Messages API, from
+// the ANTHROPIC_API_KEY environment variable
+func streamAnthropic(ctx context.Context, prompt string, send func(string)) error {
+	key := os.Getenv("ANTHROPIC_API_KEY")
+	if key == "" {
+		return errors.New("ANTHROPIC_API_KEY environment variable not set (or set the LLM provider to ollama to use a local model)")
+	}
+
+	resp, err := post(ctx, anthropicAPIURL, map[string]string{
+		"x-api-key":         key,
+		"anthropic-version": anthropicVersion,
+	}, anthropicRequest{
+		Model:     modelName(),
+		MaxTokens: 400,
+		Stream:    true,
+		Messages:  []anthropicMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = readLines(resp.Body, func(line []byte) error {
+		data, ok := bytes.CutPrefix(line, []byte("data:"))
+		if !ok {
+			return nil
+		}
+		var event anthropicEvent
+		if err := json.Unmarshal(bytes.TrimSpace(data), &event); err != nil {
+			return nil
+		}
+		switch event.Type {
+		case "content_block_delta":
+			if event.Delta.Type == "text_delta" {
+				send(event.Delta.Text)
+			}
+		case "message_stop":
+			return errStop
+		case "error":
+			return errors.New("summary failed: " + event.Error.Message)
+		}
+		return nil
+	})
+	if errors.Is(err, errStop) {
+		return nil
+	}
+	return err
+}
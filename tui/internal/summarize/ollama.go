@@ -0,0 +1,70 @@
+package summarize
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	ollamaModel = "llama3.1:8b"
+	ollamaURL   = "http://localhost:11434"
+)
+
+type ollamaRequest struct {
+	Model   string         `json:"model"`
+	Prompt  string         `json:"prompt"`
+	Stream  bool           `json:"stream"`
+	Options map[string]any `json:"options"`
+}
+
+// ollamaResponse is one line of a streamed generation
+type ollamaResponse struct {
+	Response string `json:"response"`
+	Done     bool   `json:"done"`
+	Error    string `json:"error"`
+}
+
+// streamOllama streams a reply to prompt from a local Ollama server
+func streamOllama(ctx context.Context, prompt string, send func(string)) error {
+	server := url
+	if server == "" {
+		server = os.Getenv("OLLAMA_URL")
+	}
+	if server == "" {
+		server = ollamaURL
+	}
+
+	resp, err := post(ctx, strings.TrimSuffix(server, "/")+"/api/generate", nil, ollamaRequest{
+		Model:   modelName(),
+		Prompt:  prompt,
+		Stream:  true,
+		Options: map[string]any{"temperature": 0, "num_predict": 400},
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	err = readLines(resp.Body, func(line []byte) error {
+		var r ollamaResponse
+		if err := json.Unmarshal(line, &r); err != nil {
+			return fmt.Errorf("failed to decode summary: %w", err)
+		}
+		if r.Error != "" {
+			return errors.New("summary failed: " + r.Error)
+		}
+		send(r.Response)
+		if r.Done {
+			return errStop
+		}
+		return nil
+	})
+	if errors.Is(err, errStop) {
+		return nil
+	}
+	return err
+}
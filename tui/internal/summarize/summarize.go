@@ -0,0 +1,192 @@
+// Package summarize writes story summaries with a generative model, as
+// scripts/resummarize.py does for the whole corpus, streaming the summary
+// as it's written. The provider is Anthropic (the default) over its API,
+// or a local model served by Ollama. Whatever the config leaves empty is
+// read from the environment variables scripts/llm.py uses, so the TUI and
+// the pipeline summarize with the same model.
+package summarize
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Providers
+const (
+	Anthropic = "anthropic"
+	Ollama    = "ollama"
+)
+
+// maxContentChars is how much of a story is summarized: enough to
+// summarize it, without the longest ones blowing the context of a small
+// local model
+const maxContentChars = 12000
+
+// prompt is resummarize.py's, asking for the summary alone since it's
+// streamed as text rather than filled into a schema
+const prompt = `Summarize this first-person paranormal story for someone browsing a catalog of them.
+
+Title: {title}
+
+Write two or three plain sentences: who experienced what, where and when if the story says,
+and how it ended. Don't judge whether it really happened, and don't add details the story doesn't give.
+Reply with only the summary.
+
+Story:
+{content}
+`
+
+// Chunk is a piece of the summary as it's written, or its end
+type Chunk struct {
+	Text string
+	Done bool
+	Err  error
+}
+
+var (
+	provider string
+	model    string
+	url      string
+)
+
+// Configure chooses the provider, its model, and the URL of an Ollama
+// server. Empty values fall back to LLM_PROVIDER, LLM_MODEL_SUMMARIES or
+// LLM_MODEL (or ANTHROPIC_MODEL for anthropic), and OLLAMA_URL, then to
+// anthropic, the provider's default model, and Ollama's usual address.
+func Configure(name, modelName, serverURL string) error {
+	if name == "" {
+		name = strings.ToLower(strings.TrimSpace(os.Getenv("LLM_PROVIDER")))
+	}
+	switch name {
+	case "":
+		name = Anthropic
+	case Anthropic, Ollama:
+	default:
+		return fmt.Errorf("unknown LLM provider %q (want anthropic or ollama)", name)
+	}
+	provider, model, url = name, modelName, serverURL
+	return nil
+}
+
+// Model names the model summaries are written with, to show beside a new
+// one; local ones are named as such
+func Model() string {
+	name := modelName()
+	if provider == Ollama {
+		return Ollama + "/" + name
+	}
+	return name
+}
+
+// modelName is the configured model, or the provider's default
+func modelName() string {
+	for _, name := range []string{model, os.Getenv("LLM_MODEL_SUMMARIES"), os.Getenv("LLM_MODEL")} {
+		if name != "" {
+			return name
+		}
+	}
+	if provider == Ollama {
+		return ollamaModel
+	}
+	if name := os.Getenv("ANTHROPIC_MODEL"); name != "" {
+		return name
+	}
+	return anthropicModel
+}
+
+// Stream summarizes a story, sending the summary a chunk at a time as the
+// model writes it, then a Chunk that's Done, with an error if it failed.
+// Cancelling ctx stops it.
+func Stream(ctx context.Context, title, content string) <-chan Chunk {
+	chunks := make(chan Chunk, 16)
+	go func() {
+		defer close(chunks)
+		send := func(text string) {
+			select {
+			case chunks <- Chunk{Text: text}:
+			case <-ctx.Done():
+			}
+		}
+		var err error
+		if provider == Ollama {
+			err = streamOllama(ctx, buildPrompt(title, content), send)
+		} else {
+			err = streamAnthropic(ctx, buildPrompt(title, content), send)
+		}
+		select {
+		case chunks <- Chunk{Done: true, Err: err}:
+		case <-ctx.Done():
+		}
+	}()
+	return chunks
+}
+
+// buildPrompt fills the story into the prompt. It's not a template, so the
+// story can contain anything.
+func buildPrompt(title, content string) string {
+	if title == "" {
+		title = "(untitled)"
+	}
+	if runes := []rune(content); len(runes) > maxContentChars {
+		content = string(runes[:maxContentChars])
+	}
+	return strings.NewReplacer("{title}", title, "{content}", content).Replace(prompt)
+}
+
+// post sends body to endpoint with headers and returns the response,
+// which the caller closes, if the request succeeded
+func post(ctx context.Context, endpoint string, headers map[string]string, body any) (*http.Response, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request summary: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		// Providers explain what's wrong, e.g. a bad key or a model not pulled
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 300))
+		resp.Body.Close()
+		if len(bytes.TrimSpace(detail)) > 0 {
+			return nil, fmt.Errorf("summary request failed: %s: %s", resp.Status, bytes.TrimSpace(detail))
+		}
+		return nil, fmt.Errorf("summary request failed: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// readLines calls line with each line of a streamed response
+func readLines(r io.Reader, line func([]byte) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := line(scanner.Bytes()); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read summary: %w", err)
+	}
+	return nil
+}
+
+// errStop ends reading a stream early, once it says it's done
+var errStop = errors.New("stop")
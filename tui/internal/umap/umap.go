@@ -0,0 +1,105 @@
+// Package umap recomputes the 2D projection the Visualize view plots. The
+// projection itself is done by a Python script (scripts/project_umap.py by
+// default), which prints its progress and the new coordinates as JSON
+// lines; the coordinates are saved here.
+package umap
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// DefaultCommand runs the projection script from the repository root
+var DefaultCommand = []string{"python3", "scripts/project_umap.py"}
+
+// Progress reports a projection's step, or how it ended
+type Progress struct {
+	Stage string
+	Step  int
+	Steps int
+	Done  bool
+	Saved int64 // Stories given new coordinates, once done
+	Err   error
+}
+
+// line is one line of the script's output: a stage or a point
+type line struct {
+	Stage string `json:"stage"`
+	Step  int    `json:"step"`
+	Steps int    `json:"steps"`
+	db.ProjectedPoint
+}
+
+// Run projects the stories with command and saves the coordinates. Progress
+// comes on the returned channel, which is closed after the final report.
+// Cancelling ctx stops the script, leaving the old coordinates in place.
+func Run(ctx context.Context, database *db.DB, command []string) <-chan Progress {
+	progress := make(chan Progress, 1)
+	go func() {
+		defer close(progress)
+		saved, err := run(ctx, database, command, progress)
+		progress <- Progress{Done: true, Saved: saved, Err: err}
+	}()
+	return progress
+}
+
+func run(ctx context.Context, database *db.DB, command []string, progress chan<- Progress) (int64, error) {
+	if len(command) == 0 {
+		command = DefaultCommand
+	}
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, fmt.Errorf("failed to start %s: %w", command[0], err)
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start %s: %w", command[0], err)
+	}
+
+	var points []db.ProjectedPoint
+	steps := 0
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		var l line
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			// Stray prints from libraries aren't ours to parse
+			continue
+		}
+		switch {
+		case l.Stage != "":
+			steps = l.Steps
+			progress <- Progress{Stage: l.Stage, Step: l.Step, Steps: l.Steps}
+		case l.ID != "":
+			points = append(points, l.ProjectedPoint)
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		if msg := lastLine(stderr.String()); msg != "" {
+			return 0, fmt.Errorf("%s failed: %s", strings.Join(command, " "), msg)
+		}
+		return 0, fmt.Errorf("%s failed: %w", strings.Join(command, " "), err)
+	}
+	if len(points) == 0 {
+		return 0, errors.New("the projection script printed no coordinates")
+	}
+
+	progress <- Progress{Stage: fmt.Sprintf("Saving %d positions", len(points)), Step: steps, Steps: steps}
+	return database.SaveProjection(ctx, points)
+}
+
+// lastLine is the last non-blank line of a script's error output, usually
+// the message of whatever stopped it
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
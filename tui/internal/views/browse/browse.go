@@ -4,17 +4,22 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"paranormal-tui/internal/db"
 	"paranormal-tui/internal/styles"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
 const pageSize = 15
 
+// minWordSteps are the length filters the W key cycles through
+var minWordSteps = []int{0, 500, 1000, 2000, 5000}
+
 // Model represents the browse view
 type Model struct {
 	database *db.DB
@@ -23,16 +28,83 @@ type Model struct {
 	cursor   int
 	page     int
 	loading  bool
+	loaded   bool // A load has completed
 	err      error
 	width    int
 	height   int
 
+	// Keyset pagination for date sort: pageStarts[i] is the position page i
+	// starts after (nil for the first page). Only pages reached by paging
+	// forward are known, so the page number is exact but the total is the
+	// count from the first page.
+	pageStarts []*db.StoryCursor
+
+	// next is page+1 fetched in the background, so n flips instantly
+	next *prefetched
+
+	// stepTo is set while the page a step from the story view turned to
+	// loads: 1 to select its first story, -1 its last
+	stepTo int
+
 	// Filters
 	filters    db.BrowseFilters
 	sort       db.BrowseSort
 	showFilter bool
 	filterIdx  int
+	filterSel  map[string]bool // Types toggled in the filter menu, applied on enter
 	storyTypes []string
+
+	// Date range picker
+	showDateRange bool
+	dateInputs    [2]textinput.Model // From, To
+	dateFocus     int
+	dateErr       string
+
+	// Location filter with autocomplete
+	showLocation  bool
+	locationInput textinput.Model
+	locations     []db.LocationCount
+	locationIdx   int // Highlighted suggestion, -1 for the typed text
+	locationErr   error
+
+	// Distance filter
+	showDistance   bool
+	distanceInputs [2]textinput.Model // Place, radius
+	distanceFocus  int
+	distanceErr    string
+
+	// readOnly disables trashing (kiosk mode)
+	readOnly bool
+
+	// rowColor colors titles by rating or read status
+	rowColor styles.RowColor
+
+	// List columns and the menu that edits them
+	columns     []Column
+	showColumns bool
+	columnItems []columnItem
+	columnIdx   int
+
+	// Saved filter + sort presets
+	showPresets  bool
+	presets      []db.Preset // nil until loaded
+	presetIdx    int
+	presetErr    error
+	presetNaming bool // Typing a name to save the current filters under
+	presetInput  textinput.Model
+
+	// Summary preview of the highlighted story beside the list
+	showPreview bool
+
+	// Continuous mode scrolls one growing list instead of paging
+	continuous   bool
+	top          int  // First visible row
+	fetchingMore bool // Next chunk is loading
+	exhausted    bool // Last chunk came back short; nothing more to fetch
+
+	// Grouped mode nests stories under episode headers
+	grouped   bool
+	collapsed map[string]bool // Folded episodes, by episodeKey
 }
 
 // New creates a new browse model
@@ -44,6 +116,7 @@ func New(database *db.DB) Model {
 			Ascending: false,
 		},
 		storyTypes: db.StoryTypes,
+		columns:    DefaultColumns(),
 	}
 }
 
@@ -68,10 +141,66 @@ func (m Model) loadStories() tea.Cmd {
 		return nil
 	}
 
+	if m.keyset() {
+		return m.loadStoriesAfter()
+	}
+
+	limit := m.chunkSize()
 	return func() tea.Msg {
 		ctx := context.Background()
 		offset := m.page * pageSize
-		stories, total, err := m.database.ListStories(ctx, pageSize, offset, &m.filters, &m.sort)
+		stories, total, err := m.database.ListStories(ctx, limit, offset, &m.filters, &m.sort)
+		return StoriesLoadedMsg{Stories: stories, Total: total, Err: err}
+	}
+}
+
+// keyset reports whether the current sort pages by cursor instead of offset
+func (m Model) keyset() bool {
+	return m.sort.Field == "date"
+}
+
+// nextPage turns to the next page, with the cursor at its top, reporting
+// false if this is the last
+func (m *Model) nextPage() (tea.Cmd, bool) {
+	if m.keyset() {
+		if len(m.stories) < pageSize || (m.page+1)*pageSize >= m.total {
+			return nil, false
+		}
+		if len(m.pageStarts) == 0 {
+			m.pageStarts = []*db.StoryCursor{nil}
+		}
+		m.pageStarts = append(m.pageStarts[:m.page+1], db.CursorAfter(m.stories[len(m.stories)-1]))
+	} else if m.page >= (m.total-1)/pageSize {
+		return nil, false
+	}
+
+	m.page++
+	m.cursor = 0
+	if m.takePrefetched() {
+		return m.schedulePrefetch(), true
+	}
+	m.loading = true
+	return m.loadStories(), true
+}
+
+func (m Model) loadStoriesAfter() tea.Cmd {
+	var after *db.StoryCursor
+	if m.page < len(m.pageStarts) {
+		after = m.pageStarts[m.page]
+	}
+	filters, ascending, page, total := m.filters, m.sort.Ascending, m.page, m.total
+	limit := m.chunkSize()
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		// Recount only on the first page; deeper pages reuse the estimate
+		if page == 0 {
+			var err error
+			if total, err = m.database.CountStories(ctx, &filters); err != nil {
+				return StoriesLoadedMsg{Err: err}
+			}
+		}
+		stories, err := m.database.ListStoriesAfter(ctx, limit, after, &filters, ascending)
 		return StoriesLoadedMsg{Stories: stories, Total: total, Err: err}
 	}
 }
@@ -83,6 +212,13 @@ type StoriesLoadedMsg struct {
 	Err     error
 }
 
+// StoryTrashedMsg reports a story moved to the trash
+type StoryTrashedMsg struct {
+	ID    string
+	Title string
+	Err   error
+}
+
 // StorySelectedMsg indicates a story was selected
 type StorySelectedMsg struct {
 	Story db.Story
@@ -95,41 +231,109 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		m.loading = false
 		if msg.Err != nil {
 			m.err = msg.Err
+			m.stepTo = 0
 			return m, nil
 		}
 		m.stories = msg.Stories
 		m.total = msg.Total
+		m.loaded = true
+		m.fetchingMore = false
+		m.exhausted = len(msg.Stories) < m.chunkSize()
 		if m.cursor >= len(m.stories) {
 			m.cursor = max(0, len(m.stories)-1)
 		}
+		m.follow()
+		return m, tea.Batch(m.finishStep(), m.schedulePrefetch())
+
+	case StoriesAppendedMsg:
+		return m.appendStories(msg)
+
+	case PrefetchTickMsg:
+		return m, m.prefetch(msg)
+
+	case PagePrefetchedMsg:
+		m.storePrefetched(msg)
+		return m, nil
+
+	case StoryTrashedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		if m.continuous {
+			m.removeStory(msg.ID)
+			return m, nil
+		}
+		m.loading = true
+		return m, m.loadStories()
+
+	case LocationsLoadedMsg:
+		m.locationErr = msg.Err
+		m.locations = msg.Locations
+		if m.locations == nil && msg.Err == nil {
+			m.locations = []db.LocationCount{}
+		}
 		return m, nil
 
+	case PlaceResolvedMsg:
+		return m.applyDistance(msg)
+
+	case PresetsLoadedMsg:
+		m.presetErr = msg.Err
+		m.presets = msg.Presets
+		if m.presets == nil && msg.Err == nil {
+			m.presets = []db.Preset{}
+		}
+		if m.presetIdx >= len(m.presets) {
+			m.presetIdx = max(0, len(m.presets)-1)
+		}
+		return m, nil
+
+	case PresetsChangedMsg:
+		if msg.Err != nil {
+			m.presetErr = msg.Err
+			return m, nil
+		}
+		return m, m.loadPresets()
+
 	case tea.KeyMsg:
 		// Handle filter mode
 		if m.showFilter {
 			return m.handleFilterKeys(msg)
 		}
+		if m.showDateRange {
+			return m.handleDateRangeKeys(msg)
+		}
+		if m.showLocation {
+			return m.handleLocationKeys(msg)
+		}
+		if m.showDistance {
+			return m.handleDistanceKeys(msg)
+		}
+		if m.showColumns {
+			return m.handleColumnKeys(msg)
+		}
+		if m.showPresets {
+			return m.handlePresetKeys(msg)
+		}
 
 		switch {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
-			if m.cursor > 0 {
-				m.cursor--
-			}
+			return m, m.moveCursor(-1)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
-			if m.cursor < len(m.stories)-1 {
-				m.cursor++
-			}
+			return m, m.moveCursor(1)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("n", "]"))):
-			// Next page
-			maxPage := (m.total - 1) / pageSize
-			if m.page < maxPage {
-				m.page++
-				m.cursor = 0
-				m.loading = true
-				return m, m.loadStories()
+			// Next page; continuous mode moves a screenful instead
+			if m.continuous {
+				return m, m.moveCursor(m.visibleRows())
 			}
+			cmd, _ := m.nextPage()
+			return m, cmd
 		case key.Matches(msg, key.NewBinding(key.WithKeys("p", "["))):
 			// Previous page
+			if m.continuous {
+				return m, m.moveCursor(-m.visibleRows())
+			}
 			if m.page > 0 {
 				m.page--
 				m.cursor = 0
@@ -137,6 +341,10 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				return m, m.loadStories()
 			}
 		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if m.cursor < len(m.stories) && m.collapsedAt(m.cursor) {
+				m.toggleGroup()
+				return m, nil
+			}
 			if len(m.stories) > 0 && m.cursor < len(m.stories) {
 				return m, func() tea.Msg {
 					return StorySelectedMsg{Story: m.stories[m.cursor]}
@@ -145,16 +353,63 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("f"))):
 			m.showFilter = true
 			m.filterIdx = 0
+			m.filterSel = make(map[string]bool)
+			for _, t := range m.filters.StoryTypes {
+				m.filterSel[t] = true
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("F"))):
+			return m, m.openPresetMenu()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("L"))):
+			return m, m.openLocationFilter()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
+			m.openDistanceFilter()
+			return m, textinput.Blink
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			if !m.readOnly && m.cursor < len(m.stories) {
+				return m, m.trashStory(m.stories[m.cursor])
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+			m.openColumnMenu()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("v"))):
+			m.showPreview = !m.showPreview
+		case key.Matches(msg, key.NewBinding(key.WithKeys("G"))):
+			m.SetGrouped(!m.grouped)
+			m.loading = true
+			return m, m.loadStories()
+		case key.Matches(msg, key.NewBinding(key.WithKeys(" "))):
+			m.toggleGroup()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+			m.SetContinuous(!m.continuous)
+			m.loading = true
+			return m, m.loadStories()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("u"))):
+			m.filters.UnreadOnly = !m.filters.UnreadOnly
+			return m, m.reloadFromFirstPage()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("W"))):
+			// Cycle the minimum length: off, 500, 1000, 2000, 5000 words
+			next := 0
+			for i, n := range minWordSteps {
+				if n == m.filters.MinWords {
+					next = (i + 1) % len(minWordSteps)
+				}
+			}
+			m.filters.MinWords = minWordSteps[next]
+			return m, m.reloadFromFirstPage()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("B"))):
+			m.filters.BookmarkedOnly = !m.filters.BookmarkedOnly
+			return m, m.reloadFromFirstPage()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
+			m.openDateRange()
+			return m, textinput.Blink
 		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
 			// Cycle sort field
-			switch m.sort.Field {
-			case "date":
-				m.sort.Field = "title"
-			case "title":
-				m.sort.Field = "type"
-			default:
-				m.sort.Field = "date"
+			next := 0
+			for i, field := range db.SortFields {
+				if field == m.sort.Field {
+					next = (i + 1) % len(db.SortFields)
+				}
 			}
+			m.sort.Field = db.SortFields[next]
 			m.page = 0
 			m.cursor = 0
 			m.loading = true
@@ -192,13 +447,20 @@ func (m Model) handleFilterKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
 		if m.filterIdx < len(m.storyTypes) {
 			m.filterIdx++
 		}
-	case "enter":
+	case " ", "space":
 		if m.filterIdx == 0 {
-			// "All" option
-			m.filters.StoryType = ""
+			// "All" option clears the selection
+			m.filterSel = make(map[string]bool)
 		} else {
-			m.filters.StoryType = m.storyTypes[m.filterIdx-1]
+			t := m.storyTypes[m.filterIdx-1]
+			m.filterSel[t] = !m.filterSel[t]
 		}
+	case "enter":
+		// With nothing toggled, enter picks the highlighted row alone
+		if len(m.selectedTypes()) == 0 && m.filterIdx > 0 {
+			m.filterSel[m.storyTypes[m.filterIdx-1]] = true
+		}
+		m.filters.StoryTypes = m.selectedTypes()
 		m.showFilter = false
 		m.page = 0
 		m.cursor = 0
@@ -208,6 +470,248 @@ func (m Model) handleFilterKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
 	return m, nil
 }
 
+// selectedTypes returns the toggled types in menu order
+func (m Model) selectedTypes() []string {
+	var types []string
+	for _, t := range m.storyTypes {
+		if m.filterSel[t] {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+func (m *Model) openDateRange() {
+	m.showDateRange = true
+	m.dateFocus = 0
+	m.dateErr = ""
+
+	for i, current := range []*time.Time{m.filters.DateFrom, m.filters.DateTo} {
+		ti := textinput.New()
+		ti.Placeholder = "YYYY-MM-DD"
+		ti.CharLimit = 20
+		ti.Width = 16
+		if current != nil {
+			ti.SetValue(current.Format("2006-01-02"))
+		}
+		m.dateInputs[i] = ti
+	}
+	if m.filters.DateRange != "" {
+		m.dateInputs[0].SetValue(m.filters.DateRange)
+		m.dateInputs[1].SetValue("")
+	}
+	m.dateInputs[0].Focus()
+}
+
+func (m Model) handleDateRangeKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.showDateRange = false
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		m.dateInputs[m.dateFocus].Blur()
+		m.dateFocus = 1 - m.dateFocus
+		m.dateInputs[m.dateFocus].Focus()
+		return m, textinput.Blink
+	case "enter":
+		// A relative range in From is resolved on every query
+		if value := strings.TrimSpace(m.dateInputs[0].Value()); value != "" && !startsWithDigit(value) {
+			r, err := db.ParseDateRange(value)
+			if err != nil {
+				m.dateErr = fmt.Sprintf("From: %v", err)
+				return m, nil
+			}
+			if strings.TrimSpace(m.dateInputs[1].Value()) != "" {
+				m.dateErr = "Leave To blank for a relative range"
+				return m, nil
+			}
+			m.filters.DateRange = r
+			m.filters.DateFrom, m.filters.DateTo = nil, nil
+			m.showDateRange = false
+			return m, m.reloadFromFirstPage()
+		}
+
+		from, err := parseDateBound(m.dateInputs[0].Value(), false)
+		if err != nil {
+			m.dateErr = fmt.Sprintf("From: %v", err)
+			return m, nil
+		}
+		to, err := parseDateBound(m.dateInputs[1].Value(), true)
+		if err != nil {
+			m.dateErr = fmt.Sprintf("To: %v", err)
+			return m, nil
+		}
+		if from != nil && to != nil && to.Before(*from) {
+			m.dateErr = "To date is before From date"
+			return m, nil
+		}
+		m.filters.DateFrom = from
+		m.filters.DateTo = to
+		m.filters.DateRange = ""
+		m.showDateRange = false
+		m.page = 0
+		m.cursor = 0
+		m.loading = true
+		return m, m.loadStories()
+	}
+
+	var cmd tea.Cmd
+	m.dateInputs[m.dateFocus], cmd = m.dateInputs[m.dateFocus].Update(msg)
+	return m, cmd
+}
+
+// parseDateBound parses a typed ISO date. Partial dates (YYYY or YYYY-MM)
+// expand to the start of the period, or the end of it for an upper bound.
+// An empty value means no bound.
+func parseDateBound(value string, upper bool) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+
+	layouts := []struct {
+		layout        string
+		years, months int
+		days          int
+	}{
+		{"2006-01-02", 0, 0, 1},
+		{"2006-01", 0, 1, 0},
+		{"2006", 1, 0, 0},
+	}
+	for _, l := range layouts {
+		t, err := time.Parse(l.layout, value)
+		if err != nil {
+			continue
+		}
+		if upper {
+			t = t.AddDate(l.years, l.months, l.days).AddDate(0, 0, -1)
+		}
+		return &t, nil
+	}
+
+	return nil, fmt.Errorf("invalid date %q (use YYYY-MM-DD, YYYY-MM, or YYYY)", value)
+}
+
+// startsWithDigit tells typed dates from relative ranges
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// dateRangeLabel describes the active date filter, or "" if none
+func (m Model) dateRangeLabel() string {
+	if m.filters.DateRange != "" {
+		return m.filters.DateRange
+	}
+	from, to := m.filters.DateFrom, m.filters.DateTo
+	switch {
+	case from != nil && to != nil:
+		return fmt.Sprintf("%s → %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	case from != nil:
+		return fmt.Sprintf("≥ %s", from.Format("2006-01-02"))
+	case to != nil:
+		return fmt.Sprintf("≤ %s", to.Format("2006-01-02"))
+	}
+	return ""
+}
+
+// filterInfo summarizes the active filters for the footer
+func (m Model) filterInfo() string {
+	filterInfo := ""
+	if len(m.filters.StoryTypes) > 0 {
+		filterInfo = fmt.Sprintf(" | Filter: %s", strings.Join(m.filters.StoryTypes, "+"))
+	}
+	if m.filters.Location != "" {
+		filterInfo += fmt.Sprintf(" | Location: %s", m.filters.Location)
+	}
+	if label := m.distanceLabel(); label != "" {
+		filterInfo += fmt.Sprintf(" | Near: %s", label)
+	}
+	if label := m.dateRangeLabel(); label != "" {
+		filterInfo += fmt.Sprintf(" | Dates: %s", label)
+	}
+	if m.filters.EpisodeLabel != "" {
+		filterInfo += fmt.Sprintf(" | Episode: %s", m.filters.EpisodeLabel)
+	}
+	if m.filters.EntityName != "" {
+		filterInfo += fmt.Sprintf(" | Names %s: %s", m.filters.EntityKind, m.filters.EntityName)
+	}
+	if m.filters.MinWords > 0 && m.filters.MaxWords > 0 {
+		filterInfo += fmt.Sprintf(" | Words: %d-%d", m.filters.MinWords, m.filters.MaxWords)
+	} else if m.filters.MinWords > 0 {
+		filterInfo += fmt.Sprintf(" | Words ≥ %d", m.filters.MinWords)
+	} else if m.filters.MaxWords > 0 {
+		filterInfo += fmt.Sprintf(" | Words ≤ %d", m.filters.MaxWords)
+	}
+	if m.filters.UnreadOnly {
+		filterInfo += " | Unread"
+	}
+	if m.filters.BookmarkedOnly {
+		filterInfo += " | ★"
+	}
+	return filterInfo
+}
+
+// reloadFromFirstPage resets paging and reloads after a filter change
+func (m *Model) reloadFromFirstPage() tea.Cmd {
+	m.page = 0
+	m.cursor = 0
+	m.loading = true
+	return m.loadStories()
+}
+
+func (m Model) trashStory(story db.Story) tea.Cmd {
+	return func() tea.Msg {
+		err := m.database.TrashStory(context.Background(), story.ID, "deleted")
+		return StoryTrashedMsg{ID: story.ID, Title: story.Title, Err: err}
+	}
+}
+
+// MarkRead shows a story as read without reloading the list
+func (m *Model) MarkRead(id string) {
+	for i := range m.stories {
+		if m.stories[i].ID == id {
+			m.stories[i].Read = true
+		}
+	}
+}
+
+// SetBookmarked updates a story's star without reloading the list
+func (m *Model) SetBookmarked(id string, bookmarked bool) {
+	for i := range m.stories {
+		if m.stories[i].ID == id {
+			m.stories[i].Bookmarked = bookmarked
+		}
+	}
+}
+
+// SetRating updates a story's rating without reloading
+func (m *Model) SetRating(id string, rating int) {
+	for i := range m.stories {
+		if m.stories[i].ID == id {
+			m.stories[i].Rating = rating
+		}
+	}
+}
+
+// SetHoax updates a story's hoax flag without reloading the list
+func (m *Model) SetHoax(id string, hoax bool) {
+	for i := range m.stories {
+		if m.stories[i].ID == id {
+			m.stories[i].Hoax = hoax
+		}
+	}
+}
+
+// SetRowColor sets what colors the titles in the list
+func (m *Model) SetRowColor(rowColor styles.RowColor) {
+	m.rowColor = rowColor
+}
+
+// SetReadOnly disables trashing stories
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+}
+
 // Reload refreshes the story list
 func (m *Model) Reload() tea.Cmd {
 	m.loading = true
@@ -219,11 +723,27 @@ func (m Model) View() string {
 	if m.showFilter {
 		return m.renderFilterView()
 	}
+	if m.showDateRange {
+		return m.renderDateRangeView()
+	}
+	if m.showLocation {
+		return m.renderLocationView()
+	}
+	if m.showDistance {
+		return m.renderDistanceView()
+	}
+	if m.showColumns {
+		return m.renderColumnMenu()
+	}
+	if m.showPresets {
+		return m.renderPresetMenu()
+	}
 
 	var b strings.Builder
+	listWidth := m.listWidth()
 
 	// Header
-	header := styles.HeaderStyle.Width(m.width - 4).Render(
+	header := styles.HeaderStyle.Width(listWidth - 4).Render(
 		fmt.Sprintf("Browse Stories (%d total)", m.total),
 	)
 	b.WriteString(header)
@@ -245,47 +765,17 @@ func (m Model) View() string {
 		return b.String()
 	}
 
-	// Calculate available height for list
-	listHeight := m.height - 8 // Header, footer, margins
-
-	// Story list
-	for i, story := range m.stories {
-		if i >= listHeight {
-			break
-		}
-
-		// Cursor indicator
-		cursor := "  "
-		itemStyle := styles.NormalItemStyle
-		if i == m.cursor {
-			cursor = "▸ "
-			itemStyle = styles.SelectedItemStyle
-		}
-
-		// Format story line
-		typeStr := story.FormattedType()
-		dateStr := story.FormattedDate()
-
-		// Truncate title if needed
-		maxTitleLen := m.width - 40
-		title := story.Title
-		if len(title) > maxTitleLen {
-			title = title[:maxTitleLen-3] + "..."
-		}
-
-		line := fmt.Sprintf("%s%-*s  %s  %s",
-			cursor,
-			maxTitleLen,
-			title,
-			styles.TypeBadge(typeStr),
-			styles.DimStyle.Render(dateStr),
-		)
-
-		if i == m.cursor {
-			b.WriteString(itemStyle.Width(m.width - 4).Render(line))
-		} else {
-			b.WriteString(line)
-		}
+	widths := m.columnWidths()
+	b.WriteString(m.renderColumnHeader(widths))
+	b.WriteString("\n")
+	b.WriteString(m.renderList(widths, listWidth-4))
+
+	if width := m.previewWidth(); width > 0 {
+		list := strings.TrimSuffix(b.String(), "\n")
+		b.Reset()
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(listWidth).Render(list),
+			m.renderPreview(width, m.height-6)))
 		b.WriteString("\n")
 	}
 
@@ -299,11 +789,7 @@ func (m Model) View() string {
 		totalPages = 1
 	}
 
-	// Active filters
-	filterInfo := ""
-	if m.filters.StoryType != "" {
-		filterInfo = fmt.Sprintf(" | Filter: %s", m.filters.StoryType)
-	}
+	filterInfo := m.filterInfo()
 
 	// Sort info
 	sortDir := "↓"
@@ -311,10 +797,25 @@ func (m Model) View() string {
 		sortDir = "↑"
 	}
 	sortInfo := fmt.Sprintf(" | Sort: %s%s", m.sort.Field, sortDir)
+	if m.groupedView() {
+		sortInfo += " (grouped)"
+	}
+
+	pageInfo := fmt.Sprintf("Page %d/%d", currentPage, totalPages)
+	if m.keyset() && m.page > 0 {
+		// Total was counted on the first page and may have drifted
+		pageInfo = fmt.Sprintf("Page %d/~%d", currentPage, totalPages)
+	}
+	if m.continuous {
+		pageInfo = fmt.Sprintf("Row %d/%d", m.cursor+1, m.total)
+		if m.fetchingMore {
+			pageInfo += " (loading more)"
+		}
+	}
 
 	footer := styles.DimStyle.Render(
-		fmt.Sprintf("Page %d/%d%s%s | n/p: page • f: filter • s/S: sort • c: clear • enter: view",
-			currentPage, totalPages, filterInfo, sortInfo),
+		fmt.Sprintf("%s%s%s | n/p: page • i: scroll • G: group • f: filter • F: presets • L: location • g: near • d: dates • s/S: sort • u: unread • W: min words • B: ★ only • C: columns • v: preview • x: trash • c: clear • enter: view",
+			pageInfo, filterInfo, sortInfo),
 	)
 	b.WriteString(footer)
 
@@ -337,7 +838,7 @@ func (m Model) renderFilterView() string {
 		style = styles.SelectedItemStyle
 	}
 	allLabel := "All Types"
-	if m.filters.StoryType == "" {
+	if len(m.selectedTypes()) == 0 {
 		allLabel += " (current)"
 	}
 	b.WriteString(style.Render(cursor + allLabel))
@@ -354,16 +855,94 @@ func (m Model) renderFilterView() string {
 			style = styles.SelectedItemStyle
 		}
 
-		label := fmt.Sprintf("%s %s", styles.TypeBadge(t), t)
-		if m.filters.StoryType == t {
-			label += " (current)"
+		check := "[ ]"
+		if m.filterSel[t] {
+			check = "[x]"
 		}
+		label := fmt.Sprintf("%s %s %s", check, styles.TypeBadge(t), t)
 		b.WriteString(style.Render(cursor + label))
 		b.WriteString("\n")
 	}
 
 	b.WriteString("\n")
-	b.WriteString(styles.DimStyle.Render("↑↓: navigate • enter: select • esc: cancel"))
+	b.WriteString(styles.DimStyle.Render("↑↓: navigate • space: toggle • enter: apply • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Render(b.String())
+}
+
+// InputActive reports whether a text field or the presets menu has
+// focus, so global keys should be passed through instead of switching views
+func (m Model) InputActive() bool {
+	return m.showDateRange || m.showLocation || m.showDistance || m.showPresets
+}
+
+// Typing reports whether keys are going into a text field, as opposed to
+// the presets menu
+func (m Model) Typing() bool {
+	return m.showDateRange || m.showLocation || m.showDistance || m.presetNaming
+}
+
+// SetStoryTypeFilter filters the list to the given story types.
+// Takes effect on the next load.
+func (m *Model) SetStoryTypeFilter(storyTypes []string) {
+	m.filters.StoryTypes = storyTypes
+	m.page = 0
+	m.cursor = 0
+}
+
+// SetFilters replaces all filters. Takes effect on the next load.
+func (m *Model) SetFilters(filters db.BrowseFilters) {
+	m.filters = filters
+	m.page = 0
+	m.cursor = 0
+}
+
+// NoMatches reports whether the loaded list came back empty
+func (m Model) NoMatches() bool {
+	return m.loaded && !m.loading && m.err == nil && len(m.stories) == 0
+}
+
+// Filters returns the active browse filters
+func (m Model) Filters() db.BrowseFilters {
+	return m.filters
+}
+
+// Sort returns the active browse sort
+func (m Model) Sort() db.BrowseSort {
+	return m.sort
+}
+
+func (m Model) renderDateRangeView() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render("Filter by Air Date"))
+	b.WriteString("\n\n")
+
+	labels := []string{"From", "To"}
+	for i, ti := range m.dateInputs {
+		style := styles.InputStyle
+		if i == m.dateFocus {
+			style = styles.FocusedInputStyle
+		}
+		b.WriteString(fmt.Sprintf("%-5s %s\n", labels[i], style.Render(ti.View())))
+	}
+
+	if m.dateErr != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(m.dateErr))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("YYYY-MM-DD, YYYY-MM, or YYYY • blank: no bound"))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("or a relative range in From: " + strings.Join(db.DateRangeExamples, ", ")))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("tab: switch field • enter: apply • esc: cancel"))
 
 	return lipgloss.NewStyle().
 		BorderStyle(lipgloss.RoundedBorder()).
@@ -0,0 +1,323 @@
+package browse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Column is one field shown in the story list. Width is in cells; 0 lets
+// the column take whatever space the others leave.
+type Column struct {
+	Name  string
+	Width int
+}
+
+// ColumnNames lists every column that can be shown, in menu order
+var ColumnNames = []string{"title", "type", "date", "show", "location", "cluster", "words", "rating", "read"}
+
+// defaultWidths apply when a column is shown without a width. Dates
+// are as wide as the configured format needs (see defaultWidth).
+var defaultWidths = map[string]int{
+	"title":    0,
+	"type":     17,
+	"date":     0,
+	"show":     20,
+	"location": 20,
+	"cluster":  7,
+	"words":    6,
+	"rating":   6,
+	"read":     4,
+}
+
+// DefaultColumns is the list layout when the config doesn't set one
+func DefaultColumns() []Column {
+	return []Column{{Name: "title"}, {Name: "type", Width: 17}, {Name: "date", Width: dates.Width()}}
+}
+
+// defaultWidth is the width a column gets when none is set
+func defaultWidth(name string) int {
+	if name == "date" {
+		return dates.Width()
+	}
+	return defaultWidths[name]
+}
+
+const (
+	minColumnWidth = 4
+	minTitleWidth  = 10
+	columnGap      = 2
+)
+
+// NewColumn validates a column name, filling in the default width if width is 0
+func NewColumn(name string, width int) (Column, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := defaultWidths[name]; !ok {
+		return Column{}, fmt.Errorf("unknown browse column %q (want one of: %s)", name, strings.Join(ColumnNames, ", "))
+	}
+	if width < 0 {
+		return Column{}, fmt.Errorf("browse column %q: width must not be negative", name)
+	}
+	if width == 0 {
+		width = defaultWidth(name)
+	}
+	if width > 0 && width < minColumnWidth {
+		width = minColumnWidth
+	}
+	return Column{Name: name, Width: width}, nil
+}
+
+// SetColumns sets which columns the list shows, in order
+func (m *Model) SetColumns(columns []Column) {
+	if len(columns) == 0 {
+		columns = DefaultColumns()
+	}
+	m.columns = append([]Column(nil), columns...)
+}
+
+// Columns returns the visible columns, in order
+func (m Model) Columns() []Column {
+	return m.columns
+}
+
+// columnHeader is the label shown above a column
+func columnHeader(name string) string {
+	if name == "words" {
+		return "Words"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// columnWidths resolves flexible columns against the space available
+func (m Model) columnWidths() []int {
+	widths := make([]int, len(m.columns))
+	used, flexible := 0, 0
+	for i, c := range m.columns {
+		widths[i] = c.Width
+		used += c.Width + columnGap
+		if c.Width == 0 {
+			flexible++
+		}
+	}
+	if flexible > 0 {
+		// Leave room for the cursor and the selected-row padding
+		share := (m.listWidth() - 8 - used) / flexible
+		if share < minTitleWidth {
+			share = minTitleWidth
+		}
+		for i := range widths {
+			if widths[i] == 0 {
+				widths[i] = share
+			}
+		}
+	}
+	return widths
+}
+
+// cell renders one story field padded or truncated to width. Titles are
+// colored by rowColor.
+func cell(story db.Story, name string, width int, rowColor styles.RowColor) string {
+	var text string
+	switch name {
+	case "title":
+		text = story.Title
+		if story.Bookmarked {
+			text = "★ " + text
+		}
+		title := rowColor.Title(truncate(text, width), story.Rating, story.Read)
+		if !story.Read {
+			title = styles.BoldStyle.Render(title)
+		}
+		return fit(title, width)
+	case "type":
+		// The badge adds a cell of padding on each side
+		return fit(styles.TypeBadge(truncate(story.FormattedType(), width-2)), width)
+	case "date":
+		return fit(styles.DimStyle.Render(truncate(story.FormattedDate(), width)), width)
+	case "show":
+		text = story.FormattedShow()
+	case "location":
+		text = story.FormattedLocation()
+	case "cluster":
+		text = "-"
+		if story.ClusterID != nil {
+			text = "#" + strconv.Itoa(*story.ClusterID)
+		}
+	case "words":
+		return fmt.Sprintf("%*d", width, story.WordCount())
+	case "rating":
+		if width < 5 {
+			// Too narrow for stars
+			text = strconv.Itoa(story.Rating)
+			if story.Rating == 0 {
+				text = "-"
+			}
+			break
+		}
+		return fit(styles.Stars(story.Rating), width)
+	case "read":
+		if story.Read {
+			return fit(styles.DimStyle.Render("✓"), width)
+		}
+		return fit(lipgloss.NewStyle().Foreground(styles.Primary).Render("●"), width)
+	}
+	return fit(truncate(text, width), width)
+}
+
+// truncate shortens s to at most width cells, marking the cut with "..."
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
+
+// fit pads a possibly styled string to width cells
+func fit(s string, width int) string {
+	if pad := width - lipgloss.Width(s); pad > 0 {
+		return s + strings.Repeat(" ", pad)
+	}
+	return s
+}
+
+// renderRow lays out a story's fields in the configured columns
+func (m Model) renderRow(story db.Story, widths []int) string {
+	cells := make([]string, len(m.columns))
+	for i, c := range m.columns {
+		cells[i] = cell(story, c.Name, widths[i], m.rowColor)
+	}
+	return strings.Join(cells, strings.Repeat(" ", columnGap))
+}
+
+// renderColumnHeader labels the columns above the list
+func (m Model) renderColumnHeader(widths []int) string {
+	cells := make([]string, len(m.columns))
+	for i, c := range m.columns {
+		cells[i] = fit(truncate(columnHeader(c.Name), widths[i]), widths[i])
+	}
+	return styles.DimStyle.Render("  " + strings.Join(cells, strings.Repeat(" ", columnGap)))
+}
+
+// columnItem is a row in the column menu
+type columnItem struct {
+	Column
+	visible bool
+}
+
+func (m *Model) openColumnMenu() {
+	m.showColumns = true
+	m.columnIdx = 0
+	m.columnItems = nil
+
+	shown := make(map[string]bool)
+	for _, c := range m.columns {
+		m.columnItems = append(m.columnItems, columnItem{Column: c, visible: true})
+		shown[c.Name] = true
+	}
+	for _, name := range ColumnNames {
+		if !shown[name] {
+			m.columnItems = append(m.columnItems, columnItem{Column: Column{Name: name, Width: defaultWidth(name)}})
+		}
+	}
+}
+
+func (m Model) handleColumnKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	items := m.columnItems
+	switch msg.String() {
+	case "esc", "C":
+		m.showColumns = false
+	case "up", "k":
+		if m.columnIdx > 0 {
+			m.columnIdx--
+		}
+	case "down", "j":
+		if m.columnIdx < len(items)-1 {
+			m.columnIdx++
+		}
+	case "K", "shift+up":
+		// Move the column earlier
+		if i := m.columnIdx; i > 0 {
+			items[i-1], items[i] = items[i], items[i-1]
+			m.columnIdx--
+		}
+	case "J", "shift+down":
+		if i := m.columnIdx; i < len(items)-1 {
+			items[i+1], items[i] = items[i], items[i+1]
+			m.columnIdx++
+		}
+	case " ", "x":
+		items[m.columnIdx].visible = !items[m.columnIdx].visible
+	case "+", "=", "right", "l":
+		if items[m.columnIdx].Width > 0 {
+			items[m.columnIdx].Width += 2
+		}
+	case "-", "left", "h":
+		if w := items[m.columnIdx].Width; w > minColumnWidth {
+			items[m.columnIdx].Width = max(minColumnWidth, w-2)
+		}
+	case "enter":
+		var columns []Column
+		for _, item := range items {
+			if item.visible {
+				columns = append(columns, item.Column)
+			}
+		}
+		if len(columns) == 0 {
+			return m, nil
+		}
+		m.columns = columns
+		m.showColumns = false
+	}
+	return m, nil
+}
+
+func (m Model) renderColumnMenu() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render("Columns"))
+	b.WriteString("\n\n")
+
+	for i, item := range m.columnItems {
+		cursor := "  "
+		style := styles.NormalItemStyle
+		if i == m.columnIdx {
+			cursor = "▸ "
+			style = styles.SelectedItemStyle
+		}
+		check := "[ ]"
+		if item.visible {
+			check = "[x]"
+		}
+		width := "auto"
+		if item.Width > 0 {
+			width = strconv.Itoa(item.Width)
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%s %-10s %5s", cursor, check, item.Name, width)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("space: show/hide • J/K: move • +/-: width"))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("enter: apply • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Render(b.String())
+}
@@ -0,0 +1,161 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// defaultRadiusKm pre-fills the radius field
+const defaultRadiusKm = 100
+
+// PlaceResolvedMsg carries coordinates for a typed place name
+type PlaceResolvedMsg struct {
+	Label    string
+	Point    *db.GeoPoint
+	RadiusKm float64
+	Err      error
+}
+
+func (m *Model) openDistanceFilter() {
+	m.showDistance = true
+	m.distanceFocus = 0
+	m.distanceErr = ""
+
+	place := textinput.New()
+	place.Placeholder = "place name or lat,lng"
+	place.CharLimit = 100
+	place.Width = 30
+	place.SetValue(m.filters.NearLabel)
+	place.Focus()
+
+	radius := textinput.New()
+	radius.Placeholder = "km"
+	radius.CharLimit = 6
+	radius.Width = 8
+	radius.SetValue(strconv.Itoa(defaultRadiusKm))
+	if m.filters.RadiusKm > 0 {
+		radius.SetValue(strconv.FormatFloat(m.filters.RadiusKm, 'f', -1, 64))
+	}
+
+	m.distanceInputs = [2]textinput.Model{place, radius}
+}
+
+func (m Model) handleDistanceKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.showDistance = false
+		return m, nil
+	case "tab", "shift+tab", "up", "down":
+		m.distanceInputs[m.distanceFocus].Blur()
+		m.distanceFocus = 1 - m.distanceFocus
+		m.distanceInputs[m.distanceFocus].Focus()
+		return m, textinput.Blink
+	case "enter":
+		label := strings.TrimSpace(m.distanceInputs[0].Value())
+		if label == "" {
+			// Blank place clears the distance filter
+			m.filters.Near = nil
+			m.filters.NearLabel = ""
+			m.filters.RadiusKm = 0
+			m.showDistance = false
+			return m, m.reloadFromFirstPage()
+		}
+
+		radius, err := strconv.ParseFloat(strings.TrimSpace(m.distanceInputs[1].Value()), 64)
+		if err != nil || radius <= 0 {
+			m.distanceErr = "Radius must be a positive number of km"
+			return m, nil
+		}
+
+		if point, err := db.ParseGeoPoint(label); err == nil {
+			return m.applyDistance(PlaceResolvedMsg{Label: label, Point: point, RadiusKm: radius})
+		}
+
+		// Not a point, so look the place up among geocoded stories
+		m.distanceErr = ""
+		return m, m.resolvePlace(label, radius)
+	}
+
+	var cmd tea.Cmd
+	m.distanceInputs[m.distanceFocus], cmd = m.distanceInputs[m.distanceFocus].Update(msg)
+	return m, cmd
+}
+
+func (m Model) resolvePlace(label string, radius float64) tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		point, err := m.database.ResolvePlace(ctx, label)
+		return PlaceResolvedMsg{Label: label, Point: point, RadiusKm: radius, Err: err}
+	}
+}
+
+func (m Model) applyDistance(msg PlaceResolvedMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.distanceErr = msg.Err.Error()
+		return m, nil
+	}
+
+	m.filters.Near = msg.Point
+	m.filters.NearLabel = msg.Label
+	m.filters.RadiusKm = msg.RadiusKm
+	m.showDistance = false
+	return m, m.reloadFromFirstPage()
+}
+
+// distanceLabel describes the active distance filter, or "" if none
+func (m Model) distanceLabel() string {
+	if m.filters.Near == nil {
+		return ""
+	}
+	return fmt.Sprintf("≤%gkm of %s", m.filters.RadiusKm, m.filters.NearLabel)
+}
+
+func (m Model) renderDistanceView() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render("Filter by Distance"))
+	b.WriteString("\n\n")
+
+	labels := []string{"Near", "Within"}
+	for i, ti := range m.distanceInputs {
+		style := styles.InputStyle
+		if i == m.distanceFocus {
+			style = styles.FocusedInputStyle
+		}
+		suffix := ""
+		if i == 1 {
+			suffix = " km"
+		}
+		b.WriteString(fmt.Sprintf("%-7s %s%s\n", labels[i], style.Render(ti.View()), suffix))
+	}
+
+	if m.distanceErr != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(m.distanceErr))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("Only geocoded stories match • blank place: clear"))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("tab: switch field • enter: apply • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Render(b.String())
+}
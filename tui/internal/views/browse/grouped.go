@@ -0,0 +1,114 @@
+package browse
+
+import (
+	"fmt"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+)
+
+// SetGrouped nests stories under episode headers. Grouping needs the
+// episode sort, so turning it on switches to it. Takes effect on the
+// next load.
+func (m *Model) SetGrouped(grouped bool) {
+	m.grouped = grouped
+	if grouped && m.sort.Field != "episode" {
+		m.sort = db.BrowseSort{Field: "episode"}
+	}
+	m.page = 0
+	m.cursor = 0
+	m.top = 0
+}
+
+// groupedView reports whether the list is shown under episode headers
+func (m Model) groupedView() bool {
+	return m.grouped && m.sort.Field == "episode"
+}
+
+// episodeKey identifies the episode a story belongs to
+func episodeKey(s db.Story) string {
+	return s.FormattedDate() + "\x00" + s.FormattedShow()
+}
+
+// startsGroup reports whether story i is the first of its episode
+func (m Model) startsGroup(i int) bool {
+	return i == 0 || episodeKey(m.stories[i-1]) != episodeKey(m.stories[i])
+}
+
+// collapsedAt reports whether story i's episode is collapsed
+func (m Model) collapsedAt(i int) bool {
+	return m.groupedView() && m.collapsed[episodeKey(m.stories[i])]
+}
+
+// rowVisible reports whether the cursor can land on story i. A collapsed
+// episode keeps its first story so the cursor can reach its header.
+func (m Model) rowVisible(i int) bool {
+	return !m.collapsedAt(i) || m.startsGroup(i)
+}
+
+// rowLines is how many lines story i takes, counting its episode header
+// when it opens a group or is the first row on screen
+func (m Model) rowLines(i int) int {
+	if !m.groupedView() {
+		return 1
+	}
+	if !m.rowVisible(i) {
+		return 0
+	}
+	lines := 0
+	if m.startsGroup(i) || i == m.top {
+		lines++
+	}
+	if !m.collapsedAt(i) {
+		lines++
+	}
+	return lines
+}
+
+// toggleGroup collapses or expands the episode under the cursor
+func (m *Model) toggleGroup() {
+	if !m.groupedView() || m.cursor >= len(m.stories) {
+		return
+	}
+	if m.collapsed == nil {
+		m.collapsed = make(map[string]bool)
+	}
+	key := episodeKey(m.stories[m.cursor])
+	m.collapsed[key] = !m.collapsed[key]
+
+	// Keep the cursor on the group's header when it folds away
+	for m.cursor > 0 && !m.rowVisible(m.cursor) {
+		m.cursor--
+	}
+	m.follow()
+}
+
+// groupSize counts the loaded stories in story i's episode. The episode
+// sort keeps them together, so only that run is scanned.
+func (m Model) groupSize(i int) int {
+	key := episodeKey(m.stories[i])
+	first, last := i, i
+	for first > 0 && episodeKey(m.stories[first-1]) == key {
+		first--
+	}
+	for last < len(m.stories)-1 && episodeKey(m.stories[last+1]) == key {
+		last++
+	}
+	return last - first + 1
+}
+
+// renderGroupHeader renders an episode's date, show and story count
+func (m Model) renderGroupHeader(i int, width int) string {
+	story := m.stories[i]
+	marker := "▾"
+	if m.collapsedAt(i) {
+		marker = "▸"
+	}
+	line := truncate(fmt.Sprintf("%s %s  %s", marker, story.FormattedDate(), story.FormattedShow()), width-10)
+	line += styles.DimStyle.Render(fmt.Sprintf("  (%d)", m.groupSize(i)))
+
+	if m.collapsedAt(i) && i == m.cursor {
+		return styles.SelectedItemStyle.Width(width).Render("▸ " + line)
+	}
+	return styles.BoldStyle.Render("  " + line)
+}
@@ -0,0 +1,160 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxSuggestions caps the autocomplete list
+const maxSuggestions = 10
+
+// LocationsLoadedMsg indicates the distinct locations have loaded
+type LocationsLoadedMsg struct {
+	Locations []db.LocationCount
+	Err       error
+}
+
+func (m Model) loadLocations() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		locations, err := m.database.GetLocations(ctx)
+		return LocationsLoadedMsg{Locations: locations, Err: err}
+	}
+}
+
+func (m *Model) openLocationFilter() tea.Cmd {
+	m.showLocation = true
+	m.locationIdx = -1
+
+	ti := textinput.New()
+	ti.Placeholder = "Ohio, Scotland..."
+	ti.CharLimit = 100
+	ti.Width = 40
+	ti.SetValue(m.filters.Location)
+	ti.CursorEnd()
+	ti.Focus()
+	m.locationInput = ti
+
+	// Locations are loaded once and matched in memory as the user types
+	if m.locations == nil {
+		return tea.Batch(textinput.Blink, m.loadLocations())
+	}
+	return textinput.Blink
+}
+
+// locationSuggestions returns known locations containing the typed text
+func (m Model) locationSuggestions() []db.LocationCount {
+	needle := strings.ToLower(strings.TrimSpace(m.locationInput.Value()))
+
+	var matches []db.LocationCount
+	for _, lc := range m.locations {
+		if needle == "" || strings.Contains(strings.ToLower(lc.Location), needle) {
+			matches = append(matches, lc)
+			if len(matches) == maxSuggestions {
+				break
+			}
+		}
+	}
+	return matches
+}
+
+func (m Model) handleLocationKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	suggestions := m.locationSuggestions()
+
+	switch msg.String() {
+	case "esc":
+		m.showLocation = false
+		return m, nil
+	case "up":
+		if m.locationIdx >= 0 {
+			m.locationIdx--
+		}
+		return m, nil
+	case "down":
+		if m.locationIdx < len(suggestions)-1 {
+			m.locationIdx++
+		}
+		return m, nil
+	case "tab":
+		// Complete to the highlighted (or first) suggestion
+		idx := max(m.locationIdx, 0)
+		if idx < len(suggestions) {
+			m.locationInput.SetValue(suggestions[idx].Location)
+			m.locationInput.CursorEnd()
+			m.locationIdx = -1
+		}
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.locationInput.Value())
+		if m.locationIdx >= 0 && m.locationIdx < len(suggestions) {
+			value = suggestions[m.locationIdx].Location
+		}
+		m.filters.Location = value
+		m.showLocation = false
+		m.page = 0
+		m.cursor = 0
+		m.loading = true
+		return m, m.loadStories()
+	}
+
+	var cmd tea.Cmd
+	m.locationInput, cmd = m.locationInput.Update(msg)
+	m.locationIdx = -1
+	return m, cmd
+}
+
+func (m Model) renderLocationView() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render("Filter by Location"))
+	b.WriteString("\n\n")
+	b.WriteString(styles.FocusedInputStyle.Render(m.locationInput.View()))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.locationErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.locationErr)))
+		b.WriteString("\n")
+	case m.locations == nil:
+		b.WriteString(styles.DimStyle.Render("Loading locations..."))
+		b.WriteString("\n")
+	default:
+		suggestions := m.locationSuggestions()
+		if len(suggestions) == 0 {
+			b.WriteString(styles.DimStyle.Render("No matching locations (substring match still applies)"))
+			b.WriteString("\n")
+		}
+		for i, lc := range suggestions {
+			cursor := "  "
+			style := styles.NormalItemStyle
+			if i == m.locationIdx {
+				cursor = "▸ "
+				style = styles.SelectedItemStyle
+			}
+			b.WriteString(style.Render(fmt.Sprintf("%s%-30s %s", cursor, lc.Location,
+				styles.DimStyle.Render(fmt.Sprintf("%d", lc.Count)))))
+			b.WriteString("\n")
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("↑↓: pick • tab: complete • enter: apply (blank clears) • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Render(b.String())
+}
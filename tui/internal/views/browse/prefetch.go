@@ -0,0 +1,110 @@
+package browse
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"paranormal-tui/internal/db"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// prefetchDelay is how long a page stays on screen before the next one
+// is fetched, so paging quickly doesn't start a query per page passed
+const prefetchDelay = 300 * time.Millisecond
+
+// PrefetchTickMsg fires once a page has been shown for prefetchDelay
+type PrefetchTickMsg struct {
+	Page int // Page to fetch
+	Key  string
+}
+
+// PagePrefetchedMsg carries a page fetched ahead of time
+type PagePrefetchedMsg struct {
+	Page    int
+	Key     string
+	Stories []db.Story
+	Total   int
+	Err     error
+}
+
+// prefetched is the next page, held until n is pressed
+type prefetched struct {
+	page    int
+	key     string
+	stories []db.Story
+	total   int
+}
+
+// queryKey identifies the filters and sort a page was fetched with
+func (m Model) queryKey() string {
+	key, _ := json.Marshal(struct {
+		Filters db.BrowseFilters
+		Sort    db.BrowseSort
+	}{m.filters, m.sort})
+	return string(key)
+}
+
+// hasNextPage reports whether paging forward would load anything
+func (m Model) hasNextPage() bool {
+	if m.keyset() {
+		return len(m.stories) == pageSize && (m.page+1)*pageSize < m.total
+	}
+	return m.page < (m.total-1)/pageSize
+}
+
+// schedulePrefetch starts the delay before fetching the next page
+func (m *Model) schedulePrefetch() tea.Cmd {
+	m.next = nil
+	if m.continuous || !m.hasNextPage() {
+		return nil
+	}
+	tick := PrefetchTickMsg{Page: m.page + 1, Key: m.queryKey()}
+	return tea.Tick(prefetchDelay, func(time.Time) tea.Msg {
+		return tick
+	})
+}
+
+// prefetch fetches the next page if the user is still on the page that
+// scheduled it
+func (m Model) prefetch(msg PrefetchTickMsg) tea.Cmd {
+	if m.database == nil || m.loading || msg.Page != m.page+1 || msg.Key != m.queryKey() || len(m.stories) == 0 {
+		return nil
+	}
+	filters, sort, total := m.filters, m.sort, m.total
+	after := db.CursorAfter(m.stories[len(m.stories)-1])
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		if m.keyset() {
+			stories, err := m.database.ListStoriesAfter(ctx, pageSize, after, &filters, sort.Ascending)
+			return PagePrefetchedMsg{Page: msg.Page, Key: msg.Key, Stories: stories, Total: total, Err: err}
+		}
+		stories, total, err := m.database.ListStories(ctx, pageSize, msg.Page*pageSize, &filters, &sort)
+		return PagePrefetchedMsg{Page: msg.Page, Key: msg.Key, Stories: stories, Total: total, Err: err}
+	}
+}
+
+// storePrefetched keeps a prefetched page unless the user has moved on
+// or changed the filters since it was requested
+func (m *Model) storePrefetched(msg PagePrefetchedMsg) {
+	if msg.Err != nil || msg.Page != m.page+1 || msg.Key != m.queryKey() {
+		return
+	}
+	m.next = &prefetched{page: msg.Page, key: msg.Key, stories: msg.Stories, total: msg.Total}
+}
+
+// takePrefetched shows the prefetched page if it's the one now wanted
+func (m *Model) takePrefetched() bool {
+	next := m.next
+	m.next = nil
+	if next == nil || next.page != m.page || next.key != m.queryKey() {
+		return false
+	}
+	m.stories = next.stories
+	m.total = next.total
+	m.err = nil
+	m.follow()
+	return true
+}
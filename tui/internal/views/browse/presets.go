@@ -0,0 +1,203 @@
+package browse
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PresetsLoadedMsg carries the saved filter presets
+type PresetsLoadedMsg struct {
+	Presets []db.Preset
+	Err     error
+}
+
+// PresetsChangedMsg reports a preset saved or deleted
+type PresetsChangedMsg struct {
+	Err error
+}
+
+func (m Model) loadPresets() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		presets, err := m.database.ListPresets(context.Background())
+		return PresetsLoadedMsg{Presets: presets, Err: err}
+	}
+}
+
+func (m *Model) openPresetMenu() tea.Cmd {
+	m.showPresets = true
+	m.presetIdx = 0
+	m.presetNaming = false
+	m.presetErr = nil
+	return m.loadPresets()
+}
+
+// savePreset stores the current filters and sort under name
+func (m Model) savePreset(name string) tea.Cmd {
+	preset := db.Preset{Name: name, Filters: m.filters, Sort: m.sort}
+	return func() tea.Msg {
+		return PresetsChangedMsg{Err: m.database.SavePreset(context.Background(), preset)}
+	}
+}
+
+func (m Model) deletePreset(name string) tea.Cmd {
+	return func() tea.Msg {
+		return PresetsChangedMsg{Err: m.database.DeletePreset(context.Background(), name)}
+	}
+}
+
+// applyPreset replaces the filters and sort and reloads
+func (m Model) applyPreset(p db.Preset) (Model, tea.Cmd) {
+	m.filters = p.Filters
+	m.sort = p.Sort
+	if !slices.Contains(db.SortFields, m.sort.Field) {
+		m.sort.Field = "date"
+	}
+	m.showPresets = false
+	return m, m.reloadFromFirstPage()
+}
+
+func (m Model) handlePresetKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.presetNaming {
+		switch msg.String() {
+		case "esc":
+			m.presetNaming = false
+			return m, nil
+		case "enter":
+			name := strings.TrimSpace(m.presetInput.Value())
+			if name == "" {
+				return m, nil
+			}
+			m.presetNaming = false
+			return m, m.savePreset(name)
+		}
+		var cmd tea.Cmd
+		m.presetInput, cmd = m.presetInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "F":
+		m.showPresets = false
+	case "up", "k":
+		if m.presetIdx > 0 {
+			m.presetIdx--
+		}
+	case "down", "j":
+		if m.presetIdx < len(m.presets)-1 {
+			m.presetIdx++
+		}
+	case "enter":
+		if m.presetIdx < len(m.presets) {
+			return m.applyPreset(m.presets[m.presetIdx])
+		}
+	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// One keystroke applies a preset
+		if i := int(msg.String()[0] - '1'); i < len(m.presets) {
+			return m.applyPreset(m.presets[i])
+		}
+	case "a":
+		if m.readOnly {
+			return m, nil
+		}
+		ti := textinput.New()
+		ti.Placeholder = "UK cryptids, newest first"
+		ti.CharLimit = 60
+		ti.Width = 40
+		ti.Focus()
+		m.presetInput = ti
+		m.presetNaming = true
+		return m, textinput.Blink
+	case "x":
+		if !m.readOnly && m.presetIdx < len(m.presets) {
+			return m, m.deletePreset(m.presets[m.presetIdx].Name)
+		}
+	}
+	return m, nil
+}
+
+// presetSummary describes a preset's filters and sort on one line
+func (m Model) presetSummary(p db.Preset) string {
+	view := m
+	view.filters = p.Filters
+	info := strings.TrimPrefix(view.filterInfo(), " | ")
+	if info == "" {
+		info = "No filters"
+	}
+	dir := "↓"
+	if p.Sort.Ascending {
+		dir = "↑"
+	}
+	return fmt.Sprintf("%s | Sort: %s%s", info, p.Sort.Field, dir)
+}
+
+func (m Model) renderPresetMenu() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Render("Filter Presets"))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.presetErr != nil:
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.presetErr)))
+		b.WriteString("\n")
+	case m.presets == nil:
+		b.WriteString(styles.DimStyle.Render("Loading presets..."))
+		b.WriteString("\n")
+	case len(m.presets) == 0:
+		b.WriteString(styles.DimStyle.Render("No presets yet"))
+		b.WriteString("\n")
+	}
+
+	for i, p := range m.presets {
+		cursor := "  "
+		style := styles.NormalItemStyle
+		if i == m.presetIdx {
+			cursor = "▸ "
+			style = styles.SelectedItemStyle
+		}
+		shortcut := " "
+		if i < 9 {
+			shortcut = fmt.Sprintf("%d", i+1)
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%s%s  %s", cursor, shortcut, p.Name)))
+		b.WriteString("\n")
+		b.WriteString(styles.DimStyle.Render("      " + m.presetSummary(p)))
+		b.WriteString("\n")
+	}
+
+	if m.presetNaming {
+		b.WriteString("\n")
+		b.WriteString("Save current filters as\n")
+		b.WriteString(styles.FocusedInputStyle.Render(m.presetInput.View()))
+		b.WriteString("\n\n")
+		b.WriteString(styles.DimStyle.Render("enter: save (same name replaces) • esc: cancel"))
+	} else {
+		b.WriteString("\n")
+		b.WriteString(styles.DimStyle.Render("Current: " + m.presetSummary(db.Preset{Filters: m.filters, Sort: m.sort})))
+		b.WriteString("\n\n")
+		help := "1-9/enter: apply • a: save current • x: delete • esc: close"
+		if m.readOnly {
+			help = "1-9/enter: apply • esc: close"
+		}
+		b.WriteString(styles.DimStyle.Render(help))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Render(b.String())
+}
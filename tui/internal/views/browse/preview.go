@@ -0,0 +1,91 @@
+package browse
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	// minPreviewListWidth keeps the story list usable beside the preview
+	minPreviewListWidth = 60
+	// minPreviewWidth hides the preview when there's no room for it
+	minPreviewWidth = 30
+	// previewExcerptChars is how much content stands in for a missing summary
+	previewExcerptChars = 600
+)
+
+// SetPreview shows or hides the summary preview pane
+func (m *Model) SetPreview(show bool) {
+	m.showPreview = show
+}
+
+// previewWidth returns the preview pane width, or 0 when it's hidden or
+// the terminal is too narrow for both it and the list
+func (m Model) previewWidth() int {
+	if !m.showPreview {
+		return 0
+	}
+	width := m.width * 2 / 5
+	if width < minPreviewWidth || m.width-width < minPreviewListWidth {
+		return 0
+	}
+	return width
+}
+
+// listWidth is the width left for the story list
+func (m Model) listWidth() int {
+	return m.width - m.previewWidth()
+}
+
+// renderPreview renders the selected story's metadata and summary
+func (m Model) renderPreview(width, height int) string {
+	if m.cursor >= len(m.stories) {
+		return ""
+	}
+	story := m.stories[m.cursor]
+	inner := width - 4 // Border and padding
+
+	var b strings.Builder
+	b.WriteString(styles.BoldStyle.Foreground(styles.Primary).Width(inner).Render(story.Title))
+	b.WriteString("\n\n")
+
+	meta := func(label, value string) {
+		b.WriteString(fmt.Sprintf("%s %s\n", styles.DimStyle.Render(label), value))
+	}
+	meta("Show:", story.FormattedShow())
+	meta("Date:", story.FormattedDate())
+	meta("Type:", styles.TypeBadge(story.FormattedType()))
+	meta("Location:", story.FormattedLocation())
+	meta("Words:", fmt.Sprintf("%d", story.WordCount()))
+	b.WriteString("\n")
+
+	if story.Summary.Valid && strings.TrimSpace(story.Summary.String) != "" {
+		b.WriteString(lipgloss.NewStyle().Width(inner).Render(story.Summary.String))
+	} else {
+		// No summary yet; show the opening of the story instead
+		excerpt := strings.Join(strings.Fields(story.Content), " ")
+		if len(excerpt) > previewExcerptChars {
+			excerpt = strings.TrimSpace(excerpt[:previewExcerptChars]) + "…"
+		}
+		b.WriteString(styles.DimStyle.Render("No summary.") + "\n\n")
+		b.WriteString(styles.DimStyle.Width(inner).Render(excerpt))
+	}
+
+	// Clip to the pane so long summaries don't push the layout down
+	lines := strings.Split(b.String(), "\n")
+	if len(lines) > height-2 {
+		lines = append(lines[:max(0, height-3)], styles.DimStyle.Render("… enter: full story"))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height - 2).
+		Render(strings.Join(lines, "\n"))
+}
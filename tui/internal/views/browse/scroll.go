@@ -0,0 +1,160 @@
+package browse
+
+import (
+	"context"
+
+	"paranormal-tui/internal/db"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// scrollChunk is how many stories continuous mode fetches at a time
+	scrollChunk = 50
+	// scrollMargin is how close to the end the cursor gets before the
+	// next chunk is fetched
+	scrollMargin = 10
+)
+
+// StoriesAppendedMsg carries the next chunk in continuous mode
+type StoriesAppendedMsg struct {
+	Offset  int // len(stories) when the fetch started; stale chunks are dropped
+	Stories []db.Story
+	Total   int
+	Err     error
+}
+
+// SetContinuous switches between pages and one continuously scrolling
+// list. Takes effect on the next load.
+func (m *Model) SetContinuous(continuous bool) {
+	m.continuous = continuous
+	m.page = 0
+	m.cursor = 0
+	m.top = 0
+}
+
+// chunkSize is how many stories one load fetches
+func (m Model) chunkSize() int {
+	if m.continuous {
+		return scrollChunk
+	}
+	return pageSize
+}
+
+// visibleRows is how many stories fit in the list
+func (m Model) visibleRows() int {
+	return m.height - 9 // Header, column labels, footer, margins
+}
+
+// follow scrolls the list so the cursor stays on screen
+func (m *Model) follow() {
+	rows := max(1, m.visibleRows())
+	if m.cursor < m.top {
+		m.top = m.cursor
+	}
+	if m.groupedView() {
+		// Episode headers take lines too, so count them
+		for m.top < m.cursor && m.linesThrough(m.cursor) > rows {
+			m.top++
+		}
+		return
+	}
+	if m.cursor >= m.top+rows {
+		m.top = m.cursor - rows + 1
+	}
+	if m.top > max(0, len(m.stories)-rows) {
+		m.top = max(0, len(m.stories)-rows)
+	}
+}
+
+// linesThrough counts the lines from the top of the list to story i
+func (m Model) linesThrough(i int) int {
+	lines := 0
+	for j := m.top; j <= i && j < len(m.stories); j++ {
+		lines += m.rowLines(j)
+	}
+	return lines
+}
+
+// moveCursor moves by delta rows, skipping stories folded into a
+// collapsed episode, and fetches the next chunk when the cursor nears
+// the end of what's loaded
+func (m *Model) moveCursor(delta int) tea.Cmd {
+	step := 1
+	if delta < 0 {
+		step, delta = -1, -delta
+	}
+	for ; delta > 0; delta-- {
+		next := m.cursor + step
+		for next >= 0 && next < len(m.stories) && !m.rowVisible(next) {
+			next += step
+		}
+		if next < 0 || next >= len(m.stories) {
+			break
+		}
+		m.cursor = next
+	}
+	m.follow()
+
+	if !m.continuous || m.loading || m.fetchingMore || m.exhausted {
+		return nil
+	}
+	if m.cursor < len(m.stories)-scrollMargin {
+		return nil
+	}
+	m.fetchingMore = true
+	return m.loadMore()
+}
+
+// loadMore fetches the chunk after the last loaded story
+func (m Model) loadMore() tea.Cmd {
+	if m.database == nil || len(m.stories) == 0 {
+		return nil
+	}
+	offset := len(m.stories)
+	last := m.stories[offset-1]
+	filters, sort, total := m.filters, m.sort, m.total
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		if m.keyset() {
+			stories, err := m.database.ListStoriesAfter(ctx, scrollChunk, db.CursorAfter(last), &filters, sort.Ascending)
+			return StoriesAppendedMsg{Offset: offset, Stories: stories, Total: total, Err: err}
+		}
+		stories, total, err := m.database.ListStories(ctx, scrollChunk, offset, &filters, &sort)
+		return StoriesAppendedMsg{Offset: offset, Stories: stories, Total: total, Err: err}
+	}
+}
+
+// appendStories adds a fetched chunk to the end of the list
+func (m Model) appendStories(msg StoriesAppendedMsg) (Model, tea.Cmd) {
+	if msg.Offset != len(m.stories) || !m.continuous {
+		// The list was reloaded while this chunk was in flight
+		return m, nil
+	}
+	m.fetchingMore = false
+	if msg.Err != nil {
+		m.err = msg.Err
+		return m, nil
+	}
+	m.stories = append(m.stories, msg.Stories...)
+	m.total = msg.Total
+	m.exhausted = len(msg.Stories) < scrollChunk
+	return m, nil
+}
+
+// removeStory drops a story from the loaded list in place, so continuous
+// mode keeps its position instead of reloading from the top
+func (m *Model) removeStory(id string) {
+	for i := range m.stories {
+		if m.stories[i].ID == id {
+			m.stories = append(m.stories[:i], m.stories[i+1:]...)
+			m.total--
+			break
+		}
+	}
+	if m.cursor >= len(m.stories) {
+		m.cursor = max(0, len(m.stories)-1)
+	}
+	m.follow()
+}
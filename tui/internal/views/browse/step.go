@@ -0,0 +1,69 @@
+package browse
+
+import (
+	"paranormal-tui/internal/db"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// StoryStepMsg carries the story ctrl+n or ctrl+p in the story view
+// stepped to, once the page it's on has loaded
+type StoryStepMsg struct {
+	Story db.Story
+}
+
+// Step moves the cursor to the next (delta 1) or previous (-1) story, as
+// ctrl+n and ctrl+p in the story view do, in the list's order and with its
+// filters. It returns the story now selected; past the edge of a page it
+// turns the page instead, and the command sends a StoryStepMsg once the
+// story has loaded. Both are nil at either end of the list.
+func (m *Model) Step(delta int) (*db.Story, tea.Cmd) {
+	if m.loading || len(m.stories) == 0 {
+		return nil, nil
+	}
+	from := m.cursor
+	cmd := m.moveCursor(delta)
+	if m.cursor != from {
+		return &m.stories[m.cursor], cmd
+	}
+	if m.continuous {
+		return nil, cmd
+	}
+
+	if delta > 0 {
+		cmd, ok := m.nextPage()
+		switch {
+		case !ok:
+			return nil, nil
+		case !m.loading:
+			// The page was prefetched
+			return &m.stories[0], cmd
+		}
+		m.stepTo = 1
+		return nil, cmd
+	}
+	if m.page == 0 {
+		return nil, nil
+	}
+	m.page--
+	m.loading = true
+	m.stepTo = -1
+	return nil, m.loadStories()
+}
+
+// finishStep selects the story a step past the edge of a page was for,
+// now the page has loaded
+func (m *Model) finishStep() tea.Cmd {
+	to := m.stepTo
+	m.stepTo = 0
+	if to == 0 || len(m.stories) == 0 {
+		return nil
+	}
+	m.cursor = 0
+	if to < 0 {
+		m.cursor = len(m.stories) - 1
+	}
+	m.follow()
+	story := m.stories[m.cursor]
+	return func() tea.Msg { return StoryStepMsg{Story: story} }
+}
@@ -0,0 +1,59 @@
+package browse
+
+import (
+	"strings"
+
+	"paranormal-tui/internal/styles"
+)
+
+// windowLine is one line of the list: a story, or the episode header
+// above it
+type windowLine struct {
+	index  int
+	header bool
+}
+
+// window lays out the lines that fit on screen, starting at m.top. Only
+// these are rendered, so a frame costs the same whether the list holds a
+// page or everything continuous scroll has loaded.
+func (m Model) window(height int) []windowLine {
+	lines := make([]windowLine, 0, max(0, height))
+	grouped := m.groupedView()
+	for i := m.top; i < len(m.stories) && len(lines) < height; i++ {
+		if !grouped {
+			lines = append(lines, windowLine{index: i})
+			continue
+		}
+		if !m.rowVisible(i) {
+			continue
+		}
+		if m.startsGroup(i) || i == m.top {
+			lines = append(lines, windowLine{index: i, header: true})
+		}
+		if !m.collapsedAt(i) && len(lines) < height {
+			lines = append(lines, windowLine{index: i})
+		}
+	}
+	return lines
+}
+
+// renderList renders the stories in the window
+func (m Model) renderList(widths []int, width int) string {
+	var b strings.Builder
+	for _, line := range m.window(m.visibleRows()) {
+		if line.header {
+			b.WriteString(m.renderGroupHeader(line.index, width))
+			b.WriteString("\n")
+			continue
+		}
+
+		row := m.renderRow(m.stories[line.index], widths)
+		if line.index == m.cursor {
+			b.WriteString(styles.SelectedItemStyle.Width(width).Render("▸ " + row))
+		} else {
+			b.WriteString("  " + row)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
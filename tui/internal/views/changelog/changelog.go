@@ -0,0 +1,241 @@
+package changelog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/export"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// spans are the numbers of weeks w cycles through
+var spans = []int{12, 26, 52, 104}
+
+// barWidth is the widest the bar of stories added gets
+const barWidth = 20
+
+// Model represents the changelog screen: how the corpus changed week by
+// week
+type Model struct {
+	database  *db.DB
+	exportDir string // "" if the changelog can't be exported from here
+	span      int    // Index in spans
+	weeks     []db.ChangelogWeek
+	offset    int // First week shown
+	loading   bool
+	exporting bool // Waiting for the format to export in
+	status    string
+	err       error
+	width     int
+	height    int
+}
+
+// New creates a new changelog model
+func New(database *db.DB) Model {
+	return Model{database: database, loading: true}
+}
+
+// SetExportDir allows exporting the changelog to dir, which writes a file
+// on this machine
+func (m *Model) SetExportDir(dir string) {
+	m.exportDir = dir
+}
+
+// Init loads the changelog
+func (m Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// ChangelogLoadedMsg carries the weeks of the changelog
+type ChangelogLoadedMsg struct {
+	Weeks []db.ChangelogWeek
+	Err   error
+}
+
+// ChangelogExportedMsg reports the changelog written to a file
+type ChangelogExportedMsg struct {
+	Path string
+	Err  error
+}
+
+func (m Model) load() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	weeks := spans[m.span]
+	return func() tea.Msg {
+		changelog, err := m.database.Changelog(context.Background(), weeks)
+		return ChangelogLoadedMsg{Weeks: changelog, Err: err}
+	}
+}
+
+// Reload refreshes the changelog
+func (m *Model) Reload() tea.Cmd {
+	m.loading = true
+	return m.load()
+}
+
+func (m Model) export(format string) tea.Cmd {
+	weeks, dir := m.weeks, m.exportDir
+	return func() tea.Msg {
+		path, err := export.WriteChangelog(weeks, format, dir, time.Now())
+		return ChangelogExportedMsg{Path: path, Err: err}
+	}
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ChangelogLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		m.weeks = msg.Weeks
+		m.offset = min(m.offset, max(0, len(m.weeks)-1))
+		return m, nil
+
+	case ChangelogExportedMsg:
+		if msg.Err != nil {
+			m.status = styles.ErrorStyle.Render("Export failed: " + msg.Err.Error())
+		} else {
+			m.status = styles.SuccessStyle.Render("Exported to " + msg.Path)
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.exporting {
+			m.exporting = false
+			m.status = ""
+			switch msg.String() {
+			case "m":
+				return m, m.export(export.Markdown)
+			case "j":
+				return m, m.export(export.JSON)
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			if m.offset > 0 {
+				m.offset--
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			if m.offset < len(m.weeks)-m.listHeight() {
+				m.offset++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
+			m.span = (m.span + 1) % len(spans)
+			m.offset = 0
+			return m, m.Reload()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.Reload()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			if m.exportDir == "" || m.loading || m.err != nil {
+				return m, nil
+			}
+			m.exporting = true
+			m.status = "Export as m: Markdown • j: JSON • esc: cancel"
+		}
+	}
+
+	return m, nil
+}
+
+// listHeight is how many weeks fit on screen
+func (m Model) listHeight() int {
+	return max(1, m.height-9)
+}
+
+// View renders the changelog screen
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Width(m.width - 4).Render(fmt.Sprintf("Changelog (last %d weeks)", spans[m.span])))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString("\n  Loading...")
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+		return b.String()
+	}
+
+	var total db.ChangelogWeek
+	most := 1
+	for _, w := range m.weeks {
+		total.Added += w.Added
+		total.Approved += w.Approved
+		total.Edits += w.Edits
+		total.Merged += w.Merged
+		most = max(most, w.Added)
+	}
+	b.WriteString(fmt.Sprintf("  %d stories added • %d submissions approved • %d corrections • %d duplicates merged",
+		total.Added, total.Approved, total.Edits, total.Merged))
+	b.WriteString("\n\n")
+
+	dateWidth := dates.Width()
+	b.WriteString(styles.DimStyle.Render(fmt.Sprintf("  %-*s  %6s %-*s %8s %6s  %s",
+		dateWidth, "week of", "added", barWidth, "", "approved", "merged", "corrections")))
+	b.WriteString("\n")
+
+	end := min(len(m.weeks), m.offset+m.listHeight())
+	for _, w := range m.weeks[m.offset:end] {
+		bar := strings.Repeat("█", (w.Added*barWidth+most-1)/most)
+		corrections := "-"
+		if w.Edits > 0 {
+			corrections = fmt.Sprintf("%d: %s", w.Edits, w.FieldSummary())
+		}
+		line := fmt.Sprintf("  %-*s  %6d %-*s %8d %6d  %s",
+			dateWidth, dates.Time(w.Week), w.Added, barWidth, bar, w.Approved, w.Merged, corrections)
+		if maxLen := m.width - 4; maxLen > 10 {
+			line = truncate(line, maxLen)
+		}
+		if w.Quiet() {
+			line = styles.DimStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	if end < len(m.weeks) {
+		b.WriteString(styles.DimStyle.Render(fmt.Sprintf("  ... %d earlier weeks", len(m.weeks)-end)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.status != "" {
+		b.WriteString("  " + m.status + "\n")
+	}
+	help := "↑↓: scroll • w: weeks shown • E: export • r: refresh"
+	if m.exportDir == "" {
+		help = "↑↓: scroll • w: weeks shown • r: refresh"
+	}
+	b.WriteString(styles.DimStyle.Render("  " + help))
+
+	return b.String()
+}
+
+// truncate cuts s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimRight(string(runes[:n-1]), " ") + "…"
+}
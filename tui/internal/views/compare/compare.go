@@ -0,0 +1,475 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+	"paranormal-tui/internal/quota"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const resultLimit = 20
+
+// Config is one side's search mode
+type Config struct {
+	Mode  string // "text", "vector" or "hybrid"
+	Alpha float64
+}
+
+// Label names the config, e.g. "hybrid α=0.7"
+func (c Config) Label() string {
+	if c.Mode == "hybrid" {
+		return fmt.Sprintf("hybrid α=%.1f", c.Alpha)
+	}
+	return c.Mode
+}
+
+// Configs are the choices each side cycles through
+var Configs = []Config{
+	{Mode: "text"},
+	{Mode: "vector"},
+	{Mode: "hybrid", Alpha: 0.3},
+	{Mode: "hybrid", Alpha: 0.5},
+	{Mode: "hybrid", Alpha: 0.7},
+}
+
+// Model runs one query under two configs side by side
+type Model struct {
+	database   *db.DB
+	input      textinput.Model
+	inputFocus bool
+	configs    [2]int // Index into Configs for sides A and B
+	side       int    // Column the cursor is in
+	cursor     [2]int
+	results    [2][]db.Story
+	errs       [2]error
+	running    bool
+	lastQuery  string
+	quota      *quota.User
+	quotaErr   error // Why the last query wasn't run
+	width      int
+	height     int
+}
+
+// ComparedMsg carries both sides' results for a query
+type ComparedMsg struct {
+	Query   string
+	Results [2][]db.Story
+	Errs    [2]error
+}
+
+// StorySelectedMsg indicates a story was selected
+type StorySelectedMsg struct {
+	Story db.Story
+	Query string // The query it was found by
+}
+
+// New creates a compare model: vector on the left, hybrid on the right
+func New(database *db.DB) Model {
+	ti := textinput.New()
+	ti.Placeholder = "Query to compare..."
+	ti.Focus()
+	ti.CharLimit = 256
+	ti.Width = 50
+
+	return Model{
+		database:   database,
+		input:      ti,
+		inputFocus: true,
+		configs:    [2]int{1, 4},
+	}
+}
+
+// SetQuota rations the queries that need an embedding
+func (m *Model) SetQuota(q *quota.User) {
+	m.quota = q
+}
+
+// Init initializes the model
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.input.Width = width - 20
+}
+
+// Focus gives focus to the query input
+func (m *Model) Focus() {
+	m.input.Focus()
+	m.inputFocus = true
+}
+
+// InputActive reports whether the query input has focus
+func (m Model) InputActive() bool {
+	return m.inputFocus
+}
+
+// SelectedStory returns the story under the cursor, if any
+func (m Model) SelectedStory() *db.Story {
+	if m.inputFocus || m.cursor[m.side] >= len(m.results[m.side]) {
+		return nil
+	}
+	return &m.results[m.side][m.cursor[m.side]]
+}
+
+// SetBookmarked updates a result's star without searching again
+func (m *Model) SetBookmarked(id string, bookmarked bool) {
+	for s := range m.results {
+		for i := range m.results[s] {
+			if m.results[s][i].ID == id {
+				m.results[s][i].Bookmarked = bookmarked
+			}
+		}
+	}
+}
+
+// MarkRead shows a result as read without searching again
+func (m *Model) MarkRead(id string) {
+	for s := range m.results {
+		for i := range m.results[s] {
+			if m.results[s][i].ID == id {
+				m.results[s][i].Read = true
+			}
+		}
+	}
+}
+
+// start runs query, unless a side needs it embedded and the quota for
+// vector searches is used up
+func (m *Model) start(query string) (tea.Cmd, bool) {
+	m.quotaErr = nil
+	if Configs[m.configs[0]].Mode != "text" || Configs[m.configs[1]].Mode != "text" {
+		if err := m.quota.Allow(quota.VectorSearch); err != nil {
+			m.quotaErr = err
+			return nil, false
+		}
+	}
+	m.running = true
+	return m.run(query), true
+}
+
+// run searches with both configs, embedding the query once if needed
+func (m Model) run(query string) tea.Cmd {
+	if m.database == nil || query == "" {
+		return nil
+	}
+	configs := [2]Config{Configs[m.configs[0]], Configs[m.configs[1]]}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		msg := ComparedMsg{Query: query}
+
+		var embedding []float32
+		var embedErr error
+		if configs[0].Mode != "text" || configs[1].Mode != "text" {
+			client, err := embed.New()
+			if err == nil {
+				embedding, err = client.Embed(ctx, query)
+			}
+			if err != nil {
+				embedErr = fmt.Errorf("failed to embed query: %w", err)
+			}
+		}
+
+		for i, c := range configs {
+			if c.Mode != "text" && embedErr != nil {
+				msg.Errs[i] = embedErr
+				continue
+			}
+			msg.Results[i], msg.Errs[i] = search(ctx, m.database, c, query, embedding)
+		}
+		return msg
+	}
+}
+
+// search runs one side's query
+func search(ctx context.Context, database *db.DB, c Config, query string, embedding []float32) ([]db.Story, error) {
+	switch c.Mode {
+	case "vector":
+		return database.VectorSearch(ctx, embedding, resultLimit)
+	case "hybrid":
+		results, err := database.HybridSearch(ctx, query, embedding, resultLimit, c.Alpha)
+		if err != nil {
+			return nil, err
+		}
+		stories := make([]db.Story, len(results))
+		for i, r := range results {
+			stories[i] = r.Story
+		}
+		return stories, nil
+	default:
+		return database.TextSearch(ctx, query, resultLimit)
+	}
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ComparedMsg:
+		m.running = false
+		m.results = msg.Results
+		m.errs = msg.Errs
+		m.lastQuery = msg.Query
+		m.cursor = [2]int{}
+		if len(m.results[0]) > 0 || len(m.results[1]) > 0 {
+			m.inputFocus = false
+			m.input.Blur()
+			if len(m.results[m.side]) == 0 {
+				m.side = 1 - m.side
+			}
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.inputFocus {
+			switch msg.String() {
+			case "enter":
+				if q := m.input.Value(); q != "" {
+					cmd, _ := m.start(q)
+					return m, cmd
+				}
+			case "esc", "down":
+				if len(m.results[m.side]) > 0 {
+					m.inputFocus = false
+					m.input.Blur()
+				} else if msg.String() == "esc" {
+					m.input.SetValue("")
+				}
+			case "tab":
+				m.configs[m.side] = (m.configs[m.side] + 1) % len(Configs)
+			case "shift+tab":
+				m.side = 1 - m.side
+			default:
+				var cmd tea.Cmd
+				m.input, cmd = m.input.Update(msg)
+				return m, cmd
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			if m.cursor[m.side] > 0 {
+				m.cursor[m.side]--
+			} else {
+				m.Focus()
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			if m.cursor[m.side] < len(m.results[m.side])-1 {
+				m.cursor[m.side]++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h", "right", "l", "shift+tab"))):
+			m.switchSide()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("tab", "m"))):
+			// Change this side's mode and rerun the query; if it can't be
+			// rerun the results would no longer match the mode, so it
+			// stays put
+			previous := m.configs[m.side]
+			m.configs[m.side] = (m.configs[m.side] + 1) % len(Configs)
+			if m.lastQuery != "" {
+				cmd, ok := m.start(m.lastQuery)
+				if !ok {
+					m.configs[m.side] = previous
+				}
+				return m, cmd
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if story := m.SelectedStory(); story != nil {
+				selected := *story
+				return m, func() tea.Msg {
+					return StorySelectedMsg{Story: selected, Query: m.lastQuery}
+				}
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("/", "i", "esc"))):
+			m.Focus()
+		}
+	}
+
+	return m, nil
+}
+
+// switchSide moves the cursor to the other column, matching the story
+// if it appears there too
+func (m *Model) switchSide() {
+	other := 1 - m.side
+	if len(m.results[other]) == 0 {
+		return
+	}
+	if story := m.SelectedStory(); story != nil {
+		if i, ok := ranks(m.results[other])[story.ID]; ok {
+			m.cursor[other] = i
+		}
+	}
+	if m.cursor[other] >= len(m.results[other]) {
+		m.cursor[other] = len(m.results[other]) - 1
+	}
+	m.side = other
+}
+
+// ranks maps story IDs to their position in results
+func ranks(results []db.Story) map[string]int {
+	r := make(map[string]int, len(results))
+	for i, s := range results {
+		r[s.ID] = i
+	}
+	return r
+}
+
+// View renders the compare view
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Width(m.width - 4).Render("Compare Search Modes"))
+	b.WriteString("\n\n")
+
+	inputStyle := styles.InputStyle
+	if m.inputFocus {
+		inputStyle = styles.FocusedInputStyle
+	}
+	b.WriteString(fmt.Sprintf("  %s\n", inputStyle.Width(m.width-20).Render(m.input.View())))
+	b.WriteString(styles.DimStyle.Render("  tab: change focused side's mode • shift+tab: switch side"))
+	b.WriteString("\n\n")
+	if m.quotaErr != nil {
+		b.WriteString(styles.ErrorStyle.Render("  " + truncate(m.quotaErr.Error(), m.width-4)))
+		b.WriteString("\n\n")
+	}
+
+	if m.running {
+		b.WriteString("  Searching...")
+		return b.String()
+	}
+
+	if m.lastQuery != "" {
+		overlap := 0
+		inA := ranks(m.results[0])
+		for _, s := range m.results[1] {
+			if _, ok := inA[s.ID]; ok {
+				overlap++
+			}
+		}
+		b.WriteString(fmt.Sprintf("  %q • %d shared of top %d\n\n", m.lastQuery, overlap, resultLimit))
+	}
+
+	colWidth := (m.width - 6) / 2
+	columns := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderColumn(0, colWidth),
+		"  ",
+		m.renderColumn(1, colWidth),
+	)
+	b.WriteString(columns)
+
+	b.WriteString("\n\n")
+	b.WriteString(styles.DimStyle.Render("  ↑↓: navigate • ←→: switch side • m: change mode • enter: view • /: query"))
+
+	return b.String()
+}
+
+// renderColumn lists one side's results. The right side shows how each
+// story moved relative to the left; the left marks stories the right dropped.
+func (m Model) renderColumn(side, width int) string {
+	var b strings.Builder
+
+	label := fmt.Sprintf("%s: %s", string(rune('A'+side)), Configs[m.configs[side]].Label())
+	headerStyle := styles.DimStyle
+	if m.side == side {
+		headerStyle = styles.BoldStyle.Foreground(styles.Primary)
+	}
+	b.WriteString(headerStyle.Render(" " + label))
+	b.WriteString("\n")
+
+	if err := m.errs[side]; err != nil {
+		b.WriteString(styles.ErrorStyle.Render(" " + truncate(err.Error(), width-1)))
+		return lipgloss.NewStyle().Width(width).Render(b.String())
+	}
+	if m.lastQuery != "" && len(m.results[side]) == 0 {
+		b.WriteString(styles.DimStyle.Render(" No results"))
+		return lipgloss.NewStyle().Width(width).Render(b.String())
+	}
+
+	other := ranks(m.results[1-side])
+	listHeight := m.height - 12
+	if m.quotaErr != nil {
+		listHeight -= 2
+	}
+
+	for i, story := range m.results[side] {
+		if i >= listHeight {
+			break
+		}
+
+		selected := !m.inputFocus && m.side == side && i == m.cursor[side]
+		cursor := "  "
+		if selected {
+			cursor = "▸ "
+		}
+
+		indicator := rankChange(side, i, other, story.ID)
+
+		title := story.Title
+		if story.Bookmarked {
+			title = "★ " + title
+		}
+		title = truncate(title, width-14)
+		if !story.Read {
+			title = styles.BoldStyle.Render(title)
+		}
+
+		line := fmt.Sprintf("%s%2d. %s %s", cursor, i+1, indicator, title)
+		if selected {
+			line = styles.SelectedItemStyle.Width(width).Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().Width(width).Render(b.String())
+}
+
+// rankChange renders a fixed-width marker for story at rank i on side
+func rankChange(side, i int, other map[string]int, id string) string {
+	j, ok := other[id]
+	if side == 0 {
+		if ok {
+			return "    "
+		}
+		return styles.DimStyle.Render("  ✗ ")
+	}
+
+	switch {
+	case !ok:
+		return lipgloss.NewStyle().Foreground(styles.Warning).Render("new ")
+	case j > i:
+		return styles.SuccessStyle.Render(fmt.Sprintf("↑%-3d", j-i))
+	case j < i:
+		return styles.ErrorStyle.Render(fmt.Sprintf("↓%-3d", i-j))
+	default:
+		return styles.DimStyle.Render(" =  ")
+	}
+}
+
+// truncate shortens s to at most width cells, marking the cut with "..."
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 3 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-3]) + "..."
+}
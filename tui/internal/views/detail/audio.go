@@ -0,0 +1,54 @@
+package detail
+
+import (
+	"fmt"
+	"time"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var playbackStyle = lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+
+// Playback is the story's audio playing, or paused, from p
+type Playback struct {
+	StoryID string
+	Paused  bool
+	At      time.Duration // Into the episode
+	End     time.Duration // 0 if the story's end isn't known
+}
+
+// SetPlayback shows how the story's audio is playing in the footer; nil
+// if it isn't
+func (m *Model) SetPlayback(p *Playback) {
+	m.playback = p
+}
+
+// renderPlayback is the footer's play/pause status; "" if the story shown
+// isn't playing
+func (m Model) renderPlayback() string {
+	p := m.playback
+	if p == nil || m.story == nil || p.StoryID != m.story.ID {
+		return ""
+	}
+
+	status, hint := "▶ ", "p: pause"
+	if p.Paused {
+		status, hint = "⏸ ", "p: resume"
+	}
+	status += clock(p.At)
+	if p.End > 0 {
+		status += " / " + clock(p.End)
+	}
+	return playbackStyle.Render(status) + styles.DimStyle.Render(" • "+hint)
+}
+
+// clock formats a time into the episode as m:ss, or h:mm:ss past an hour
+func clock(d time.Duration) string {
+	s := int(d.Seconds())
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
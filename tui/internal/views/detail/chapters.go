@@ -0,0 +1,123 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+)
+
+// SetChapters sets the sections of the story shown, found by
+// `paranormal-tui chapters`. A story with fewer than two has no contents.
+func (m *Model) SetChapters(id string, chapters []db.Chapter) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.chapters = nil
+	if len(chapters) > 1 {
+		m.chapters = chapters
+	}
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// renderContents lists the sections, for above the story text
+func (m Model) renderContents() string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("Contents"))
+	b.WriteString("\n\n")
+	for i, c := range m.chapters {
+		b.WriteString(fmt.Sprintf("%s %s\n", styles.DimStyle.Render(fmt.Sprintf("%2d.", i+1)), c.Title))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// wrapChapters wraps the story text with a heading before each section,
+// returning the text, the line within it that each heading is on, and the
+// line of content each wrapped line came from (-1 for headings and the
+// space around them)
+func (m Model) wrapChapters(content string, width int) (string, []int, []int) {
+	lines := strings.Split(content, "\n")
+	blank := func(i int) bool { return strings.TrimSpace(lines[i]) == "" }
+
+	var b strings.Builder
+	var headings, sources []int
+	for i, c := range m.chapters {
+		start := min(max(c.StartLine, 0), len(lines))
+		end := len(lines)
+		if i+1 < len(m.chapters) {
+			end = min(max(m.chapters[i+1].StartLine, start), len(lines))
+		}
+		if i == 0 {
+			// Text before the first section, such as an introduction
+			start = 0
+		}
+		for start < end && blank(start) {
+			start++
+		}
+		for end > start && blank(end-1) {
+			end--
+		}
+
+		if i > 0 {
+			b.WriteString("\n\n")
+			sources = append(sources, -1)
+		}
+		headings = append(headings, len(sources))
+		heading := styles.BoldStyle.Foreground(styles.Secondary).Render(fmt.Sprintf("§%d %s", i+1, c.Title))
+		b.WriteString(heading + "\n\n")
+		sources = append(sources, -1, -1)
+
+		wrapped, from := m.wrapStory(strings.Join(lines[start:end], "\n"), width)
+		b.WriteString(wrapped)
+		for _, line := range from {
+			if line >= 0 {
+				line += start
+			}
+			sources = append(sources, line)
+		}
+	}
+	return b.String(), headings, sources
+}
+
+// currentChapter is the index of the section at the top of the screen, or
+// -1 above the first
+func (m Model) currentChapter() int {
+	current := -1
+	for i, line := range m.chapterLines {
+		if line <= m.viewport.YOffset {
+			current = i
+		}
+	}
+	return current
+}
+
+// jumpToChapter scrolls to the start of the next (dir 1) or previous
+// (dir -1) section
+func (m *Model) jumpToChapter(dir int) {
+	if len(m.chapterLines) == 0 {
+		return
+	}
+	current := m.currentChapter()
+	target := current + dir
+	if dir < 0 && current >= 0 && m.chapterLines[current] < m.viewport.YOffset {
+		// Part way into a section, go back to its start first
+		target = current
+	}
+	if target < 0 || target >= len(m.chapterLines) {
+		return
+	}
+	m.viewport.SetYOffset(m.chapterLines[target])
+}
+
+// renderChapterStatus names the section on screen, for the footer
+func (m Model) renderChapterStatus() string {
+	label := "contents"
+	if i := m.currentChapter(); i >= 0 {
+		label = fmt.Sprintf("§%d/%d", i+1, len(m.chapters))
+	}
+	return styles.DimStyle.Render(label + " • {/}: sections")
+}
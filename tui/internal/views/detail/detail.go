@@ -2,11 +2,14 @@ package detail
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
+	"paranormal-tui/internal/config"
 	"paranormal-tui/internal/db"
 	"paranormal-tui/internal/styles"
 
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -15,33 +18,141 @@ import (
 // Model represents the detail view for a single story
 type Model struct {
 	story    *db.Story
+	stats    storyStats
 	viewport viewport.Model
 	width    int
 	height   int
 	ready    bool
+
+	// Pattern for the terms of the search the story was found by, or for
+	// the text found with /, and the viewport lines that contain them
+	terms        *regexp.Regexp
+	matchLines   []int
+	contentStart int // Viewport line the story text starts on
+
+	// Sections of a long story, and the viewport lines their headings are on
+	chapters     []db.Chapter
+	chapterLines []int
+
+	// Line of the story content shown on each viewport line from
+	// contentStart on
+	sourceLines []int
+
+	// Lines of the story content marked with m, and the outline overlay
+	// listing sections, speaker changes, and marks
+	marks         []int
+	showOutline   bool
+	outline       []outlineItem
+	outlineCursor int
+
+	// Links in the story text and the viewport lines they're on. In hint
+	// mode the ones on screen are labelled by their index in links.
+	links      []Link
+	linkLines  []int
+	hinting    bool
+	hintLabels map[int]string
+	hintTyped  string
+
+	// What's typed at the / prompt, to find in the story, or the : one, a
+	// time to jump to; prompt is "/" or ":" while one has focus
+	input  textinput.Model
+	prompt string
+
+	// Where each paragraph is in the episode's audio, shown in a gutter,
+	// and a note on the last : or y in the footer
+	utterances []db.Utterance
+	timeStatus string
+
+	// Reading width, paragraph spacing, and speaker labels
+	layout config.Reading
+
+	// The tab shown, and where the transcript was scrolled to when another
+	// was chosen
+	tab              Tab
+	transcriptOffset int
+
+	// Stories like this one, on the Related tab, and the one chosen
+	related       []db.RelatedStory
+	relatedLoaded bool
+	relatedCursor int
+
+	// Stories nearest this one by embedding, in the panel v toggles, which
+	// stays open from story to story
+	neighbors       []db.RelatedStory
+	neighborsLoaded bool
+	showNeighbors   bool
+
+	// Episode the story is from, with its page and audio URLs, and its
+	// audio playing from p, if it is
+	episode  *db.EpisodeLinks
+	playback *Playback
+
+	// People, places, and dates the story names, shown as chips under its
+	// details. While inEntities the arrows choose among them.
+	entities     []db.Entity
+	inEntities   bool
+	entityCursor int
+
+	// My note on the story, for the Notes tab
+	note string
+
+	// A new summary being written with R, until it's saved or discarded
+	draft *Draft
 }
 
 // New creates a new detail view model
 func New() Model {
-	return Model{}
+	ti := textinput.New()
+	ti.Prompt = ""
+	ti.CharLimit = 100
+
+	return Model{input: ti}
 }
 
 // SetStory sets the story to display
 func (m *Model) SetStory(story *db.Story) {
 	m.story = story
+	m.stats = countStats(story)
+	m.chapters = nil
+	m.marks = nil
+	m.showOutline = false
+	m.hinting = false
+	m.hintLabels = nil
+	m.tab = TabTranscript
+	m.transcriptOffset = 0
+	m.related = nil
+	m.relatedLoaded = false
+	m.neighbors = nil
+	m.neighborsLoaded = false
+	m.episode = nil
+	m.entities = nil
+	m.inEntities = false
+	m.note = ""
+	m.utterances = nil
+	m.timeStatus = ""
 	if m.ready {
 		m.updateContent()
 	}
 }
 
+// SetQuery sets the search the story was found by; its terms are
+// highlighted and mapped in the footer. Call before SetStory.
+func (m *Model) SetQuery(query string) {
+	m.terms = termPattern(queryTerms(query))
+}
+
 // SetSize sets the dimensions of the detail view
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 
-	// Account for border and padding
+	// Account for border and padding, and the title and tabs above
 	contentWidth := width - 6
-	contentHeight := height - 4
+	contentHeight := height - 7
+	if m.neighborsBeside() {
+		// The story keeps 2 columns spare, which the panel can't use
+		contentWidth -= neighborsWidth + 4
+	}
 
 	if !m.ready {
 		m.viewport = viewport.New(contentWidth, contentHeight)
@@ -57,70 +168,132 @@ func (m *Model) SetSize(width, height int) {
 	}
 }
 
+// updateContent renders the tab shown into the viewport. The transcript is
+// laid out whichever tab is shown, so marks and links stay placed.
 func (m *Model) updateContent() {
 	if m.story == nil {
 		return
 	}
 
-	var b strings.Builder
-
-	// Title
-	b.WriteString(styles.BoldStyle.Foreground(styles.Primary).Render(m.story.Title))
-	b.WriteString("\n\n")
-
-	// Metadata
-	metaStyle := styles.DimStyle
-
-	b.WriteString(fmt.Sprintf("%s %s\n",
-		metaStyle.Render("Show:"),
-		m.story.FormattedShow()))
-
-	b.WriteString(fmt.Sprintf("%s %s\n",
-		metaStyle.Render("Date:"),
-		m.story.FormattedDate()))
-
-	b.WriteString(fmt.Sprintf("%s %s\n",
-		metaStyle.Render("Type:"),
-		styles.TypeBadge(m.story.FormattedType())))
+	transcript := m.renderTranscript()
+	switch m.tab {
+	case TabTranscript:
+		m.viewport.SetContent(transcript)
+	case TabSummary:
+		m.viewport.SetContent(m.renderSummary())
+	case TabMetadata:
+		m.viewport.SetContent(m.renderMetadata())
+	case TabRelated:
+		m.viewport.SetContent(m.renderRelated())
+	case TabNotes:
+		m.viewport.SetContent(m.renderNotes())
+	}
+}
 
-	b.WriteString(fmt.Sprintf("%s %s\n",
-		metaStyle.Render("Location:"),
-		m.story.FormattedLocation()))
+// renderTranscript lays out the Transcript tab: the contents of a long
+// story, then its text with sections, marks, links, and search terms
+func (m *Model) renderTranscript() string {
+	var b strings.Builder
+	if len(m.chapters) > 0 {
+		b.WriteString(m.renderContents())
+	}
 
-	b.WriteString("\n")
-	b.WriteString(styles.HeaderStyle.Render("Story"))
-	b.WriteString("\n\n")
+	m.contentStart = strings.Count(b.String(), "\n")
 
 	// Content - wrap to viewport width
 	content := m.story.Content
-	wrapped := wrapText(content, m.viewport.Width-2)
-	b.WriteString(wrapped)
+	var wrapped string
+	m.chapterLines = nil
+	if len(m.chapters) > 0 {
+		var headings []int
+		wrapped, headings, m.sourceLines = m.wrapChapters(content, m.textWidth()-m.gutterWidth())
+		for _, line := range headings {
+			m.chapterLines = append(m.chapterLines, m.contentStart+line)
+		}
+	} else {
+		wrapped, m.sourceLines = m.wrapStory(content, m.textWidth()-m.gutterWidth())
+	}
+	wrapped = m.markLines(wrapped)
 
-	m.viewport.SetContent(b.String())
+	m.links, m.linkLines = nil, nil
+	lines := strings.Split(wrapped, "\n")
+	for i, line := range lines {
+		styled, links := m.linkify(line, len(m.links))
+		lines[i] = styled
+		for _, link := range links {
+			m.links = append(m.links, link)
+			m.linkLines = append(m.linkLines, m.contentStart+i)
+		}
+	}
+	wrapped = strings.Join(lines, "\n")
+
+	m.matchLines = nil
+	if m.terms != nil {
+		lines := strings.Split(wrapped, "\n")
+		for i, line := range lines {
+			if highlighted, n := highlightTerms(line, m.terms); n > 0 {
+				lines[i] = highlighted
+				m.matchLines = append(m.matchLines, m.contentStart+i)
+			}
+		}
+		wrapped = strings.Join(lines, "\n")
+	}
+	b.WriteString(m.addGutter(wrapped))
+	return m.center(b.String())
 }
 
 // wrapText wraps text to the specified width
 func wrapText(text string, width int) string {
+	wrapped, _ := wrapLines(text, width, config.Reading{})
+	return wrapped
+}
+
+// wrapStory wraps story text to the specified width in the reading layout
+func (m Model) wrapStory(text string, width int) (string, []int) {
+	return wrapLines(text, width, m.layout)
+}
+
+// wrapLines wraps text to the specified width, also returning the line of
+// text each wrapped line came from (-1 for the space between paragraphs)
+func wrapLines(text string, width int, layout config.Reading) (string, []int) {
 	if width <= 0 {
 		width = 80
 	}
 
 	var result strings.Builder
+	var sources []int
 	lines := strings.Split(text, "\n")
 
 	for i, line := range lines {
 		if i > 0 {
 			result.WriteString("\n")
+			if layout.Spacing && strings.TrimSpace(line) != "" && strings.TrimSpace(lines[i-1]) != "" {
+				result.WriteString("\n")
+				sources = append(sources, -1)
+			}
 		}
+		sources = append(sources, i)
 
-		// Handle speaker labels specially
-		if strings.HasPrefix(line, "[Speaker") {
-			result.WriteString(styles.DimStyle.Render(line[:strings.Index(line, "]")+1]))
-			line = line[strings.Index(line, "]")+1:]
+		// Speaker labels are dimmed, or left out
+		label := speakerPrefix.FindString(line)
+		line = line[len(label):]
+		if layout.HideSpeakers {
+			label = ""
 		}
 
 		words := strings.Fields(line)
+		if label != "" {
+			words = append([]string{label}, words...)
+		}
 		currentLine := ""
+		first := true
+		flush := func() {
+			if first && label != "" {
+				currentLine = styles.DimStyle.Render(label) + currentLine[len(label):]
+			}
+			result.WriteString(currentLine)
+			first = false
+		}
 
 		for _, word := range words {
 			if len(currentLine)+len(word)+1 <= width {
@@ -130,17 +303,19 @@ func wrapText(text string, width int) string {
 				currentLine += word
 			} else {
 				if currentLine != "" {
-					result.WriteString(currentLine + "\n")
+					flush()
+					result.WriteString("\n")
+					sources = append(sources, i)
 				}
 				currentLine = word
 			}
 		}
 		if currentLine != "" {
-			result.WriteString(currentLine)
+			flush()
 		}
 	}
 
-	return result.String()
+	return result.String(), sources
 }
 
 // Init initializes the model
@@ -154,11 +329,67 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		m.timeStatus = ""
+		if m.showOutline {
+			return m.updateOutline(msg), nil
+		}
+		if m.hinting {
+			return m.updateHints(msg)
+		}
+		if m.prompt != "" {
+			return m.updatePrompt(msg)
+		}
+		if m.inEntities {
+			return m.updateEntities(msg)
+		}
+		if m.tab == TabRelated {
+			if used, cmd := m.updateRelated(msg); used {
+				return m, cmd
+			}
+		}
 		switch msg.String() {
+		case "left", "h", "shift+tab":
+			m.stepTab(-1)
+			return m, nil
+		case "right", "l", "tab":
+			m.stepTab(1)
+			return m, nil
+		case "t":
+			m.setTab(TabTranscript)
+			m.openOutline()
+			return m, nil
+		case "f":
+			m.setTab(TabTranscript)
+			m.startHints()
+			return m, nil
+		case "/", ":":
+			m.startPrompt(msg.String())
+			return m, nil
+		case "y":
+			m.setTab(TabTranscript)
+			if citation := m.citation(); citation != "" {
+				return m, func() tea.Msg { return CitationMsg{Text: citation} }
+			}
+			m.timeStatus = "No timestamps for this story"
+			return m, nil
 		case "up", "k":
 			m.viewport.LineUp(1)
 		case "down", "j":
 			m.viewport.LineDown(1)
+		case "r":
+			m.setTab(TabRelated)
+			return m, nil
+		case "v":
+			m.toggleNeighbors()
+			return m, nil
+		case "w", "P", "L":
+			return m.updateLayout(msg.String())
+		case "c":
+			if len(m.entities) > 0 {
+				m.setTab(TabMetadata)
+				m.enterEntities()
+				return m, nil
+			}
 		case "pgup", "ctrl+u":
 			m.viewport.HalfViewUp()
 		case "pgdown", "ctrl+d":
@@ -167,6 +398,18 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.viewport.GotoTop()
 		case "end", "G":
 			m.viewport.GotoBottom()
+		case "n":
+			m.setTab(TabTranscript)
+			m.jumpToMatch(1)
+		case "N":
+			m.setTab(TabTranscript)
+			m.jumpToMatch(-1)
+		case "}":
+			m.setTab(TabTranscript)
+			m.jumpToChapter(1)
+		case "{":
+			m.setTab(TabTranscript)
+			m.jumpToChapter(-1)
 		}
 	}
 
@@ -195,14 +438,82 @@ func (m Model) View() string {
 		}
 	}
 
-	footer := styles.DimStyle.Render(fmt.Sprintf(
-		"↑↓ scroll • esc close • %d%%",
-		scrollPercent,
-	))
+	footer := styles.DimStyle.Render(fmt.Sprintf("↑↓ scroll • ←→ tabs • esc close • %d%%", scrollPercent))
+	switch m.tab {
+	case TabTranscript:
+		footer = styles.DimStyle.Render(fmt.Sprintf(
+			"↑↓ scroll • / find • t outline • ←→ tabs • esc close • %d%%",
+			scrollPercent,
+		))
+		if len(m.links) > 0 {
+			footer = styles.DimStyle.Render(fmt.Sprintf("%d links • f: follow", len(m.links))) + "  " + footer
+		}
+		if layout := m.renderLayoutStatus(); layout != "" {
+			footer = styles.DimStyle.Render(layout) + "  " + footer
+		}
+		if len(m.chapters) > 0 {
+			footer = m.renderChapterStatus() + "  " + footer
+		}
+		if times := m.renderTimeStatus(); times != "" {
+			footer = times + "  " + footer
+		}
+		if m.terms != nil {
+			footer = m.renderMatchMap() + "  " + footer
+		}
+	case TabSummary:
+		if draft := m.currentDraft(); draft != nil {
+			footer = m.renderDraftStatus(draft)
+		}
+	case TabMetadata:
+		if len(m.entities) > 0 {
+			footer = styles.DimStyle.Render("c: people, places, dates") + "  " + footer
+		}
+	case TabRelated:
+		if len(m.related) > 0 {
+			footer = styles.DimStyle.Render("↑↓ choose • enter: open • ←→ tabs • esc close")
+		}
+	}
+
+	if playback := m.renderPlayback(); playback != "" {
+		footer = playback + "  " + footer
+	}
+
+	body := m.viewport.View()
+	if m.inEntities {
+		footer = styles.DimStyle.Render("←→ choose • enter: stories naming it • esc: back")
+	}
+	if m.hinting {
+		footer = styles.DimStyle.Render("Type a label to follow its link • esc: cancel")
+	}
+	if m.prompt != "" {
+		footer = m.renderPrompt()
+	}
+	if m.showNeighbors {
+		if m.neighborsBeside() {
+			body = lipgloss.JoinHorizontal(lipgloss.Top, body, "  ", m.renderNeighbors())
+		} else {
+			body = m.renderNeighbors()
+		}
+	}
+	if m.showOutline {
+		body, footer = m.renderOutline(), m.renderOutlineStatus()
+	}
+
+	title := m.story.Title
+	if m.story.Bookmarked {
+		title = "★ " + title
+	}
+	header := lipgloss.JoinVertical(
+		lipgloss.Left,
+		styles.BoldStyle.Foreground(styles.Primary).MaxWidth(m.viewport.Width).Render(title),
+		m.renderTabs(),
+		"",
+	)
 
 	content := lipgloss.JoinVertical(
 		lipgloss.Left,
-		m.viewport.View(),
+		header,
+		body,
 		footer,
 	)
 
@@ -211,6 +522,41 @@ func (m Model) View() string {
 		Render(content)
 }
 
+// Story returns the story shown, or nil
+func (m Model) Story() *db.Story {
+	return m.story
+}
+
+// SetBookmarked updates the star on the story shown
+func (m *Model) SetBookmarked(id string, bookmarked bool) {
+	if m.story != nil && m.story.ID == id {
+		m.story.Bookmarked = bookmarked
+		if m.ready {
+			m.updateContent()
+		}
+	}
+}
+
+// SetRating updates the rating of the story shown
+func (m *Model) SetRating(id string, rating int) {
+	if m.story != nil && m.story.ID == id {
+		m.story.Rating = rating
+		if m.ready {
+			m.updateContent()
+		}
+	}
+}
+
+// SetHoax updates the hoax flag of the story shown
+func (m *Model) SetHoax(id string, hoax bool) {
+	if m.story != nil && m.story.ID == id {
+		m.story.Hoax = hoax
+		if m.ready {
+			m.updateContent()
+		}
+	}
+}
+
 // HasStory returns true if a story is loaded
 func (m Model) HasStory() bool {
 	return m.story != nil
@@ -0,0 +1,85 @@
+package detail
+
+import (
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var confirmStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+
+// Draft is a new summary being written for the story by the model, shown
+// on the Summary tab until it's saved or discarded
+type Draft struct {
+	StoryID string
+	Model   string
+	Text    string
+	Done    bool
+	Err     error
+}
+
+// SetDraft shows a new summary as it's written, switching to the Summary
+// tab when it starts; nil clears it
+func (m *Model) SetDraft(d *Draft) {
+	starting := m.draft == nil && d != nil
+	m.draft = d
+	if starting && m.story != nil && d.StoryID == m.story.ID {
+		m.setTab(TabSummary)
+	}
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// SetSummary updates the summary of the story shown, once a new one is
+// saved
+func (m *Model) SetSummary(id, summary string) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.story.Summary.String, m.story.Summary.Valid = summary, summary != ""
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// currentDraft is the draft for the story shown, if there is one
+func (m Model) currentDraft() *Draft {
+	if m.draft == nil || m.story == nil || m.draft.StoryID != m.story.ID {
+		return nil
+	}
+	return m.draft
+}
+
+// renderDraft is the new summary under the current one on the Summary
+// tab, as far as it's been written
+func (m Model) renderDraft(d *Draft) string {
+	var b strings.Builder
+	b.WriteString(styles.DimStyle.Render(strings.Repeat("─", m.textWidth())) + "\n")
+	b.WriteString(styles.BoldStyle.Render("New summary") + styles.DimStyle.Render(" from "+d.Model) + "\n\n")
+	if text := strings.TrimSpace(d.Text); text != "" {
+		b.WriteString(renderMarkdown(text, m.textWidth()) + "\n\n")
+	}
+
+	switch {
+	case d.Err != nil:
+		b.WriteString(styles.ErrorStyle.Render(wrapText("Failed: "+d.Err.Error(), m.textWidth())))
+	case !d.Done:
+		b.WriteString(styles.DimStyle.Render("Writing..."))
+	case strings.TrimSpace(d.Text) == "":
+		b.WriteString(styles.ErrorStyle.Render("The model wrote nothing."))
+	default:
+		b.WriteString(confirmStyle.Render("Replace the summary with this one? enter: save • esc: discard"))
+	}
+	return b.String()
+}
+
+// renderDraftStatus is the footer while there's a draft
+func (m Model) renderDraftStatus(d *Draft) string {
+	if d.Done && d.Err == nil && strings.TrimSpace(d.Text) != "" {
+		return styles.DimStyle.Render("enter save • esc discard • ↑↓ scroll")
+	}
+	return styles.DimStyle.Render("esc stop • ↑↓ scroll")
+}
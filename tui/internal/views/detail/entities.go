@@ -0,0 +1,111 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// EntitySelectedMsg is sent when an entity chip is chosen, to browse the
+// stories that name it
+type EntitySelectedMsg struct {
+	Kind string
+	Name string
+}
+
+var (
+	chipStyle         = lipgloss.NewStyle().Foreground(styles.TextPrimary).Background(lipgloss.Color("#3A3A4A")).Padding(0, 1)
+	selectedChipStyle = chipStyle.Background(styles.Accent).Bold(true)
+)
+
+// entityRows labels the rows of chips, one a kind
+var entityRows = []struct{ kind, label string }{
+	{db.EntityPerson, "People:"},
+	{db.EntityPlace, "Places:"},
+	{db.EntityDate, "Dates:"},
+}
+
+// SetEntities sets the people, places, and dates the story shown names
+func (m *Model) SetEntities(id string, entities []db.Entity) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.entities = entities
+	m.entityCursor = 0
+	m.inEntities = false
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// renderEntities is a row of chips for each kind of entity the story
+// names, wrapped to the viewport, with the chosen one lit while the chips
+// have the keys; "" if it names none
+func (m Model) renderEntities() string {
+	var b strings.Builder
+	for _, row := range entityRows {
+		label := styles.DimStyle.Render(fmt.Sprintf("%-8s", row.label))
+		indent := strings.Repeat(" ", lipgloss.Width(label))
+		line, lineWidth := label, lipgloss.Width(label)
+		found := false
+		for i, e := range m.entities {
+			if e.Kind != row.kind {
+				continue
+			}
+			text := e.Name
+			if e.Others > 0 {
+				text += fmt.Sprintf(" ·%d", e.Others)
+			}
+			style := chipStyle
+			if m.inEntities && i == m.entityCursor {
+				style = selectedChipStyle
+			}
+			chip := style.Render(text)
+			if w := lipgloss.Width(chip); found && lineWidth+1+w > m.viewport.Width-2 {
+				b.WriteString(line + "\n")
+				line, lineWidth = indent+" "+chip, len(indent)+1+w
+			} else {
+				line += " " + chip
+				lineWidth += 1 + w
+			}
+			found = true
+		}
+		if found {
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// enterEntities moves the keys to the chips, scrolled into view
+func (m *Model) enterEntities() {
+	m.inEntities = true
+	m.entityCursor = 0
+	m.updateContent()
+	m.viewport.GotoTop()
+}
+
+// updateEntities handles keys while the chips have them: arrows choose,
+// enter browses the stories naming the chosen one
+func (m Model) updateEntities(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "left", "h", "shift+tab", "up", "k":
+		m.entityCursor = max(m.entityCursor-1, 0)
+	case "right", "l", "tab", "down", "j":
+		m.entityCursor = min(m.entityCursor+1, len(m.entities)-1)
+	case "enter":
+		e := m.entities[m.entityCursor]
+		return m, func() tea.Msg { return EntitySelectedMsg{Kind: e.Kind, Name: e.Name} }
+	case "esc", "q", "c":
+		m.inEntities = false
+	default:
+		return m, nil
+	}
+	m.updateContent()
+	return m, nil
+}
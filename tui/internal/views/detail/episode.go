@@ -0,0 +1,45 @@
+package detail
+
+import (
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+)
+
+// SetEpisode sets the episode the story shown is from, with its links;
+// nil if it has none
+func (m *Model) SetEpisode(id string, episode *db.EpisodeLinks) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.episode = episode
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// Episode returns the episode the story shown is from, or nil if it has
+// none or it hasn't loaded yet
+func (m Model) Episode() *db.EpisodeLinks {
+	return m.episode
+}
+
+// renderEpisode is the episode's metadata line, its name a hyperlink to
+// the episode page or audio in terminals that support them; "" if the
+// story has no episode
+func (m Model) renderEpisode() string {
+	if m.episode == nil {
+		return ""
+	}
+	name := m.episode.Label()
+	url := m.episode.URL()
+	if url == "" {
+		return styles.DimStyle.Render("Episode:") + " " + name + "\n"
+	}
+	return styles.DimStyle.Render("Episode:") + " " + hyperlink(url, name) + styles.DimStyle.Render("  o: open") + "\n"
+}
+
+// hyperlink wraps text in an OSC 8 escape so terminals that support it
+// open url when it's clicked; others show the text alone
+func hyperlink(url, text string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + text + "\x1b]8;;\x1b\\"
+}
@@ -0,0 +1,76 @@
+package detail
+
+import (
+	"regexp"
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startPrompt opens the / prompt, to find text, or the : one, to jump to a
+// time, on the transcript
+func (m *Model) startPrompt(prompt string) {
+	m.setTab(TabTranscript)
+	m.prompt = prompt
+	m.input.Placeholder = "find in story"
+	if prompt == ":" {
+		m.input.Placeholder = "time in the episode, e.g. 12:30"
+	}
+	m.input.SetValue("")
+	m.input.Focus()
+}
+
+// updatePrompt handles keys while the / or : prompt has them
+func (m Model) updatePrompt(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.prompt = ""
+		m.input.Blur()
+		return m, nil
+	case "enter":
+		prompt, text := m.prompt, strings.TrimSpace(m.input.Value())
+		m.prompt = ""
+		m.input.Blur()
+		if prompt == ":" {
+			m.jumpToTime(text)
+		} else {
+			m.find(text)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// find highlights text, ignoring case, and jumps to its first match from
+// the top of the screen on; "" clears the highlighting
+func (m *Model) find(text string) {
+	m.terms = nil
+	if text != "" {
+		m.terms = regexp.MustCompile(`(?i)` + regexp.QuoteMeta(text))
+	}
+	m.updateContent()
+	if len(m.matchLines) > 0 {
+		target := m.matchLines[0]
+		for _, line := range m.matchLines {
+			if line >= m.viewport.YOffset {
+				target = line
+				break
+			}
+		}
+		m.viewport.SetYOffset(target)
+	}
+}
+
+// renderPrompt is the footer while typing at the / or : prompt
+func (m Model) renderPrompt() string {
+	hint := "enter: find • esc: cancel"
+	if m.prompt == ":" {
+		hint = "enter: jump • esc: cancel"
+	}
+	return m.prompt + m.input.View() + "  " + styles.DimStyle.Render(hint)
+}
@@ -0,0 +1,106 @@
+package detail
+
+import (
+	"strings"
+
+	"paranormal-tui/internal/config"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Reading widths w cycles through, in columns; "" is the whole window
+var readingWidths = map[string]int{
+	"narrow": 72,
+	"wide":   100,
+}
+
+// nextWidth follows each reading width in the order w cycles through them
+var nextWidth = map[string]string{
+	"narrow": "wide",
+	"wide":   "",
+	"":       "narrow",
+}
+
+// LayoutChangedMsg is sent when the reading layout changes, to remember
+// it for next time
+type LayoutChangedMsg struct {
+	Layout config.Reading
+}
+
+// Layout returns the reading layout
+func (m Model) Layout() config.Reading {
+	return m.layout
+}
+
+// RestoreLayout sets the reading layout, as remembered from last time
+func (m *Model) RestoreLayout(layout config.Reading) {
+	if _, ok := nextWidth[layout.Width]; !ok {
+		layout.Width = ""
+	}
+	m.layout = layout
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// textWidth is the width story text wraps to: the reading width, if the
+// window is wider
+func (m Model) textWidth() int {
+	width := m.viewport.Width - 2
+	if w, ok := readingWidths[m.layout.Width]; ok {
+		width = min(width, w)
+	}
+	return width
+}
+
+// center indents text so a column narrower than the window sits in the
+// middle of it
+func (m Model) center(text string) string {
+	margin := (m.viewport.Width - 2 - m.textWidth()) / 2
+	if margin <= 0 {
+		return text
+	}
+	pad := strings.Repeat(" ", margin)
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = pad + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// updateLayout handles w, P, and L, which change the reading layout,
+// keeping the line at the top of the screen there
+func (m Model) updateLayout(key string) (Model, tea.Cmd) {
+	m.setTab(TabTranscript)
+	top := m.MarkLine()
+	switch key {
+	case "w":
+		m.layout.Width = nextWidth[m.layout.Width]
+	case "P":
+		m.layout.Spacing = !m.layout.Spacing
+	case "L":
+		m.layout.HideSpeakers = !m.layout.HideSpeakers
+	}
+	m.updateContent()
+	m.viewport.SetYOffset(m.viewportLine(top))
+
+	layout := m.layout
+	return m, func() tea.Msg { return LayoutChangedMsg{Layout: layout} }
+}
+
+// renderLayoutStatus names the reading layout, for the footer
+func (m Model) renderLayoutStatus() string {
+	var parts []string
+	if m.layout.Width != "" {
+		parts = append(parts, m.layout.Width)
+	}
+	if m.layout.Spacing {
+		parts = append(parts, "spaced")
+	}
+	if m.layout.HideSpeakers {
+		parts = append(parts, "no speakers")
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,207 @@
+package detail
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// LinkKind is what a link in a story's text refers to
+type LinkKind int
+
+const (
+	LinkURL       LinkKind = iota // A web address
+	LinkEpisode                   // "episode 12": another episode of the show
+	LinkTimestamp                 // "01:02:03" or "[12:34]": a point in the episode's audio
+)
+
+// Link is something actionable found in a story's text
+type Link struct {
+	Kind    LinkKind
+	Text    string  // As written in the story
+	URL     string  // For LinkURL
+	Episode string  // For LinkEpisode, the episode number
+	Seconds float64 // For LinkTimestamp, the offset into the episode
+}
+
+// LinkSelectedMsg is sent when a link is picked in hint mode
+type LinkSelectedMsg struct {
+	StoryID string
+	Link    Link
+}
+
+// linkPattern finds links: a URL, an episode reference, a long timestamp,
+// or a bracketed short one. Bare "3:15" is left alone, being more often a
+// time of day than a place in the audio.
+var linkPattern = regexp.MustCompile(
+	`(https?://[^\s<>"'\x1b]+)` +
+		`|(?i:\b(?:episode|ep\.?)\s*(?:number\s+|#\s*)?(\d{1,4})\b)` +
+		`|\b(\d{1,2}:\d{2}:\d{2})\b` +
+		`|[\[(](\d{1,2}:\d{2})[\])]`)
+
+// hintKeys are the keys hint labels are made from, easiest first
+const hintKeys = "asdfghjklqwertyuiopzxcvbnm"
+
+var (
+	linkStyle = lipgloss.NewStyle().Underline(true).Foreground(styles.Primary)
+	hintStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#000000")).Background(styles.Warning)
+)
+
+// parseLink turns a match's submatch indices into a link
+func parseLink(line string, loc []int) Link {
+	group := func(i int) string {
+		if loc[2*i] < 0 {
+			return ""
+		}
+		return line[loc[2*i]:loc[2*i+1]]
+	}
+	link := Link{Text: group(0)}
+	switch {
+	case group(1) != "":
+		link.Kind, link.URL = LinkURL, group(1)
+	case group(2) != "":
+		link.Kind, link.Episode = LinkEpisode, group(2)
+	case group(3) != "":
+		link.Kind, link.Seconds = LinkTimestamp, clockSeconds(group(3))
+	default:
+		link.Kind, link.Seconds = LinkTimestamp, clockSeconds(group(4))
+	}
+	return link
+}
+
+// clockSeconds converts "h:mm:ss" or "m:ss" to seconds
+func clockSeconds(clock string) float64 {
+	seconds := 0
+	for _, part := range strings.Split(clock, ":") {
+		n, _ := strconv.Atoi(part)
+		seconds = seconds*60 + n
+	}
+	return float64(seconds)
+}
+
+// findLinks returns the links in a line with their positions. Trailing
+// punctuation is left off URLs, being more likely the end of a sentence.
+func findLinks(line string) ([]Link, [][2]int) {
+	var links []Link
+	var spans [][2]int
+	for _, loc := range linkPattern.FindAllStringSubmatchIndex(line, -1) {
+		link := parseLink(line, loc)
+		end := loc[1]
+		if link.Kind == LinkURL {
+			link.URL = strings.TrimRight(link.URL, ".,;:!?)")
+			link.Text = link.URL
+			end = loc[0] + len(link.URL)
+		}
+		links = append(links, link)
+		spans = append(spans, [2]int{loc[0], end})
+	}
+	return links, spans
+}
+
+// linkify styles the links in a wrapped line, putting the hint label for
+// each in front of it in hint mode. next is the index of the line's first
+// link among all the story's links.
+func (m Model) linkify(line string, next int) (string, []Link) {
+	links, spans := findLinks(line)
+	if len(links) == 0 {
+		return line, nil
+	}
+
+	var b strings.Builder
+	prev := 0
+	for i, span := range spans {
+		b.WriteString(line[prev:span[0]])
+		if label, ok := m.hintLabels[next+i]; ok && strings.HasPrefix(label, m.hintTyped) {
+			b.WriteString(hintStyle.Render(label))
+		}
+		b.WriteString(linkStyle.Render(line[span[0]:span[1]]))
+		prev = span[1]
+	}
+	b.WriteString(line[prev:])
+	return b.String(), links
+}
+
+// hintLabelsFor makes n distinct labels, one key each while they fit and
+// two keys each after
+func hintLabelsFor(n int) []string {
+	labels := make([]string, 0, n)
+	if n <= len(hintKeys) {
+		for i := 0; i < n; i++ {
+			labels = append(labels, hintKeys[i:i+1])
+		}
+		return labels
+	}
+	for _, a := range hintKeys {
+		for _, b := range hintKeys {
+			if len(labels) == n {
+				return labels
+			}
+			labels = append(labels, string(a)+string(b))
+		}
+	}
+	return labels
+}
+
+// startHints labels the links on screen for picking by key
+func (m *Model) startHints() {
+	var visible []int
+	for i, line := range m.linkLines {
+		if line >= m.viewport.YOffset && line < m.viewport.YOffset+m.viewport.Height {
+			visible = append(visible, i)
+		}
+	}
+	if len(visible) == 0 {
+		return
+	}
+
+	labels := hintLabelsFor(len(visible))
+	m.hintLabels = make(map[int]string, len(visible))
+	for i, link := range visible {
+		m.hintLabels[link] = labels[i]
+	}
+	m.hintTyped = ""
+	m.hinting = true
+	m.updateContent()
+}
+
+// stopHints leaves hint mode
+func (m *Model) stopHints() {
+	m.hinting = false
+	m.hintLabels = nil
+	m.hintTyped = ""
+	m.updateContent()
+}
+
+// updateHints narrows the labels by the keys typed; a complete label
+// follows its link
+func (m Model) updateHints(msg tea.KeyMsg) (Model, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" || len(key) != 1 {
+		m.stopHints()
+		return m, nil
+	}
+
+	m.hintTyped += key
+	for i, label := range m.hintLabels {
+		if label == m.hintTyped {
+			link, id := m.links[i], m.story.ID
+			m.stopHints()
+			return m, func() tea.Msg {
+				return LinkSelectedMsg{StoryID: id, Link: link}
+			}
+		}
+	}
+	for _, label := range m.hintLabels {
+		if strings.HasPrefix(label, m.hintTyped) {
+			m.updateContent()
+			return m, nil
+		}
+	}
+	m.stopHints()
+	return m, nil
+}
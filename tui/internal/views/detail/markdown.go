@@ -0,0 +1,51 @@
+package detail
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// styleMargin is the document margin of glamour's dark and light styles
+const styleMargin = 2
+
+// markdownRenderers are glamour renderers by width, made once each since
+// parsing a style isn't free. Sessions served over SSH share them, so the
+// lock is held while rendering too.
+var markdownRenderers = struct {
+	sync.Mutex
+	byWidth map[int]*glamour.TermRenderer
+}{byWidth: make(map[int]*glamour.TermRenderer)}
+
+// renderMarkdown lays out the markdown the pipeline writes summaries in
+// with glamour, wrapped to width, in its dark or light style to suit the
+// terminal. If glamour fails, the text is wrapped as written.
+func renderMarkdown(text string, width int) string {
+	markdownRenderers.Lock()
+	defer markdownRenderers.Unlock()
+
+	r, ok := markdownRenderers.byWidth[width]
+	if !ok {
+		style := styles.LightStyle
+		if lipgloss.HasDarkBackground() {
+			style = styles.DarkStyle
+		}
+		// The styles indent by a margin glamour adds on top of the wrap width
+		wrap := max(width-styleMargin, 1)
+		var err error
+		r, err = glamour.NewTermRenderer(glamour.WithStandardStyle(style), glamour.WithWordWrap(wrap))
+		if err != nil {
+			return wrapText(text, width)
+		}
+		markdownRenderers.byWidth[width] = r
+	}
+
+	out, err := r.Render(text)
+	if err != nil {
+		return wrapText(text, width)
+	}
+	return strings.Trim(out, "\n")
+}
@@ -0,0 +1,149 @@
+package detail
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// sparkBars are the match map's levels, empty to full
+var sparkBars = []rune(" ▁▂▃▄▅▆▇█")
+
+// stopWords are too common to be worth mapping
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "was": true,
+	"that": true, "this": true, "from": true, "are": true, "were": true,
+}
+
+var matchStyle = lipgloss.NewStyle().Bold(true).Foreground(styles.Warning)
+
+// termPattern matches any of the terms as a word prefix, so "ghost" finds
+// "ghosts" and "ghostly". It is nil without terms.
+func termPattern(terms []string) *regexp.Regexp {
+	if len(terms) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(terms))
+	for i, t := range terms {
+		quoted[i] = regexp.QuoteMeta(t)
+	}
+	return regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\w*`)
+}
+
+// queryTerms splits a search query into the words worth finding in the text
+func queryTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len([]rune(word)) < 3 || stopWords[word] {
+			continue
+		}
+		// Short numbers would also match inside the color codes of
+		// styled lines
+		if len(word) < 4 && strings.Trim(word, "0123456789") == "" {
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// escapeSequence matches the terminal codes styling a line, which
+// highlighting leaves alone
+var escapeSequence = regexp.MustCompile(`\x1b\[[0-9;]*m|\x1b\]8;[^\x1b]*\x1b\\`)
+
+// highlightTerms marks every match of re in line, outside its escape
+// sequences, and reports how many there were
+func highlightTerms(line string, re *regexp.Regexp) (string, int) {
+	n := 0
+	var b strings.Builder
+	text := 0
+	for _, esc := range append(escapeSequence.FindAllStringIndex(line, -1), []int{len(line), len(line)}) {
+		b.WriteString(re.ReplaceAllStringFunc(line[text:esc[0]], func(match string) string {
+			n++
+			return matchStyle.Render(match)
+		}))
+		b.WriteString(line[esc[0]:esc[1]])
+		text = esc[1]
+	}
+	return b.String(), n
+}
+
+// jumpToMatch scrolls to the next (dir 1) or previous (dir -1) line with a
+// match, wrapping around
+func (m *Model) jumpToMatch(dir int) {
+	if len(m.matchLines) == 0 {
+		return
+	}
+	current := m.viewport.YOffset
+	target := -1
+	if dir > 0 {
+		for _, line := range m.matchLines {
+			if line > current {
+				target = line
+				break
+			}
+		}
+		if target < 0 {
+			target = m.matchLines[0]
+		}
+	} else {
+		for i := len(m.matchLines) - 1; i >= 0; i-- {
+			if m.matchLines[i] < current {
+				target = m.matchLines[i]
+				break
+			}
+		}
+		if target < 0 {
+			target = m.matchLines[len(m.matchLines)-1]
+		}
+	}
+	m.viewport.SetYOffset(target)
+}
+
+// renderMatchMap draws where matches fall through the story as a
+// sparkline, with the part on screen highlighted, like the marks on an
+// editor's scrollbar
+func (m Model) renderMatchMap() string {
+	if len(m.matchLines) == 0 {
+		return styles.DimStyle.Render("no lines match the search terms")
+	}
+
+	width := max(10, min(40, m.viewport.Width/3))
+	total := max(1, m.viewport.TotalLineCount()-m.contentStart)
+	bucket := func(line int) int {
+		return min(width-1, max(0, (line-m.contentStart)*width/total))
+	}
+
+	counts := make([]int, width)
+	peak := 0
+	for _, line := range m.matchLines {
+		b := bucket(line)
+		counts[b]++
+		peak = max(peak, counts[b])
+	}
+
+	first := bucket(m.viewport.YOffset)
+	last := bucket(m.viewport.YOffset + m.viewport.Height - 1)
+
+	var b strings.Builder
+	for i, n := range counts {
+		level := 0
+		if n > 0 {
+			level = (n*(len(sparkBars)-1) + peak - 1) / peak
+		}
+		style := lipgloss.NewStyle().Foreground(styles.Warning)
+		if i >= first && i <= last {
+			style = style.Background(styles.BgLight)
+		}
+		b.WriteString(style.Render(string(sparkBars[level])))
+	}
+	return fmt.Sprintf("%s %s", b.String(),
+		styles.DimStyle.Render(fmt.Sprintf("%d lines match • n/N: jump", len(m.matchLines))))
+}
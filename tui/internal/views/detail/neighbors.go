@@ -0,0 +1,92 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// NeighborCount is how many stories the neighbors panel lists
+const NeighborCount = 10
+
+// neighborsWidth is the width of the neighbors panel, and minStoryWidth
+// the narrowest the story is squeezed to beside it; in a narrower window
+// the panel is shown in place of the story
+const (
+	neighborsWidth = 44
+	minStoryWidth  = 50
+)
+
+// SetNeighbors sets the stories nearest the one shown by embedding
+func (m *Model) SetNeighbors(id string, neighbors []db.RelatedStory) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.neighbors = neighbors
+	m.neighborsLoaded = true
+}
+
+// toggleNeighbors shows or hides the neighbors panel, rewrapping the story
+// to the width left beside it
+func (m *Model) toggleNeighbors() {
+	m.showNeighbors = !m.showNeighbors
+	m.SetSize(m.width, m.height)
+}
+
+// neighborsBeside reports whether the neighbors panel is shown beside the
+// story rather than in place of it
+func (m Model) neighborsBeside() bool {
+	return m.showNeighbors && m.width-6-neighborsWidth-4 >= minStoryWidth
+}
+
+// renderNeighbors is the neighbors panel: the stories nearest this one by
+// embedding with their cosine similarity, and whether each shares its
+// cluster, to judge the clustering by
+func (m Model) renderNeighbors() string {
+	var b strings.Builder
+	b.WriteString(styles.BoldStyle.Render("Nearest by embedding") + "\n")
+
+	switch {
+	case !m.neighborsLoaded:
+		b.WriteString(styles.DimStyle.Render("Finding nearest stories..."))
+	case len(m.neighbors) == 0:
+		b.WriteString(styles.DimStyle.Render("None: this story has no embedding."))
+	default:
+		dot := lipgloss.NewStyle().Foreground(lipgloss.Color(db.GetClusterColor(m.story.ClusterID)))
+		shared := 0
+		for _, n := range m.neighbors {
+			if n.SameCluster {
+				shared++
+			}
+		}
+		if m.story.ClusterID == nil {
+			b.WriteString(styles.DimStyle.Render("Not in a cluster (noise)") + "\n\n")
+		} else {
+			b.WriteString(styles.DimStyle.Render(fmt.Sprintf(
+				"Cluster %d · %d of %d share it", *m.story.ClusterID, shared, len(m.neighbors),
+			)) + "\n\n")
+		}
+
+		for i, n := range m.neighbors {
+			mark := styles.DimStyle.Render("○")
+			if n.SameCluster {
+				mark = dot.Render("●")
+			}
+			b.WriteString(fmt.Sprintf("%.3f %s %s", n.Similarity, mark, truncate(n.Title, neighborsWidth-8)))
+			if i < len(m.neighbors)-1 {
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\n\n" + styles.DimStyle.Render("● same cluster  ○ other  · cosine"))
+	}
+
+	return lipgloss.NewStyle().
+		Width(neighborsWidth).
+		Height(m.viewport.Height).
+		MaxHeight(m.viewport.Height).
+		Render(b.String())
+}
@@ -0,0 +1,244 @@
+package detail
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// outlineKind is what an outline entry points at
+type outlineKind int
+
+const (
+	outlineSection outlineKind = iota
+	outlineSpeaker
+	outlineMark
+)
+
+// outlineItem is one entry in the outline overlay
+type outlineItem struct {
+	kind  outlineKind
+	label string
+	line  int // Viewport line to jump to
+}
+
+// outlineLabelLen is how much of a line an entry quotes
+const outlineLabelLen = 50
+
+var speakerPrefix = regexp.MustCompile(`^\[(Speaker[^\]]*)\]`)
+
+var markStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+
+// SetMarks sets the marked lines of the story shown
+func (m *Model) SetMarks(id string, lines []int) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.marks = lines
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// SetMark marks or unmarks a line of the story shown
+func (m *Model) SetMark(id string, line int, marked bool) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.marks = slices.DeleteFunc(m.marks, func(l int) bool { return l == line })
+	if marked {
+		m.marks = append(m.marks, line)
+		slices.Sort(m.marks)
+	}
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// MarkLine is the line of story content at the top of the screen, the one
+// m marks
+func (m Model) MarkLine() int {
+	offset := m.viewport.YOffset
+	if m.tab != TabTranscript {
+		offset = m.transcriptOffset
+	}
+	for i := max(0, offset-m.contentStart); i < len(m.sourceLines); i++ {
+		if m.sourceLines[i] >= 0 {
+			return m.sourceLines[i]
+		}
+	}
+	return 0
+}
+
+// InputActive reports whether the outline overlay, hint mode, the / or :
+// prompt, or the entity chips are taking keys
+func (m Model) InputActive() bool {
+	return m.showOutline || m.hinting || m.prompt != "" || m.inEntities
+}
+
+// viewportLine is the first viewport line showing a line of content
+func (m Model) viewportLine(source int) int {
+	for i, s := range m.sourceLines {
+		if s >= source {
+			return m.contentStart + i
+		}
+	}
+	return m.contentStart + max(0, len(m.sourceLines)-1)
+}
+
+// markLines flags marked lines in the wrapped story text with a ▸, which
+// fits in the columns the wrap width leaves spare
+func (m Model) markLines(wrapped string) string {
+	if len(m.marks) == 0 {
+		return wrapped
+	}
+	lines := strings.Split(wrapped, "\n")
+	prev := -1
+	for i, line := range lines {
+		if i >= len(m.sourceLines) {
+			break
+		}
+		source := m.sourceLines[i]
+		if source != prev && slices.Contains(m.marks, source) && strings.TrimSpace(line) != "" {
+			lines[i] = markStyle.Render("▸ ") + line
+		}
+		prev = source
+	}
+	return strings.Join(lines, "\n")
+}
+
+// buildOutline lists the sections, speaker changes, and marks in the order
+// they come in the story
+func (m Model) buildOutline() []outlineItem {
+	var items []outlineItem
+	for i, c := range m.chapters {
+		if i < len(m.chapterLines) {
+			items = append(items, outlineItem{outlineSection, fmt.Sprintf("%d. %s", i+1, c.Title), m.chapterLines[i]})
+		}
+	}
+
+	lines := strings.Split(m.story.Content, "\n")
+	speaker := ""
+	for i, line := range lines {
+		match := speakerPrefix.FindStringSubmatch(line)
+		if match == nil || match[1] == speaker {
+			continue
+		}
+		speaker = match[1]
+		text := strings.TrimSpace(line[len(match[0]):])
+		items = append(items, outlineItem{outlineSpeaker, speaker + ": " + truncate(text, outlineLabelLen), m.viewportLine(i)})
+	}
+
+	for _, mark := range m.marks {
+		if mark < len(lines) {
+			items = append(items, outlineItem{outlineMark, truncate(strings.TrimSpace(speakerPrefix.ReplaceAllString(lines[mark], "")), outlineLabelLen), m.viewportLine(mark)})
+		}
+	}
+
+	slices.SortStableFunc(items, func(a, b outlineItem) int { return a.line - b.line })
+	return items
+}
+
+// openOutline shows the outline with the entry for the place on screen
+// selected
+func (m *Model) openOutline() {
+	m.outline = m.buildOutline()
+	m.outlineCursor = 0
+	for i, item := range m.outline {
+		if item.line <= m.viewport.YOffset {
+			m.outlineCursor = i
+		}
+	}
+	m.showOutline = true
+}
+
+// updateOutline moves through the outline; enter jumps to the entry
+func (m Model) updateOutline(msg tea.KeyMsg) Model {
+	page := max(1, m.viewport.Height-2)
+	switch msg.String() {
+	case "up", "k":
+		m.outlineCursor--
+	case "down", "j":
+		m.outlineCursor++
+	case "pgup", "ctrl+u":
+		m.outlineCursor -= page
+	case "pgdown", "ctrl+d":
+		m.outlineCursor += page
+	case "home", "g":
+		m.outlineCursor = 0
+	case "end", "G":
+		m.outlineCursor = len(m.outline) - 1
+	case "enter":
+		if m.outlineCursor < len(m.outline) {
+			m.viewport.SetYOffset(m.outline[m.outlineCursor].line)
+		}
+		m.showOutline = false
+	case "esc", "q", "t":
+		m.showOutline = false
+	}
+	m.outlineCursor = max(0, min(m.outlineCursor, len(m.outline)-1))
+	return m
+}
+
+// renderOutline draws the outline in place of the story text, scrolled to
+// keep the selected entry on screen
+func (m Model) renderOutline() string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("Outline"))
+	b.WriteString("\n\n")
+
+	if len(m.outline) == 0 {
+		b.WriteString(styles.DimStyle.Render("No sections, speakers, or marks in this story (m marks the top line)"))
+		return lipgloss.NewStyle().Height(m.viewport.Height).Render(b.String())
+	}
+
+	rows := max(1, m.viewport.Height-2)
+	start := max(0, min(m.outlineCursor-rows/2, len(m.outline)-rows))
+	end := min(len(m.outline), start+rows)
+
+	for i := start; i < end; i++ {
+		item := m.outline[i]
+		var icon string
+		switch item.kind {
+		case outlineSection:
+			icon = styles.BoldStyle.Foreground(styles.Secondary).Render("§")
+		case outlineSpeaker:
+			icon = styles.DimStyle.Render("»")
+		case outlineMark:
+			icon = markStyle.Render("▸")
+		}
+		line := item.label
+		if item.kind == outlineSpeaker {
+			line = styles.DimStyle.Render(line)
+		}
+		if i == m.outlineCursor {
+			line = styles.SelectedItemStyle.Render(item.label)
+		}
+		b.WriteString(icon + " " + line)
+		if i < end-1 {
+			b.WriteString("\n")
+		}
+	}
+	return lipgloss.NewStyle().Height(m.viewport.Height).Render(b.String())
+}
+
+// renderOutlineStatus is the footer while the outline is open
+func (m Model) renderOutlineStatus() string {
+	return styles.DimStyle.Render(fmt.Sprintf("%d/%d • ↑↓ select • enter: jump • esc/t: close",
+		min(m.outlineCursor+1, len(m.outline)), len(m.outline)))
+}
+
+// truncate cuts s to at most n runes, marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return strings.TrimRight(string(runes[:n-1]), " ") + "…"
+}
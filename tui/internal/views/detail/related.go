@@ -0,0 +1,84 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// RelatedCount is how many related stories the Related tab lists
+const RelatedCount = 5
+
+// RelatedSelectedMsg is sent when a related story is opened
+type RelatedSelectedMsg struct {
+	StoryID string
+}
+
+var relatedCursorStyle = lipgloss.NewStyle().Foreground(styles.Accent).Bold(true)
+
+// SetRelated sets the stories listed as related to the story shown
+func (m *Model) SetRelated(id string, related []db.RelatedStory) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.related = related
+	m.relatedLoaded = true
+	m.relatedCursor = 0
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// renderRelated is the Related tab: the stories most like this one, the
+// one enter opens marked
+func (m Model) renderRelated() string {
+	switch {
+	case !m.relatedLoaded:
+		return styles.DimStyle.Render("Finding related stories...")
+	case len(m.related) == 0:
+		return styles.DimStyle.Render("No related stories. Stories are related by their embeddings.")
+	}
+
+	var b strings.Builder
+	for i, r := range m.related {
+		detail := fmt.Sprintf("%s · %.0f%% alike", strings.ReplaceAll(r.StoryType, "_", " "), r.Similarity*100)
+		if r.SameCluster {
+			detail += " · same cluster"
+		}
+		line := fmt.Sprintf("  %s  %s", r.Title, styles.DimStyle.Render(detail))
+		if i == m.relatedCursor {
+			line = relatedCursorStyle.Render("▸ "+r.Title) + "  " + styles.DimStyle.Render(detail)
+		}
+		b.WriteString(line)
+		if i < len(m.related)-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// updateRelated handles keys on the Related tab: arrows choose, enter
+// opens. It reports whether it used the key.
+func (m *Model) updateRelated(msg tea.KeyMsg) (bool, tea.Cmd) {
+	if len(m.related) == 0 {
+		return false, nil
+	}
+	switch msg.String() {
+	case "up", "k":
+		m.relatedCursor = max(m.relatedCursor-1, 0)
+	case "down", "j":
+		m.relatedCursor = min(m.relatedCursor+1, len(m.related)-1)
+	case "enter":
+		id := m.related[m.relatedCursor].ID
+		return true, func() tea.Msg { return RelatedSelectedMsg{StoryID: id} }
+	default:
+		return false, nil
+	}
+	m.updateContent()
+	return true, nil
+}
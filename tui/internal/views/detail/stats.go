@@ -0,0 +1,49 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+)
+
+// readingWPM is the reading speed reading times are estimated at
+const readingWPM = 238
+
+// storyStats are the story's length and shape, for the Metadata tab.
+// They're counted once, when the story is set.
+type storyStats struct {
+	words    int
+	turns    int // Changes of speaker, counting the first
+	speakers int
+}
+
+// countStats counts the story's words, speaker turns, and speakers
+func countStats(story *db.Story) storyStats {
+	stats := storyStats{words: story.WordCount()}
+	seen := map[string]bool{}
+	speaker := ""
+	for _, line := range strings.Split(story.Content, "\n") {
+		match := speakerPrefix.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil || match[1] == speaker {
+			continue
+		}
+		speaker = match[1]
+		stats.turns++
+		seen[speaker] = true
+	}
+	stats.speakers = len(seen)
+	return stats
+}
+
+// readingTime estimates how long the story takes to read, to the minute
+func (s storyStats) readingTime() string {
+	minutes := (s.words + readingWPM - 1) / readingWPM
+	if minutes <= 1 {
+		return "about a minute"
+	}
+	if minutes < 60 {
+		return fmt.Sprintf("about %d min", minutes)
+	}
+	return fmt.Sprintf("about %dh %02dm", minutes/60, minutes%60)
+}
@@ -0,0 +1,165 @@
+package detail
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Tab is a page of the story view
+type Tab int
+
+const (
+	TabTranscript Tab = iota
+	TabSummary
+	TabMetadata
+	TabRelated
+	TabNotes
+	tabCount
+)
+
+var tabNames = [tabCount]string{"Transcript", "Summary", "Metadata", "Related", "Notes"}
+
+// SetNote sets my note on the story shown; "" if there is none
+func (m *Model) SetNote(id, note string) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.note = note
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// setTab switches to a tab. The transcript comes back where it was left;
+// the others start at the top.
+func (m *Model) setTab(tab Tab) {
+	if tab == m.tab {
+		return
+	}
+	if m.tab == TabTranscript {
+		m.transcriptOffset = m.viewport.YOffset
+	}
+	m.tab = tab
+	m.inEntities = false
+	m.updateContent()
+	if tab == TabTranscript {
+		m.viewport.SetYOffset(m.transcriptOffset)
+	} else {
+		m.viewport.GotoTop()
+	}
+}
+
+// stepTab moves to the tab dir places along, wrapping around
+func (m *Model) stepTab(dir int) {
+	m.setTab(Tab((int(m.tab) + dir + int(tabCount)) % int(tabCount)))
+}
+
+// renderTabs is the row of tab names, the current one lit, with counts
+// for the tabs that list things
+func (m Model) renderTabs() string {
+	var tabs []string
+	for i, name := range tabNames {
+		switch {
+		case Tab(i) == TabRelated && len(m.related) > 0:
+			name += fmt.Sprintf(" (%d)", len(m.related))
+		case Tab(i) == TabNotes && m.note != "":
+			name += " •"
+		}
+		style := styles.InactiveTabStyle.Padding(0, 1)
+		if Tab(i) == m.tab {
+			style = styles.ActiveTabStyle.Padding(0, 1)
+		}
+		tabs = append(tabs, style.Render(name))
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, tabs...)
+}
+
+// renderSummary is the Summary tab, with the markdown summaries are written
+// in laid out
+func (m Model) renderSummary() string {
+	summary := strings.TrimSpace(m.story.Summary.String)
+	draft := m.currentDraft()
+	switch {
+	case summary == "" && draft == nil:
+		return styles.DimStyle.Render("No summary yet. e edits the story's details, summary included, and R writes one.")
+	case summary == "":
+		summary = styles.DimStyle.Render("No summary yet.")
+	default:
+		summary = renderMarkdown(summary, m.textWidth())
+	}
+	if draft != nil {
+		summary += "\n\n" + m.renderDraft(draft)
+	}
+	return m.center(summary)
+}
+
+// renderMetadata is the Metadata tab: the story's details, the episode
+// it's from, and the people, places, and dates it names
+func (m Model) renderMetadata() string {
+	var b strings.Builder
+	metaStyle := styles.DimStyle
+
+	b.WriteString(fmt.Sprintf("%s %s\n",
+		metaStyle.Render("Show:"),
+		m.story.FormattedShow()))
+
+	b.WriteString(fmt.Sprintf("%s %s\n",
+		metaStyle.Render("Date:"),
+		m.story.FormattedDate()))
+
+	b.WriteString(m.renderEpisode())
+
+	b.WriteString(fmt.Sprintf("%s %s\n",
+		metaStyle.Render("Type:"),
+		styles.TypeBadge(m.story.FormattedType())))
+
+	b.WriteString(fmt.Sprintf("%s %s\n",
+		metaStyle.Render("Location:"),
+		m.story.FormattedLocation()))
+
+	if m.story.Rating > 0 {
+		b.WriteString(fmt.Sprintf("%s %s\n", metaStyle.Render("Rating:"), styles.Stars(m.story.Rating)))
+	}
+	if m.story.Hoax {
+		b.WriteString(fmt.Sprintf("%s %s\n", metaStyle.Render("Flagged:"), styles.ErrorStyle.Render("hoax")))
+	}
+
+	if m.story.Latitude.Valid && m.story.Longitude.Valid {
+		coords := fmt.Sprintf("%.3f, %.3f", m.story.Latitude.Float64, m.story.Longitude.Float64)
+		if m.story.GeoClusterID != nil {
+			coords += fmt.Sprintf("  (hotspot #%d)", *m.story.GeoClusterID)
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n", metaStyle.Render("Coordinates:"), coords))
+	}
+
+	b.WriteString(fmt.Sprintf("%s %d\n", metaStyle.Render("Words:"), m.stats.words))
+	b.WriteString(fmt.Sprintf("%s %s\n", metaStyle.Render("Reading time:"), m.stats.readingTime()))
+	if m.stats.turns > 0 {
+		speakers := fmt.Sprintf("%d speakers", m.stats.speakers)
+		if m.stats.speakers == 1 {
+			speakers = "1 speaker"
+		}
+		b.WriteString(fmt.Sprintf("%s %d (%s)\n", metaStyle.Render("Speaker turns:"), m.stats.turns, speakers))
+	}
+	b.WriteString(fmt.Sprintf("%s %s\n", metaStyle.Render("ID:"), m.story.ID))
+
+	if len(m.entities) > 0 {
+		b.WriteString("\n")
+		b.WriteString(m.renderEntities())
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// renderNotes is the Notes tab
+func (m Model) renderNotes() string {
+	if strings.TrimSpace(m.note) == "" {
+		return styles.DimStyle.Render(wrapText(
+			"No note on this story. Notes are Markdown files, written in any editor: paranormal-tui notes --dir DIR "+m.story.ID,
+			m.viewport.Width-2))
+	}
+	return m.center(wrapText(m.note, m.textWidth()))
+}
@@ -0,0 +1,164 @@
+package detail
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+)
+
+// citationQuoteLen is the most of a paragraph a citation quotes
+const citationQuoteLen = 280
+
+// timePattern matches what : takes: minutes, m:ss, or h:mm:ss
+var timePattern = regexp.MustCompile(`^\d{1,3}(:\d{2}){0,2}$`)
+
+// CitationMsg is sent by y with a citation of the paragraph at the top of
+// the screen, quoted and timestamped, to copy
+type CitationMsg struct {
+	Text string
+}
+
+// SetUtterances sets where each paragraph of the story shown is in its
+// episode's audio
+func (m *Model) SetUtterances(id string, utterances []db.Utterance) {
+	if m.story == nil || m.story.ID != id {
+		return
+	}
+	m.utterances = utterances
+	if m.ready {
+		m.updateContent()
+	}
+}
+
+// utteranceAt is the utterance the line of content is part of; nil if it's
+// before the first or the story has none
+func (m Model) utteranceAt(line int) *db.Utterance {
+	var found *db.Utterance
+	for i := range m.utterances {
+		if m.utterances[i].Line > line {
+			break
+		}
+		found = &m.utterances[i]
+	}
+	return found
+}
+
+// seconds converts a time in the audio to a duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// gutterWidth is the width of the column of times left of the story text;
+// 0 if the story has none
+func (m Model) gutterWidth() int {
+	width := 0
+	for _, u := range m.utterances {
+		width = max(width, len(clock(seconds(u.Start))))
+	}
+	if width == 0 {
+		return 0
+	}
+	return width + 2
+}
+
+// addGutter puts the time each paragraph starts in a column left of its
+// first line. It goes on after links and matches are styled, so times
+// aren't taken for either.
+func (m Model) addGutter(wrapped string) string {
+	width := m.gutterWidth()
+	if width == 0 {
+		return wrapped
+	}
+	starts := make(map[int]float64, len(m.utterances))
+	for _, u := range m.utterances {
+		starts[u.Line] = u.Start
+	}
+
+	blank := strings.Repeat(" ", width)
+	lines := strings.Split(wrapped, "\n")
+	prev := -1
+	for i, line := range lines {
+		gutter := blank
+		if i < len(m.sourceLines) {
+			source := m.sourceLines[i]
+			if start, ok := starts[source]; ok && source != prev {
+				gutter = styles.DimStyle.Render(fmt.Sprintf("%*s", width-2, clock(seconds(start)))) + "  "
+			}
+			prev = source
+		}
+		lines[i] = gutter + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// jumpToTime scrolls to the paragraph playing at a time in the episode,
+// typed at the : prompt as minutes, m:ss, or h:mm:ss
+func (m *Model) jumpToTime(text string) {
+	switch {
+	case len(m.utterances) == 0:
+		m.timeStatus = "No timestamps for this story"
+		return
+	case !timePattern.MatchString(text):
+		m.timeStatus = fmt.Sprintf("%q isn't a time (try 12:30 or 1:02:05)", text)
+		return
+	}
+
+	at := clockSeconds(text)
+	if !strings.Contains(text, ":") {
+		at *= 60
+	}
+	target := m.utterances[0]
+	for _, u := range m.utterances {
+		if u.Start > at {
+			break
+		}
+		target = u
+	}
+	m.viewport.SetYOffset(m.viewportLine(target.Line))
+}
+
+// citation quotes the paragraph at the top of the screen with the story,
+// episode, and time it's from, and a link into the audio when there's one.
+// It's "" if the story has no times.
+func (m Model) citation() string {
+	u := m.utteranceAt(m.MarkLine())
+	if u == nil {
+		return ""
+	}
+
+	var paragraph []string
+	for _, line := range strings.Split(m.story.Content, "\n")[u.Line:] {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		paragraph = append(paragraph, strings.TrimSpace(speakerPrefix.ReplaceAllString(line, "")))
+	}
+	quote := truncate(strings.Join(paragraph, " "), citationQuoteLen)
+
+	source := m.story.FormattedShow() + ", " + m.story.FormattedDate()
+	if m.episode != nil {
+		source = m.episode.Label() + " (" + source + ")"
+	}
+	citation := fmt.Sprintf("“%s” — %s, %s, at %s", quote, m.story.Title, source, clock(seconds(u.Start)))
+	if m.episode != nil && m.episode.AudioURL != "" {
+		citation += fmt.Sprintf("\n%s#t=%d", m.episode.AudioURL, int(u.Start))
+	}
+	return citation
+}
+
+// renderTimeStatus is the footer's time at the top of the screen, or what
+// went wrong with the last : or y; "" if the story has no times
+func (m Model) renderTimeStatus() string {
+	if m.timeStatus != "" {
+		return styles.ErrorStyle.Render(m.timeStatus)
+	}
+	u := m.utteranceAt(m.MarkLine())
+	if u == nil {
+		return ""
+	}
+	return styles.DimStyle.Render("⏱ " + clock(seconds(u.Start)))
+}
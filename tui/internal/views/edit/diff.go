@@ -0,0 +1,62 @@
+package edit
+
+import (
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+var (
+	removedStyle = lipgloss.NewStyle().Foreground(styles.Error).Strikethrough(true)
+	addedStyle   = lipgloss.NewStyle().Foreground(styles.Success).Bold(true)
+)
+
+// wordDiff marks the words of old missing from new, and the words of new
+// missing from old, using a longest common subsequence over words
+func wordDiff(old, new string) (string, string) {
+	a, b := strings.Fields(old), strings.Fields(new)
+
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var left, right []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			left = append(left, a[i])
+			right = append(right, b[j])
+			i++
+			j++
+		case j < len(b) && (i == len(a) || lcs[i][j+1] >= lcs[i+1][j]):
+			right = append(right, addedStyle.Render(b[j]))
+			j++
+		default:
+			left = append(left, removedStyle.Render(a[i]))
+			i++
+		}
+	}
+
+	return strings.Join(left, " "), strings.Join(right, " ")
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
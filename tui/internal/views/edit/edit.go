@@ -0,0 +1,312 @@
+package edit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// fields are the editable story fields in form order (keys of db.EditableFields)
+var fields = []string{"title", "summary", "type", "location"}
+
+var labels = map[string]string{
+	"title":    "Title",
+	"summary":  "Summary",
+	"type":     "Type",
+	"location": "Location",
+}
+
+// Model is the metadata edit form for one story. Submitting shows a diff
+// of the changes, which must be confirmed before anything is saved.
+type Model struct {
+	database *db.DB
+	story    db.Story
+	old      []string
+	inputs   []textinput.Model
+	focus    int
+
+	// Confirmation step
+	changes []db.FieldChange
+	saving  bool
+
+	err    string
+	width  int
+	height int
+}
+
+// SavedMsg is sent when confirmed changes have been written
+type SavedMsg struct {
+	Story   db.Story // With the changes applied
+	Changes []db.FieldChange
+	Err     error
+}
+
+// CancelledMsg is sent when the form is closed without saving
+type CancelledMsg struct{}
+
+// New creates an edit form pre-filled with the story's current values
+func New(database *db.DB, story db.Story) Model {
+	m := Model{database: database, story: story}
+	for i, field := range fields {
+		value := fieldValue(story, field)
+		m.old = append(m.old, value)
+
+		ti := textinput.New()
+		ti.CharLimit = 200
+		if field == "summary" {
+			ti.CharLimit = 4000
+		}
+		ti.SetValue(value)
+		if i == 0 {
+			ti.Focus()
+		}
+		m.inputs = append(m.inputs, ti)
+	}
+	return m
+}
+
+func fieldValue(story db.Story, field string) string {
+	switch field {
+	case "title":
+		return story.Title
+	case "summary":
+		return story.Summary.String
+	case "type":
+		return story.StoryType.String
+	case "location":
+		return story.Location.String
+	}
+	return ""
+}
+
+// SetSize sets the form dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	for i := range m.inputs {
+		m.inputs[i].Width = width - 20
+	}
+}
+
+// Init starts the cursor blinking
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SavedMsg:
+		m.saving = false
+		if msg.Err != nil {
+			m.err = msg.Err.Error()
+			m.changes = nil
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+		if m.changes != nil {
+			return m.handleConfirmKeys(msg)
+		}
+
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return CancelledMsg{} }
+		case "tab", "down":
+			return m, m.focusField(m.focus + 1)
+		case "shift+tab", "up":
+			return m, m.focusField(m.focus - 1)
+		case "ctrl+s":
+			m.submit()
+			return m, nil
+		case "enter":
+			if m.focus < len(m.inputs)-1 {
+				return m, m.focusField(m.focus + 1)
+			}
+			m.submit()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m *Model) focusField(i int) tea.Cmd {
+	m.inputs[m.focus].Blur()
+	m.focus = (i + len(m.inputs)) % len(m.inputs)
+	m.inputs[m.focus].Focus()
+	return textinput.Blink
+}
+
+// submit validates the form and moves to the diff if anything changed
+func (m *Model) submit() {
+	m.err = ""
+	var changes []db.FieldChange
+	for i, field := range fields {
+		value := strings.TrimSpace(m.inputs[i].Value())
+		if value != m.old[i] {
+			changes = append(changes, db.FieldChange{Field: field, Old: m.old[i], New: value})
+		}
+	}
+
+	for _, c := range changes {
+		switch {
+		case c.Field == "title" && c.New == "":
+			m.err = "Title must not be empty"
+			return
+		case c.Field == "type" && c.New != "" && !validType(c.New):
+			m.err = fmt.Sprintf("Unknown type %q (want one of %s)", c.New, strings.Join(db.StoryTypes, ", "))
+			return
+		}
+	}
+
+	if len(changes) == 0 {
+		m.err = "No changes"
+		return
+	}
+	m.changes = changes
+}
+
+func validType(storyType string) bool {
+	for _, t := range db.StoryTypes {
+		if t == storyType {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) handleConfirmKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		m.saving = true
+		return m, m.save()
+	case "n", "esc":
+		// Back to the form with the edits intact
+		m.changes = nil
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+func (m Model) save() tea.Cmd {
+	story, changes := m.story, m.changes
+	return func() tea.Msg {
+		err := m.database.UpdateStory(context.Background(), story.ID, changes)
+		if err == nil {
+			for _, c := range changes {
+				apply(&story, c)
+			}
+		}
+		return SavedMsg{Story: story, Changes: changes, Err: err}
+	}
+}
+
+// apply sets a changed field on a story, as UpdateStory did in the database
+func apply(story *db.Story, c db.FieldChange) {
+	valid := c.New != ""
+	switch c.Field {
+	case "title":
+		story.Title = c.New
+	case "summary":
+		story.Summary.String, story.Summary.Valid = c.New, valid
+	case "type":
+		story.StoryType.String, story.StoryType.Valid = c.New, valid
+	case "location":
+		story.Location.String, story.Location.Valid = c.New, valid
+		story.Latitude.Valid, story.Longitude.Valid = false, false
+		story.GeoClusterID = nil
+	}
+}
+
+// View renders the form, or the diff awaiting confirmation
+func (m Model) View() string {
+	if m.changes != nil {
+		return m.renderDiff()
+	}
+
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("Edit Story"))
+	b.WriteString("\n\n")
+
+	for i, field := range fields {
+		style := styles.InputStyle
+		if i == m.focus {
+			style = styles.FocusedInputStyle
+		}
+		b.WriteString(fmt.Sprintf("%-9s %s\n", labels[field], style.Render(m.inputs[i].View())))
+	}
+
+	if m.err != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(m.err))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("Blank clears a field (except title) • a new location clears its geocode"))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("tab: next field • enter on last field / ctrl+s: review changes • esc: cancel"))
+
+	return m.frame(b.String())
+}
+
+// renderDiff shows each changed field's old and new values side by side
+func (m Model) renderDiff() string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render(fmt.Sprintf("Confirm %d change(s) to %q", len(m.changes), m.story.Title)))
+	b.WriteString("\n\n")
+
+	colWidth := (m.width - 12) / 2
+	if colWidth < 20 {
+		colWidth = 20
+	}
+	col := lipgloss.NewStyle().Width(colWidth)
+
+	b.WriteString(styles.DimStyle.Render(col.Render("Before") + "  " + col.Render("After")))
+	b.WriteString("\n")
+
+	for _, c := range m.changes {
+		b.WriteString(styles.BoldStyle.Render(labels[c.Field]))
+		b.WriteString("\n")
+
+		before, after := wordDiff(c.Old, c.New)
+		if c.Old == "" {
+			before = styles.DimStyle.Render("(empty)")
+		}
+		if c.New == "" {
+			after = styles.DimStyle.Render("(empty)")
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, col.Render(before), "  ", col.Render(after)))
+		b.WriteString("\n\n")
+	}
+
+	if m.saving {
+		b.WriteString("Saving...")
+	} else {
+		b.WriteString(styles.DimStyle.Render("y/enter: save • n/esc: back to editing"))
+	}
+
+	return m.frame(b.String())
+}
+
+func (m Model) frame(content string) string {
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(m.width - 4).
+		Render(content)
+}
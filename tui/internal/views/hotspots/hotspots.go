@@ -0,0 +1,227 @@
+package hotspots
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/hotspot"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// recentWindow is how close to the newest story a flap must end to raise an alert
+const recentWindow = 60 * 24 * time.Hour
+
+// Model represents the hotspots panel
+type Model struct {
+	database *db.DB
+	flaps    []hotspot.Flap
+	spatial  []db.GeoHotspot
+	latest   time.Time // Newest air date among geocoded stories
+	cursor   int
+	loading  bool
+	err      error
+	width    int
+	height   int
+}
+
+// New creates a new hotspots model
+func New(database *db.DB) Model {
+	return Model{database: database, loading: true}
+}
+
+// Init runs detection in the background
+func (m Model) Init() tea.Cmd {
+	return m.detect()
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// FlapsDetectedMsg carries the results of a hotspot scan
+type FlapsDetectedMsg struct {
+	Flaps   []hotspot.Flap
+	Spatial []db.GeoHotspot
+	Latest  time.Time
+	Err     error
+}
+
+// FlapSelectedMsg asks to browse the stories in a flap
+type FlapSelectedMsg struct {
+	Flap hotspot.Flap
+}
+
+func (m Model) detect() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		stories, err := m.database.GetGeoStories(ctx)
+		if err != nil {
+			return FlapsDetectedMsg{Err: err}
+		}
+		spatial, err := m.database.GetGeoHotspots(ctx)
+		if err != nil {
+			return FlapsDetectedMsg{Err: err}
+		}
+
+		var latest time.Time
+		for _, s := range stories {
+			if s.AirDate.After(latest) {
+				latest = s.AirDate
+			}
+		}
+
+		return FlapsDetectedMsg{
+			Flaps:   hotspot.Scan(stories, hotspot.DefaultParams),
+			Spatial: spatial,
+			Latest:  latest,
+		}
+	}
+}
+
+// Reload re-runs detection
+func (m *Model) Reload() tea.Cmd {
+	m.loading = true
+	return m.detect()
+}
+
+// Recent returns flaps ending near the newest story, which warrant an alert
+func (m Model) Recent() []hotspot.Flap {
+	var recent []hotspot.Flap
+	for _, f := range m.flaps {
+		if m.isRecent(f) {
+			recent = append(recent, f)
+		}
+	}
+	return recent
+}
+
+func (m Model) isRecent(f hotspot.Flap) bool {
+	return m.latest.Sub(f.To) <= recentWindow
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FlapsDetectedMsg:
+		m.loading = false
+		m.err = msg.Err
+		if msg.Err != nil {
+			return m, nil
+		}
+		m.flaps = msg.Flaps
+		m.spatial = msg.Spatial
+		m.latest = msg.Latest
+		if m.cursor >= len(m.flaps) {
+			m.cursor = 0
+		}
+		return m, nil
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			if m.cursor < len(m.flaps)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.Reload()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
+			if m.cursor < len(m.flaps) {
+				flap := m.flaps[m.cursor]
+				return m, func() tea.Msg {
+					return FlapSelectedMsg{Flap: flap}
+				}
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the hotspots panel
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Width(m.width - 4).Render("Hotspots"))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString("\n  Scanning for hotspots...")
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+		return b.String()
+	}
+
+	// Space-time flaps
+	b.WriteString(styles.BoldStyle.Render("  Flaps"))
+	b.WriteString(styles.DimStyle.Render("  more stories in a place and time than expected"))
+	b.WriteString("\n\n")
+
+	if len(m.flaps) == 0 {
+		b.WriteString(styles.DimStyle.Render("  No unusual space-time clusters. Geocode stories with scripts/geocode_stories.py."))
+		b.WriteString("\n")
+	}
+
+	for i, f := range m.flaps {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+		marker := "  "
+		if m.isRecent(f) {
+			marker = styles.ErrorStyle.Render("! ")
+		}
+
+		place := f.Place
+		if maxLen := m.width - 70; maxLen > 10 && len(place) > maxLen {
+			place = place[:maxLen-3] + "..."
+		}
+
+		line := fmt.Sprintf("%s%s%-30s %s → %s  ≤%gkm  %d stories (%.1f expected)  LLR %.1f",
+			cursor, marker, place,
+			dates.Date(f.From), dates.Date(f.To),
+			f.RadiusKm, f.Count, f.Expected, f.LLR)
+
+		if i == m.cursor {
+			b.WriteString(styles.SelectedItemStyle.Width(m.width - 4).Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	// Purely spatial hotspots from scripts/cluster_locations.py
+	if len(m.spatial) > 0 {
+		b.WriteString("\n")
+		b.WriteString(styles.BoldStyle.Render("  Geographic clusters"))
+		b.WriteString(styles.DimStyle.Render("  all time"))
+		b.WriteString("\n\n")
+		for _, h := range m.spatial {
+			b.WriteString(fmt.Sprintf("    #%-3d %4d stories  %s\n", h.ID, h.Count, h.Place))
+		}
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("  ↑↓: navigate • enter: browse flap • r: rescan • ! = recent"))
+
+	return b.String()
+}
@@ -0,0 +1,211 @@
+package maintenance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// deadRatioWarn flags tables where this share of rows are dead tuples
+const deadRatioWarn = 0.2
+
+// leafDensityWarn flags btree indexes whose leaf pages are this empty
+const leafDensityWarn = 60.0
+
+// Model represents the maintenance screen
+type Model struct {
+	database *db.DB
+	readOnly bool
+	tables   []db.TableHealth
+	indexes  []db.IndexHealth
+	loading  bool
+	running  string // Action in progress, "" if idle
+	status   string // Result of the last action
+	err      error
+	width    int
+	height   int
+}
+
+// New creates a new maintenance model; readOnly disables ANALYZE/REINDEX
+func New(database *db.DB, readOnly bool) Model {
+	return Model{database: database, readOnly: readOnly, loading: true}
+}
+
+// Init loads table and index health
+func (m Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// HealthLoadedMsg carries table and index health
+type HealthLoadedMsg struct {
+	Tables  []db.TableHealth
+	Indexes []db.IndexHealth
+	Err     error
+}
+
+// ActionDoneMsg reports a finished ANALYZE or REINDEX
+type ActionDoneMsg struct {
+	Action string
+	Err    error
+}
+
+func (m Model) load() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		tables, err := m.database.GetTableHealth(ctx)
+		if err != nil {
+			return HealthLoadedMsg{Err: err}
+		}
+		indexes, err := m.database.GetIndexHealth(ctx)
+		return HealthLoadedMsg{Tables: tables, Indexes: indexes, Err: err}
+	}
+}
+
+// Reload refreshes table and index health
+func (m *Model) Reload() tea.Cmd {
+	m.loading = true
+	return m.load()
+}
+
+func (m Model) run(action string, fn func(context.Context) error) tea.Cmd {
+	return func() tea.Msg {
+		return ActionDoneMsg{Action: action, Err: fn(context.Background())}
+	}
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case HealthLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		m.tables = msg.Tables
+		m.indexes = msg.Indexes
+		return m, nil
+
+	case ActionDoneMsg:
+		m.running = ""
+		if msg.Err != nil {
+			m.status = styles.ErrorStyle.Render(fmt.Sprintf("%s failed: %v", msg.Action, msg.Err))
+			return m, nil
+		}
+		m.status = msg.Action + " done"
+		return m, m.Reload()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.Reload()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+			if m.readOnly || m.running != "" {
+				return m, nil
+			}
+			m.running = "ANALYZE"
+			return m, m.run("ANALYZE", m.database.AnalyzeTables)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("R"))):
+			if m.readOnly || m.running != "" {
+				return m, nil
+			}
+			m.running = "REINDEX stories"
+			return m, m.run("REINDEX stories", func(ctx context.Context) error {
+				return m.database.ReindexTable(ctx, "stories")
+			})
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the maintenance screen
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Width(m.width - 4).Render("Maintenance"))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString("\n  Loading...")
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+		return b.String()
+	}
+
+	b.WriteString(styles.BoldStyle.Render("  Tables"))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render(fmt.Sprintf("  %-14s %10s %10s %10s %10s %14s  %-10s %-10s",
+		"table", "total", "table", "indexes", "live", "dead", "vacuumed", "analyzed")))
+	b.WriteString("\n")
+	for _, t := range m.tables {
+		dead := fmt.Sprintf("%d (%.0f%%)", t.DeadRows, 100*t.DeadRatio())
+		line := fmt.Sprintf("  %-14s %10s %10s %10s %10d %14s  %-10s %-10s",
+			t.Name, db.FormatBytes(t.TotalBytes), db.FormatBytes(t.TableBytes), db.FormatBytes(t.IndexBytes),
+			t.LiveRows, dead, db.FormatAge(t.LastVacuum), db.FormatAge(t.LastAnalyze))
+		if t.DeadRatio() >= deadRatioWarn || t.LastAnalyze == nil {
+			line = styles.ErrorStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.BoldStyle.Render("  Indexes"))
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render(fmt.Sprintf("  %-36s %-14s %10s %10s %8s",
+		"index", "table", "size", "scans", "density")))
+	b.WriteString("\n")
+
+	listHeight := m.height - len(m.tables) - 12
+	for i, ix := range m.indexes {
+		if i >= listHeight {
+			b.WriteString(styles.DimStyle.Render(fmt.Sprintf("  ... %d more (paranormal-tui maintenance)", len(m.indexes)-i)))
+			b.WriteString("\n")
+			break
+		}
+		density := "-"
+		if ix.LeafDensity != nil {
+			density = fmt.Sprintf("%.0f%%", *ix.LeafDensity)
+		}
+		line := fmt.Sprintf("  %-36s %-14s %10s %10d %8s",
+			ix.Name, ix.Table, db.FormatBytes(ix.Bytes), ix.Scans, density)
+		if ix.LeafDensity != nil && *ix.LeafDensity < leafDensityWarn {
+			line = styles.ErrorStyle.Render(line)
+		}
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.running != "":
+		b.WriteString(fmt.Sprintf("  Running %s...\n", m.running))
+	case m.status != "":
+		b.WriteString("  " + m.status + "\n")
+	}
+
+	help := "a: analyze • R: reindex stories • r: refresh • red = needs attention"
+	if m.readOnly {
+		help = "r: refresh • red = needs attention"
+	}
+	b.WriteString(styles.DimStyle.Render("  " + help))
+
+	return b.String()
+}
@@ -0,0 +1,399 @@
+// Package newstory is the form for adding a story from inside the TUI, for
+// small additions that don't warrant the ingest command: its details in
+// fields, a type picked from the known ones, and its text pasted in or
+// written in $EDITOR.
+package newstory
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Form fields in order. Type and text aren't text inputs: type is picked
+// with the arrows, and text is edited in $EDITOR or pasted.
+const (
+	fieldShow = iota
+	fieldDate
+	fieldTitle
+	fieldType
+	fieldLocation
+	fieldText
+	fieldCount
+)
+
+var labels = []string{"Show", "Date", "Title", "Type", "Location", "Text"}
+
+// previewLines is how much of the text the form shows
+const previewLines = 6
+
+// Model is the New Story form
+type Model struct {
+	database *db.DB
+	inputs   []textinput.Model // By field; type's and text's are unused
+	focus    int
+	types    []string // "" first, for leaving it to ClassifyStory
+	typeIdx  int
+	content  string
+
+	saving bool
+	err    string
+	width  int
+	height int
+}
+
+// SavedMsg is sent when the story has been added
+type SavedMsg struct {
+	ID      string
+	Show    string
+	Title   string
+	Content string
+	Err     error
+}
+
+// EmbeddedMsg is sent when a saved story has been embedded, and given a
+// type if it had none
+type EmbeddedMsg struct {
+	ID        string
+	Title     string
+	StoryType string // The type it was classified as; "" if it had one or none was found
+	Err       error
+}
+
+// EditedMsg is sent when $EDITOR exits with the story's text
+type EditedMsg struct {
+	Content string
+	Err     error
+}
+
+// CancelledMsg is sent when the form is closed without saving
+type CancelledMsg struct{}
+
+// New creates an empty form, with the show to file the story under
+// pre-filled, e.g. the one the last story was added to
+func New(database *db.DB, show string) Model {
+	m := Model{
+		database: database,
+		types:    append([]string{""}, db.StoryTypes...),
+	}
+	for i := 0; i < fieldCount; i++ {
+		ti := textinput.New()
+		ti.CharLimit = 200
+		m.inputs = append(m.inputs, ti)
+	}
+	m.inputs[fieldShow].SetValue(show)
+	m.inputs[fieldDate].Placeholder = "YYYY-MM-DD, or blank for undated"
+	m.inputs[fieldTitle].Placeholder = "blank for the text's first line"
+	m.focusField(fieldShow)
+	if show != "" {
+		m.focusField(fieldTitle)
+	}
+	return m
+}
+
+// SetSize sets the form dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	for i := range m.inputs {
+		m.inputs[i].Width = width - 30
+	}
+}
+
+// Init starts the cursor blinking
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SavedMsg:
+		m.saving = false
+		if msg.Err != nil {
+			m.err = msg.Err.Error()
+		}
+		return m, nil
+
+	case EditedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err.Error()
+			return m, nil
+		}
+		m.err = ""
+		m.content = normalize(msg.Content)
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+		if msg.Paste && m.focus == fieldText {
+			m.content = normalize(m.content + "\n\n" + string(msg.Runes))
+			return m, nil
+		}
+
+		switch msg.String() {
+		case "esc":
+			return m, func() tea.Msg { return CancelledMsg{} }
+		case "tab", "down":
+			return m, m.focusField(m.focus + 1)
+		case "shift+tab", "up":
+			return m, m.focusField(m.focus - 1)
+		case "ctrl+o":
+			return m, m.openEditor()
+		case "ctrl+s":
+			return m, m.submit()
+		case "enter":
+			if m.focus == fieldText {
+				return m, m.openEditor()
+			}
+			return m, m.focusField(m.focus + 1)
+		}
+
+		if m.focus == fieldType {
+			switch msg.String() {
+			case "right", "l", " ":
+				m.typeIdx = (m.typeIdx + 1) % len(m.types)
+			case "left", "h":
+				m.typeIdx = (m.typeIdx - 1 + len(m.types)) % len(m.types)
+			}
+			return m, nil
+		}
+		if m.focus == fieldText {
+			return m, nil
+		}
+	}
+
+	if !m.isInput(m.focus) {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+// isInput reports whether a field is typed into
+func (m Model) isInput(field int) bool {
+	return field != fieldType && field != fieldText
+}
+
+func (m *Model) focusField(i int) tea.Cmd {
+	m.inputs[m.focus].Blur()
+	m.focus = (i + fieldCount) % fieldCount
+	if !m.isInput(m.focus) {
+		return nil
+	}
+	m.inputs[m.focus].Focus()
+	return textinput.Blink
+}
+
+// openEditor suspends the TUI to edit the text in $VISUAL or $EDITOR (vi
+// if neither is set), from a temporary file removed afterwards
+func (m Model) openEditor() tea.Cmd {
+	f, err := os.CreateTemp("", "paranormal-story-*.txt")
+	if err != nil {
+		return func() tea.Msg { return EditedMsg{Err: fmt.Errorf("failed to create temporary file: %w", err)} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(m.content)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return EditedMsg{Err: fmt.Errorf("failed to write temporary file: %w", err)} }
+	}
+
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	// The variable may carry arguments, e.g. "code --wait"
+	args := append(strings.Fields(editor), path)
+	cmd := exec.Command(args[0], args[1:]...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return EditedMsg{Err: fmt.Errorf("%s failed: %w", args[0], err)}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return EditedMsg{Err: fmt.Errorf("failed to read the edited text: %w", err)}
+		}
+		return EditedMsg{Content: string(data)}
+	})
+}
+
+// submit validates the form and adds the story
+func (m *Model) submit() tea.Cmd {
+	m.err = ""
+	show := strings.TrimSpace(m.inputs[fieldShow].Value())
+	title := strings.TrimSpace(m.inputs[fieldTitle].Value())
+	var airDate *time.Time
+	if value := strings.TrimSpace(m.inputs[fieldDate].Value()); value != "" {
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			m.err = fmt.Sprintf("Invalid date %q (want YYYY-MM-DD)", value)
+			return nil
+		}
+		airDate = &t
+	}
+	switch {
+	case show == "":
+		m.err = "Show must not be empty"
+		return nil
+	case m.content == "":
+		m.err = "No text: press enter on Text to write it, or paste it there"
+		return nil
+	}
+	if title == "" {
+		title = firstLine(m.content)
+	}
+
+	m.saving = true
+	sum := sha256.Sum256([]byte(m.content))
+	story := db.NewStory{
+		Show:      show,
+		AirDate:   airDate,
+		Title:     title,
+		Content:   m.content,
+		StoryType: m.types[m.typeIdx],
+		Location:  strings.TrimSpace(m.inputs[fieldLocation].Value()),
+		Source:    "tui",
+		SourceID:  hex.EncodeToString(sum[:]),
+	}
+	database := m.database
+	return func() tea.Msg {
+		id, err := database.AddStory(context.Background(), story)
+		return SavedMsg{ID: id, Show: show, Title: title, Content: story.Content, Err: err}
+	}
+}
+
+// Embed computes a saved story's embedding, as the pipeline would have,
+// then classifies it by its neighbors if it was left without a type
+func Embed(database *db.DB, id, title, content string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		client, err := embed.New()
+		if err != nil {
+			return EmbeddedMsg{ID: id, Title: title, Err: err}
+		}
+		embedding, method, err := embed.Story(ctx, client, content)
+		if err != nil {
+			return EmbeddedMsg{ID: id, Title: title, Err: err}
+		}
+		if err := database.SetEmbedding(ctx, id, embedding, method, embed.EstimateTokens(content)); err != nil {
+			return EmbeddedMsg{ID: id, Title: title, Err: err}
+		}
+		storyType, err := database.ClassifyStory(ctx, id)
+		return EmbeddedMsg{ID: id, Title: title, StoryType: storyType, Err: err}
+	}
+}
+
+// normalize tidies pasted or edited text: LF line endings, no surrounding
+// blank lines
+func normalize(text string) string {
+	return strings.TrimSpace(strings.ReplaceAll(text, "\r\n", "\n"))
+}
+
+// firstLine is the text's first line, cut at a word to 80 characters
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+	line = strings.TrimSpace(line)
+	if r := []rune(line); len(r) > 80 {
+		line = string(r[:80])
+		if i := strings.LastIndex(line, " "); i > 40 {
+			line = line[:i]
+		}
+		line += "…"
+	}
+	return line
+}
+
+// View renders the form
+func (m Model) View() string {
+	var b strings.Builder
+	b.WriteString(styles.HeaderStyle.Render("New Story"))
+	b.WriteString("\n\n")
+
+	for field := 0; field < fieldCount; field++ {
+		style := styles.InputStyle
+		if field == m.focus {
+			style = styles.FocusedInputStyle
+		}
+		var value string
+		switch field {
+		case fieldType:
+			value = "‹ " + styles.DimStyle.Render("classify automatically") + " ›"
+			if t := m.types[m.typeIdx]; t != "" {
+				value = "‹ " + styles.TypeBadge(t) + " ›"
+			}
+		case fieldText:
+			value = m.renderPreview()
+		default:
+			value = m.inputs[field].View()
+		}
+		b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, fmt.Sprintf("\n%-9s ", labels[field]), style.Render(value)))
+		b.WriteString("\n")
+	}
+
+	if m.err != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(m.err))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	if m.saving {
+		b.WriteString("Saving...")
+	} else {
+		b.WriteString(styles.DimStyle.Render("The story is embedded once saved; without a type it takes its neighbors' most common one"))
+		b.WriteString("\n")
+		b.WriteString(styles.DimStyle.Render("tab: next • ←→: type • enter on Text / ctrl+o: $EDITOR • ctrl+s: save • esc: cancel"))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(1, 2).
+		Width(m.width - 4).
+		Render(b.String())
+}
+
+// renderPreview is the start of the text, with its length
+func (m Model) renderPreview() string {
+	if m.content == "" {
+		return styles.DimStyle.Render("empty: press enter to write it, or paste it here")
+	}
+	lines := strings.Split(m.content, "\n")
+	width := max(m.width-30, 20)
+	var preview []string
+	for _, line := range lines[:min(len(lines), previewLines)] {
+		if r := []rune(line); len(r) > width {
+			line = string(r[:width-1]) + "…"
+		}
+		preview = append(preview, line)
+	}
+	if len(lines) > previewLines {
+		preview = append(preview, "…")
+	}
+	preview = append(preview, styles.DimStyle.Render(fmt.Sprintf("%d words", len(strings.Fields(m.content)))))
+	return strings.Join(preview, "\n")
+}
@@ -0,0 +1,220 @@
+package present
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// maxStories caps how many stories a presentation cycles through
+const maxStories = 500
+
+// DefaultInterval is how long each story stays on screen
+const DefaultInterval = 20 * time.Second
+
+// Model represents the auto-cycling presentation mode
+type Model struct {
+	database *db.DB
+	stories  []db.Story
+	index    int
+	loading  bool
+	err      error
+	width    int
+	height   int
+
+	// Timing
+	interval time.Duration
+	elapsed  time.Duration
+	paused   bool
+	tickID   int // Incremented on restart so stale ticks are dropped
+}
+
+// New creates a new presentation model
+func New(database *db.DB, interval time.Duration) Model {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return Model{
+		database: database,
+		interval: interval,
+	}
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// StoriesLoadedMsg indicates the presentation stories have loaded
+type StoriesLoadedMsg struct {
+	Stories []db.Story
+	Err     error
+}
+
+// tickMsg advances the presentation clock
+type tickMsg struct {
+	id int
+}
+
+// Start loads the stories matching filters and begins cycling
+func (m *Model) Start(filters db.BrowseFilters, sort db.BrowseSort) tea.Cmd {
+	m.loading = true
+	m.err = nil
+	m.index = 0
+	m.elapsed = 0
+	m.paused = false
+	m.tickID++
+
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		ctx := context.Background()
+		stories, _, err := m.database.ListStories(ctx, maxStories, 0, &filters, &sort)
+		return StoriesLoadedMsg{Stories: stories, Err: err}
+	}
+}
+
+// Pause holds the current story on screen
+func (m *Model) Pause() {
+	m.paused = true
+}
+
+// Stop halts the presentation clock
+func (m *Model) Stop() {
+	m.tickID++
+}
+
+func (m Model) tick() tea.Cmd {
+	id := m.tickID
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return tickMsg{id: id}
+	})
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case StoriesLoadedMsg:
+		m.loading = false
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		m.stories = msg.Stories
+		return m, m.tick()
+
+	case tickMsg:
+		if msg.id != m.tickID {
+			return m, nil
+		}
+		if !m.paused && len(m.stories) > 0 {
+			m.elapsed += time.Second
+			if m.elapsed >= m.interval {
+				m.advance(1)
+			}
+		}
+		return m, m.tick()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys(" ", "space"))):
+			m.paused = !m.paused
+		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l", "n"))):
+			m.advance(1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("left", "h", "p"))):
+			m.advance(-1)
+		}
+	}
+
+	return m, nil
+}
+
+// advance moves by delta stories, wrapping around, and resets the clock
+func (m *Model) advance(delta int) {
+	if len(m.stories) == 0 {
+		return
+	}
+	m.index = (m.index + delta + len(m.stories)) % len(m.stories)
+	m.elapsed = 0
+}
+
+// CurrentStory returns the story on screen, if any
+func (m Model) CurrentStory() *db.Story {
+	if m.index < len(m.stories) {
+		return &m.stories[m.index]
+	}
+	return nil
+}
+
+// View renders the presentation
+func (m Model) View() string {
+	if m.loading {
+		return m.place("Loading presentation...")
+	}
+
+	if m.err != nil {
+		return m.place(styles.ErrorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+	}
+
+	story := m.CurrentStory()
+	if story == nil {
+		return m.place("No stories match the current filters.")
+	}
+
+	cardWidth := m.width * 3 / 4
+	if cardWidth > 100 {
+		cardWidth = 100
+	}
+	if cardWidth < 30 {
+		cardWidth = m.width - 4
+	}
+	textWidth := cardWidth - 6
+
+	var b strings.Builder
+
+	b.WriteString(styles.BoldStyle.Foreground(styles.Primary).Width(textWidth).Render(story.Title))
+	b.WriteString("\n\n")
+	b.WriteString(fmt.Sprintf("%s  %s\n",
+		styles.TypeBadge(story.FormattedType()),
+		styles.DimStyle.Render(story.FormattedDate()+" • "+story.FormattedShow()),
+	))
+	b.WriteString(fmt.Sprintf("%s %s\n\n",
+		styles.DimStyle.Render("Location:"),
+		story.FormattedLocation()))
+
+	summary := story.Snippet(600)
+	if story.Summary.Valid && story.Summary.String != "" {
+		summary = story.Summary.String
+	}
+	summary = strings.ReplaceAll(summary, "\n", " ")
+	b.WriteString(lipgloss.NewStyle().Width(textWidth).Render(summary))
+
+	card := styles.ModalStyle.Width(cardWidth).Render(b.String())
+
+	// Progress and controls
+	state := fmt.Sprintf("next in %ds", int((m.interval - m.elapsed).Seconds()))
+	if m.paused {
+		state = "paused"
+	}
+	footer := styles.DimStyle.Render(fmt.Sprintf(
+		"%d/%d • %s • space: pause • ←/→: prev/next • esc: exit",
+		m.index+1, len(m.stories), state,
+	))
+
+	return m.place(lipgloss.JoinVertical(lipgloss.Center, card, "", footer))
+}
+
+func (m Model) place(content string) string {
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content)
+}
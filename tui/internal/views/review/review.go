@@ -0,0 +1,329 @@
+package review
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Model represents the review queue of community submissions
+type Model struct {
+	database    *db.DB
+	readOnly    bool
+	submissions []db.Submission
+	types       map[string]string // Type chosen per submission ID
+	cursor      int
+	loading     bool
+	err         error
+	width       int
+	height      int
+
+	// Rejection note being typed; rejecting is true while it has focus
+	note      textinput.Model
+	rejecting bool
+}
+
+// New creates a new review model; readOnly disables approving and rejecting
+func New(database *db.DB, readOnly bool) Model {
+	ti := textinput.New()
+	ti.Placeholder = "reason (optional)"
+	ti.CharLimit = 200
+
+	return Model{
+		database: database,
+		readOnly: readOnly,
+		types:    make(map[string]string),
+		note:     ti,
+		loading:  true,
+	}
+}
+
+// Init loads the queue
+func (m Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.note.Width = width/2 - 12
+}
+
+// InputActive reports whether the rejection note has focus
+func (m Model) InputActive() bool {
+	return m.rejecting
+}
+
+// Pending returns how many submissions are waiting
+func (m Model) Pending() int {
+	return len(m.submissions)
+}
+
+// SubmissionsLoadedMsg carries the pending submissions
+type SubmissionsLoadedMsg struct {
+	Submissions []db.Submission
+	Err         error
+}
+
+// SubmissionReviewedMsg reports an approved or rejected submission.
+// StoryID is set when it was approved.
+type SubmissionReviewedMsg struct {
+	Title   string
+	StoryID string
+	Err     error
+}
+
+func (m Model) load() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		subs, err := m.database.ListPendingSubmissions(context.Background())
+		return SubmissionsLoadedMsg{Submissions: subs, Err: err}
+	}
+}
+
+// Reload refreshes the queue
+func (m *Model) Reload() tea.Cmd {
+	m.loading = true
+	return m.load()
+}
+
+// storyType is the type the submission will be approved as: the reviewer's
+// choice, else the submitter's suggestion if it's a known type
+func (m Model) storyType(s db.Submission) string {
+	if t, ok := m.types[s.ID]; ok {
+		return t
+	}
+	if slices.Contains(db.StoryTypes, s.StoryType) {
+		return s.StoryType
+	}
+	return "other"
+}
+
+// cycleType moves the selected submission's type through db.StoryTypes
+func (m *Model) cycleType(step int) {
+	s := m.submissions[m.cursor]
+	i := slices.Index(db.StoryTypes, m.storyType(s))
+	n := len(db.StoryTypes)
+	m.types[s.ID] = db.StoryTypes[((i+step)%n+n)%n]
+}
+
+func (m Model) approve(s db.Submission, storyType string) tea.Cmd {
+	return func() tea.Msg {
+		id, err := m.database.ApproveSubmission(context.Background(), s.ID, storyType)
+		return SubmissionReviewedMsg{Title: s.Title, StoryID: id, Err: err}
+	}
+}
+
+func (m Model) reject(s db.Submission, note string) tea.Cmd {
+	return func() tea.Msg {
+		err := m.database.RejectSubmission(context.Background(), s.ID, note)
+		return SubmissionReviewedMsg{Title: s.Title, Err: err}
+	}
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case SubmissionsLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		m.submissions = msg.Submissions
+		if m.cursor >= len(m.submissions) {
+			m.cursor = max(0, len(m.submissions)-1)
+		}
+		return m, nil
+
+	case SubmissionReviewedMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		return m, m.Reload()
+
+	case tea.KeyMsg:
+		if m.rejecting {
+			switch msg.String() {
+			case "esc":
+				m.rejecting = false
+				m.note.Blur()
+			case "enter":
+				m.rejecting = false
+				m.note.Blur()
+				if m.cursor < len(m.submissions) {
+					return m, m.reject(m.submissions[m.cursor], strings.TrimSpace(m.note.Value()))
+				}
+			default:
+				var cmd tea.Cmd
+				m.note, cmd = m.note.Update(msg)
+				return m, cmd
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			if m.cursor < len(m.submissions)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.Reload()
+		}
+
+		if m.readOnly || m.cursor >= len(m.submissions) {
+			return m, nil
+		}
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("t"))):
+			m.cycleType(1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("T"))):
+			m.cycleType(-1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("a"))):
+			s := m.submissions[m.cursor]
+			return m, m.approve(s, m.storyType(s))
+		case key.Matches(msg, key.NewBinding(key.WithKeys("x"))):
+			m.rejecting = true
+			m.note.SetValue("")
+			m.note.Focus()
+			return m, textinput.Blink
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the review queue
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Width(m.width - 4).Render(fmt.Sprintf("Review (%d pending)", len(m.submissions))))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString("\n  Loading...")
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	if len(m.submissions) == 0 {
+		b.WriteString(styles.DimStyle.Render("\n  No submissions waiting. Community members submit through the web backend (paranormal-tui submitters add)."))
+		return b.String()
+	}
+
+	listWidth := m.width / 2
+	paneHeight := m.height - 6
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderList(listWidth, paneHeight),
+		m.renderSubmission(m.width-listWidth-4, paneHeight)))
+	b.WriteString("\n")
+
+	switch {
+	case m.rejecting:
+		b.WriteString("  Reject: " + m.note.View() + styles.DimStyle.Render("  enter: reject • esc: cancel"))
+	case m.readOnly:
+		b.WriteString(styles.DimStyle.Render("  ↑↓: navigate • r: refresh"))
+	default:
+		b.WriteString(styles.DimStyle.Render("  ↑↓: navigate • t/T: change type • a: approve • x: reject • r: refresh"))
+	}
+
+	return b.String()
+}
+
+func (m Model) renderList(width, height int) string {
+	var b strings.Builder
+
+	start := 0
+	if m.cursor >= height {
+		start = m.cursor - height + 1
+	}
+	for i := start; i < len(m.submissions) && i < start+height; i++ {
+		s := m.submissions[i]
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+
+		title := s.Title
+		if maxLen := width - 22 - dates.Width(); maxLen > 10 && len(title) > maxLen {
+			title = title[:maxLen-3] + "..."
+		}
+		line := fmt.Sprintf("%s%-*s  %-14s  %s", cursor, dates.Width(), dates.Date(s.SubmittedAt), truncate(s.Submitter, 14), title)
+
+		if i == m.cursor {
+			b.WriteString(styles.SelectedItemStyle.Width(width - 2).Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	return lipgloss.NewStyle().Width(width).Height(height).Render(b.String())
+}
+
+// renderSubmission shows the selected submission in full, as it will be
+// stored
+func (m Model) renderSubmission(width, height int) string {
+	s := m.submissions[m.cursor]
+	inner := width - 4 // Border and padding
+
+	var b strings.Builder
+	b.WriteString(styles.BoldStyle.Foreground(styles.Primary).Width(inner).Render(s.Title))
+	b.WriteString("\n\n")
+
+	meta := func(label, value string) {
+		if value == "" {
+			value = "-"
+		}
+		b.WriteString(fmt.Sprintf("%s %s\n", styles.DimStyle.Render(label), value))
+	}
+	meta("From:", s.Submitter)
+	meta("Submitted:", dates.Time(s.SubmittedAt))
+	meta("Location:", s.Location)
+	meta("When:", s.TimePeriod)
+	meta("Suggested:", s.StoryType)
+	meta("Approve as:", styles.TypeBadge(m.storyType(s)))
+	b.WriteString("\n")
+	b.WriteString(lipgloss.NewStyle().Width(inner).Render(s.Content))
+
+	lines := strings.Split(b.String(), "\n")
+	if len(lines) > height-2 {
+		lines = append(lines[:max(0, height-3)], styles.DimStyle.Render("…"))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(0, 1).
+		Width(width - 2).
+		Height(height - 2).
+		Render(strings.Join(lines, "\n"))
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
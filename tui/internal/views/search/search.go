@@ -6,6 +6,8 @@ import (
 	"strings"
 
 	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/embed"
+	"paranormal-tui/internal/quota"
 	"paranormal-tui/internal/styles"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -35,6 +37,13 @@ func (m SearchMode) String() string {
 	}
 }
 
+// resultLimit is how many results a search shows
+const resultLimit = 20
+
+// hybridAlpha is how much of a hybrid search's score is vector similarity
+// rather than text rank
+const hybridAlpha = 0.5
+
 // Model represents the search view
 type Model struct {
 	database   *db.DB
@@ -48,6 +57,9 @@ type Model struct {
 	width      int
 	height     int
 	inputFocus bool
+	rowColor   styles.RowColor // Colors titles by rating or read status
+	weighted   bool            // Rank by my ratings and hoax flags as well as the match
+	quota      *quota.User
 }
 
 // New creates a new search model
@@ -78,6 +90,11 @@ func (m *Model) SetSize(width, height int) {
 	m.input.Width = width - 20
 }
 
+// SetQuota rations the searches that need an embedding
+func (m *Model) SetQuota(q *quota.User) {
+	m.quota = q
+}
+
 // SetDatabase sets the database connection
 func (m *Model) SetDatabase(database *db.DB) {
 	m.database = database
@@ -89,6 +106,60 @@ func (m *Model) Focus() {
 	m.inputFocus = true
 }
 
+// Typing reports whether keys are going into the search input
+func (m Model) Typing() bool {
+	return m.inputFocus
+}
+
+// SetQuery fills the search input and runs the search
+func (m *Model) SetQuery(query string) tea.Cmd {
+	m.input.SetValue(query)
+	m.searching = true
+	m.err = nil
+	return m.performSearch()
+}
+
+// SetBookmarked updates a result's star without searching again
+func (m *Model) SetBookmarked(id string, bookmarked bool) {
+	for i := range m.results {
+		if m.results[i].ID == id {
+			m.results[i].Bookmarked = bookmarked
+		}
+	}
+}
+
+// SetRating updates a result's rating without searching again
+func (m *Model) SetRating(id string, rating int) {
+	for i := range m.results {
+		if m.results[i].ID == id {
+			m.results[i].Rating = rating
+		}
+	}
+}
+
+// SetHoax updates a result's hoax flag without searching again
+func (m *Model) SetHoax(id string, hoax bool) {
+	for i := range m.results {
+		if m.results[i].ID == id {
+			m.results[i].Hoax = hoax
+		}
+	}
+}
+
+// SetRowColor sets what colors the result titles
+func (m *Model) SetRowColor(rowColor styles.RowColor) {
+	m.rowColor = rowColor
+}
+
+// MarkRead shows a result as read without searching again
+func (m *Model) MarkRead(id string) {
+	for i := range m.results {
+		if m.results[i].ID == id {
+			m.results[i].Read = true
+		}
+	}
+}
+
 // SearchResultsMsg indicates search completed
 type SearchResultsMsg struct {
 	Results []db.Story
@@ -99,6 +170,7 @@ type SearchResultsMsg struct {
 // StorySelectedMsg indicates a story was selected
 type StorySelectedMsg struct {
 	Story db.Story
+	Query string // The query it was found by
 }
 
 func (m Model) performSearch() tea.Cmd {
@@ -111,12 +183,59 @@ func (m Model) performSearch() tea.Cmd {
 		return nil
 	}
 
+	mode, weighted, q := m.mode, m.weighted, m.quota
 	return func() tea.Msg {
-		ctx := context.Background()
-		// For now, only text search is implemented (no Voyage API in Go)
-		results, err := m.database.TextSearch(ctx, query, 20)
-		return SearchResultsMsg{Results: results, Query: query, Err: err}
+		// Look further down the matches when weighting, so well-judged
+		// stories there can rise into the results
+		limit := resultLimit
+		if weighted {
+			limit *= 2
+		}
+		results, err := search(context.Background(), m.database, q, mode, query, limit)
+		if err != nil {
+			return SearchResultsMsg{Query: query, Err: err}
+		}
+		if weighted {
+			db.WeightByJudgment(results)
+		}
+		if len(results) > resultLimit {
+			results = results[:resultLimit]
+		}
+		return SearchResultsMsg{Results: results, Query: query}
+	}
+}
+
+// search runs query in mode, embedding it first for vector and hybrid
+// searches if the quota allows
+func search(ctx context.Context, database *db.DB, q *quota.User, mode SearchMode, query string, limit int) ([]db.Story, error) {
+	if mode == ModeText {
+		return database.TextSearch(ctx, query, limit)
+	}
+
+	if err := q.Allow(quota.VectorSearch); err != nil {
+		return nil, err
+	}
+	client, err := embed.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
 	}
+	embedding, err := client.Embed(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	if mode == ModeVector {
+		return database.VectorSearch(ctx, embedding, limit)
+	}
+	results, err := database.HybridSearch(ctx, query, embedding, limit, hybridAlpha)
+	if err != nil {
+		return nil, err
+	}
+	stories := make([]db.Story, len(results))
+	for i, r := range results {
+		stories[i] = r.Story
+	}
+	return stories, nil
 }
 
 // Update handles messages
@@ -138,6 +257,16 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		return m, nil
 
 	case tea.KeyMsg:
+		if msg.String() == "ctrl+r" {
+			// Per query: the toggle re-runs the last search either way
+			m.weighted = !m.weighted
+			if m.lastQuery != "" && m.input.Value() != "" {
+				m.searching = true
+				m.err = nil
+				return m, m.performSearch()
+			}
+			return m, nil
+		}
 		if m.inputFocus {
 			switch msg.String() {
 			case "enter":
@@ -147,9 +276,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 					return m, m.performSearch()
 				}
 			case "esc":
+				// Esc clears the box, then leaves it, so the view keys
+				// work even before there are results
 				if m.input.Value() != "" {
 					m.input.SetValue("")
-				} else if len(m.results) > 0 {
+				} else {
 					m.inputFocus = false
 					m.input.Blur()
 				}
@@ -183,7 +314,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			case key.Matches(msg, key.NewBinding(key.WithKeys("enter"))):
 				if len(m.results) > 0 && m.cursor < len(m.results) {
 					return m, func() tea.Msg {
-						return StorySelectedMsg{Story: m.results[m.cursor]}
+						return StorySelectedMsg{Story: m.results[m.cursor], Query: m.lastQuery}
 					}
 				}
 			case key.Matches(msg, key.NewBinding(key.WithKeys("/", "i"))):
@@ -210,11 +341,11 @@ func (m Model) View() string {
 	b.WriteString("\n\n")
 
 	// Search input with mode indicator
-	modeStyle := styles.DimStyle
-	if m.mode == ModeText {
-		modeStyle = styles.SuccessStyle
+	mode := m.mode.String()
+	if m.weighted {
+		mode += " · by my judgment"
 	}
-	modeIndicator := modeStyle.Render(fmt.Sprintf("[%s]", m.mode.String()))
+	modeIndicator := styles.SuccessStyle.Render(fmt.Sprintf("[%s]", mode))
 
 	inputStyle := styles.InputStyle
 	if m.inputFocus {
@@ -225,7 +356,15 @@ func (m Model) View() string {
 		inputStyle.Width(m.width-20).Render(m.input.View()),
 		modeIndicator,
 	))
-	b.WriteString(styles.DimStyle.Render("  tab: toggle mode (Text/Hybrid/Vector)"))
+	weightHint := "ctrl+r: weight by my ratings and hoax flags"
+	if m.weighted {
+		weightHint = "ctrl+r: ignore my ratings and hoax flags"
+	}
+	escHint := "/: search"
+	if m.inputFocus {
+		escHint = "esc: clear, then leave the box"
+	}
+	b.WriteString(styles.DimStyle.Render("  tab: toggle mode (Text/Hybrid/Vector) • " + weightHint + " • " + escHint))
 	b.WriteString("\n\n")
 
 	if m.searching {
@@ -276,11 +415,24 @@ func (m Model) View() string {
 		if len(title) > maxTitleLen {
 			title = title[:maxTitleLen-3] + "..."
 		}
+		if story.Bookmarked {
+			title = "★ " + title
+		}
+		title = m.rowColor.Title(title, story.Rating, story.Read)
+		if !story.Read {
+			title = styles.BoldStyle.Render(title)
+		}
+		if stars := styles.Stars(story.Rating); stars != "" && m.rowColor == styles.RowColorRating {
+			title += " " + stars
+		}
 
 		// Score display
 		scoreStr := ""
-		if story.Rank > 0 {
-			scoreStr = styles.DimStyle.Render(fmt.Sprintf(" (%.2f)", story.Rank))
+		if story.Score > 0 {
+			scoreStr = styles.DimStyle.Render(fmt.Sprintf(" (%.2f)", story.Score))
+		}
+		if story.Hoax {
+			scoreStr += styles.ErrorStyle.Render(" hoax")
 		}
 
 		line := fmt.Sprintf("%s%s%s  %s  %s",
@@ -314,6 +466,11 @@ func (m Model) View() string {
 	return b.String()
 }
 
+// NoResults reports whether the last search found nothing
+func (m Model) NoResults() bool {
+	return !m.searching && m.err == nil && m.lastQuery != "" && len(m.results) == 0
+}
+
 // SelectedStory returns the currently selected story
 func (m Model) SelectedStory() *db.Story {
 	if !m.inputFocus && len(m.results) > 0 && m.cursor < len(m.results) {
@@ -321,3 +478,15 @@ func (m Model) SelectedStory() *db.Story {
 	}
 	return nil
 }
+
+// Step moves the cursor to the next (delta 1) or previous (-1) result, as
+// ctrl+n and ctrl+p in the story view do. It returns the result now
+// selected and the query it was found by; nil at either end.
+func (m *Model) Step(delta int) (*db.Story, string) {
+	next := m.cursor + delta
+	if m.inputFocus || next < 0 || next >= len(m.results) {
+		return nil, ""
+	}
+	m.cursor = next
+	return &m.results[m.cursor], m.lastQuery
+}
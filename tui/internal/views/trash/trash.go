@@ -0,0 +1,190 @@
+package trash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/dates"
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Model represents the trash screen
+type Model struct {
+	database  *db.DB
+	retention time.Duration
+	readOnly  bool
+	stories   []db.TrashedStory
+	cursor    int
+	loading   bool
+	err       error
+	width     int
+	height    int
+}
+
+// New creates a new trash model. Stories are purged retention after being
+// trashed; readOnly disables restoring.
+func New(database *db.DB, retention time.Duration, readOnly bool) Model {
+	return Model{database: database, retention: retention, readOnly: readOnly, loading: true}
+}
+
+// Init loads the trash
+func (m Model) Init() tea.Cmd {
+	return m.load()
+}
+
+// SetSize sets the view dimensions
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// TrashLoadedMsg carries the trashed stories
+type TrashLoadedMsg struct {
+	Stories []db.TrashedStory
+	Err     error
+}
+
+// StoryRestoredMsg reports a story taken out of the trash
+type StoryRestoredMsg struct {
+	ID    string
+	Title string
+	Err   error
+}
+
+func (m Model) load() tea.Cmd {
+	if m.database == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		stories, err := m.database.ListTrash(context.Background())
+		return TrashLoadedMsg{Stories: stories, Err: err}
+	}
+}
+
+// Reload refreshes the trash
+func (m *Model) Reload() tea.Cmd {
+	m.loading = true
+	return m.load()
+}
+
+func (m Model) restore(story db.TrashedStory) tea.Cmd {
+	return func() tea.Msg {
+		err := m.database.RestoreStory(context.Background(), story.ID)
+		return StoryRestoredMsg{ID: story.ID, Title: story.Title, Err: err}
+	}
+}
+
+// Update handles messages
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case TrashLoadedMsg:
+		m.loading = false
+		m.err = msg.Err
+		m.stories = msg.Stories
+		if m.cursor >= len(m.stories) {
+			m.cursor = max(0, len(m.stories)-1)
+		}
+		return m, nil
+
+	case StoryRestoredMsg:
+		if msg.Err != nil {
+			m.err = msg.Err
+			return m, nil
+		}
+		return m, m.Reload()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
+			if m.cursor < len(m.stories)-1 {
+				m.cursor++
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
+			return m, m.Reload()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("u", "enter"))):
+			if !m.readOnly && m.cursor < len(m.stories) {
+				return m, m.restore(m.stories[m.cursor])
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the trash screen
+func (m Model) View() string {
+	var b strings.Builder
+
+	b.WriteString(styles.HeaderStyle.Width(m.width - 4).Render(fmt.Sprintf("Trash (%d)", len(m.stories))))
+	b.WriteString("\n")
+
+	if m.loading {
+		b.WriteString("\n  Loading...")
+		return b.String()
+	}
+
+	if m.err != nil {
+		b.WriteString(styles.ErrorStyle.Render(fmt.Sprintf("\n  Error: %v", m.err)))
+		return b.String()
+	}
+
+	if len(m.stories) == 0 {
+		b.WriteString(styles.DimStyle.Render("\n  Trash is empty. Press x in Browse to trash a story."))
+		return b.String()
+	}
+
+	b.WriteString(styles.DimStyle.Render(fmt.Sprintf("  Purged %d days after trashing (paranormal-tui trash purge)",
+		int(m.retention.Hours()/24))))
+	b.WriteString("\n\n")
+
+	listHeight := m.height - 8
+	for i, t := range m.stories {
+		if i >= listHeight {
+			break
+		}
+
+		cursor := "  "
+		if i == m.cursor {
+			cursor = "▸ "
+		}
+
+		left := time.Until(t.DeletedAt.Add(m.retention))
+		days := max(0, int(left.Hours()/24))
+
+		title := t.Title
+		if maxLen := m.width - 60; maxLen > 10 && len(title) > maxLen {
+			title = title[:maxLen-3] + "..."
+		}
+
+		line := fmt.Sprintf("%s%-*s  %s  %3dd left  %s",
+			cursor, max(10, m.width-60), title,
+			dates.Time(t.DeletedAt), days, t.Reason)
+
+		if i == m.cursor {
+			b.WriteString(styles.SelectedItemStyle.Width(m.width - 4).Render(line))
+		} else {
+			b.WriteString(line)
+		}
+		b.WriteString("\n")
+	}
+
+	help := "↑↓: navigate • u/enter: restore • r: refresh"
+	if m.readOnly {
+		help = "↑↓: navigate • r: refresh"
+	}
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("  " + help))
+
+	return b.String()
+}
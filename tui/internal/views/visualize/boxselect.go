@@ -0,0 +1,238 @@
+package visualize
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CollectionSavedMsg reports stories from a box select or lasso saved to
+// a collection
+type CollectionSavedMsg struct {
+	Name  string
+	Added int64
+	Err   error
+}
+
+var boxStyle = lipgloss.NewStyle().Background(styles.BgLight)
+
+// SetReadOnly stops box selections being saved as collections (kiosk mode)
+func (m *Model) SetReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+}
+
+// InputActive reports whether a box select or lasso, their results, the
+// clustering dialog, the projection prompt, or the export prompt are
+// taking keys
+func (m Model) InputActive() bool {
+	return m.boxing || m.lassoing || m.showBoxResults || m.confirmProject || m.showClusterDialog || m.exportNaming
+}
+
+// Typing reports whether keys are going into the collection name or
+// export file name input
+func (m Model) Typing() bool {
+	return m.boxNaming || m.exportNaming
+}
+
+// startBox anchors one corner of the box at the cursor. The anchor is kept
+// in data coordinates so it stays put through zooming and panning.
+func (m *Model) startBox() {
+	m.boxing = true
+	m.boxAnchorX, m.boxAnchorY = m.cellData(m.cursorX, m.cursorY)
+}
+
+// cellData is the data coordinate at the middle of plot cell (x, y)
+func (m Model) cellData(x, y int) (float64, float64) {
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	plotWidth, plotHeight := m.plotSize()
+	return viewMinX + (float64(x)+0.5)/float64(plotWidth)*rangeX,
+		viewMaxY - (float64(y)+0.5)/float64(plotHeight)*rangeY
+}
+
+// boxBounds is the box in plot cells, between the anchor and the cursor.
+// An anchor scrolled off the plot is held at its edge.
+func (m Model) boxBounds() (x0, y0, x1, y1 int) {
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	plotWidth, plotHeight := m.plotSize()
+	ax := int((m.boxAnchorX - viewMinX) / rangeX * float64(plotWidth))
+	ay := int((viewMaxY - m.boxAnchorY) / rangeY * float64(plotHeight))
+	ax = max(0, min(plotWidth-1, ax))
+	ay = max(0, min(plotHeight-1, ay))
+	return min(ax, m.cursorX), min(ay, m.cursorY), max(ax, m.cursorX), max(ay, m.cursorY)
+}
+
+// inBox reports whether plot cell (x, y) is inside the box being drawn
+func (m Model) inBox(x, y int) bool {
+	if !m.boxing {
+		return false
+	}
+	x0, y0, x1, y1 := m.boxBounds()
+	return x >= x0 && x <= x1 && y >= y0 && y <= y1
+}
+
+// boxedPoints returns the points plotted inside the box, by title
+func (m Model) boxedPoints() []*db.UmapPoint {
+	var points []*db.UmapPoint
+	x0, y0, x1, y1 := m.boxBounds()
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			for _, i := range m.cells.at(x, y) {
+				points = append(points, m.plottedPoints[i].Point)
+			}
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Title < points[j].Title })
+	return points
+}
+
+// confirmBox ends the box select and lists the stories inside it
+func (m *Model) confirmBox() {
+	m.boxResults = m.boxedPoints()
+	m.boxing = false
+	m.showBoxResults = true
+	m.boxCursor = 0
+	m.boxNaming = false
+	m.boxNotice = ""
+}
+
+func (m Model) handleBoxResultsKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.boxNaming {
+		switch msg.String() {
+		case "esc":
+			m.boxNaming = false
+			return m, nil
+		case "enter":
+			name := strings.TrimSpace(m.boxInput.Value())
+			if name == "" {
+				return m, nil
+			}
+			m.boxNaming = false
+			m.boxNotice = "Saving..."
+			return m, m.saveCollection(name)
+		}
+		var cmd tea.Cmd
+		m.boxInput, cmd = m.boxInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.showBoxResults = false
+		m.boxResults = nil
+	case "up", "k":
+		if m.boxCursor > 0 {
+			m.boxCursor--
+		}
+	case "down", "j":
+		if m.boxCursor < len(m.boxResults)-1 {
+			m.boxCursor++
+		}
+	case "enter":
+		if m.boxCursor < len(m.boxResults) {
+			id := m.boxResults[m.boxCursor].ID
+			return m, func() tea.Msg {
+				return StorySelectedMsg{StoryID: id}
+			}
+		}
+	case "s":
+		if m.readOnly || len(m.boxResults) == 0 {
+			return m, nil
+		}
+		ti := textinput.New()
+		ti.Placeholder = "Lake monsters"
+		ti.CharLimit = 60
+		ti.Width = max(10, m.width/2-12)
+		ti.Focus()
+		m.boxInput = ti
+		m.boxNaming = true
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+// saveCollection adds the listed stories to the named collection
+func (m Model) saveCollection(name string) tea.Cmd {
+	ids := make([]string, len(m.boxResults))
+	for i, p := range m.boxResults {
+		ids[i] = p.ID
+	}
+	return func() tea.Msg {
+		added, err := m.database.AddToCollection(context.Background(), name, ids)
+		return CollectionSavedMsg{Name: name, Added: added, Err: err}
+	}
+}
+
+// renderBoxResults lists the boxed or lassoed stories in place of the info
+// panel
+func (m Model) renderBoxResults(width, height int) string {
+	var b strings.Builder
+	b.WriteString(styles.BoldStyle.Render(fmt.Sprintf("Selected (%d stories)", len(m.boxResults))))
+	b.WriteString("\n\n")
+
+	// Room for the list after the heading and the prompt or help below it
+	rows := max(1, height-7)
+	if len(m.boxResults) == 0 {
+		b.WriteString(styles.DimStyle.Render("No stories inside the selection"))
+		b.WriteString("\n")
+	}
+	start := max(0, min(m.boxCursor-rows/2, len(m.boxResults)-rows))
+	end := min(len(m.boxResults), start+rows)
+	for i := start; i < end; i++ {
+		p := m.boxResults[i]
+		marker := lipgloss.NewStyle().Foreground(styles.GetTypeColor(p.StoryType)).Render("●")
+		title := truncateTitle(p.Title, width-6)
+		if i == m.boxCursor {
+			b.WriteString(marker + " " + styles.SelectedItemStyle.Render(title))
+		} else {
+			b.WriteString(marker + " " + title)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.boxNaming:
+		b.WriteString("Add to collection\n")
+		b.WriteString(styles.FocusedInputStyle.Render(m.boxInput.View()))
+		b.WriteString("\n")
+		b.WriteString(styles.DimStyle.Render("enter: save (existing name adds) • esc: cancel"))
+	case m.boxNotice != "":
+		b.WriteString(m.boxNotice)
+		b.WriteString("\n")
+		b.WriteString(styles.DimStyle.Render(m.boxResultsHelp()))
+	default:
+		b.WriteString(styles.DimStyle.Render(m.boxResultsHelp()))
+	}
+
+	return lipgloss.NewStyle().
+		Width(width-2).
+		Height(height).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(0, 1).
+		Render(b.String())
+}
+
+func (m Model) boxResultsHelp() string {
+	if m.readOnly {
+		return "↑↓ select • enter: view • esc: close"
+	}
+	return "↑↓ select • enter: view • s: save as collection • esc: close"
+}
+
+// truncateTitle cuts a title to width runes
+func truncateTitle(title string, width int) string {
+	runes := []rune(title)
+	if width < 2 || len(runes) <= width {
+		return title
+	}
+	return string(runes[:width-1]) + "…"
+}
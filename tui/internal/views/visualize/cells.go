@@ -0,0 +1,42 @@
+package visualize
+
+// cellIndex finds the points plotted in a screen cell without scanning
+// them all, so moving the cursor stays quick with tens of thousands of
+// points. It's a counting sort of the points by cell: the points in cell c
+// are order[start[c]:start[c+1]], indexes into plottedPoints in plotting
+// order.
+type cellIndex struct {
+	width, height int
+	start         []int32
+	order         []int32
+}
+
+// newCellIndex indexes points by the cell each is plotted in
+func newCellIndex(points []PlottedPoint, width, height int) cellIndex {
+	idx := cellIndex{width: width, height: height, start: make([]int32, width*height+1)}
+	for _, pp := range points {
+		idx.start[pp.ScreenY*width+pp.ScreenX+1]++
+	}
+	for c := 1; c < len(idx.start); c++ {
+		idx.start[c] += idx.start[c-1]
+	}
+
+	next := make([]int32, width*height)
+	copy(next, idx.start)
+	idx.order = make([]int32, len(points))
+	for i, pp := range points {
+		c := pp.ScreenY*width + pp.ScreenX
+		idx.order[next[c]] = int32(i)
+		next[c]++
+	}
+	return idx
+}
+
+// at returns the indexes of the points plotted in cell (x, y)
+func (idx cellIndex) at(x, y int) []int32 {
+	if x < 0 || x >= idx.width || y < 0 || y >= idx.height {
+		return nil
+	}
+	c := y*idx.width + x
+	return idx.order[idx.start[c]:idx.start[c+1]]
+}
@@ -0,0 +1,185 @@
+package visualize
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/cluster"
+	"paranormal-tui/internal/quota"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ClustersSavedMsg reports a clustering run from the C dialog
+type ClustersSavedMsg struct {
+	Result cluster.Result
+	Err    error
+}
+
+// Fields of the clustering dialog
+const (
+	clusterFieldSize = iota
+	clusterFieldSamples
+	clusterFieldSource
+	clusterFields
+)
+
+// openClusterDialog shows the clustering parameters, filled in with the
+// last run's
+func (m *Model) openClusterDialog() tea.Cmd {
+	if m.clusterParams.MinClusterSize == 0 {
+		m.clusterParams = cluster.Params{
+			MinClusterSize: cluster.DefaultMinClusterSize,
+			MinSamples:     cluster.DefaultMinSamples,
+		}
+	}
+	m.showClusterDialog = true
+	m.clusterField = clusterFieldSize
+	m.clusterErr = ""
+	m.clusterEmbeddings = m.clusterParams.Embeddings
+
+	size := textinput.New()
+	size.CharLimit = 4
+	size.Width = 6
+	size.SetValue(strconv.Itoa(m.clusterParams.MinClusterSize))
+	size.Focus()
+
+	samples := textinput.New()
+	samples.CharLimit = 4
+	samples.Width = 6
+	samples.SetValue(strconv.Itoa(m.clusterParams.MinSamples))
+
+	m.clusterInputs = [2]textinput.Model{size, samples}
+	return textinput.Blink
+}
+
+// focusClusterField moves the dialog's focus to field
+func (m *Model) focusClusterField(field int) tea.Cmd {
+	if m.clusterField < clusterFieldSource {
+		m.clusterInputs[m.clusterField].Blur()
+	}
+	m.clusterField = (field + clusterFields) % clusterFields
+	if m.clusterField < clusterFieldSource {
+		m.clusterInputs[m.clusterField].Focus()
+		return textinput.Blink
+	}
+	return nil
+}
+
+func (m Model) handleClusterDialogKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.showClusterDialog = false
+		return m, nil
+	case "tab", "down":
+		return m, m.focusClusterField(m.clusterField + 1)
+	case "shift+tab", "up":
+		return m, m.focusClusterField(m.clusterField - 1)
+	case "enter":
+		size, err := strconv.Atoi(strings.TrimSpace(m.clusterInputs[0].Value()))
+		if err != nil || size < 2 {
+			m.clusterErr = "Min cluster size must be a whole number, 2 or more"
+			return m, nil
+		}
+		samples, err := strconv.Atoi(strings.TrimSpace(m.clusterInputs[1].Value()))
+		if err != nil || samples < 1 {
+			m.clusterErr = "Min samples must be a whole number, 1 or more"
+			return m, nil
+		}
+		if err := m.quota.Allow(quota.Clustering); err != nil {
+			m.clusterErr = "Can't recluster: " + err.Error()
+			return m, nil
+		}
+		m.clusterParams = cluster.Params{MinClusterSize: size, MinSamples: samples, Embeddings: m.clusterEmbeddings}
+		m.showClusterDialog = false
+		m.clustering = true
+		m.notice = ""
+		return m, m.runClustering(m.clusterParams)
+	}
+
+	if m.clusterField == clusterFieldSource {
+		switch msg.String() {
+		case " ", "left", "right", "h", "l":
+			m.clusterEmbeddings = !m.clusterEmbeddings
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.clusterInputs[m.clusterField], cmd = m.clusterInputs[m.clusterField].Update(msg)
+	return m, cmd
+}
+
+// runClustering clusters the stories in the background and saves the
+// clusters
+func (m Model) runClustering(p cluster.Params) tea.Cmd {
+	database := m.database
+	return func() tea.Msg {
+		result, err := cluster.Run(context.Background(), database, p)
+		return ClustersSavedMsg{Result: result, Err: err}
+	}
+}
+
+// clustersSaved reports a clustering run and loads the new clusters
+func (m Model) clustersSaved(msg ClustersSavedMsg) (Model, tea.Cmd) {
+	m.clustering = false
+	if msg.Err != nil {
+		m.notice = styles.ErrorStyle.Render("Clustering failed: " + msg.Err.Error())
+		return m, nil
+	}
+	r := msg.Result
+	m.notice = styles.SuccessStyle.Render(fmt.Sprintf("%d clusters among %d stories, %d as noise", r.Clusters, r.Stories, r.Noise)) + styles.DimStyle.Render(" • old clusters kept: paranormal-tui recovery")
+	// Isolation is by cluster number, which now means another cluster
+	m.isolated = false
+	m.loading = true
+	return m, m.loadPoints()
+}
+
+// renderClusterDialog shows the clustering parameters in place of the
+// info panel
+func (m Model) renderClusterDialog(width, height int) string {
+	var b strings.Builder
+	b.WriteString(styles.BoldStyle.Render("Cluster stories (HDBSCAN)"))
+	b.WriteString("\n\n")
+
+	labels := []string{"Min cluster size", "Min samples"}
+	for i, ti := range m.clusterInputs {
+		style := styles.InputStyle
+		if i == m.clusterField {
+			style = styles.FocusedInputStyle
+		}
+		b.WriteString(fmt.Sprintf("%-17s %s\n", labels[i], style.Render(ti.View())))
+	}
+
+	source := "UMAP coordinates"
+	if m.clusterEmbeddings {
+		source = "embeddings (slower)"
+	}
+	if m.clusterField == clusterFieldSource {
+		source = styles.SelectedItemStyle.Render("‹ " + source + " ›")
+	}
+	b.WriteString(fmt.Sprintf("%-17s %s\n", "Cluster by", source))
+
+	if m.clusterErr != "" {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorStyle.Render(m.clusterErr))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(styles.DimStyle.Render("Larger sizes find fewer, bigger clusters; more samples mark more stories as noise. Replaces every story's cluster."))
+	b.WriteString("\n\n")
+	b.WriteString(styles.DimStyle.Render("tab: next field • space: change source • enter: run • esc: cancel"))
+
+	return lipgloss.NewStyle().
+		Width(width-2).
+		Height(height).
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(styles.Primary).
+		Padding(0, 1).
+		Render(b.String())
+}
@@ -0,0 +1,92 @@
+package visualize
+
+import (
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// dateBins is how many spans of time the air date legend splits the
+// range into
+const dateBins = 5
+
+// unknownColor is for stories whose air date or region is unknown, gray as
+// noise is
+const unknownColor = lipgloss.Color("#555555")
+
+// dateRange is the oldest and newest air dates among points; both are
+// zero if none has one
+func dateRange(points []db.UmapPoint) (oldest, newest time.Time) {
+	for _, p := range points {
+		if p.AirDate == nil {
+			continue
+		}
+		if oldest.IsZero() || p.AirDate.Before(oldest) {
+			oldest = *p.AirDate
+		}
+		if newest.IsZero() || p.AirDate.After(newest) {
+			newest = *p.AirDate
+		}
+	}
+	return oldest, newest
+}
+
+// dateFraction places t along the range of air dates, from 0 (oldest) to
+// 1 (newest)
+func (m Model) dateFraction(t time.Time) float64 {
+	span := m.newest.Sub(m.oldest)
+	if span <= 0 {
+		return 1
+	}
+	return float64(t.Sub(m.oldest)) / float64(span)
+}
+
+// dateColor is a point's color on the old-to-new gradient
+func (m Model) dateColor(p *db.UmapPoint) lipgloss.Color {
+	if p.AirDate == nil {
+		return unknownColor
+	}
+	return styles.GetDateColor(m.dateFraction(*p.AirDate))
+}
+
+// dateLegend splits the range of air dates into even spans, each in the
+// color of its middle, then the stories with no date
+func (m Model) dateLegend() []legendEntry {
+	var counts [dateBins]int
+	dated, undated := 0, 0
+	for _, p := range m.points {
+		if p.AirDate == nil {
+			undated++
+			continue
+		}
+		dated++
+		counts[min(dateBins-1, int(m.dateFraction(*p.AirDate)*dateBins))]++
+	}
+
+	var entries []legendEntry
+	span := m.newest.Sub(m.oldest)
+	switch {
+	case dated == 0:
+	case span <= 0:
+		// One date for all
+		entries = append(entries, legendEntry{styles.GetDateColor(1), m.oldest.Format("2006-01-02"), dated})
+	default:
+		for i := range counts {
+			from := m.oldest.Add(span * time.Duration(i) / dateBins)
+			to := m.oldest.Add(span * time.Duration(i+1) / dateBins)
+			label := from.Format("2006-01")
+			if to.Format("2006-01") != label {
+				label += "–" + to.Format("2006-01")
+			}
+			color := styles.GetDateColor((float64(i) + 0.5) / dateBins)
+			entries = append(entries, legendEntry{color, label, counts[i]})
+		}
+	}
+	if undated > 0 {
+		entries = append(entries, legendEntry{unknownColor, "no date", undated})
+	}
+	return entries
+}
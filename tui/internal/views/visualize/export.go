@@ -0,0 +1,206 @@
+package visualize
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/plotimage"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ImageExportedMsg reports an export of the plot with E
+type ImageExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// SetExport allows exporting the plot as an image, which writes a file on
+// this machine
+func (m *Model) SetExport(enabled bool) {
+	m.canExport = enabled
+}
+
+// startExport asks where to save the image, suggesting a PNG in the
+// current directory named for the time
+func (m *Model) startExport() tea.Cmd {
+	ti := textinput.New()
+	ti.CharLimit = 200
+	ti.Width = max(20, m.width-40)
+	ti.SetValue(time.Now().Format("umap-20060102-150405.png"))
+	ti.Focus()
+	m.exportInput = ti
+	m.exportNaming = true
+	m.notice = ""
+	return textinput.Blink
+}
+
+func (m Model) handleExportKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.exportNaming = false
+		return m, nil
+	case "enter":
+		path := strings.TrimSpace(m.exportInput.Value())
+		if path == "" {
+			return m, nil
+		}
+		m.exportNaming = false
+		plot := m.plotImage()
+		return m, func() tea.Msg {
+			if abs, err := filepath.Abs(path); err == nil {
+				path = abs
+			}
+			return ImageExportedMsg{Path: path, Err: plotimage.Save(path, plot)}
+		}
+	}
+	var cmd tea.Cmd
+	m.exportInput, cmd = m.exportInput.Update(msg)
+	return m, cmd
+}
+
+// exportFooter is the footer while naming the image
+func (m Model) exportFooter() string {
+	return fmt.Sprintf("  Export plot to %s %s",
+		styles.FocusedInputStyle.Render(m.exportInput.View()),
+		styles.DimStyle.Render(".png or .svg • enter: save • esc: cancel"))
+}
+
+// plotImage is the current viewport as an image: the points shown, in
+// the colors they're shown in, with the legend and the selected story
+func (m Model) plotImage() plotimage.Plot {
+	plotWidth, plotHeight := m.plotSize()
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+
+	// Image pixels are square and terminal cells about twice as tall as
+	// they are wide
+	p := plotimage.Plot{
+		Title:  "UMAP projection",
+		Aspect: float64(plotHeight*2) / float64(max(1, plotWidth)),
+	}
+
+	var dimmed, shown []plotimage.Point
+	for i := range m.points {
+		pt := &m.points[i]
+		isolated := m.inIsolation(pt)
+		if !isolated && !m.dimOthers {
+			continue
+		}
+		fx := (pt.X - viewMinX) / rangeX
+		fy := (viewMaxY - pt.Y) / rangeY
+		if fx < 0 || fx >= 1 || fy < 0 || fy >= 1 {
+			continue
+		}
+		point := plotimage.Point{X: fx, Y: fy, Color: string(m.pointColor(pt))}
+		if isolated {
+			shown = append(shown, point)
+		} else {
+			dimmed = append(dimmed, point)
+		}
+		if m.selected != nil && pt.ID == m.selected.ID {
+			selected := point
+			p.Selected = &selected
+			p.SelectedLabel = pt.Title
+		}
+	}
+	// Dimmed points go underneath
+	p.Points = append(dimmed, shown...)
+
+	parts := []string{
+		fmt.Sprintf("%d of %d stories", len(p.Points), len(m.points)),
+		"colored " + m.colorMode.label(),
+	}
+	if m.isolated {
+		cluster := "noise"
+		if m.isolatedCluster != nil {
+			cluster = fmt.Sprintf("cluster %d", *m.isolatedCluster)
+		}
+		parts = append(parts, "isolated: "+cluster)
+	}
+	parts = append(parts, fmt.Sprintf("zoom %.1fx", m.zoom), time.Now().Format("2006-01-02"))
+	p.Subtitle = strings.Join(parts, " • ")
+
+	var entries []legendEntry
+	p.LegendTitle, entries = m.legend()
+	for _, e := range entries {
+		p.Legend = append(p.Legend, plotimage.Entry{Color: string(e.color), Label: e.label, Count: e.count})
+	}
+	return p
+}
+
+// pointColor is the color a point is drawn in
+func (m Model) pointColor(p *db.UmapPoint) lipgloss.Color {
+	switch {
+	case !m.inIsolation(p):
+		return styles.Muted
+	case m.colorMode == ColorByCluster:
+		return styles.GetClusterColor(p.ClusterID)
+	case m.colorMode == ColorByDate:
+		return m.dateColor(p)
+	case m.colorMode == ColorByRegion:
+		return m.regionColor(p)
+	}
+	return styles.GetTypeColor(p.StoryType)
+}
+
+// legendEntry is a color in the legend, with how many stories have it
+type legendEntry struct {
+	color lipgloss.Color
+	label string
+	count int
+}
+
+// legend lists the colors in use: the clusters in order then noise, spans
+// of air dates, the top regions, or the story types that have stories
+func (m Model) legend() (string, []legendEntry) {
+	var entries []legendEntry
+	switch m.colorMode {
+	case ColorByDate:
+		return "Legend (Air date)", m.dateLegend()
+	case ColorByRegion:
+		return "Legend (Regions)", m.regionLegend()
+	}
+	if m.colorMode == ColorByCluster {
+		clusterCounts := make(map[int]int)
+		noiseCount := 0
+		for _, p := range m.points {
+			if p.ClusterID != nil {
+				clusterCounts[*p.ClusterID]++
+			} else {
+				noiseCount++
+			}
+		}
+
+		clusterIDs := make([]int, 0, len(clusterCounts))
+		for id := range clusterCounts {
+			clusterIDs = append(clusterIDs, id)
+		}
+		sort.Ints(clusterIDs)
+
+		for _, id := range clusterIDs {
+			entries = append(entries, legendEntry{styles.GetClusterColor(&id), fmt.Sprintf("cluster %d", id), clusterCounts[id]})
+		}
+		if noiseCount > 0 {
+			entries = append(entries, legendEntry{styles.GetClusterColor(nil), "noise", noiseCount})
+		}
+		return "Legend (Clusters)", entries
+	}
+
+	typeCounts := make(map[string]int)
+	for _, p := range m.points {
+		typeCounts[p.StoryType]++
+	}
+	for _, t := range db.StoryTypes {
+		if count := typeCounts[t]; count > 0 {
+			entries = append(entries, legendEntry{styles.GetTypeColor(t), t, count})
+		}
+	}
+	return "Legend (Types)", entries
+}
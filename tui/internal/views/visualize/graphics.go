@@ -0,0 +1,100 @@
+package visualize
+
+import (
+	"image"
+	"image/color"
+	"strconv"
+	"strings"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/graphics"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SetGraphics lets the points be drawn as an image, on a terminal that can
+// show one (see Graphic)
+func (m *Model) SetGraphics(available bool) {
+	m.graphics = available
+}
+
+// drawsImage reports whether the points are drawn as an image rather than
+// characters. A slow terminal gets characters, which are far less to send.
+func (m Model) drawsImage() bool {
+	return m.graphics && !m.charGrid && !m.reduced
+}
+
+// Graphic is the plotted points as an image, cellWidth by cellHeight pixels
+// a cell, placed relative to the view; nil while the plot is characters.
+// Between the points it is transparent, so the cursor, cluster overlay,
+// box, and mini-map, still drawn as text, show with it.
+func (m Model) Graphic(cellWidth, cellHeight int) *graphics.Frame {
+	if !m.drawsImage() || m.loading || m.err != nil || len(m.plottedPoints) == 0 {
+		return nil
+	}
+	width, height := m.plotSize()
+	if width < 20 || height < 10 {
+		return nil
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width*cellWidth, height*cellHeight))
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	miniWidth, miniHeight := m.miniMapSize(width, height)
+	radius := max(1, min(cellWidth, cellHeight/2)/3)
+
+	// Dimmed points first, under the isolated cluster's
+	for _, dimmed := range []bool{true, false} {
+		for _, pp := range m.plottedPoints {
+			if m.inIsolation(pp.Point) == dimmed {
+				continue
+			}
+			if pp.ScreenX >= width-miniWidth && pp.ScreenY < miniHeight {
+				continue
+			}
+			// Spread points have been moved to other cells; the rest go
+			// where they are, finer than the braille dot
+			x := (pp.Point.X - viewMinX) / rangeX * float64(width)
+			y := (viewMaxY - pp.Point.Y) / rangeY * float64(height)
+			if m.spreading() {
+				x = float64(pp.ScreenX) + (float64(pp.SubX)+0.5)/2
+				y = float64(pp.ScreenY) + (float64(pp.SubY)+0.5)/4
+			}
+			disc(img, int(x*float64(cellWidth)), int(y*float64(cellHeight)),
+				m.pointRadius(pp.Point, radius), rgba(m.pointColor(pp.Point)))
+		}
+	}
+
+	originX, originY := m.plotOrigin()
+	return &graphics.Frame{Image: img, Col: originX, Row: originY, Cols: width, Rows: height}
+}
+
+// pointRadius sizes a point's disc by its story's length, when sized
+func (m Model) pointRadius(p *db.UmapPoint, radius int) int {
+	switch m.singleGlyph(p) {
+	case glyphShort:
+		return max(1, radius-1)
+	case glyphLong:
+		return radius + 1
+	}
+	return radius
+}
+
+// disc fills a circle, clipped to the image
+func disc(img *image.RGBA, cx, cy, radius int, c color.RGBA) {
+	for y := -radius; y <= radius; y++ {
+		for x := -radius; x <= radius; x++ {
+			if x*x+y*y <= radius*radius {
+				img.SetRGBA(cx+x, cy+y, c)
+			}
+		}
+	}
+}
+
+// rgba reads a "#rrggbb" color, opaque
+func rgba(c lipgloss.Color) color.RGBA {
+	v, err := strconv.ParseUint(strings.TrimPrefix(string(c), "#"), 16, 32)
+	if err != nil {
+		return color.RGBA{0x80, 0x80, 0x80, 0xff}
+	}
+	return color.RGBA{uint8(v >> 16), uint8(v >> 8), uint8(v), 0xff}
+}
@@ -0,0 +1,116 @@
+package visualize
+
+import (
+	"sort"
+
+	"paranormal-tui/internal/db"
+)
+
+// startLasso begins a lasso at the cursor. Moving the cursor, or dragging
+// with the mouse, traces the outline; enter closes it back to the start and
+// lists the stories inside. Like the box anchor, the outline is kept in
+// data coordinates so it stays put through zooming and panning.
+func (m *Model) startLasso() {
+	m.lassoing = true
+	m.lasso = nil
+	m.traceLasso()
+}
+
+// traceLasso adds the cursor to the outline when it has moved to a new cell
+func (m *Model) traceLasso() {
+	if n := len(m.lasso); n > 0 {
+		if x, y := m.dataCell(m.lasso[n-1]); x == m.cursorX && y == m.cursorY {
+			return
+		}
+	}
+	x, y := m.cellData(m.cursorX, m.cursorY)
+	m.lasso = append(m.lasso, [2]float64{x, y})
+}
+
+// undoLasso takes back the last step of the outline, keeping the cursor on
+// its end
+func (m *Model) undoLasso() {
+	if len(m.lasso) <= 1 {
+		return
+	}
+	m.lasso = m.lasso[:len(m.lasso)-1]
+	plotWidth, plotHeight := m.plotSize()
+	x, y := m.dataCell(m.lasso[len(m.lasso)-1])
+	m.cursorX = max(0, min(plotWidth-1, x))
+	m.cursorY = max(0, min(plotHeight-1, y))
+	m.updateSelection()
+}
+
+// dataCell is the plot cell showing data coordinate p, which may be off
+// the plot
+func (m Model) dataCell(p [2]float64) (int, int) {
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	plotWidth, plotHeight := m.plotSize()
+	return int((p[0] - viewMinX) / rangeX * float64(plotWidth)),
+		int((viewMaxY - p[1]) / rangeY * float64(plotHeight))
+}
+
+// inOutline reports whether data coordinate (x, y) is inside the outline,
+// closed from its end back to its start, by counting crossings of a ray
+// to the right
+func inOutline(outline [][2]float64, x, y float64) bool {
+	if len(outline) < 3 {
+		return false
+	}
+	inside := false
+	j := len(outline) - 1
+	for i, a := range outline {
+		b := outline[j]
+		if (a[1] > y) != (b[1] > y) && x < (b[0]-a[0])*(y-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+		j = i
+	}
+	return inside
+}
+
+// lassoMask marks the plot cells on or inside the outline, for shading
+func (m Model) lassoMask(width, height int) [][]bool {
+	if !m.lassoing {
+		return nil
+	}
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	mask := make([][]bool, height)
+	for y := range mask {
+		mask[y] = make([]bool, width)
+		dataY := viewMaxY - (float64(y)+0.5)/float64(height)*rangeY
+		for x := range mask[y] {
+			mask[y][x] = inOutline(m.lasso, viewMinX+(float64(x)+0.5)/float64(width)*rangeX, dataY)
+		}
+	}
+	for _, p := range m.lasso {
+		if x, y := m.dataCell(p); x >= 0 && x < width && y >= 0 && y < height {
+			mask[y][x] = true
+		}
+	}
+	return mask
+}
+
+// lassoedPoints returns the points plotted inside the outline, by title
+func (m Model) lassoedPoints() []*db.UmapPoint {
+	var points []*db.UmapPoint
+	for _, pp := range m.plottedPoints {
+		if inOutline(m.lasso, pp.Point.X, pp.Point.Y) {
+			points = append(points, pp.Point)
+		}
+	}
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Title < points[j].Title })
+	return points
+}
+
+// confirmLasso ends the lasso and lists the stories inside it, where they
+// can be saved as a collection as with a box select
+func (m *Model) confirmLasso() {
+	m.boxResults = m.lassoedPoints()
+	m.lassoing = false
+	m.lasso = nil
+	m.showBoxResults = true
+	m.boxCursor = 0
+	m.boxNaming = false
+	m.boxNotice = ""
+}
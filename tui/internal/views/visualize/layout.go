@@ -0,0 +1,95 @@
+package visualize
+
+import (
+	"fmt"
+	"strings"
+
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Layout is how the view divides the terminal between plot and info
+type Layout int
+
+const (
+	LayoutSplit Layout = iota // Plot on the left, legend and info on the right
+	LayoutFull                // Plot alone, the full width
+	LayoutStrip               // Full-width plot over a one-line legend and the selection
+
+	layouts = 3
+)
+
+func (l Layout) label() string {
+	return []string{"split", "full plot", "legend strip"}[l]
+}
+
+// layout is the layout in use. The box results and the cluster dialog
+// need the info panel, so they bring it back while they're open.
+func (m Model) layout() Layout {
+	if m.showBoxResults || m.showClusterDialog {
+		return LayoutSplit
+	}
+	return m.plotLayout
+}
+
+// plotSize is the plot's size in cells, inside its border
+func (m Model) plotSize() (width, height int) {
+	switch m.layout() {
+	case LayoutFull:
+		return m.width - 6, m.height - 8
+	case LayoutStrip:
+		return m.width - 6, m.height - 10
+	}
+	return m.width/2 - 4, m.height - 8
+}
+
+// cycleLayout switches to the next layout
+func (m *Model) cycleLayout() {
+	m.plotLayout = (m.plotLayout + 1) % layouts
+	m.fitLayout()
+}
+
+// fitLayout re-plots the points if the plot has changed size, as it does
+// when the layout changes or a dialog brings the info panel back, keeping
+// the selected story under the cursor
+func (m *Model) fitLayout() {
+	w, h := m.plotSize()
+	if w == m.lastPlotWidth && h == m.lastPlotHeight {
+		return
+	}
+	selectedID := m.selectedID
+	m.cursorX = max(0, min(m.cursorX, w-1))
+	m.cursorY = max(0, min(m.cursorY, h-1))
+	m.computeScreenPositions()
+	m.moveCursorTo(selectedID)
+}
+
+// renderStrip is the legend squeezed onto one line, as many entries as fit,
+// and the selected story on the line below
+func (m Model) renderStrip(width int) string {
+	_, entries := m.legend()
+	var b strings.Builder
+	used := 0
+	for i, e := range entries {
+		entry := fmt.Sprintf("%s %d", e.label, e.count)
+		if used+lipgloss.Width(entry)+4 > width {
+			b.WriteString(styles.DimStyle.Render(fmt.Sprintf("+%d more", len(entries)-i)))
+			break
+		}
+		b.WriteString(lipgloss.NewStyle().Foreground(e.color).Render("●"))
+		b.WriteString(" " + entry + "  ")
+		used += lipgloss.Width(entry) + 4
+	}
+
+	selection := styles.DimStyle.Render(fmt.Sprintf("Zoom %.1fx • nothing selected", m.zoom))
+	if m.selected != nil {
+		title := m.selected.Title
+		if len(m.pointsAtCursor) > 1 {
+			title = fmt.Sprintf("(%d/%d) %s", m.overlapIndex+1, len(m.pointsAtCursor), title)
+		}
+		selection = styles.BoldStyle.Render(truncateTitle(title, width-len(m.selected.StoryType)-4)) +
+			styles.DimStyle.Render(" • "+m.selected.StoryType)
+	}
+	return " " + b.String() + "\n " + selection
+}
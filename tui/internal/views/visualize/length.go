@@ -0,0 +1,66 @@
+package visualize
+
+import (
+	"fmt"
+	"sort"
+
+	"paranormal-tui/internal/db"
+)
+
+// Glyphs for a lone story when points are sized by length: brief mentions,
+// the middle third, and long detailed accounts
+const (
+	glyphShort  = '·'
+	glyphMedium = '●'
+	glyphLong   = '⬤'
+)
+
+// lengthCuts splits the counted stories into thirds by word count: below
+// short is the shortest third, at or above long the longest. Both are zero
+// if no story has been counted.
+func lengthCuts(points []db.UmapPoint) (short, long int) {
+	var counts []int
+	for _, p := range points {
+		if p.WordCount != nil {
+			counts = append(counts, *p.WordCount)
+		}
+	}
+	if len(counts) == 0 {
+		return 0, 0
+	}
+	sort.Ints(counts)
+	return counts[len(counts)/3], counts[len(counts)*2/3]
+}
+
+// singleGlyph is how a cell holding one story is drawn: by the story's
+// length if points are sized by it, and otherwise, or for a story not yet
+// counted, the usual dot
+func (m Model) singleGlyph(p *db.UmapPoint) rune {
+	if !m.sizeByLength || p.WordCount == nil || m.longWords == 0 {
+		return glyphMedium
+	}
+	switch words := *p.WordCount; {
+	case words < m.shortWords:
+		return glyphShort
+	case words >= m.longWords:
+		return glyphLong
+	}
+	return glyphMedium
+}
+
+// isSingle reports whether a cell's glyph is one story's
+func isSingle(r rune) bool {
+	return r == glyphShort || r == glyphMedium || r == glyphLong
+}
+
+// lengthLegend is the legend row for sized points
+func (m Model) lengthLegend() string {
+	if m.longWords == 0 {
+		return "Sized by length: no word counts yet\n(run paranormal-tui words backfill)"
+	}
+	if m.shortWords == m.longWords {
+		return fmt.Sprintf("%c <%d  %c %d+ words", glyphShort, m.shortWords, glyphLong, m.longWords)
+	}
+	return fmt.Sprintf("%c <%d  %c %d-%d  %c %d+ words",
+		glyphShort, m.shortWords, glyphMedium, m.shortWords, m.longWords-1, glyphLong, m.longWords)
+}
@@ -0,0 +1,167 @@
+package visualize
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hullTrim is the share of each cluster's points, farthest from its
+// centroid, left out of its outline so a few strays don't swallow the plot
+const hullTrim = 0.05
+
+type vec struct{ x, y float64 }
+
+// clusterShape is a cluster's approximate extent
+type clusterShape struct {
+	id       int
+	count    int
+	centroid vec
+	hull     []vec // Counter-clockwise, not closed
+}
+
+// clusterShapes finds the centroid and outline of every cluster; noise has
+// neither
+func clusterShapes(points []db.UmapPoint) []clusterShape {
+	members := make(map[int][]vec)
+	for _, p := range points {
+		if p.ClusterID != nil {
+			members[*p.ClusterID] = append(members[*p.ClusterID], vec{p.X, p.Y})
+		}
+	}
+
+	shapes := make([]clusterShape, 0, len(members))
+	for id, pts := range members {
+		var c vec
+		for _, p := range pts {
+			c.x += p.x
+			c.y += p.y
+		}
+		c.x /= float64(len(pts))
+		c.y /= float64(len(pts))
+
+		sort.Slice(pts, func(i, j int) bool {
+			return dist2(pts[i], c) < dist2(pts[j], c)
+		})
+		keep := len(pts) - int(float64(len(pts))*hullTrim)
+		shapes = append(shapes, clusterShape{
+			id:       id,
+			count:    len(pts),
+			centroid: c,
+			hull:     convexHull(pts[:keep]),
+		})
+	}
+	sort.Slice(shapes, func(i, j int) bool { return shapes[i].id < shapes[j].id })
+	return shapes
+}
+
+func dist2(a, b vec) float64 {
+	return (a.x-b.x)*(a.x-b.x) + (a.y-b.y)*(a.y-b.y)
+}
+
+// convexHull is Andrew's monotone chain
+func convexHull(pts []vec) []vec {
+	pts = append([]vec(nil), pts...)
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].x != pts[j].x {
+			return pts[i].x < pts[j].x
+		}
+		return pts[i].y < pts[j].y
+	})
+	if len(pts) < 3 {
+		return pts
+	}
+
+	cross := func(o, a, b vec) float64 {
+		return (a.x-o.x)*(b.y-o.y) - (a.y-o.y)*(b.x-o.x)
+	}
+	hull := make([]vec, 0, 2*len(pts))
+	for _, p := range pts {
+		for len(hull) >= 2 && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	lower := len(hull) + 1
+	for i := len(pts) - 2; i >= 0; i-- {
+		p := pts[i]
+		for len(hull) >= lower && cross(hull[len(hull)-2], hull[len(hull)-1], p) <= 0 {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, p)
+	}
+	return hull[:len(hull)-1]
+}
+
+// renderOverlay draws cluster outlines into empty cells of grid and labels
+// over whatever is there. The returned styles color the cells it drew.
+func (m Model) renderOverlay(grid [][]rune, width, height int) [][]*lipgloss.Style {
+	marks := make([][]*lipgloss.Style, height)
+	for y := range marks {
+		marks[y] = make([]*lipgloss.Style, width)
+	}
+	if m.overlay == OverlayNone {
+		return marks
+	}
+
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	toScreen := func(p vec) (float64, float64) {
+		return (p.x - viewMinX) / rangeX * float64(width),
+			(viewMaxY - p.y) / rangeY * float64(height)
+	}
+
+	var shown []clusterShape
+	for _, s := range m.shapes {
+		id := s.id
+		if !m.isolated || m.dimOthers || m.inIsolation(&db.UmapPoint{ClusterID: &id}) {
+			shown = append(shown, s)
+		}
+	}
+
+	if m.overlay == OverlayHulls {
+		for _, s := range shown {
+			id := s.id
+			style := lipgloss.NewStyle().Foreground(styles.GetClusterColor(&id))
+			for i := range s.hull {
+				x0, y0 := toScreen(s.hull[i])
+				x1, y1 := toScreen(s.hull[(i+1)%len(s.hull)])
+				// Step at most half a cell so the outline has no gaps
+				steps := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0)) * 2))
+				for t := 0; t <= steps; t++ {
+					f := float64(t) / float64(max(steps, 1))
+					x := int(math.Floor(x0 + (x1-x0)*f))
+					y := int(math.Floor(y0 + (y1-y0)*f))
+					if x >= 0 && x < width && y >= 0 && y < height && grid[y][x] == ' ' {
+						grid[y][x] = '·'
+						marks[y][x] = &style
+					}
+				}
+			}
+		}
+	}
+
+	for _, s := range shown {
+		id := s.id
+		style := lipgloss.NewStyle().Bold(true).Foreground(styles.GetClusterColor(&id))
+		label := []rune(fmt.Sprintf("c%d: %d", s.id, s.count))
+		cx, cy := toScreen(s.centroid)
+		y := int(math.Floor(cy))
+		if y < 0 || y >= height {
+			continue
+		}
+		start := int(math.Floor(cx)) - len(label)/2
+		for i, r := range label {
+			if x := start + i; x >= 0 && x < width {
+				grid[y][x] = r
+				marks[y][x] = &style
+			}
+		}
+	}
+
+	return marks
+}
@@ -0,0 +1,138 @@
+package visualize
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"paranormal-tui/internal/quota"
+	"paranormal-tui/internal/styles"
+	"paranormal-tui/internal/umap"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var confirmStyle = lipgloss.NewStyle().Foreground(styles.Warning)
+
+// ProjectionProgressMsg carries a step of a projection run with U, or its
+// end
+type ProjectionProgressMsg struct {
+	Progress umap.Progress
+}
+
+// ProjectionTickMsg updates the elapsed time while a projection runs
+type ProjectionTickMsg struct{}
+
+// SetProjectCommand sets the command that computes a new projection; nil
+// runs umap.DefaultCommand
+func (m *Model) SetProjectCommand(command []string) {
+	m.projectCommand = command
+}
+
+// SetQuota rations projections and clusterings
+func (m *Model) SetQuota(q *quota.User) {
+	m.quota = q
+}
+
+// Projecting reports whether a projection is running
+func (m Model) Projecting() bool {
+	return m.projecting
+}
+
+// startProjection runs the projection script in the background
+func (m *Model) startProjection() tea.Cmd {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.projecting = true
+	m.confirmProject = false
+	m.projectCancel = cancel
+	m.projectProgress = umap.Progress{Stage: "Starting"}
+	m.projectStart = time.Now()
+	m.notice = ""
+	m.projectCh = umap.Run(ctx, m.database, m.projectCommand)
+	return tea.Batch(waitProjection(m.projectCh), projectionTick())
+}
+
+// waitProjection reads the next progress report
+func waitProjection(ch <-chan umap.Progress) tea.Cmd {
+	return func() tea.Msg {
+		return ProjectionProgressMsg{Progress: <-ch}
+	}
+}
+
+func projectionTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return ProjectionTickMsg{}
+	})
+}
+
+// updateProjection follows a projection; when it is done the new points
+// are loaded
+func (m Model) updateProjection(p umap.Progress) (Model, tea.Cmd) {
+	if !m.projecting {
+		return m, nil
+	}
+	if !p.Done {
+		m.projectProgress = p
+		return m, waitProjection(m.projectCh)
+	}
+
+	m.projecting = false
+	m.projectCancel()
+	m.projectCh = nil
+	elapsed := time.Since(m.projectStart).Round(time.Second)
+	switch {
+	case p.Err != nil && m.projectCancelled:
+		m.notice = styles.DimStyle.Render("Projection cancelled; the map is unchanged")
+	case p.Err != nil:
+		m.notice = styles.ErrorStyle.Render("Projection failed: " + p.Err.Error())
+	default:
+		m.notice = styles.SuccessStyle.Render(fmt.Sprintf("Projected %d stories in %s", p.Saved, elapsed)) + styles.DimStyle.Render(" • old positions kept: paranormal-tui recovery")
+		m.loading = true
+		return m, m.loadPoints()
+	}
+	return m, nil
+}
+
+// cancelProjection stops the script; the old coordinates are kept
+func (m *Model) cancelProjection() {
+	m.projectCancelled = true
+	m.projectCancel()
+}
+
+// projectionFooter is the footer while a projection is being confirmed or
+// runs or stories are being clustered, or just after either ends; ""
+// otherwise
+func (m Model) projectionFooter() string {
+	switch {
+	case m.confirmProject || m.projecting:
+		return m.renderProjection()
+	case m.clustering:
+		return styles.DimStyle.Render("  Clustering stories...")
+	case m.notice != "":
+		return "  " + m.notice
+	}
+	return ""
+}
+
+// renderProjection shows the confirmation prompt or the running step
+func (m Model) renderProjection() string {
+	if m.confirmProject {
+		return confirmStyle.Render("  Recompute the UMAP projection? Every story's position changes. y: run • n: cancel")
+	}
+
+	p := m.projectProgress
+	const barWidth = 20
+	filled := 0
+	if p.Steps > 0 {
+		filled = barWidth * p.Step / p.Steps
+	}
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	step := ""
+	if p.Steps > 0 {
+		step = fmt.Sprintf(" %d/%d", p.Step, p.Steps)
+	}
+	elapsed := time.Since(m.projectStart).Round(time.Second)
+	return styles.DimStyle.Render(fmt.Sprintf("  Projecting %s%s %s... %s • esc: cancel", bar, step, p.Stage, elapsed))
+}
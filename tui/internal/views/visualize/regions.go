@@ -0,0 +1,94 @@
+package visualize
+
+import (
+	"sort"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/region"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// topRegions is how many regions get their own color; the rest share one
+const topRegions = 10
+
+// otherRegionsColor is for stories in regions outside the top few
+const otherRegionsColor = lipgloss.Color("#808080")
+
+// storyRegions finds each story's region, and ranks the regions by how
+// many stories they have
+func storyRegions(points []db.UmapPoint) (map[string]string, []string) {
+	regions := make(map[string]string, len(points))
+	counts := make(map[string]int)
+	found := make(map[string]string) // Locations repeat; each is looked up once
+	for _, p := range points {
+		r, ok := found[p.Location]
+		if !ok {
+			r = region.Of(p.Location)
+			found[p.Location] = r
+		}
+		regions[p.ID] = r
+		if r != "" {
+			counts[r]++
+		}
+	}
+
+	ranked := make([]string, 0, len(counts))
+	for r := range counts {
+		ranked = append(ranked, r)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if counts[ranked[i]] != counts[ranked[j]] {
+			return counts[ranked[i]] > counts[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return regions, ranked
+}
+
+// regionColor is a point's color: its region's if that's among the top
+// few, otherwise one shared by the rest
+func (m Model) regionColor(p *db.UmapPoint) lipgloss.Color {
+	r := m.regions[p.ID]
+	if r == "" {
+		return unknownColor
+	}
+	for i, top := range m.rankedRegions[:min(topRegions, len(m.rankedRegions))] {
+		if top == r {
+			return styles.GetClusterColor(&i)
+		}
+	}
+	return otherRegionsColor
+}
+
+// regionLegend lists the top regions, then how many stories are elsewhere
+// and how many couldn't be placed
+func (m Model) regionLegend() []legendEntry {
+	counts := make(map[string]int)
+	unknown := 0
+	for _, p := range m.points {
+		if r := m.regions[p.ID]; r != "" {
+			counts[r]++
+		} else {
+			unknown++
+		}
+	}
+
+	var entries []legendEntry
+	others := 0
+	for i, r := range m.rankedRegions {
+		if i >= topRegions {
+			others += counts[r]
+			continue
+		}
+		entries = append(entries, legendEntry{styles.GetClusterColor(&i), r, counts[r]})
+	}
+	if others > 0 {
+		entries = append(entries, legendEntry{otherRegionsColor, "other regions", others})
+	}
+	if unknown > 0 {
+		entries = append(entries, legendEntry{unknownColor, "unknown", unknown})
+	}
+	return entries
+}
@@ -0,0 +1,57 @@
+package visualize
+
+import "paranormal-tui/internal/config"
+
+// Viewport returns where the plot is, to pick up from on the next run. The
+// cursor is kept in data coordinates so it lands on the same spot whatever
+// the terminal's size. An isolated cluster's view is fitted to that
+// cluster, which isn't kept, so the whole plot is saved instead.
+func (m Model) Viewport() config.Viewport {
+	v := config.Viewport{Zoom: m.zoom, OffsetX: m.offsetX, OffsetY: m.offsetY, Color: m.colorMode.label()}
+	if m.isolated {
+		v.Zoom, v.OffsetX, v.OffsetY = 1, 0, 0
+	}
+
+	plotWidth, plotHeight := m.plotSize()
+	switch {
+	case m.savedCursor != nil:
+		// Never placed; the plot wasn't shown
+		v.Cursor = m.savedCursor
+	case len(m.points) > 0 && plotWidth > 0 && plotHeight > 0:
+		viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+		v.Cursor = &[2]float64{
+			viewMinX + (float64(m.cursorX)+0.5)/float64(plotWidth)*rangeX,
+			viewMaxY - (float64(m.cursorY)+0.5)/float64(plotHeight)*rangeY,
+		}
+	}
+	return v
+}
+
+// RestoreViewport picks up from a saved Viewport. The cursor is placed
+// once the points are loaded and the plot has a size.
+func (m *Model) RestoreViewport(v config.Viewport) {
+	if v.Zoom > 0 {
+		m.setZoom(v.Zoom)
+	}
+	m.offsetX, m.offsetY = v.OffsetX, v.OffsetY
+	for c := ColorMode(0); c < colorModes; c++ {
+		if c.label() == v.Color {
+			m.colorMode = c
+		}
+	}
+	m.savedCursor = v.Cursor
+}
+
+// placeSavedCursor moves the cursor to the restored spot, kept on the plot
+func (m *Model) placeSavedCursor() {
+	plotWidth, plotHeight := m.plotSize()
+	if m.savedCursor == nil || len(m.points) == 0 || plotWidth <= 0 || plotHeight <= 0 {
+		return
+	}
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	x := int((m.savedCursor[0] - viewMinX) / rangeX * float64(plotWidth))
+	y := int((viewMaxY - m.savedCursor[1]) / rangeY * float64(plotHeight))
+	m.cursorX = max(0, min(plotWidth-1, x))
+	m.cursorY = max(0, min(plotHeight-1, y))
+	m.savedCursor = nil
+}
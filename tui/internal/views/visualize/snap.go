@@ -0,0 +1,63 @@
+package visualize
+
+// snapAspect is how many columns a row is worth: terminal cells are about
+// twice as tall as they are wide, so a point one row up is as far as one
+// two columns across
+const snapAspect = 2
+
+// toggleSnap turns snap navigation on or off. Turning it on jumps to the
+// nearest point if the cursor isn't on one already.
+func (m *Model) toggleSnap() {
+	m.snap = !m.snap
+	if m.snap && len(m.pointsAtCursor) == 0 {
+		m.snapNearest()
+	}
+}
+
+// snapNearest moves the cursor onto the point nearest it in any direction
+func (m *Model) snapNearest() {
+	best, bestDist := -1, 0
+	for i, pp := range m.plottedPoints {
+		if !m.inIsolation(pp.Point) {
+			continue
+		}
+		dx, dy := pp.ScreenX-m.cursorX, (pp.ScreenY-m.cursorY)*snapAspect
+		if dist := dx*dx + dy*dy; best < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	if best >= 0 {
+		m.cursorX, m.cursorY = m.plottedPoints[best].ScreenX, m.plottedPoints[best].ScreenY
+	}
+	m.updateSelection()
+}
+
+// snapStep moves the cursor to the nearest point in direction (dx, dy),
+// one of the four arrow directions. Points within 45° of it are preferred,
+// closest along it and least off to the side first; with none there, the
+// nearest anywhere on that side is taken. Where there is no point that way
+// the cursor stays put. Dimmed points are skipped.
+func (m *Model) snapStep(dx, dy int) {
+	best, bestScore, bestInCone := -1, 0, false
+	for i, pp := range m.plottedPoints {
+		if !m.inIsolation(pp.Point) {
+			continue
+		}
+		ox, oy := pp.ScreenX-m.cursorX, (pp.ScreenY-m.cursorY)*snapAspect
+		along, across := ox*dx+oy*dy, abs(ox*dy+oy*dx)
+		if along <= 0 {
+			continue
+		}
+		inCone := across <= along
+		// Sideways distance counts double, so a point straight ahead beats
+		// a slightly nearer one off at an angle
+		score := along + 2*across
+		if best < 0 || (inCone && !bestInCone) || (inCone == bestInCone && score < bestScore) {
+			best, bestScore, bestInCone = i, score, inCone
+		}
+	}
+	if best >= 0 {
+		m.cursorX, m.cursorY = m.plottedPoints[best].ScreenX, m.plottedPoints[best].ScreenY
+	}
+	m.updateSelection()
+}
@@ -0,0 +1,106 @@
+package visualize
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+// spreadZoom is the zoom from which spread mode moves stacked points
+// apart. Further out, the cells around them are busy with real neighbors.
+const spreadZoom = 2.0
+
+// spreadOffsets are the cells a stacked point can move to, nearest first:
+// the ring around its cell, then the ring around that
+var spreadOffsets = func() [][2]int {
+	var offsets [][2]int
+	for r := 1; r <= 2; r++ {
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if max(abs(dx), abs(dy)) == r {
+					offsets = append(offsets, [2]int{dx, dy})
+				}
+			}
+		}
+	}
+	sort.SliceStable(offsets, func(i, j int) bool {
+		a, b := offsets[i], offsets[j]
+		return a[0]*a[0]+a[1]*a[1] < b[0]*b[0]+b[1]*b[1]
+	})
+	return offsets
+}()
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// spreading reports whether stacked points are being moved apart
+func (m Model) spreading() bool {
+	return m.spread && m.zoom >= spreadZoom
+}
+
+// toggleSpread turns spread mode on or off, keeping the selected story
+// under the cursor
+func (m *Model) toggleSpread() {
+	m.spread = !m.spread
+	selectedID := m.selectedID
+	m.computeScreenPositions()
+	m.moveCursorTo(selectedID)
+}
+
+// spreadPoints moves all but one of the points sharing a cell into free
+// cells nearby, so each can be selected on its own. Where each goes is
+// jittered by its story ID, so the layout is the same from frame to frame
+// and looks scattered rather than stacked in rings. A point with no free
+// cell near it stays stacked, for [ ] to reach.
+func (m *Model) spreadPoints(width, height int) {
+	cells := make(map[[2]int][]int)
+	for i, pp := range m.plottedPoints {
+		cell := [2]int{pp.ScreenX, pp.ScreenY}
+		cells[cell] = append(cells[cell], i)
+	}
+
+	var stacked [][2]int
+	for cell, points := range cells {
+		if len(points) > 1 {
+			stacked = append(stacked, cell)
+		}
+	}
+	// Cells are claimed in order, so the same cell must always go first
+	sort.Slice(stacked, func(i, j int) bool {
+		if stacked[i][1] != stacked[j][1] {
+			return stacked[i][1] < stacked[j][1]
+		}
+		return stacked[i][0] < stacked[j][0]
+	})
+
+	for _, cell := range stacked {
+		points := cells[cell]
+		sort.Slice(points, func(i, j int) bool {
+			return m.plottedPoints[points[i]].Point.ID < m.plottedPoints[points[j]].Point.ID
+		})
+		for _, i := range points[1:] {
+			pp := &m.plottedPoints[i]
+			h := fnv.New32a()
+			h.Write([]byte(pp.Point.ID))
+			start := int(h.Sum32() % 8) // Within the nearest ring
+
+			for k := range spreadOffsets {
+				offset := spreadOffsets[k]
+				if k < 8 {
+					offset = spreadOffsets[(k+start)%8]
+				}
+				x, y := cell[0]+offset[0], cell[1]+offset[1]
+				target := [2]int{x, y}
+				if x < 0 || x >= width || y < 0 || y >= height || len(cells[target]) > 0 {
+					continue
+				}
+				pp.ScreenX, pp.ScreenY = x, y
+				cells[target] = []int{i}
+				break
+			}
+		}
+	}
+}
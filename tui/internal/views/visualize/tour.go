@@ -0,0 +1,92 @@
+package visualize
+
+import (
+	"fmt"
+	"math"
+
+	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/styles"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// tourShapes are the clusters tab can visit: all of them, or only the
+// isolated one while the others are hidden
+func (m Model) tourShapes() []clusterShape {
+	var shown []clusterShape
+	for _, s := range m.shapes {
+		id := s.id
+		if !m.isolated || m.dimOthers || m.inIsolation(&db.UmapPoint{ClusterID: &id}) {
+			shown = append(shown, s)
+		}
+	}
+	return shown
+}
+
+// tourStep moves the cursor to the centroid of the next cluster by ID, or
+// the previous one for step -1, wrapping around. The view pans to the
+// centroid if it's off screen.
+func (m *Model) tourStep(step int) {
+	shapes := m.tourShapes()
+	if len(shapes) == 0 {
+		return
+	}
+	next := 0
+	if step < 0 {
+		next = len(shapes) - 1
+	}
+	if m.tourID != nil {
+		for i, s := range shapes {
+			if s.id == *m.tourID {
+				next = (i + step + len(shapes)) % len(shapes)
+				break
+			}
+		}
+	}
+	s := shapes[next]
+	m.tourID = &s.id
+
+	x, y, ok := m.centroidCell(s)
+	if !ok {
+		m.offsetX = s.centroid.x - (m.minX+m.maxX)/2
+		m.offsetY = s.centroid.y - (m.minY+m.maxY)/2
+		m.computeScreenPositions()
+		x, y, _ = m.centroidCell(s)
+	}
+	m.cursorX, m.cursorY = x, y
+	m.updateSelection()
+}
+
+// centroidCell is the screen cell of a cluster's centroid, and whether
+// it's on screen
+func (m Model) centroidCell(s clusterShape) (x, y int, ok bool) {
+	plotWidth, plotHeight := m.plotSize()
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	fx := (s.centroid.x - viewMinX) / rangeX * float64(plotWidth)
+	fy := (viewMaxY - s.centroid.y) / rangeY * float64(plotHeight)
+	x, y = int(math.Floor(fx)), int(math.Floor(fy))
+	return x, y, x >= 0 && x < plotWidth && y >= 0 && y < plotHeight
+}
+
+// renderTour announces the cluster tab last moved to, while the cursor is
+// still on its centroid
+func (m Model) renderTour() string {
+	if m.tourID == nil {
+		return ""
+	}
+	shapes := m.tourShapes()
+	for i, s := range shapes {
+		if s.id != *m.tourID {
+			continue
+		}
+		if x, y, ok := m.centroidCell(s); !ok || x != m.cursorX || y != m.cursorY {
+			return ""
+		}
+		id := s.id
+		marker := lipgloss.NewStyle().Foreground(styles.GetClusterColor(&id)).Render("●")
+		return fmt.Sprintf("%s %s %s\n", marker,
+			styles.BoldStyle.Render(fmt.Sprintf("Cluster c%d", s.id)),
+			styles.DimStyle.Render(fmt.Sprintf("(%d of %d): %d stories", i+1, len(shapes), s.count)))
+	}
+	return ""
+}
@@ -3,13 +3,19 @@ package visualize
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
 	"strings"
+	"time"
 
+	"paranormal-tui/internal/cluster"
 	"paranormal-tui/internal/db"
+	"paranormal-tui/internal/quota"
 	"paranormal-tui/internal/styles"
+	"paranormal-tui/internal/umap"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -19,6 +25,17 @@ type PlottedPoint struct {
 	Point   *db.UmapPoint
 	ScreenX int // Integer screen position (0 to width-1)
 	ScreenY int // Integer screen position (0 to height-1)
+	SubX    int // Braille dot column within the cell (0-1)
+	SubY    int // Braille dot row within the cell (0-3)
+}
+
+// brailleDots maps a dot's [row][column] within a cell to its bit in the
+// braille block starting at U+2800
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
 }
 
 // ColorMode determines how points are colored
@@ -27,6 +44,24 @@ type ColorMode int
 const (
 	ColorByStoryType ColorMode = iota
 	ColorByCluster
+	ColorByDate   // Air date, old to new along a gradient
+	ColorByRegion // US state or country, from the location
+
+	colorModes = 4
+)
+
+// label says how points are colored, e.g. "by type"
+func (c ColorMode) label() string {
+	return [...]string{"by type", "by cluster", "by air date", "by region"}[c]
+}
+
+// Overlay chooses what is drawn over the plot to show cluster extents
+type Overlay int
+
+const (
+	OverlayNone   Overlay = iota
+	OverlayLabels         // "c3: 142" at each cluster's centroid
+	OverlayHulls          // Outlines and labels
 )
 
 // Model represents the visualization view
@@ -46,10 +81,45 @@ type Model struct {
 	offsetY    float64
 	selected   *db.UmapPoint
 	selectedID string
-	colorMode  ColorMode // Toggle between story_type and cluster coloring
-
-	// Pre-computed screen positions (single source of truth)
+	colorMode  ColorMode // Cycle through story_type, cluster, air date, and region coloring
+	blocks     bool      // Draw one symbol per cell instead of braille dots
+	reduced    bool      // Slow terminal: blocks and no per-cell color
+	graphics   bool      // The terminal can show the points as an image
+	charGrid   bool      // Characters anyway, on such a terminal
+	overlay    Overlay
+	spread     bool // Move stacked points apart when zoomed in (see spreadZoom)
+	snap       bool // Arrows jump to the nearest point that way (see snapStep)
+	tourID     *int // Cluster whose centroid tab last moved to
+	plotLayout Layout
+
+	// A restored cursor in data coordinates, until the plot is shown
+	savedCursor *[2]float64
+
+	// Lone points drawn by story length, and the word counts splitting
+	// the corpus into short, medium, and long thirds (see lengthCuts)
+	sizeByLength          bool
+	shortWords, longWords int
+
+	// Cluster outlines and centroids in data coordinates, computed on load
+	shapes []clusterShape
+
+	// The range of air dates that ColorByDate spreads its gradient over,
+	// and each story's region with the regions ranked by stories for
+	// ColorByRegion, computed on load
+	oldest, newest time.Time
+	regions        map[string]string // Story ID to region; "" if unknown
+	rankedRegions  []string
+
+	// Cluster isolation: only isolatedCluster's points are shown (nil is
+	// noise), or the rest are dimmed, and the bounds fit that cluster
+	isolated        bool
+	isolatedCluster *int
+	dimOthers       bool
+
+	// Pre-computed screen positions (single source of truth), and the
+	// points in each cell
 	plottedPoints []PlottedPoint
+	cells         cellIndex
 	// Overlap handling: points at cursor position
 	pointsAtCursor []*db.UmapPoint
 	overlapIndex   int // Which overlapping point is currently selected
@@ -61,6 +131,63 @@ type Model struct {
 	// Cached plot dimensions for detecting resize
 	lastPlotWidth  int
 	lastPlotHeight int
+
+	// Mouse drag state: where the left button went down and the pan
+	// offset at that moment
+	dragging    bool
+	dragged     bool
+	dragStartX  int
+	dragStartY  int
+	dragOffsetX float64
+	dragOffsetY float64
+
+	// Box select: v anchors a corner (in data coordinates), the cursor is
+	// the opposite corner, and enter lists the stories inside
+	boxing         bool
+	boxAnchorX     float64
+	boxAnchorY     float64
+	showBoxResults bool
+	boxResults     []*db.UmapPoint
+	boxCursor      int
+	boxNaming      bool // Typing a collection name to save the results under
+	boxInput       textinput.Model
+	boxNotice      string
+	readOnly       bool
+
+	// Lasso: L traces an outline (in data coordinates) with the cursor or
+	// mouse, and enter lists the stories inside as a box select does
+	lassoing bool
+	lasso    [][2]float64
+
+	// Projection: U recomputes the UMAP coordinates with projectCommand,
+	// after a y to confirm
+	projectCommand   []string
+	confirmProject   bool
+	projecting       bool
+	projectCancelled bool
+	projectCancel    context.CancelFunc
+	projectCh        <-chan umap.Progress
+	projectProgress  umap.Progress
+	projectStart     time.Time
+
+	// Clustering: C asks for the parameters, then runs HDBSCAN and saves
+	// the clusters
+	showClusterDialog bool
+	clusterInputs     [2]textinput.Model // Min cluster size, min samples
+	clusterField      int
+	clusterEmbeddings bool
+	clusterErr        string
+	clusterParams     cluster.Params // The last run's
+	clustering        bool
+
+	// Export: E saves the viewport as an image, once named
+	canExport    bool
+	exportNaming bool
+	exportInput  textinput.Model
+
+	notice string // How the last projection, clustering, or export ended, until the next key
+
+	quota *quota.User // Rations projections and clusterings; nil is no limit
 }
 
 // New creates a new visualization model
@@ -91,10 +218,22 @@ func (m *Model) SetSize(width, height int) {
 	// Recompute screen positions if we have points loaded
 	if len(m.points) > 0 {
 		m.computeScreenPositions()
+		m.placeSavedCursor()
 		m.updateSelection()
 	}
 }
 
+// SetBlocks chooses block symbols over braille dots
+func (m *Model) SetBlocks(blocks bool) {
+	m.blocks = blocks
+}
+
+// SetReduced draws the plot cheaply for slow terminals: one uncolored
+// symbol per cell
+func (m *Model) SetReduced(reduced bool) {
+	m.reduced = reduced
+}
+
 // SetDatabase sets the database connection
 func (m *Model) SetDatabase(database *db.DB) {
 	m.database = database
@@ -131,6 +270,15 @@ func (m *Model) Reload() tea.Cmd {
 
 // Update handles messages
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	m, cmd := m.update(msg)
+	if m.lassoing {
+		m.traceLasso()
+	}
+	m.fitLayout()
+	return m, cmd
+}
+
+func (m Model) update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case UmapPointsLoadedMsg:
 		m.loading = false
@@ -139,13 +287,124 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, nil
 		}
 		m.points = msg.Points
+		m.shapes = clusterShapes(m.points)
+		m.oldest, m.newest = dateRange(m.points)
+		m.regions, m.rankedRegions = storyRegions(m.points)
+		m.shortWords, m.longWords = lengthCuts(m.points)
 		m.computeBounds()
 		m.computeScreenPositions()
+		m.placeSavedCursor()
 		m.updateSelection()
 		return m, nil
 
+	case CollectionSavedMsg:
+		if msg.Err != nil {
+			m.boxNotice = styles.ErrorStyle.Render(msg.Err.Error())
+		} else {
+			m.boxNotice = styles.SuccessStyle.Render(fmt.Sprintf("Added %d to %q", msg.Added, msg.Name))
+		}
+		return m, nil
+
+	case ProjectionProgressMsg:
+		return m.updateProjection(msg.Progress)
+
+	case ProjectionTickMsg:
+		if m.projecting {
+			return m, projectionTick()
+		}
+		return m, nil
+
+	case ClustersSavedMsg:
+		return m.clustersSaved(msg)
+
+	case ImageExportedMsg:
+		if msg.Err != nil {
+			m.notice = styles.ErrorStyle.Render("Export failed: " + msg.Err.Error())
+		} else {
+			m.notice = styles.SuccessStyle.Render("Saved the plot to " + msg.Path)
+		}
+		return m, nil
+
 	case tea.KeyMsg:
+		m.notice = ""
+		if m.confirmProject {
+			if msg.String() == "y" {
+				if err := m.quota.Allow(quota.Projection); err != nil {
+					m.confirmProject = false
+					m.notice = styles.ErrorStyle.Render("Can't reproject: " + err.Error())
+					return m, nil
+				}
+				return m, m.startProjection()
+			}
+			m.confirmProject = false
+			return m, nil
+		}
+		if m.projecting && msg.String() == "esc" {
+			m.cancelProjection()
+			return m, nil
+		}
+		if m.showClusterDialog {
+			return m.handleClusterDialogKeys(msg)
+		}
+		if m.exportNaming {
+			return m.handleExportKeys(msg)
+		}
+		if m.showBoxResults {
+			return m.handleBoxResultsKeys(msg)
+		}
+		if m.boxing {
+			switch msg.String() {
+			case "enter":
+				m.confirmBox()
+				return m, nil
+			case "esc", "v":
+				m.boxing = false
+				return m, nil
+			}
+		}
+		if m.lassoing {
+			switch msg.String() {
+			case "enter":
+				m.confirmLasso()
+				return m, nil
+			case "esc", "L":
+				m.lassoing = false
+				m.lasso = nil
+				return m, nil
+			case "backspace":
+				m.undoLasso()
+				return m, nil
+			case "v":
+				return m, nil
+			}
+		}
 		switch {
+		case key.Matches(msg, key.NewBinding(key.WithKeys("v"))):
+			m.startBox()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("L"))):
+			if !m.boxing {
+				m.startLasso()
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("U"))):
+			if !m.readOnly && !m.projecting && m.database != nil {
+				m.confirmProject = true
+				m.projectCancelled = false
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("C"))):
+			if !m.readOnly && !m.projecting && !m.clustering && m.database != nil {
+				return m, m.openClusterDialog()
+			}
+		case m.snap && !m.boxing && key.Matches(msg, key.NewBinding(key.WithKeys("up", "k", "down", "j", "left", "h", "right", "l"))):
+			switch msg.String() {
+			case "up", "k":
+				m.snapStep(0, -1)
+			case "down", "j":
+				m.snapStep(0, 1)
+			case "left", "h":
+				m.snapStep(-1, 0)
+			case "right", "l":
+				m.snapStep(1, 0)
+			}
 		case key.Matches(msg, key.NewBinding(key.WithKeys("up", "k"))):
 			m.cursorY--
 			if m.cursorY < 0 {
@@ -153,7 +412,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			m.updateSelection()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("down", "j"))):
-			plotHeight := m.height - 8
+			_, plotHeight := m.plotSize()
 			m.cursorY++
 			if m.cursorY >= plotHeight {
 				m.cursorY = plotHeight - 1
@@ -166,26 +425,16 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			}
 			m.updateSelection()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("right", "l"))):
-			plotWidth := m.width/2 - 4
+			plotWidth, _ := m.plotSize()
 			m.cursorX++
 			if m.cursorX >= plotWidth {
 				m.cursorX = plotWidth - 1
 			}
 			m.updateSelection()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("+", "="))):
-			m.zoom *= 1.2
-			if m.zoom > 5.0 {
-				m.zoom = 5.0
-			}
-			m.computeScreenPositions()
-			m.updateSelection()
+			m.zoomAtCursor(m.zoom * 1.2)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("-", "_"))):
-			m.zoom /= 1.2
-			if m.zoom < 0.2 {
-				m.zoom = 0.2
-			}
-			m.computeScreenPositions()
-			m.updateSelection()
+			m.zoomAtCursor(m.zoom / 1.2)
 		case key.Matches(msg, key.NewBinding(key.WithKeys("r"))):
 			m.zoom = 1.0
 			m.offsetX = 0
@@ -219,32 +468,228 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 				}
 			}
 		case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
-			// Toggle color mode between story_type and cluster
-			if m.colorMode == ColorByStoryType {
-				m.colorMode = ColorByCluster
-			} else {
-				m.colorMode = ColorByStoryType
+			m.colorMode = (m.colorMode + 1) % colorModes
+		case key.Matches(msg, key.NewBinding(key.WithKeys("i"))):
+			m.toggleIsolation()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("I"))):
+			// Hidden clusters give no context; dimmed ones show where the
+			// isolated cluster sits among them
+			if m.isolated {
+				m.dimOthers = !m.dimOthers
+				m.computeScreenPositions()
+				m.updateSelection()
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("o"))):
+			m.overlay = (m.overlay + 1) % 3
+		case key.Matches(msg, key.NewBinding(key.WithKeys("E"))):
+			if m.canExport && len(m.points) > 0 {
+				return m, m.startExport()
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("s"))):
+			m.toggleSpread()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
+			m.toggleSnap()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("f"))):
+			m.cycleLayout()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
+			m.sizeByLength = !m.sizeByLength
+		case m.graphics && key.Matches(msg, key.NewBinding(key.WithKeys("g"))):
+			m.charGrid = !m.charGrid
+		case key.Matches(msg, key.NewBinding(key.WithKeys("tab"))):
+			m.tourStep(1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("shift+tab"))):
+			m.tourStep(-1)
+		case key.Matches(msg, key.NewBinding(key.WithKeys("m"))):
+			// Braille dots separate nearby points; blocks are the fallback
+			// for fonts without braille glyphs
+			m.blocks = !m.blocks
 		}
+
+	case tea.MouseMsg:
+		m.handleMouse(msg)
 	}
 
 	return m, nil
 }
 
-func (m *Model) computeBounds() {
+// toggleIsolation isolates the selected point's cluster, or returns to the
+// whole map. Either way the bounds are re-fitted and zoom and pan reset.
+func (m *Model) toggleIsolation() {
+	if m.isolated {
+		m.isolated = false
+	} else {
+		if m.selected == nil {
+			return
+		}
+		m.isolated = true
+		m.isolatedCluster = m.selected.ClusterID
+	}
+	selectedID := m.selectedID
+	m.zoom = 1.0
+	m.offsetX = 0
+	m.offsetY = 0
+	m.computeBounds()
+	m.computeScreenPositions()
+	m.moveCursorTo(selectedID)
+}
+
+// inIsolation reports whether a point is shown in full: always, unless
+// another cluster is isolated
+func (m Model) inIsolation(p *db.UmapPoint) bool {
+	if !m.isolated {
+		return true
+	}
+	if p.ClusterID == nil || m.isolatedCluster == nil {
+		return p.ClusterID == nil && m.isolatedCluster == nil
+	}
+	return *p.ClusterID == *m.isolatedCluster
+}
+
+// moveCursorTo keeps a story selected across a re-fit by moving the cursor
+// to wherever it is now plotted
+func (m *Model) moveCursorTo(id string) {
+	for _, pp := range m.plottedPoints {
+		if pp.Point.ID == id {
+			m.cursorX, m.cursorY = pp.ScreenX, pp.ScreenY
+			break
+		}
+	}
+	m.updateSelection()
+	for i, p := range m.pointsAtCursor {
+		if p.ID == id {
+			m.overlapIndex = i
+			m.selected = p
+			m.selectedID = id
+		}
+	}
+}
+
+// setZoom clamps and applies a zoom level
+func (m *Model) setZoom(zoom float64) {
+	m.zoom = math.Max(0.2, math.Min(5.0, zoom))
+}
+
+// plotOrigin is the screen cell of the plot's top-left point, relative to
+// the view: below the header and blank line, inside the plot border
+func (m Model) plotOrigin() (x, y int) {
+	return 1, lipgloss.Height(m.renderHeader()) + 2
+}
+
+// handleMouse clicks to select, drags to pan, and wheels to zoom around
+// the pointer. Coordinates are relative to the view.
+func (m *Model) handleMouse(msg tea.MouseMsg) {
 	if len(m.points) == 0 {
 		return
 	}
+	originX, originY := m.plotOrigin()
+	x, y := msg.X-originX, msg.Y-originY
+	plotWidth, plotHeight := m.plotSize()
+	inside := x >= 0 && x < plotWidth && y >= 0 && y < plotHeight
+
+	switch {
+	case m.lassoing && msg.Button == tea.MouseButtonLeft && msg.Action != tea.MouseActionRelease && inside:
+		// Dragging traces the lasso rather than panning
+		m.cursorX, m.cursorY = x, y
+		m.updateSelection()
+
+	case msg.Button == tea.MouseButtonWheelUp && inside:
+		m.zoomAt(x, y, m.zoom*1.2)
+
+	case msg.Button == tea.MouseButtonWheelDown && inside:
+		m.zoomAt(x, y, m.zoom/1.2)
+
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress && inside:
+		m.dragging, m.dragged = true, false
+		m.dragStartX, m.dragStartY = msg.X, msg.Y
+		m.dragOffsetX, m.dragOffsetY = m.offsetX, m.offsetY
+
+	case msg.Action == tea.MouseActionMotion && m.dragging:
+		dx, dy := msg.X-m.dragStartX, msg.Y-m.dragStartY
+		if dx == 0 && dy == 0 {
+			return
+		}
+		// Move the data with the pointer, one cell per cell
+		_, _, rangeX, rangeY := m.viewport()
+		m.dragged = true
+		m.offsetX = m.dragOffsetX - float64(dx)*rangeX/float64(plotWidth)
+		m.offsetY = m.dragOffsetY + float64(dy)*rangeY/float64(plotHeight)
+		m.computeScreenPositions()
+		m.updateSelection()
+
+	case msg.Action == tea.MouseActionRelease && m.dragging:
+		m.dragging = false
+		if m.dragged || !inside {
+			return
+		}
+		if x == m.cursorX && y == m.cursorY && len(m.pointsAtCursor) > 1 {
+			// Clicking the selected cell again cycles its overlapping points
+			m.overlapIndex = (m.overlapIndex + 1) % len(m.pointsAtCursor)
+			m.selected = m.pointsAtCursor[m.overlapIndex]
+			m.selectedID = m.selected.ID
+			return
+		}
+		m.cursorX, m.cursorY = x, y
+		m.updateSelection()
+	}
+}
+
+// zoomAt zooms so the data under plot cell (x, y) stays under it
+func (m *Model) zoomAt(x, y int, zoom float64) {
+	plotWidth, plotHeight := m.plotSize()
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
+	fx := (float64(x) + 0.5) / float64(plotWidth)
+	fy := (float64(y) + 0.5) / float64(plotHeight)
+	dataX := viewMinX + fx*rangeX
+	dataY := viewMaxY - fy*rangeY
+
+	m.setZoom(zoom)
+	_, _, rangeX, rangeY = m.viewport()
+	m.offsetX = dataX - fx*rangeX + rangeX/2 - (m.minX+m.maxX)/2
+	m.offsetY = dataY + fy*rangeY - rangeY/2 - (m.minY+m.maxY)/2
+	m.computeScreenPositions()
+	m.updateSelection()
+}
+
+// zoomAtCursor zooms toward the data under the cursor. A selected story
+// stays selected, the cursor following it if it drifts out of its cell.
+func (m *Model) zoomAtCursor(zoom float64) {
+	id := m.selectedID
+	m.zoomAt(m.cursorX, m.cursorY, zoom)
+	if id != "" && m.selectedID != id {
+		m.moveCursorTo(id)
+	}
+}
+
+// viewport is the visible data range for the current zoom and pan
+func (m Model) viewport() (viewMinX, viewMaxY, rangeX, rangeY float64) {
+	rangeX = (m.maxX - m.minX) / m.zoom
+	rangeY = (m.maxY - m.minY) / m.zoom
+	centerX := (m.minX+m.maxX)/2 + m.offsetX
+	centerY := (m.minY+m.maxY)/2 + m.offsetY
+	return centerX - rangeX/2, centerY + rangeY/2, rangeX, rangeY
+}
+
+func (m *Model) computeBounds() {
+	// Fit the isolated cluster, if it still has any points
+	var xs, ys []float64
+	for i := range m.points {
+		if p := &m.points[i]; m.inIsolation(p) {
+			xs = append(xs, p.X)
+			ys = append(ys, p.Y)
+		}
+	}
+	if len(xs) == 0 && m.isolated {
+		m.isolated = false
+		m.computeBounds()
+		return
+	}
+	if len(xs) == 0 {
+		return
+	}
 
 	// Use percentile-based bounds to handle outliers gracefully
 	// Sort X and Y values separately
-	n := len(m.points)
-	xs := make([]float64, n)
-	ys := make([]float64, n)
-	for i, p := range m.points {
-		xs[i] = p.X
-		ys[i] = p.Y
-	}
+	n := len(xs)
 
 	sort.Float64s(xs)
 	sort.Float64s(ys)
@@ -268,6 +713,14 @@ func (m *Model) computeBounds() {
 		m.minY, m.maxY = ys[0], ys[n-1]
 	}
 
+	// A lone point still needs an area around it
+	if m.maxX == m.minX {
+		m.minX, m.maxX = m.minX-0.5, m.maxX+0.5
+	}
+	if m.maxY == m.minY {
+		m.minY, m.maxY = m.minY-0.5, m.maxY+0.5
+	}
+
 	// Add padding
 	rangeX := m.maxX - m.minX
 	rangeY := m.maxY - m.minY
@@ -280,8 +733,7 @@ func (m *Model) computeBounds() {
 // computeScreenPositions converts all data points to integer screen coordinates once.
 // This is the single source of truth for where points appear on screen.
 func (m *Model) computeScreenPositions() {
-	plotWidth := m.width/2 - 4
-	plotHeight := m.height - 8
+	plotWidth, plotHeight := m.plotSize()
 
 	// Store dimensions to detect resize
 	m.lastPlotWidth = plotWidth
@@ -289,36 +741,45 @@ func (m *Model) computeScreenPositions() {
 
 	if plotWidth <= 0 || plotHeight <= 0 || len(m.points) == 0 {
 		m.plottedPoints = nil
+		m.cells = cellIndex{}
 		return
 	}
 
-	// Compute visible range based on zoom
-	rangeX := (m.maxX - m.minX) / m.zoom
-	rangeY := (m.maxY - m.minY) / m.zoom
-	centerX := (m.minX + m.maxX) / 2
-	centerY := (m.minY + m.maxY) / 2
-	viewMinX := centerX - rangeX/2
-	viewMaxY := centerY + rangeY/2
+	// Compute visible range based on zoom and pan
+	viewMinX, viewMaxY, rangeX, rangeY := m.viewport()
 
 	// Pre-allocate slice
 	m.plottedPoints = make([]PlottedPoint, 0, len(m.points))
 
 	for i := range m.points {
 		p := &m.points[i]
+		if !m.dimOthers && !m.inIsolation(p) {
+			continue
+		}
 
-		// Convert data coords to integer screen coords (same formula as rendering)
-		screenX := int((p.X - viewMinX) / rangeX * float64(plotWidth))
-		screenY := int((viewMaxY - p.Y) / rangeY * float64(plotHeight)) // Flip Y
+		// Convert data coords to screen coords; the fraction left over
+		// picks the braille dot within the cell
+		fx := (p.X - viewMinX) / rangeX * float64(plotWidth)
+		fy := (viewMaxY - p.Y) / rangeY * float64(plotHeight) // Flip Y
+		screenX := int(fx)
+		screenY := int(fy)
 
 		// Only include points that are within the visible area
-		if screenX >= 0 && screenX < plotWidth && screenY >= 0 && screenY < plotHeight {
+		if fx >= 0 && screenX < plotWidth && fy >= 0 && screenY < plotHeight {
 			m.plottedPoints = append(m.plottedPoints, PlottedPoint{
 				Point:   p,
 				ScreenX: screenX,
 				ScreenY: screenY,
+				SubX:    min(1, int((fx-float64(screenX))*2)),
+				SubY:    min(3, int((fy-float64(screenY))*4)),
 			})
 		}
 	}
+
+	if m.spreading() {
+		m.spreadPoints(plotWidth, plotHeight)
+	}
+	m.cells = newCellIndex(m.plottedPoints, plotWidth, plotHeight)
 }
 
 // updateSelection finds all points at the cursor position using exact int matching.
@@ -335,11 +796,8 @@ func (m *Model) updateSelection() {
 	}
 
 	// Find all points at exact cursor position
-	for i := range m.plottedPoints {
-		pp := &m.plottedPoints[i]
-		if pp.ScreenX == m.cursorX && pp.ScreenY == m.cursorY {
-			m.pointsAtCursor = append(m.pointsAtCursor, pp.Point)
-		}
+	for _, i := range m.cells.at(m.cursorX, m.cursorY) {
+		m.pointsAtCursor = append(m.pointsAtCursor, m.plottedPoints[i].Point)
 	}
 
 	// Update selection based on what we found
@@ -363,12 +821,18 @@ func (m Model) View() string {
 	}
 
 	if len(m.points) == 0 {
-		return "  No UMAP coordinates available.\n  Run UMAP computation to generate visualization data."
+		empty := "  No UMAP coordinates available.\n  Run UMAP computation to generate visualization data."
+		if !m.readOnly {
+			empty = "  No UMAP coordinates available.\n  Press U to compute them (runs scripts/project_umap.py)."
+		}
+		if footer := m.projectionFooter(); footer != "" {
+			empty += "\n\n" + footer
+		}
+		return empty
 	}
 
 	// Layout: plot on left, legend + info on right
-	plotWidth := m.width/2 - 4
-	plotHeight := m.height - 8
+	plotWidth, plotHeight := m.plotSize()
 	infoWidth := m.width/2 - 4
 
 	if plotWidth < 20 || plotHeight < 10 {
@@ -378,33 +842,103 @@ func (m Model) View() string {
 	// Build the plot
 	plot := m.renderPlot(plotWidth, plotHeight)
 
-	// Build the info panel
-	info := m.renderInfoPanel(infoWidth, plotHeight)
-
-	// Combine horizontally
-	combined := lipgloss.JoinHorizontal(lipgloss.Top, plot, "  ", info)
+	// Build the info panel, or the stories a box select caught
+	var info string
+	if m.layout() == LayoutSplit {
+		info = m.renderInfoPanel(infoWidth, plotHeight)
+	}
+	if m.showBoxResults {
+		info = m.renderBoxResults(infoWidth, plotHeight)
+	}
+	if m.showClusterDialog {
+		info = m.renderClusterDialog(infoWidth, plotHeight)
+	}
 
-	// Header
-	colorModeLabel := "by type"
-	if m.colorMode == ColorByCluster {
-		colorModeLabel = "by cluster"
+	// Combine horizontally, or leave the plot the whole width
+	var combined string
+	switch m.layout() {
+	case LayoutFull:
+		combined = plot
+	case LayoutStrip:
+		combined = lipgloss.JoinVertical(lipgloss.Left, plot, m.renderStrip(plotWidth))
+	default:
+		combined = lipgloss.JoinHorizontal(lipgloss.Top, plot, "  ", info)
 	}
-	header := styles.HeaderStyle.Width(m.width - 4).Render(
-		fmt.Sprintf("UMAP Visualization (%d stories) [colored %s]", len(m.points), colorModeLabel),
-	)
+
+	header := m.renderHeader()
 
 	// Footer
-	colorModeHint := "c: color by cluster"
-	if m.colorMode == ColorByCluster {
-		colorModeHint = "c: color by type"
+	colorModeHint := "c: color " + ((m.colorMode + 1) % colorModes).label()
+	markerHint := "m: blocks"
+	if m.blocks {
+		markerHint = "m: braille"
+	}
+	markerHint += " • w: size by length"
+	if m.sizeByLength {
+		markerHint = "w: same size"
+	}
+	if m.graphics && !m.reduced {
+		imageHint := " • g: characters"
+		if m.charGrid {
+			imageHint = " • g: image"
+		}
+		markerHint += imageHint
+	}
+	overlayHint := []string{"o: cluster labels", "o: cluster outlines", "o: hide clusters"}[m.overlay]
+	spreadHint := "s: spread"
+	if m.spread {
+		spreadHint = "s: unspread"
+	}
+	moveHint := "←↑↓→/click: move • n: snap to points"
+	if m.snap {
+		moveHint = "←↑↓→: next point • click: move • n: move by cell"
+	}
+	layoutHint := "f: " + ((m.plotLayout + 1) % layouts).label()
+	isolateHint := "i: isolate cluster"
+	if m.isolated {
+		isolateHint = "i: show all • I: hide/dim others"
+	}
+	projectHint := " • U: reproject • C: recluster"
+	if m.readOnly {
+		projectHint = ""
+	}
+	if m.canExport {
+		projectHint += " • E: export image"
 	}
 	footer := styles.DimStyle.Render(
-		fmt.Sprintf("  ←↑↓→: move • +/-: zoom • r: reset • [/]: cycle overlap • %s • enter: view", colorModeHint),
+		fmt.Sprintf("  %s • +/-/wheel: zoom • drag: pan • r: reset • [/]: cycle overlap • %s • %s • %s • %s • %s • %s • tab: next cluster • v: box select • L: lasso%s • enter: view", moveHint, spreadHint, colorModeHint, markerHint, overlayHint, layoutHint, isolateHint, projectHint),
 	)
+	if m.boxing {
+		footer = styles.DimStyle.Render(fmt.Sprintf(
+			"  Box select: ←↑↓→ grow • +/-: zoom • enter: list %d stories • esc: cancel", len(m.boxedPoints())))
+	}
+	if m.lassoing {
+		footer = styles.DimStyle.Render(fmt.Sprintf(
+			"  Lasso: ←↑↓→ or drag to trace • backspace: undo • +/-: zoom • enter: list %d stories • esc: cancel", len(m.lassoedPoints())))
+	}
+	if projection := m.projectionFooter(); projection != "" {
+		footer = projection
+	}
+	if m.exportNaming {
+		footer = m.exportFooter()
+	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, header, "", combined, "", footer)
 }
 
+func (m Model) renderHeader() string {
+	isolation := ""
+	if m.isolated {
+		isolation = " [isolated: noise]"
+		if m.isolatedCluster != nil {
+			isolation = fmt.Sprintf(" [isolated: cluster %d]", *m.isolatedCluster)
+		}
+	}
+	return styles.HeaderStyle.Width(m.width - 4).Render(
+		fmt.Sprintf("UMAP Visualization (%d stories) [colored %s]%s", len(m.points), m.colorMode.label(), isolation),
+	)
+}
+
 func (m Model) renderPlot(width, height int) string {
 	// Create empty grid
 	grid := make([][]rune, height)
@@ -418,29 +952,65 @@ func (m Model) renderPlot(width, height int) string {
 		}
 	}
 
-	// Plot points using pre-computed screen coordinates (single source of truth)
-	for _, pp := range m.plottedPoints {
-		x := pp.ScreenX
-		y := pp.ScreenY
+	// Sizes need glyphs, which braille dots can't vary
+	blocks := m.blocks || m.reduced || m.sizeByLength
 
-		if x >= 0 && x < width && y >= 0 && y < height {
-			if grid[y][x] == ' ' {
-				grid[y][x] = '●'
-			} else if grid[y][x] == '●' {
+	// Plot points cell by cell from the index, so a glyph cell costs the
+	// same however many points are stacked in it. Drawn as an image, they
+	// leave the grid blank.
+	image := m.drawsImage()
+	for y := 0; y < height && !image; y++ {
+		for x := 0; x < width; x++ {
+			stacked := m.cells.at(x, y)
+			if len(stacked) == 0 {
+				continue
+			}
+			first := m.plottedPoints[stacked[0]].Point
+			switch {
+			case !blocks:
+				grid[y][x] = 0x2800
+				for _, i := range stacked {
+					pp := m.plottedPoints[i]
+					grid[y][x] |= brailleDots[pp.SubY][pp.SubX]
+				}
+			case len(stacked) == 1:
+				grid[y][x] = m.singleGlyph(first)
+			case len(stacked) == 2:
 				grid[y][x] = '◉' // Overlap (2 points)
-			} else {
+			default:
 				grid[y][x] = '◆' // Cluster (3+ points)
 			}
-			pointRefs[y][x] = pp.Point
+			// A cell's color comes from the isolated cluster if any of
+			// its points are in it, else the last plotted
+			pointRefs[y][x] = first
+			for j := len(stacked) - 1; j >= 0; j-- {
+				if p := m.plottedPoints[stacked[j]].Point; m.inIsolation(p) {
+					pointRefs[y][x] = p
+					break
+				}
+			}
 		}
 	}
 
-	// Mark cursor position
+	marks := m.renderOverlay(grid, width, height)
+
+	// The box or lasso being drawn is shaded
+	lasso := m.lassoMask(width, height)
+	selecting := func(x, y int) bool {
+		return m.inBox(x, y) || (lasso != nil && lasso[y][x])
+	}
+
+	// The mini-map covers the plot's top-right corner
+	inset := m.renderMiniMap(grid, width, height)
+
+	// Mark cursor position. Braille keeps its dots under the cursor so
+	// they stay readable.
 	if m.cursorY >= 0 && m.cursorY < height && m.cursorX >= 0 && m.cursorX < width {
-		if m.selected != nil {
-			grid[m.cursorY][m.cursorX] = '█'
-		} else {
+		switch {
+		case m.selected == nil:
 			grid[m.cursorY][m.cursorX] = '+'
+		case blocks && !image:
+			grid[m.cursorY][m.cursorX] = '█'
 		}
 	}
 
@@ -455,15 +1025,26 @@ func (m Model) renderPlot(width, height int) string {
 					Foreground(lipgloss.Color("#FFFFFF")).
 					Background(lipgloss.Color("#FF6B6B")).
 					Render(ch))
+			} else if m.reduced {
+				// The box is the one color kept, as it can't be seen
+				// without it
+				if selecting(x, y) {
+					ch = boxStyle.Render(ch)
+				}
+				b.WriteString(ch)
+			} else if inset[y][x] != insetNone {
+				b.WriteString(insetStyles[inset[y][x]].Render(ch))
+			} else if marks[y][x] != nil {
+				b.WriteString(marks[y][x].Render(ch))
 			} else if pointRefs[y][x] != nil {
 				// Color based on current mode
-				var color lipgloss.Color
-				if m.colorMode == ColorByCluster {
-					color = styles.GetClusterColor(pointRefs[y][x].ClusterID)
-				} else {
-					color = styles.GetTypeColor(pointRefs[y][x].StoryType)
+				style := lipgloss.NewStyle().Foreground(m.pointColor(pointRefs[y][x]))
+				if selecting(x, y) {
+					style = style.Background(styles.BgLight)
 				}
-				b.WriteString(lipgloss.NewStyle().Foreground(color).Render(ch))
+				b.WriteString(style.Render(ch))
+			} else if selecting(x, y) {
+				b.WriteString(boxStyle.Render(ch))
 			} else {
 				b.WriteString(ch)
 			}
@@ -479,72 +1060,151 @@ func (m Model) renderPlot(width, height int) string {
 		Render(b.String())
 }
 
-func (m Model) renderInfoPanel(width, height int) string {
-	var b strings.Builder
+// Mini-map cell kinds
+const (
+	insetNone = iota
+	insetEmpty
+	insetPoint
+	insetViewport
+)
 
-	// Legend - different based on color mode
-	if m.colorMode == ColorByCluster {
-		b.WriteString(styles.BoldStyle.Render("Legend (Clusters)"))
-		b.WriteString("\n\n")
+var insetStyles = map[int]lipgloss.Style{
+	insetEmpty:    lipgloss.NewStyle().Background(styles.BgMedium),
+	insetPoint:    lipgloss.NewStyle().Background(styles.BgMedium).Foreground(styles.TextSecondary),
+	insetViewport: lipgloss.NewStyle().Background(styles.BgMedium).Foreground(styles.Accent),
+}
 
-		// Count stories by cluster
-		clusterCounts := make(map[int]int)
-		noiseCount := 0
-		for _, p := range m.points {
-			if p.ClusterID != nil {
-				clusterCounts[*p.ClusterID]++
-			} else {
-				noiseCount++
-			}
-		}
+// miniMapSize is the mini-map's size in cells, in the plot's top-right
+// corner; zero when it isn't shown
+func (m Model) miniMapSize(width, height int) (int, int) {
+	mw, mh := min(24, width/3), min(8, height/3)
+	if m.zoom <= 1 && m.offsetX == 0 && m.offsetY == 0 || mw < 8 || mh < 3 {
+		return 0, 0
+	}
+	return mw, mh
+}
 
-		// Show clusters in order
-		clusterIDs := make([]int, 0, len(clusterCounts))
-		for id := range clusterCounts {
-			clusterIDs = append(clusterIDs, id)
-		}
-		sort.Ints(clusterIDs)
+// renderMiniMap draws the whole map, with a rectangle around the part on
+// screen, into the top-right corner of grid. It is only drawn once zoomed
+// or panned away from the full view. The returned kinds say how to color
+// each cell.
+func (m Model) renderMiniMap(grid [][]rune, width, height int) [][]int {
+	inset := make([][]int, height)
+	for y := range inset {
+		inset[y] = make([]int, width)
+	}
 
-		for _, id := range clusterIDs {
-			count := clusterCounts[id]
-			color := styles.GetClusterColor(&id)
-			marker := lipgloss.NewStyle().Foreground(color).Render("●")
-			b.WriteString(fmt.Sprintf("%s cluster %-3d %3d\n", marker, id, count))
-		}
+	mw, mh := m.miniMapSize(width, height)
+	if mw == 0 {
+		return inset
+	}
+	left := width - mw
+	rangeX, rangeY := m.maxX-m.minX, m.maxY-m.minY
 
-		if noiseCount > 0 {
-			color := styles.GetClusterColor(nil)
-			marker := lipgloss.NewStyle().Foreground(color).Render("●")
-			b.WriteString(fmt.Sprintf("%s noise       %3d\n", marker, noiseCount))
-		}
-	} else {
-		b.WriteString(styles.BoldStyle.Render("Legend (Types)"))
-		b.WriteString("\n\n")
+	// Cells outside [0, n) are off the map
+	toCol := func(x float64) int { return int(math.Floor((x - m.minX) / rangeX * float64(mw))) }
+	toRow := func(y float64) int { return int(math.Floor((m.maxY - y) / rangeY * float64(mh))) }
 
-		// Count stories by type
-		typeCounts := make(map[string]int)
-		for _, p := range m.points {
-			typeCounts[p.StoryType]++
+	counts := make([][]int, mh)
+	for y := range counts {
+		counts[y] = make([]int, mw)
+	}
+	for i := range m.points {
+		p := &m.points[i]
+		if !m.dimOthers && !m.inIsolation(p) {
+			continue
 		}
-
-		for _, t := range db.StoryTypes {
-			count := typeCounts[t]
-			if count > 0 {
-				color := styles.GetTypeColor(t)
-				marker := lipgloss.NewStyle().Foreground(color).Render("●")
-				b.WriteString(fmt.Sprintf("%s %-15s %3d\n", marker, t, count))
+		if c, r := toCol(p.X), toRow(p.Y); c >= 0 && c < mw && r >= 0 && r < mh {
+			counts[r][c]++
+		}
+	}
+	for r := 0; r < mh; r++ {
+		for c := 0; c < mw; c++ {
+			kind, ch := insetEmpty, ' '
+			switch n := counts[r][c]; {
+			case n >= 3:
+				kind, ch = insetPoint, '•'
+			case n > 0:
+				kind, ch = insetPoint, '·'
 			}
+			grid[r][left+c], inset[r][left+c] = ch, kind
 		}
 	}
 
+	// The viewport, clamped to the mini-map so it stays visible when
+	// panned off the edge
+	viewMinX, viewMaxY, viewW, viewH := m.viewport()
+	clamp := func(v, n int) int { return max(0, min(n-1, v)) }
+	x0, x1 := clamp(toCol(viewMinX), mw), clamp(toCol(viewMinX+viewW), mw)
+	y0, y1 := clamp(toRow(viewMaxY), mh), clamp(toRow(viewMaxY-viewH), mh)
+	set := func(c, r int, ch rune) {
+		grid[r][left+c], inset[r][left+c] = ch, insetViewport
+	}
+	if x0 == x1 || y0 == y1 {
+		// Too small for a rectangle
+		set(x0, y0, '▪')
+		return inset
+	}
+	for c := x0 + 1; c < x1; c++ {
+		set(c, y0, '─')
+		set(c, y1, '─')
+	}
+	for r := y0 + 1; r < y1; r++ {
+		set(x0, r, '│')
+		set(x1, r, '│')
+	}
+	set(x0, y0, '┌')
+	set(x1, y0, '┐')
+	set(x0, y1, '└')
+	set(x1, y1, '┘')
+	return inset
+}
+
+func (m Model) renderInfoPanel(width, height int) string {
+	var b strings.Builder
+
+	// Legend - different based on color mode
+	title, entries := m.legend()
+	b.WriteString(styles.BoldStyle.Render(title))
+	b.WriteString("\n\n")
+	labelWidth := 15
+	if m.colorMode == ColorByCluster {
+		labelWidth = 11
+	}
+	for _, e := range entries {
+		marker := lipgloss.NewStyle().Foreground(e.color).Render("●")
+		b.WriteString(fmt.Sprintf("%s %-*s %3d\n", marker, labelWidth, e.label, e.count))
+	}
+
 	b.WriteString("\n")
 	b.WriteString(styles.BoldStyle.Render("Symbols"))
 	b.WriteString("\n")
-	b.WriteString("● single   ◉ overlap   ◆ cluster\n")
+	switch {
+	case m.sizeByLength:
+		b.WriteString(m.lengthLegend() + "\n")
+		b.WriteString("◉ overlap   ◆ cluster\n")
+	case m.blocks:
+		b.WriteString("● single   ◉ overlap   ◆ cluster\n")
+	default:
+		b.WriteString("⣿ braille: up to 8 dots per cell\n")
+	}
 
 	// Zoom info
 	b.WriteString("\n")
 	b.WriteString(fmt.Sprintf("Zoom: %.1fx\n", m.zoom))
+	switch {
+	case m.spreading():
+		b.WriteString(styles.DimStyle.Render("Spread: stacked stories moved apart"))
+		b.WriteString("\n")
+	case m.spread:
+		b.WriteString(styles.DimStyle.Render(fmt.Sprintf("Spread from %.1fx", spreadZoom)))
+		b.WriteString("\n")
+	}
+
+	if tour := m.renderTour(); tour != "" {
+		b.WriteString("\n")
+		b.WriteString(tour)
+	}
 
 	// Selected story info
 	if m.selected != nil {
@@ -586,6 +1246,11 @@ func (m Model) renderInfoPanel(width, height int) string {
 		Render(b.String())
 }
 
+// Overlapping returns how many stories share the cursor's cell
+func (m Model) Overlapping() int {
+	return len(m.pointsAtCursor)
+}
+
 // SelectedStoryID returns the ID of the selected story
 func (m Model) SelectedStoryID() string {
 	return m.selectedID
@@ -7,9 +7,15 @@ from config import settings
 
 
 @contextmanager
-def get_db_connection():
-    """Get a database connection with RealDictCursor."""
-    conn = psycopg2.connect(settings.database_url)
+def get_db_connection(read_only: bool = False):
+    """
+    Get a database connection with RealDictCursor.
+
+    read_only connections go to the read replica when DATABASE_READ_URL is
+    set. The replica may lag, so use it only for analytics reads.
+    """
+    url = settings.database_read_url if read_only and settings.database_read_url else settings.database_url
+    conn = psycopg2.connect(url)
     try:
         yield conn
     finally:
@@ -17,9 +23,9 @@ def get_db_connection():
 
 
 @contextmanager
-def get_db_cursor():
+def get_db_cursor(read_only: bool = False):
     """Get a database cursor that returns dicts."""
-    with get_db_connection() as conn:
+    with get_db_connection(read_only) as conn:
         cursor = conn.cursor(cursor_factory=RealDictCursor)
         try:
             yield cursor
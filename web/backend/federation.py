@@ -0,0 +1,123 @@
+"""Federated search across peer tracker instances."""
+
+import asyncio
+import time
+from typing import Awaitable, Callable, Optional
+
+import httpx
+
+from config import settings
+from database import get_db_cursor
+from models import (
+    FederationPeer, FederatedResult, SearchRequest, SearchResult, SourceStatus,
+)
+
+
+def list_peers(enabled_only: bool = False) -> list[FederationPeer]:
+    """Get registered peers, in name order."""
+    with get_db_cursor() as cur:
+        cur.execute("""
+            SELECT name, base_url, enabled
+            FROM federation_peers
+            WHERE enabled OR NOT %s
+            ORDER BY name
+        """, (enabled_only,))
+        return [FederationPeer(**row) for row in cur.fetchall()]
+
+
+def save_peer(peer: FederationPeer) -> None:
+    """Register a peer, or update the one with the same name."""
+    with get_db_cursor() as cur:
+        cur.execute("""
+            INSERT INTO federation_peers (name, base_url, enabled)
+            VALUES (%s, %s, %s)
+            ON CONFLICT (name) DO UPDATE
+            SET base_url = EXCLUDED.base_url, enabled = EXCLUDED.enabled
+        """, (peer.name, peer.base_url.rstrip("/"), peer.enabled))
+
+
+def delete_peer(name: str) -> bool:
+    """Remove a peer. Returns False if there was none by that name."""
+    with get_db_cursor() as cur:
+        cur.execute("DELETE FROM federation_peers WHERE name = %s", (name,))
+        return cur.rowcount > 0
+
+
+async def _query_peer(
+    client: httpx.AsyncClient, peer: FederationPeer, request: SearchRequest
+) -> list[SearchResult]:
+    """Run a search on a peer's plain /api/search, so peers never fan out again."""
+    response = await client.post(
+        f"{peer.base_url}/api/search",
+        json=request.model_dump(),
+        timeout=settings.federation_timeout,
+    )
+    response.raise_for_status()
+    return [SearchResult(**item) for item in response.json()]
+
+
+def _attribute(
+    results: list[SearchResult], source: str, source_url: Optional[str]
+) -> list[FederatedResult]:
+    """
+    Tag results with their source and rescale scores to 0-1.
+
+    Each instance ranks against its own corpus, so raw scores aren't
+    comparable across instances; dividing by the source's best score keeps
+    each source's order while letting the merge interleave them.
+    """
+    best = max((r.score or 0 for r in results), default=0) or 1
+    return [
+        FederatedResult(
+            **r.model_dump(exclude={"score"}),
+            score=(r.score or 0) / best,
+            source=source,
+            source_url=source_url,
+            source_score=r.score,
+        )
+        for r in results
+    ]
+
+
+async def federated_search(
+    request: SearchRequest,
+    local_search: Callable[[SearchRequest], Awaitable[list[SearchResult]]],
+) -> tuple[list[FederatedResult], list[SourceStatus]]:
+    """
+    Search this instance and every enabled peer concurrently, then merge.
+
+    A peer that fails or times out is reported in the statuses rather than
+    failing the whole search.
+    """
+    peers = list_peers(enabled_only=True)
+
+    async def timed(name: str, search: Awaitable[list[SearchResult]]):
+        start = time.monotonic()
+        try:
+            results = await search
+            error = None
+        except Exception as e:
+            results, error = [], str(e) or type(e).__name__
+        elapsed = int((time.monotonic() - start) * 1000)
+        return results, SourceStatus(
+            name=name, ok=error is None, count=len(results), elapsed_ms=elapsed, error=error,
+        )
+
+    # Peers go first so their requests are in flight while the local
+    # search, which blocks on the database, runs
+    async with httpx.AsyncClient() as client:
+        *remote, local = await asyncio.gather(
+            *(timed(p.name, _query_peer(client, p, request)) for p in peers),
+            timed(settings.federation_name, local_search(request)),
+        )
+    answers = [local] + remote
+
+    merged: list[FederatedResult] = []
+    statuses: list[SourceStatus] = []
+    urls = [None] + [p.base_url for p in peers]
+    for (results, status), url in zip(answers, urls):
+        merged.extend(_attribute(results, status.name, url))
+        statuses.append(status)
+
+    merged.sort(key=lambda r: r.score or 0, reverse=True)
+    return merged[:request.limit], statuses
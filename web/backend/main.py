@@ -3,10 +3,11 @@
 from contextlib import asynccontextmanager
 from datetime import date
 from typing import Optional
+import hmac
 import time
 import httpx
 
-from fastapi import FastAPI, HTTPException, Query
+from fastapi import Depends, FastAPI, Header, HTTPException, Query
 from fastapi.middleware.cors import CORSMiddleware
 
 from config import settings
@@ -14,9 +15,13 @@ from database import get_db_cursor
 from models import (
     StoryListItem, StoryDetail, SearchRequest, SearchResult,
     MapStory, VectorSpacePoint, StatsResponse,
+    FederationPeer, FederatedSearchResponse,
+    SubmissionRequest, SubmissionStatus,
     FRAMEWORK_CATEGORIES, get_frameworks_for_type
 )
 from geocoding import geocode_location
+import federation
+import submissions
 
 
 @asynccontextmanager
@@ -25,7 +30,7 @@ async def lifespan(app: FastAPI):
     # Verify database connection on startup
     try:
         with get_db_cursor() as cur:
-            cur.execute("SELECT COUNT(*) FROM stories")
+            cur.execute("SELECT COUNT(*) FROM stories WHERE deleted_at IS NULL")
             count = cur.fetchone()["count"]
             print(f"Connected to database. Found {count} stories.")
     except Exception as e:
@@ -108,28 +113,28 @@ async def health():
 @app.get("/api/stats", response_model=StatsResponse)
 async def get_stats():
     """Get database statistics."""
-    with get_db_cursor() as cur:
+    with get_db_cursor(read_only=True) as cur:
         # Total stories
-        cur.execute("SELECT COUNT(*) FROM stories")
+        cur.execute("SELECT COUNT(*) FROM stories WHERE deleted_at IS NULL")
         total = cur.fetchone()["count"]
 
         # Stories with location
-        cur.execute("SELECT COUNT(*) FROM stories WHERE location IS NOT NULL AND location != ''")
+        cur.execute("SELECT COUNT(*) FROM stories WHERE deleted_at IS NULL AND location IS NOT NULL AND location != ''")
         with_location = cur.fetchone()["count"]
 
         # Stories with embedding
-        cur.execute("SELECT COUNT(*) FROM stories WHERE embedding IS NOT NULL")
+        cur.execute("SELECT COUNT(*) FROM stories WHERE deleted_at IS NULL AND embedding IS NOT NULL")
         with_embedding = cur.fetchone()["count"]
 
         # Stories with UMAP
-        cur.execute("SELECT COUNT(*) FROM stories WHERE umap_x IS NOT NULL AND umap_y IS NOT NULL")
+        cur.execute("SELECT COUNT(*) FROM stories WHERE deleted_at IS NULL AND umap_x IS NOT NULL AND umap_y IS NOT NULL")
         with_umap = cur.fetchone()["count"]
 
         # Story types count
         cur.execute("""
             SELECT story_type, COUNT(*) as count
             FROM stories
-            WHERE story_type IS NOT NULL
+            WHERE deleted_at IS NULL AND story_type IS NOT NULL
             GROUP BY story_type
             ORDER BY count DESC
         """)
@@ -185,7 +190,7 @@ async def list_stories(
                     s.umap_x, s.umap_y
                 FROM stories s
                 LEFT JOIN episodes e ON s.episode_id = e.id
-                WHERE s.story_type = ANY(%s)
+                WHERE s.deleted_at IS NULL AND s.story_type = ANY(%s)
                 ORDER BY e.air_date DESC NULLS LAST, s.created_at DESC
                 LIMIT %s OFFSET %s
             """, (type_filter, limit, offset))
@@ -197,6 +202,7 @@ async def list_stories(
                     s.umap_x, s.umap_y
                 FROM stories s
                 LEFT JOIN episodes e ON s.episode_id = e.id
+                WHERE s.deleted_at IS NULL
                 ORDER BY e.air_date DESC NULLS LAST, s.created_at DESC
                 LIMIT %s OFFSET %s
             """, (limit, offset))
@@ -237,7 +243,7 @@ async def get_story(story_id: str):
                 e.podcast_name, e.air_date
             FROM stories s
             LEFT JOIN episodes e ON s.episode_id = e.id
-            WHERE s.id = %s::uuid
+            WHERE s.deleted_at IS NULL AND s.id = %s::uuid
         """, (story_id,))
         row = cur.fetchone()
 
@@ -305,7 +311,7 @@ async def search_stories(request: SearchRequest):
                         s.umap_x, s.umap_y
                     FROM stories s
                     LEFT JOIN episodes e ON s.episode_id = e.id
-                    WHERE s.search_vector @@ plainto_tsquery('english', %s)
+                    WHERE s.deleted_at IS NULL AND s.search_vector @@ plainto_tsquery('english', %s)
                       AND s.story_type = ANY(%s)
                     ORDER BY rank DESC
                     LIMIT %s
@@ -320,7 +326,7 @@ async def search_stories(request: SearchRequest):
                         s.umap_x, s.umap_y
                     FROM stories s
                     LEFT JOIN episodes e ON s.episode_id = e.id
-                    WHERE s.search_vector @@ plainto_tsquery('english', %s)
+                    WHERE s.deleted_at IS NULL AND s.search_vector @@ plainto_tsquery('english', %s)
                     ORDER BY rank DESC
                     LIMIT %s
                 """, (request.query, request.query, request.limit))
@@ -356,7 +362,7 @@ async def search_stories(request: SearchRequest):
                         s.umap_x, s.umap_y
                     FROM stories s
                     LEFT JOIN episodes e ON s.episode_id = e.id
-                    WHERE s.embedding IS NOT NULL AND s.story_type = ANY(%s)
+                    WHERE s.deleted_at IS NULL AND s.embedding IS NOT NULL AND s.story_type = ANY(%s)
                     ORDER BY s.embedding <=> %s::vector
                     LIMIT %s
                 """, (query_embedding, type_filter, query_embedding, request.limit))
@@ -370,7 +376,7 @@ async def search_stories(request: SearchRequest):
                         s.umap_x, s.umap_y
                     FROM stories s
                     LEFT JOIN episodes e ON s.episode_id = e.id
-                    WHERE s.embedding IS NOT NULL
+                    WHERE s.deleted_at IS NULL AND s.embedding IS NOT NULL
                     ORDER BY s.embedding <=> %s::vector
                     LIMIT %s
                 """, (query_embedding, query_embedding, request.limit))
@@ -415,7 +421,7 @@ async def search_stories(request: SearchRequest):
                     s.umap_x, s.umap_y
                 FROM stories s
                 LEFT JOIN episodes e ON s.episode_id = e.id
-                WHERE s.search_vector @@ plainto_tsquery('english', %s)
+                WHERE s.deleted_at IS NULL AND s.search_vector @@ plainto_tsquery('english', %s)
                 {type_clause}
                 ORDER BY rank DESC
                 LIMIT %s
@@ -439,7 +445,7 @@ async def search_stories(request: SearchRequest):
                     s.umap_x, s.umap_y
                 FROM stories s
                 LEFT JOIN episodes e ON s.episode_id = e.id
-                WHERE s.embedding IS NOT NULL
+                WHERE s.deleted_at IS NULL AND s.embedding IS NOT NULL
                 {type_clause}
                 ORDER BY s.embedding <=> %s::vector
                 LIMIT %s
@@ -487,6 +493,64 @@ async def search_stories(request: SearchRequest):
     return results
 
 
+@app.post("/api/federated-search", response_model=FederatedSearchResponse)
+async def federated_search(request: SearchRequest):
+    """Search this instance and all enabled peers, merged with source attribution."""
+    results, sources = await federation.federated_search(request, search_stories)
+    return FederatedSearchResponse(results=results, sources=sources)
+
+
+def require_admin(token: Optional[str]):
+    """Reject peer changes unless FEDERATION_ADMIN_TOKEN is set and matches."""
+    if not settings.federation_admin_token:
+        raise HTTPException(status_code=403, detail="Peer registration is disabled")
+    if not hmac.compare_digest((token or "").encode(), settings.federation_admin_token.encode()):
+        raise HTTPException(status_code=401, detail="Invalid admin token")
+
+
+@app.get("/api/federation/peers", response_model=list[FederationPeer])
+async def get_peers():
+    """List registered peer instances."""
+    return federation.list_peers()
+
+
+@app.put("/api/federation/peers/{name}", response_model=FederationPeer)
+async def put_peer(name: str, peer: FederationPeer, x_admin_token: Optional[str] = Header(default=None)):
+    """Register or update a peer instance."""
+    require_admin(x_admin_token)
+    if peer.name != name:
+        raise HTTPException(status_code=400, detail="Peer name does not match the URL")
+    federation.save_peer(peer)
+    return peer
+
+
+@app.delete("/api/federation/peers/{name}")
+async def remove_peer(name: str, x_admin_token: Optional[str] = Header(default=None)):
+    """Unregister a peer instance."""
+    require_admin(x_admin_token)
+    if not federation.delete_peer(name):
+        raise HTTPException(status_code=404, detail="Peer not found")
+    return {"deleted": name}
+
+
+@app.post("/api/submissions", response_model=SubmissionStatus, status_code=201)
+async def submit_story(request: SubmissionRequest, submitter_id: int = Depends(submissions.authenticate)):
+    """Submit a first-hand account. It enters the corpus once approved in the TUI's Review view."""
+    return submissions.create(submitter_id, request)
+
+
+@app.get("/api/submissions", response_model=list[SubmissionStatus])
+async def list_submissions(submitter_id: int = Depends(submissions.authenticate)):
+    """List the caller's own submissions and their review status."""
+    return submissions.list_own(submitter_id)
+
+
+@app.get("/api/submissions/{submission_id}", response_model=SubmissionStatus)
+async def get_submission(submission_id: str, submitter_id: int = Depends(submissions.authenticate)):
+    """Get the review status of one of the caller's submissions."""
+    return submissions.get(submitter_id, submission_id)
+
+
 @app.get("/api/map/stories", response_model=list[MapStory])
 async def get_map_stories(
     story_type: Optional[str] = None,
@@ -506,35 +570,44 @@ async def get_map_stories(
     elif story_type:
         type_filter = [story_type]
 
-    with get_db_cursor() as cur:
+    with get_db_cursor(read_only=True) as cur:
         if type_filter:
             cur.execute("""
-                SELECT id::text, title, story_type, location
+                SELECT id::text, title, story_type, location,
+                       latitude, longitude, geo_cluster_id
                 FROM stories
-                WHERE location IS NOT NULL AND location != '' AND location != 'Unknown'
+                WHERE deleted_at IS NULL AND location IS NOT NULL AND location != '' AND location != 'Unknown'
                   AND story_type = ANY(%s)
             """, (type_filter,))
         else:
             cur.execute("""
-                SELECT id::text, title, story_type, location
+                SELECT id::text, title, story_type, location,
+                       latitude, longitude, geo_cluster_id
                 FROM stories
-                WHERE location IS NOT NULL AND location != '' AND location != 'Unknown'
+                WHERE deleted_at IS NULL AND location IS NOT NULL AND location != '' AND location != 'Unknown'
             """)
 
         rows = cur.fetchall()
 
     stories = []
     for row in rows:
-        geo = geocode_location(row["location"])
-        if geo:
-            stories.append(MapStory(
-                id=row["id"],
-                title=row["title"],
-                story_type=row["story_type"],
-                lat=geo.lat,
-                lng=geo.lng,
-                location=row["location"],
-            ))
+        # Prefer coordinates stored by geocode_stories.py
+        if row["latitude"] is not None and row["longitude"] is not None:
+            lat, lng = row["latitude"], row["longitude"]
+        else:
+            geo = geocode_location(row["location"])
+            if not geo:
+                continue
+            lat, lng = geo.lat, geo.lng
+        stories.append(MapStory(
+            id=row["id"],
+            title=row["title"],
+            story_type=row["story_type"],
+            lat=lat,
+            lng=lng,
+            location=row["location"],
+            geo_cluster_id=row["geo_cluster_id"],
+        ))
 
     return stories
 
@@ -577,19 +650,19 @@ async def get_vector_space_points(
         "other": "#95a5a6",
     }
 
-    with get_db_cursor() as cur:
+    with get_db_cursor(read_only=True) as cur:
         if type_filter:
             cur.execute("""
                 SELECT id::text, title, story_type, umap_x, umap_y, umap_z
                 FROM stories
-                WHERE umap_x IS NOT NULL AND umap_y IS NOT NULL
+                WHERE deleted_at IS NULL AND umap_x IS NOT NULL AND umap_y IS NOT NULL
                   AND story_type = ANY(%s)
             """, (type_filter,))
         else:
             cur.execute("""
                 SELECT id::text, title, story_type, umap_x, umap_y, umap_z
                 FROM stories
-                WHERE umap_x IS NOT NULL AND umap_y IS NOT NULL
+                WHERE deleted_at IS NULL AND umap_x IS NOT NULL AND umap_y IS NOT NULL
             """)
 
         rows = cur.fetchall()
@@ -619,11 +692,11 @@ async def get_frameworks():
 @app.get("/api/story-types")
 async def get_story_types():
     """Get all story types with counts."""
-    with get_db_cursor() as cur:
+    with get_db_cursor(read_only=True) as cur:
         cur.execute("""
             SELECT story_type, COUNT(*) as count
             FROM stories
-            WHERE story_type IS NOT NULL
+            WHERE deleted_at IS NULL AND story_type IS NOT NULL
             GROUP BY story_type
             ORDER BY count DESC
         """)
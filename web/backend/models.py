@@ -105,6 +105,56 @@ class SearchResult(StoryListItem):
     snippet: Optional[str] = None
 
 
+class FederationPeer(BaseModel):
+    """A tracker instance federated searches are sent to."""
+    name: str = Field(pattern="^[A-Za-z0-9_.-]+$", max_length=64)
+    base_url: str = Field(pattern="^https?://")
+    enabled: bool = True
+
+
+class FederatedResult(SearchResult):
+    """Search result attributed to the instance it came from."""
+    source: str
+    source_url: Optional[str] = None  # Base URL for fetching the full story
+    source_score: Optional[float] = None  # Score as reported by the source
+
+
+class SourceStatus(BaseModel):
+    """How one instance answered a federated search."""
+    name: str
+    ok: bool
+    count: int = 0
+    elapsed_ms: int = 0
+    error: Optional[str] = None
+
+
+class FederatedSearchResponse(BaseModel):
+    """Merged results from this instance and its peers."""
+    results: list[FederatedResult]
+    sources: list[SourceStatus]
+
+
+class SubmissionRequest(BaseModel):
+    """A community member's own first-hand account."""
+    title: str = Field(min_length=3, max_length=200)
+    content: str = Field(min_length=100, max_length=100_000)  # Stored verbatim
+    location: Optional[str] = Field(default=None, max_length=200)
+    time_period: Optional[str] = Field(default=None, max_length=100)
+    story_type: Optional[str] = None  # A suggestion; reviewers classify
+    first_hand: bool  # Submitter confirms this happened to them
+
+
+class SubmissionStatus(BaseModel):
+    """Where a submission stands in the review queue."""
+    id: str
+    title: str
+    status: str  # pending, approved, rejected
+    review_note: Optional[str] = None
+    story_id: Optional[str] = None
+    submitted_at: datetime
+    reviewed_at: Optional[datetime] = None
+
+
 class GeoLocation(BaseModel):
     """Geocoded location."""
     location: str
@@ -122,6 +172,7 @@ class MapStory(BaseModel):
     lat: float
     lng: float
     location: str
+    geo_cluster_id: Optional[int] = None  # Geographic hotspot, if any
 
 
 class VectorSpacePoint(BaseModel):
@@ -0,0 +1,80 @@
+"""Community story submissions, held for review in the TUI."""
+
+import hashlib
+import uuid
+from typing import Optional
+
+from fastapi import Header, HTTPException
+
+from database import get_db_cursor
+from models import SubmissionRequest, SubmissionStatus
+
+
+def authenticate(authorization: Optional[str] = Header(default=None)) -> int:
+    """
+    Resolve a bearer token to its submitter's ID.
+
+    Tokens are issued by `paranormal-tui submitters add`; only their
+    SHA-256 is stored, so they're compared by hash.
+    """
+    scheme, _, token = (authorization or "").partition(" ")
+    if scheme.lower() != "bearer" or not token:
+        raise HTTPException(status_code=401, detail="Bearer token required",
+                            headers={"WWW-Authenticate": "Bearer"})
+
+    digest = hashlib.sha256(token.strip().encode()).hexdigest()
+    with get_db_cursor() as cur:
+        cur.execute("""
+            SELECT id FROM submitters
+            WHERE token_sha256 = %s AND revoked_at IS NULL
+        """, (digest,))
+        row = cur.fetchone()
+    if not row:
+        raise HTTPException(status_code=401, detail="Invalid or revoked token",
+                            headers={"WWW-Authenticate": "Bearer"})
+    return row["id"]
+
+
+def create(submitter_id: int, request: SubmissionRequest) -> SubmissionStatus:
+    """Queue a submission for review."""
+    if not request.first_hand:
+        raise HTTPException(status_code=422, detail="Only first-hand accounts are accepted")
+
+    with get_db_cursor() as cur:
+        cur.execute("""
+            INSERT INTO submissions (submitter_id, title, content, location, time_period, story_type)
+            VALUES (%s, %s, %s, NULLIF(%s, ''), NULLIF(%s, ''), NULLIF(%s, ''))
+            RETURNING id::text, title, status, review_note, story_id::text, submitted_at, reviewed_at
+        """, (submitter_id, request.title.strip(), request.content, request.location or "",
+              request.time_period or "", request.story_type or ""))
+        return SubmissionStatus(**cur.fetchone())
+
+
+def get(submitter_id: int, submission_id: str) -> SubmissionStatus:
+    """Get one of the submitter's own submissions."""
+    try:
+        uuid.UUID(submission_id)
+    except ValueError:
+        raise HTTPException(status_code=404, detail="Submission not found")
+    with get_db_cursor() as cur:
+        cur.execute("""
+            SELECT id::text, title, status, review_note, story_id::text, submitted_at, reviewed_at
+            FROM submissions
+            WHERE id = %s::uuid AND submitter_id = %s
+        """, (submission_id, submitter_id))
+        row = cur.fetchone()
+    if not row:
+        raise HTTPException(status_code=404, detail="Submission not found")
+    return SubmissionStatus(**row)
+
+
+def list_own(submitter_id: int) -> list[SubmissionStatus]:
+    """Get the submitter's submissions, newest first."""
+    with get_db_cursor() as cur:
+        cur.execute("""
+            SELECT id::text, title, status, review_note, story_id::text, submitted_at, reviewed_at
+            FROM submissions
+            WHERE submitter_id = %s
+            ORDER BY submitted_at DESC
+        """, (submitter_id,))
+        return [SubmissionStatus(**row) for row in cur.fetchall()]
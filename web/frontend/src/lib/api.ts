@@ -35,6 +35,25 @@ export interface SearchResult extends Story {
   snippet?: string;
 }
 
+export interface FederatedResult extends SearchResult {
+  source: string;
+  source_url?: string | null;
+  source_score?: number;
+}
+
+export interface SourceStatus {
+  name: string;
+  ok: boolean;
+  count: number;
+  elapsed_ms: number;
+  error?: string | null;
+}
+
+export interface FederatedSearchResponse {
+  results: FederatedResult[];
+  sources: SourceStatus[];
+}
+
 export interface MapStory {
   id: string;
   title: string;
@@ -42,6 +61,7 @@ export interface MapStory {
   lat: number;
   lng: number;
   location: string;
+  geo_cluster_id?: number | null;
 }
 
 export interface VectorPoint {
@@ -143,6 +163,25 @@ export const api = {
     });
   },
 
+  async federatedSearch(params: {
+    query: string;
+    limit?: number;
+    search_type?: 'hybrid' | 'text' | 'vector';
+    alpha?: number;
+    story_types?: string[];
+  }): Promise<FederatedSearchResponse> {
+    return fetchJson('/api/federated-search', {
+      method: 'POST',
+      body: JSON.stringify({
+        query: params.query,
+        limit: params.limit || 20,
+        search_type: params.search_type || 'hybrid',
+        alpha: params.alpha || 0.7,
+        story_types: params.story_types,
+      }),
+    });
+  },
+
   async getMapStories(params?: {
     story_type?: string;
     framework?: string;